@@ -2,19 +2,41 @@ package main
 
 import (
 	"flag"
+	"log"
+	"os"
 
 	"github.com/harpyd/thestis/internal/validate"
 )
 
 const exampleSpecPath = "./examples/specification/horns-and-hooves-test.yml"
 
+const (
+	invalidExitCode = 1
+	usageExitCode   = 2
+)
+
 func main() {
+	format := flag.String("format", string(validate.TextFormat), "output format: text, json, sarif or lsp")
+
 	flag.Parse()
 
-	specPath := flag.Arg(0)
-	if specPath == "" {
-		specPath = exampleSpecPath
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{exampleSpecPath}
+	}
+
+	report, err := validate.Specifications(patterns...)
+	if err != nil {
+		log.Printf("thestis-validate: %s", err)
+		os.Exit(usageExitCode)
+	}
+
+	if err := validate.WriteReport(os.Stdout, report, validate.Format(*format)); err != nil {
+		log.Printf("thestis-validate: %s", err)
+		os.Exit(usageExitCode)
 	}
 
-	validate.Specification(specPath)
+	if len(report.Diagnostics) > 0 {
+		os.Exit(invalidExitCode)
+	}
 }
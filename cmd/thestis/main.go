@@ -2,30 +2,168 @@ package main
 
 import (
 	"flag"
+	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/harpyd/thestis/internal/cli"
+	"github.com/harpyd/thestis/internal/diff"
+	"github.com/harpyd/thestis/internal/format"
+	"github.com/harpyd/thestis/internal/lint"
+	"github.com/harpyd/thestis/internal/run"
 	"github.com/harpyd/thestis/internal/runner"
 )
 
-const defaultConfigsPath = "configs/thestis"
+const (
+	defaultConfigsPath = "configs/thestis"
+
+	diffCommand   = "diff"
+	fmtCommand    = "fmt"
+	lintCommand   = "lint"
+	runCommand    = "run"
+	workerCommand = "worker"
+)
+
+// Client commands drive the remote server through the REST API.
+var clientCommands = map[string]func(args []string) int{
+	"campaign": cli.Campaign,
+	"spec":     cli.Specification,
+	"pipeline": cli.Pipeline,
+	"report":   cli.Report,
+}
+
+const defaultHTTPTimeout = 30 * time.Second
 
 func main() {
 	flag.Parse()
 
-	configsPath := flag.Arg(0)
-	if configsPath == "" {
-		configsPath = defaultConfigsPath
+	if flag.Arg(0) == diffCommand {
+		os.Exit(diffSpecifications(flag.Args()[1:]))
+	}
+
+	if flag.Arg(0) == fmtCommand {
+		os.Exit(formatSpecifications(flag.Args()[1:]))
+	}
+
+	if flag.Arg(0) == lintCommand {
+		os.Exit(lintSpecifications(flag.Args()[1:]))
+	}
+
+	if flag.Arg(0) == runCommand {
+		os.Exit(runSpecification(flag.Args()[1:]))
+	}
+
+	if command, ok := clientCommands[flag.Arg(0)]; ok {
+		os.Exit(command(flag.Args()[1:]))
 	}
 
 	interrupted := make(chan os.Signal, 1)
 	signal.Notify(interrupted, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
-	r := runner.New(configsPath)
+	var r *runner.Manager
+
+	if flag.Arg(0) == workerCommand {
+		r = runner.NewWorker(configsPath(flag.Arg(1)))
+	} else {
+		r = runner.New(configsPath(flag.Arg(0)))
+	}
 
 	go r.Start()
 
 	<-interrupted
 	r.Stop()
 }
+
+func configsPath(arg string) string {
+	if arg == "" {
+		return defaultConfigsPath
+	}
+
+	return arg
+}
+
+func diffSpecifications(args []string) int {
+	if len(args) != 2 {
+		log.Fatalf("usage: thestis %s <from-specification> <to-specification>", diffCommand)
+	}
+
+	if err := diff.Specifications(os.Stdout, args[0], args[1]); err != nil {
+		log.Print(err)
+
+		return 1
+	}
+
+	return 0
+}
+
+func formatSpecifications(args []string) int {
+	var (
+		fs   = flag.NewFlagSet(fmtCommand, flag.ExitOnError)
+		opts format.Options
+	)
+
+	fs.BoolVar(&opts.Check, "check", false, "report unformatted specifications without rewriting them")
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		log.Fatalf("usage: thestis %s [-check] <specification>...", fmtCommand)
+	}
+
+	code, err := format.Specifications(os.Stdout, os.Stderr, fs.Args(), opts)
+	if err != nil {
+		log.Print(err)
+
+		return 1
+	}
+
+	return code
+}
+
+func lintSpecifications(args []string) int {
+	var (
+		fs   = flag.NewFlagSet(lintCommand, flag.ExitOnError)
+		opts lint.Options
+	)
+
+	fs.StringVar(&opts.ConfigPath, "config", "", "path to lint config, "+lint.DefaultConfigPath+" if exists by default")
+	fs.StringVar(&opts.Format, "format", lint.TextFormat, "output format: text or json")
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		log.Fatalf("usage: thestis %s [flags] <specification>...", lintCommand)
+	}
+
+	code, err := lint.Specifications(os.Stdout, fs.Args(), opts)
+	if err != nil {
+		log.Print(err)
+
+		return 1
+	}
+
+	return code
+}
+
+func runSpecification(args []string) int {
+	var (
+		fs   = flag.NewFlagSet(runCommand, flag.ExitOnError)
+		opts run.Options
+	)
+
+	fs.DurationVar(&opts.HTTPTimeout, "http-timeout", defaultHTTPTimeout, "timeout of each HTTP request")
+	fs.DurationVar(&opts.FlowTimeout, "timeout", 0, "timeout of the whole run, no timeout by default")
+	fs.StringVar(&opts.JUnitPath, "junit", "", "path to write JUnit XML report")
+	fs.StringVar(&opts.JSONPath, "json", "", "path to write JSON report")
+	fs.StringVar(&opts.HTMLPath, "html", "", "path to write HTML report")
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("usage: thestis %s [flags] <specification>", runCommand)
+	}
+
+	return run.Specification(fs.Arg(0), opts)
+}
@@ -0,0 +1,87 @@
+# Gherkin version of horns-and-hooves-test.yml
+Feature: Sell horns and hooves on the market
+  Test for selling horns and hooves
+
+  As a seller
+  In order to make sure that the product is being sold
+  I want to sell horns and hooves on the test market place
+
+  Scenario: Sell existing horns and hooves
+    Sell horns and hooves when they are in stock
+
+    Given horns delivered to the warehouse
+      """yaml
+      slug: deliverHorns
+      http:
+        request:
+          method: POST
+          url: https://api.warehouse/v1/horns
+          contentType: application/json
+          body:
+            producer: Horns Inc.
+            deliveryNumber: 123456
+            code: HRN-3134141
+            batchSize: 103
+        response:
+          allowedCodes: [201]
+          allowedContentType: application/json
+      """
+    And hooves delivered to the warehouse
+      """yaml
+      slug: deliverHooves
+      http:
+        request:
+          method: POST
+          url: https://api.warehouse/v1/hooves
+          contentType: application/json
+          body:
+            producer: Hooves Inc.
+            deliveryNumber: 654321
+            code: HVS-3123313
+            batchSize: 313
+        response:
+          allowedCodes: [201]
+          allowedContentType: application/json
+      """
+
+    When selling horns and hooves
+      """yaml
+      slug: sellHornsAndHooves
+      http:
+        request:
+          method: POST
+          url: https://api.warehouse/v1/sold
+          contentType: application/json
+          body:
+            products:
+              - code: HRN-3134141
+                itemsCount: 103
+                itemPrice: 1000
+              - code: HVS-3123313
+                itemsCount: 20
+                itemPrice: 1003
+        response:
+          allowedCodes: [201]
+          allowedContentType: application/json
+      """
+
+    Then get sold products
+      """yaml
+      slug: getSoldProducts
+      http:
+        request:
+          method: GET
+          url: https://api.warehouse/v1/{{sellHornsAndHooves.response.headers.Content-Location}}
+        response:
+          allowedCodes: [200]
+          allowedContentType: application/json
+      """
+    And check that products added to sold products
+      """yaml
+      slug: checkSoldProducts
+      assertion:
+        with: jsonpath
+        assert:
+          - actual: getSoldProducts.response.body.products..itemsCount
+            expected: [103, 21]
+      """
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/harpyd/thestis/internal/client"
+)
+
+const campaignUsage = "campaign create [flags] <view-name> | campaign list [flags]"
+
+// Campaign creates test campaign or lists
+// test campaigns of the user depending on args.
+func Campaign(args []string) int {
+	if len(args) == 0 {
+		return usage(campaignUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		return createCampaign(args[1:])
+	case "list":
+		return listCampaigns(args[1:])
+	}
+
+	return usage(campaignUsage)
+}
+
+func createCampaign(args []string) int {
+	var (
+		fs      = flag.NewFlagSet("campaign create", flag.ExitOnError)
+		opts    = registerOptions(fs)
+		summary = fs.String("summary", "", "summary of the test campaign")
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usage("campaign create [flags] <view-name>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	body := client.CreateTestCampaignJSONRequestBody{ViewName: fs.Arg(0)}
+	if *summary != "" {
+		body.Summary = summary
+	}
+
+	rsp, err := c.CreateTestCampaignWithResponse(context.Background(), body)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusCreated); err != nil {
+		return fail(err)
+	}
+
+	id, err := locationID(rsp.HTTPResponse)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := printID(opts.output, id); err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+func listCampaigns(args []string) int {
+	var (
+		fs     = flag.NewFlagSet("campaign list", flag.ExitOnError)
+		opts   = registerOptions(fs)
+		search = fs.String("search", "", "show only test campaigns with view name or summary containing text")
+		limit  = fs.Int("limit", 0, "maximum number of test campaigns, all by default")
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return usage("campaign list [flags]")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	campaigns, err := fetchCampaigns(context.Background(), c, *search, *limit)
+	if err != nil {
+		return fail(err)
+	}
+
+	if opts.output == outputJSON {
+		err = printJSON(campaigns)
+	} else {
+		err = printCampaigns(campaigns)
+	}
+
+	if err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+const maxCampaignsPageSize = 100
+
+// fetchCampaigns walks through the pages of test campaigns
+// until limit is reached or the last page is returned.
+func fetchCampaigns(
+	ctx context.Context,
+	c *client.ClientWithResponses,
+	search string,
+	limit int,
+) ([]client.TestCampaignResponse, error) {
+	var (
+		campaigns = make([]client.TestCampaignResponse, 0)
+		params    client.GetTestCampaignsParams
+	)
+
+	if search != "" {
+		params.Search = &search
+	}
+
+	for {
+		pageSize := maxCampaignsPageSize
+		if limit > 0 && limit-len(campaigns) < pageSize {
+			pageSize = limit - len(campaigns)
+		}
+
+		params.Limit = &pageSize
+
+		rsp, err := c.GetTestCampaignsWithResponse(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusOK); err != nil {
+			return nil, err
+		}
+
+		campaigns = append(campaigns, rsp.JSON200.TestCampaigns...)
+
+		if rsp.JSON200.NextCursor == nil || (limit > 0 && len(campaigns) >= limit) {
+			return campaigns, nil
+		}
+
+		params.Cursor = rsp.JSON200.NextCursor
+	}
+}
+
+func printCampaigns(campaigns []client.TestCampaignResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "ID\tVIEW NAME\tCREATED AT\tLAST PIPELINE")
+
+	for _, tc := range campaigns {
+		_, _ = fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\n",
+			tc.Id,
+			tc.ViewName,
+			tc.CreatedAt.Local().Format(time.RFC3339),
+			valueOrDash(tc.LastPipelineId),
+		)
+	}
+
+	return w.Flush()
+}
+
+func valueOrDash(s *string) string {
+	if s == nil || *s == "" {
+		return "-"
+	}
+
+	return *s
+}
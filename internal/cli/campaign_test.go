@@ -0,0 +1,125 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/cli"
+)
+
+func TestCreateCampaign(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Handler       http.HandlerFunc
+		ExpectedCode  int
+		ExpectedPrint string
+	}{
+		{
+			Name: "created",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["viewName"] != "smoke" {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				w.Header().Set("Location", "/v1/test-campaigns/campaign")
+				w.WriteHeader(http.StatusCreated)
+			},
+			ExpectedCode:  0,
+			ExpectedPrint: "campaign\n",
+		},
+		{
+			Name: "no_location",
+			Handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			},
+			ExpectedCode: 1,
+		},
+		{
+			Name: "unauthorized",
+			Handler: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusUnauthorized, map[string]string{
+					"slug":    "unauthorized-user",
+					"details": "user is unauthorized",
+				})
+			},
+			ExpectedCode: 1,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			flags := newServer(t, map[string]http.HandlerFunc{
+				"POST /v1/test-campaigns": c.Handler,
+			})
+
+			printed, code := captureStdout(t, func() int {
+				return cli.Campaign(commandArgs("create", flags, "smoke"))
+			})
+
+			require.Equal(t, c.ExpectedCode, code)
+			require.Equal(t, c.ExpectedPrint, printed)
+		})
+	}
+}
+
+func TestListCampaigns(t *testing.T) {
+	var cursors []string
+
+	flags := newServer(t, map[string]http.HandlerFunc{
+		"GET /v1/test-campaigns": func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("cursor")
+			cursors = append(cursors, cursor)
+
+			if cursor == "" {
+				writeJSON(w, http.StatusOK, map[string]interface{}{
+					"testCampaigns": []map[string]interface{}{
+						{"id": "first", "viewName": "first", "createdAt": "2022-03-01T12:00:00Z"},
+					},
+					"nextCursor": "next",
+				})
+
+				return
+			}
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"testCampaigns": []map[string]interface{}{
+					{"id": "second", "viewName": "second", "createdAt": "2022-03-01T12:00:00Z"},
+				},
+			})
+		},
+	})
+
+	printed, code := captureStdout(t, func() int {
+		return cli.Campaign(commandArgs("list", append(flags, "-o", "json")))
+	})
+
+	require.Equal(t, 0, code)
+	require.Equal(t, []string{"", "next"}, cursors)
+
+	var campaigns []map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(printed), &campaigns))
+	require.Len(t, campaigns, 2)
+}
+
+func TestCampaignUsage(t *testing.T) {
+	require.Equal(t, 2, cli.Campaign(nil))
+	require.Equal(t, 2, cli.Campaign([]string{"unknown"}))
+	require.Equal(t, 2, cli.Campaign([]string{"create"}))
+}
+
+func TestEmptyServer(t *testing.T) {
+	newServer(t, nil)
+
+	t.Setenv("THESTIS_SERVER", "")
+
+	require.Equal(t, 1, cli.Campaign([]string{"list"}))
+}
@@ -0,0 +1,165 @@
+// Package cli implements commands of the thestis client
+// that drive the remote server through the REST API.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/client"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+const usageExitCode = 2
+
+var (
+	errEmptyServer     = errors.New("server URL is not set")
+	errUnknownOutput   = errors.New("unknown output, expected table or json")
+	errNoLocation      = errors.New("response has no Location header")
+	errUnexpectedReply = errors.New("unexpected reply")
+)
+
+// options are flags shared by all client commands.
+type options struct {
+	configPath string
+	server     string
+	token      string
+	output     string
+}
+
+func registerOptions(fs *flag.FlagSet) *options {
+	var opts options
+
+	fs.StringVar(&opts.configPath, "config", "", "path to client config, $THESTIS_CONFIG or ~/.thestis.yml by default")
+	fs.StringVar(&opts.server, "server", "", "server URL with API version, e.g. http://localhost:8080/v1")
+	fs.StringVar(&opts.token, "token", "", "bearer token to authenticate requests")
+	fs.StringVar(&opts.output, "o", outputTable, "output format: table or json")
+
+	return &opts
+}
+
+func (o *options) client() (*client.ClientWithResponses, error) {
+	if o.output != outputTable && o.output != outputJSON {
+		return nil, errors.Wrap(errUnknownOutput, o.output)
+	}
+
+	cfg, err := loadConfig(o.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.server != "" {
+		cfg.Server = o.server
+	}
+
+	if o.token != "" {
+		cfg.Token = o.token
+	}
+
+	if cfg.Server == "" {
+		return nil, errEmptyServer
+	}
+
+	return client.NewClientWithResponses(
+		strings.TrimSuffix(cfg.Server, "/"),
+		client.WithRequestEditorFn(bearerToken(cfg.Token)),
+	)
+}
+
+func bearerToken(token string) client.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		return nil
+	}
+}
+
+// checkResponse returns error described by the Error
+// body of the server if the response code is not expected.
+func checkResponse(rsp *http.Response, body []byte, expectedCode int) error {
+	if rsp.StatusCode == expectedCode {
+		return nil
+	}
+
+	var apiErr client.Error
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Slug != "" {
+		return &responseError{
+			slug:    apiErr.Slug,
+			details: apiErr.Details,
+		}
+	}
+
+	return errors.Wrap(errUnexpectedReply, rsp.Status)
+}
+
+// responseError is the Error body of the server.
+type responseError struct {
+	slug    client.ErrorSlug
+	details string
+}
+
+func (e *responseError) Error() string {
+	return fmt.Sprintf("%s: %s", e.slug, e.details)
+}
+
+// hasErrorSlug returns true if err is the
+// Error body of the server with given slug.
+func hasErrorSlug(err error, slug client.ErrorSlug) bool {
+	var rerr *responseError
+
+	return errors.As(err, &rerr) && rerr.slug == slug
+}
+
+// locationID returns ID of the resource from the
+// Location header of the response.
+func locationID(rsp *http.Response) (string, error) {
+	location := rsp.Header.Get("Location")
+	if location == "" {
+		return "", errNoLocation
+	}
+
+	return path.Base(location), nil
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+func printID(output, id string) error {
+	if output == outputJSON {
+		return printJSON(map[string]string{"id": id})
+	}
+
+	_, err := fmt.Println(id)
+
+	return err
+}
+
+func fail(err error) int {
+	_, _ = fmt.Fprintf(os.Stderr, "thestis: %s\n", err)
+
+	return 1
+}
+
+func usage(format string, args ...interface{}) int {
+	_, _ = fmt.Fprintf(os.Stderr, "usage: thestis "+format+"\n", args...)
+
+	return usageExitCode
+}
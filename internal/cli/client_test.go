@@ -0,0 +1,92 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newServer starts the server with handlers by "METHOD /path"
+// routes and returns flags to pass it to the commands.
+func newServer(t *testing.T, routes map[string]http.HandlerFunc) []string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{
+				"slug":    "route-not-found",
+				"details": r.Method + " " + r.URL.Path,
+			})
+
+			return
+		}
+
+		handler(w, r)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	configPath := filepath.Join(t.TempDir(), "thestis.yml")
+	require.NoError(t, os.WriteFile(configPath, nil, 0o600))
+
+	t.Setenv("THESTIS_CONFIG", configPath)
+
+	return []string{"-server", srv.URL + "/v1", "-token", "token"}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// captureStdout returns what the command
+// prints to stdout and its exit code.
+func captureStdout(t *testing.T, command func() int) (string, int) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	printed := make(chan string)
+
+	go func() {
+		var buf bytes.Buffer
+
+		_, _ = io.Copy(&buf, r)
+
+		printed <- buf.String()
+	}()
+
+	code := command()
+
+	require.NoError(t, w.Close())
+
+	return <-printed, code
+}
+
+// commandArgs returns args of the subcommand
+// with flags followed by positional args.
+func commandArgs(subcommand string, flags []string, positional ...string) []string {
+	result := make([]string, 0, 1+len(flags)+len(positional))
+
+	result = append(result, subcommand)
+	result = append(result, flags...)
+
+	return append(result, positional...)
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configEnv = "THESTIS_CONFIG"
+	serverEnv = "THESTIS_SERVER"
+	tokenEnv  = "THESTIS_TOKEN"
+
+	defaultConfigName = ".thestis.yml"
+)
+
+// config is the client config file with server
+// URL and token, for example:
+//
+//	server: http://localhost:8080/v1
+//	token: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...
+//
+// Environment variables THESTIS_SERVER and THESTIS_TOKEN
+// take precedence over the values of the file.
+type config struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+}
+
+func loadConfig(configPath string) (cfg config, err error) {
+	defer func() {
+		err = errors.Wrap(err, "loading client config")
+	}()
+
+	explicit := configPath != ""
+
+	if !explicit {
+		configPath, explicit = os.LookupEnv(configEnv)
+	}
+
+	if !explicit {
+		configPath = defaultConfigPath()
+	}
+
+	if configPath != "" {
+		if cfg, err = readConfig(configPath); err != nil && (explicit || !os.IsNotExist(errors.Cause(err))) {
+			return config{}, err
+		}
+	}
+
+	if server, ok := os.LookupEnv(serverEnv); ok {
+		cfg.Server = server
+	}
+
+	if token, ok := os.LookupEnv(tokenEnv); ok {
+		cfg.Token = token
+	}
+
+	return cfg, nil
+}
+
+func readConfig(configPath string) (config, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return config{}, err
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return config{}, errors.Wrap(err, configPath)
+	}
+
+	return cfg, nil
+}
+
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, defaultConfigName)
+}
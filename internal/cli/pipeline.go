@@ -0,0 +1,401 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/client"
+)
+
+const pipelineUsage = "pipeline start|restart|cancel|follow [flags] <id>"
+
+// Pipeline starts pipeline of the test campaign, restarts,
+// cancels or follows steps of the pipeline depending on args.
+//
+// Following returns non-zero exit code if the last
+// flow of the pipeline is not passed.
+func Pipeline(args []string) int {
+	if len(args) == 0 {
+		return usage(pipelineUsage)
+	}
+
+	switch args[0] {
+	case "start":
+		return startPipeline(args[1:])
+	case "restart":
+		return restartPipeline(args[1:])
+	case "cancel":
+		return cancelPipeline(args[1:])
+	case "follow":
+		return followPipeline(args[1:])
+	}
+
+	return usage(pipelineUsage)
+}
+
+func startPipeline(args []string) int {
+	var (
+		fs     = flag.NewFlagSet("pipeline start", flag.ExitOnError)
+		opts   = registerOptions(fs)
+		follow = fs.Bool("follow", false, "follow steps of the started pipeline")
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usage("pipeline start [flags] <test-campaign-id>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	rsp, err := c.StartPipelineWithResponse(ctx, fs.Arg(0), client.StartPipelineJSONRequestBody{})
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusAccepted); err != nil {
+		return fail(err)
+	}
+
+	pipelineID, err := locationID(rsp.HTTPResponse)
+	if err != nil {
+		return fail(err)
+	}
+
+	if *follow {
+		return followSteps(ctx, c, opts.output, pipelineID)
+	}
+
+	if err := printID(opts.output, pipelineID); err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+func restartPipeline(args []string) int {
+	var (
+		fs     = flag.NewFlagSet("pipeline restart", flag.ExitOnError)
+		opts   = registerOptions(fs)
+		follow = fs.Bool("follow", false, "follow steps of the restarted pipeline")
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usage("pipeline restart [flags] <pipeline-id>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	rsp, err := c.RestartPipelineWithResponse(ctx, fs.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusNoContent); err != nil {
+		return fail(err)
+	}
+
+	if *follow {
+		return followSteps(ctx, c, opts.output, fs.Arg(0))
+	}
+
+	return 0
+}
+
+func cancelPipeline(args []string) int {
+	var (
+		fs   = flag.NewFlagSet("pipeline cancel", flag.ExitOnError)
+		opts = registerOptions(fs)
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usage("pipeline cancel [flags] <pipeline-id>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	rsp, err := c.CancelPipelineWithResponse(context.Background(), fs.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusNoContent); err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+func followPipeline(args []string) int {
+	var (
+		fs   = flag.NewFlagSet("pipeline follow", flag.ExitOnError)
+		opts = registerOptions(fs)
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usage("pipeline follow [flags] <pipeline-id>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	return followSteps(ctx, c, opts.output, fs.Arg(0))
+}
+
+const queuedPollInterval = 2 * time.Second
+
+// followSteps prints steps of the pipeline streamed by the server
+// until the pipeline is completed and then the state of its last flow.
+// Queued pipeline is waited until it is taken by a worker.
+func followSteps(ctx context.Context, c *client.ClientWithResponses, output, pipelineID string) int {
+	for {
+		completed, err := streamSteps(ctx, c, output, pipelineID)
+		if err != nil {
+			return fail(err)
+		}
+
+		if completed {
+			break
+		}
+
+		queued, err := isQueued(ctx, c, pipelineID)
+		if err != nil {
+			return fail(err)
+		}
+
+		if !queued {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fail(ctx.Err())
+		case <-time.After(queuedPollInterval):
+		}
+	}
+
+	state, err := lastFlowState(ctx, c, pipelineID)
+	if err != nil {
+		return fail(err)
+	}
+
+	if output == outputJSON {
+		err = printJSON(map[string]string{"pipelineId": pipelineID, "state": string(state)})
+	} else {
+		_, err = fmt.Println(stateColors[state].Sprint(state))
+	}
+
+	if err != nil {
+		return fail(err)
+	}
+
+	if state != client.PipelineStatePASSED {
+		return 1
+	}
+
+	return 0
+}
+
+const (
+	stepEvent      = "step"
+	completedEvent = "completed"
+)
+
+// streamSteps prints steps of the Server-Sent Events stream.
+// It returns false without error if the pipeline is not
+// in progress, so there are no steps to stream.
+func streamSteps(ctx context.Context, c *client.ClientWithResponses, output, pipelineID string) (bool, error) {
+	rsp, err := c.GetPipelineEvents(ctx, pipelineID)
+	if err != nil {
+		return false, err
+	}
+
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusConflict {
+		return false, nil
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(rsp.Body)
+
+		return false, checkResponse(rsp, body, http.StatusOK)
+	}
+
+	var (
+		scanner = bufio.NewScanner(rsp.Body)
+		event   string
+		data    string
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case line == "":
+			if event == completedEvent {
+				return true, nil
+			}
+
+			if event == stepEvent {
+				if err := printStep(os.Stdout, output, data); err != nil {
+					return false, err
+				}
+			}
+
+			event, data = "", ""
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+
+	return false, errors.Wrap(io.ErrUnexpectedEOF, "streaming pipeline steps")
+}
+
+var (
+	stepColors = map[client.PipelineStepEvent]color.Color{
+		client.PipelineStepEventExecute: color.FgYellow,
+		client.PipelineStepEventPass:    color.FgGreen,
+		client.PipelineStepEventFail:    color.FgRed,
+		client.PipelineStepEventCrash:   color.FgMagenta,
+		client.PipelineStepEventCancel:  color.FgGray,
+		client.PipelineStepEventPause:   color.FgYellow,
+	}
+
+	stateColors = map[client.PipelineState]color.Color{
+		client.PipelineStatePASSED:      color.FgGreen,
+		client.PipelineStateFAILED:      color.FgRed,
+		client.PipelineStateCRASHED:     color.FgMagenta,
+		client.PipelineStateCANCELED:    color.FgGray,
+		client.PipelineStateEXECUTING:   color.FgYellow,
+		client.PipelineStatePAUSED:      color.FgYellow,
+		client.PipelineStateQUEUED:      color.FgGray,
+		client.PipelineStateNOTEXECUTED: color.FgGray,
+		client.PipelineStateNOSTATE:     color.FgGray,
+	}
+)
+
+func printStep(w io.Writer, output, data string) error {
+	if output == outputJSON {
+		_, err := fmt.Fprintln(w, data)
+
+		return err
+	}
+
+	var step client.PipelineStep
+	if err := json.Unmarshal([]byte(data), &step); err != nil {
+		return errors.Wrap(err, "decoding pipeline step")
+	}
+
+	line := stepColors[step.Event].Sprintf(
+		"%s %s %s %s",
+		step.OccurredAt.Local().Format("15:04:05"),
+		step.Event,
+		step.SlugKind,
+		step.Slug,
+	)
+
+	if step.Error != nil && *step.Error != "" {
+		line += ": " + *step.Error
+	}
+
+	_, err := fmt.Fprintln(w, line)
+
+	return err
+}
+
+// isQueued returns true if the pipeline waits for a free worker.
+// Queues of workers are not visible from the server in distributed
+// mode, so the unavailable queue means the pipeline is not queued.
+func isQueued(ctx context.Context, c *client.ClientWithResponses, pipelineID string) (bool, error) {
+	pipe, err := getPipeline(ctx, c, pipelineID)
+	if hasErrorSlug(err, client.ErrorSlugPipelineQueueUnavailable) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return pipe.Queued, nil
+}
+
+func lastFlowState(ctx context.Context, c *client.ClientWithResponses, pipelineID string) (client.PipelineState, error) {
+	pipe, err := getPipeline(ctx, c, pipelineID)
+	if err != nil {
+		return "", err
+	}
+
+	var last *client.Flow
+
+	for i, f := range pipe.Flows {
+		if last == nil || (f.StartedAt != nil && (last.StartedAt == nil || f.StartedAt.After(*last.StartedAt))) {
+			last = &pipe.Flows[i]
+		}
+	}
+
+	if last == nil {
+		return client.PipelineStateNOSTATE, nil
+	}
+
+	return last.OverallState, nil
+}
+
+func getPipeline(ctx context.Context, c *client.ClientWithResponses, pipelineID string) (*client.SpecificPipelineResponse, error) {
+	rsp, err := c.GetPipelineWithResponse(ctx, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return rsp.JSON200, nil
+}
@@ -0,0 +1,197 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/cli"
+)
+
+const pipelineID = "3a5b1f5c-8d2e-4b7a-9c1d-6e0f2a4b8c7d"
+
+func pipelineHandler(queuedPolls int32, state string) http.HandlerFunc {
+	var polls int32
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		queued := atomic.AddInt32(&polls, 1) <= queuedPolls
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":              pipelineID,
+			"specificationId": "spec",
+			"started":         false,
+			"queued":          queued,
+			"flows": []map[string]interface{}{
+				{"id": "flow", "overallState": state, "statuses": []interface{}{}},
+			},
+		})
+	}
+}
+
+func eventsHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+
+	step := map[string]interface{}{
+		"slug":       "story.scenario.thesis",
+		"slugKind":   "thesis",
+		"event":      "pass",
+		"occurredAt": "2022-03-01T12:00:00Z",
+	}
+
+	data, _ := json.Marshal(step)
+
+	_, _ = fmt.Fprintf(w, "event: step\ndata: %s\n\n", data)
+	_, _ = fmt.Fprint(w, "event: completed\ndata: {}\n\n")
+}
+
+func notStartedHandler(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusConflict, map[string]string{
+		"slug":    "pipeline-not-started",
+		"details": "pipeline is not started",
+	})
+}
+
+func TestFollowPipeline(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Routes           map[string]http.HandlerFunc
+		ExpectedCode     int
+		ExpectedContains string
+	}{
+		{
+			Name: "passed_pipeline",
+			Routes: map[string]http.HandlerFunc{
+				"GET /v1/pipelines/" + pipelineID + "/events": eventsHandler,
+				"GET /v1/pipelines/" + pipelineID:             pipelineHandler(0, "PASSED"),
+			},
+			ExpectedCode:     0,
+			ExpectedContains: `"state": "PASSED"`,
+		},
+		{
+			Name: "failed_pipeline",
+			Routes: map[string]http.HandlerFunc{
+				"GET /v1/pipelines/" + pipelineID + "/events": eventsHandler,
+				"GET /v1/pipelines/" + pipelineID:             pipelineHandler(0, "FAILED"),
+			},
+			ExpectedCode:     1,
+			ExpectedContains: `"state": "FAILED"`,
+		},
+		{
+			Name: "queued_pipeline_is_waited",
+			Routes: map[string]http.HandlerFunc{
+				"GET /v1/pipelines/" + pipelineID + "/events": notStartedHandler,
+				"GET /v1/pipelines/" + pipelineID:             pipelineHandler(1, "CANCELED"),
+			},
+			ExpectedCode:     1,
+			ExpectedContains: `"state": "CANCELED"`,
+		},
+		{
+			Name: "unavailable_queue_is_not_queued",
+			Routes: map[string]http.HandlerFunc{
+				"GET /v1/pipelines/" + pipelineID + "/events": notStartedHandler,
+				"GET /v1/pipelines/" + pipelineID: func(w http.ResponseWriter, _ *http.Request) {
+					writeJSON(w, http.StatusNotImplemented, map[string]string{
+						"slug":    "pipeline-queue-unavailable",
+						"details": "pipeline queue unavailable",
+					})
+				},
+			},
+			ExpectedCode: 1,
+		},
+		{
+			Name: "pipeline_not_found",
+			Routes: map[string]http.HandlerFunc{
+				"GET /v1/pipelines/" + pipelineID + "/events": func(w http.ResponseWriter, _ *http.Request) {
+					writeJSON(w, http.StatusNotFound, map[string]string{
+						"slug":    "pipeline-not-found",
+						"details": "pipeline not found",
+					})
+				},
+			},
+			ExpectedCode: 1,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			flags := append(newServer(t, c.Routes), "-o", "json")
+
+			printed, code := captureStdout(t, func() int {
+				return cli.Pipeline(commandArgs("follow", flags, pipelineID))
+			})
+
+			require.Equal(t, c.ExpectedCode, code)
+			require.Contains(t, printed, c.ExpectedContains)
+		})
+	}
+}
+
+func TestStartPipeline(t *testing.T) {
+	var authorization string
+
+	flags := newServer(t, map[string]http.HandlerFunc{
+		"POST /v1/test-campaigns/campaign/pipeline": func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+
+			w.Header().Set("Location", "/v1/pipelines/"+pipelineID)
+			w.WriteHeader(http.StatusAccepted)
+		},
+	})
+
+	printed, code := captureStdout(t, func() int {
+		return cli.Pipeline(commandArgs("start", flags, "campaign"))
+	})
+
+	require.Equal(t, 0, code)
+	require.Equal(t, pipelineID+"\n", printed)
+	require.Equal(t, "Bearer token", authorization)
+}
+
+func TestCancelPipeline(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Code         int
+		ExpectedCode int
+	}{
+		{
+			Name:         "canceled",
+			Code:         http.StatusNoContent,
+			ExpectedCode: 0,
+		},
+		{
+			Name:         "not_started",
+			Code:         http.StatusConflict,
+			ExpectedCode: 1,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			flags := newServer(t, map[string]http.HandlerFunc{
+				"PUT /v1/pipelines/" + pipelineID + "/canceled": func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(c.Code)
+				},
+			})
+
+			_, code := captureStdout(t, func() int {
+				return cli.Pipeline(commandArgs("cancel", flags, pipelineID))
+			})
+
+			require.Equal(t, c.ExpectedCode, code)
+		})
+	}
+}
+
+func TestPipelineUsage(t *testing.T) {
+	require.Equal(t, 2, cli.Pipeline(nil))
+	require.Equal(t, 2, cli.Pipeline([]string{"unknown"}))
+	require.Equal(t, 2, cli.Pipeline([]string{"follow"}))
+}
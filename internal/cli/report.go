@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/harpyd/thestis/internal/client"
+)
+
+// Report downloads report of the flow in the
+// format set by flag to the file or stdout.
+func Report(args []string) int {
+	var (
+		fs      = flag.NewFlagSet("report", flag.ExitOnError)
+		opts    = registerOptions(fs)
+		format  = fs.String("format", string(client.ReportFormatJunit), "report format: junit, html or json")
+		outPath = fs.String("out", "", "path to write report, stdout by default")
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usage("report [flags] <flow-id>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	rsp, err := c.GetFlowReport(context.Background(), fs.Arg(0), &client.GetFlowReportParams{
+		Format: client.ReportFormat(*format),
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(rsp.Body)
+
+		return fail(checkResponse(rsp, body, http.StatusOK))
+	}
+
+	if err := writeReport(*outPath, rsp.Body); err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+func writeReport(outPath string, report io.Reader) error {
+	if outPath == "" {
+		_, err := io.Copy(os.Stdout, report)
+
+		return err
+	}
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, report); err != nil {
+		_ = out.Close()
+
+		return err
+	}
+
+	return out.Close()
+}
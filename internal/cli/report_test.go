@@ -0,0 +1,70 @@
+package cli_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/cli"
+)
+
+func TestReport(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Handler        http.HandlerFunc
+		ExpectedCode   int
+		ExpectedReport string
+	}{
+		{
+			Name: "junit_report",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("format") != "junit" {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/xml")
+				_, _ = w.Write([]byte("<testsuites></testsuites>"))
+			},
+			ExpectedCode:   0,
+			ExpectedReport: "<testsuites></testsuites>",
+		},
+		{
+			Name: "flow_not_found",
+			Handler: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusNotFound, map[string]string{
+					"slug":    "flow-not-found",
+					"details": "flow not found",
+				})
+			},
+			ExpectedCode: 1,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				flags   = newServer(t, map[string]http.HandlerFunc{"GET /v1/flows/flow/report": c.Handler})
+				outPath = filepath.Join(t.TempDir(), "report.xml")
+			)
+
+			code := cli.Report(append(flags, "-out", outPath, "flow"))
+
+			require.Equal(t, c.ExpectedCode, code)
+
+			if c.ExpectedCode != 0 {
+				return
+			}
+
+			report, err := os.ReadFile(outPath)
+			require.NoError(t, err)
+			require.Equal(t, c.ExpectedReport, string(report))
+		})
+	}
+}
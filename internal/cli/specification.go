@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/harpyd/thestis/internal/client"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser"
+)
+
+const specificationUsage = "spec load [flags] <test-campaign-id> <specification> | spec export [flags] <specification-id>"
+
+// Specification loads the specification file as the active one
+// of the test campaign or exports loaded specification depending on args.
+func Specification(args []string) int {
+	if len(args) == 0 {
+		return usage(specificationUsage)
+	}
+
+	switch args[0] {
+	case "load":
+		return loadSpecification(args[1:])
+	case "export":
+		return exportSpecification(args[1:])
+	}
+
+	return usage(specificationUsage)
+}
+
+func loadSpecification(args []string) int {
+	var (
+		fs   = flag.NewFlagSet("spec load", flag.ExitOnError)
+		opts = registerOptions(fs)
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return usage("spec load [flags] <test-campaign-id> <specification>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	specFile, err := os.Open(fs.Arg(1))
+	if err != nil {
+		return fail(err)
+	}
+
+	defer specFile.Close()
+
+	rsp, err := c.LoadSpecificationWithBodyWithResponse(
+		context.Background(),
+		fs.Arg(0),
+		parser.ForFile(fs.Arg(1)).ContentType(),
+		specFile,
+	)
+	if err != nil {
+		return fail(err)
+	}
+
+	if rsp.JSON422 != nil {
+		printDiagnostics(fs.Arg(1), rsp.JSON422.Diagnostics)
+
+		return 1
+	}
+
+	if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusCreated); err != nil {
+		return fail(err)
+	}
+
+	id, err := locationID(rsp.HTTPResponse)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := printID(opts.output, id); err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+// exportSpecification prints the loaded
+// specification as the YAML source.
+func exportSpecification(args []string) int {
+	var (
+		fs   = flag.NewFlagSet("spec export", flag.ExitOnError)
+		opts = registerOptions(fs)
+	)
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usage("spec export [flags] <specification-id>")
+	}
+
+	c, err := opts.client()
+	if err != nil {
+		return fail(err)
+	}
+
+	rsp, err := c.ExportSpecificationWithResponse(
+		context.Background(),
+		fs.Arg(0),
+		&client.ExportSpecificationParams{},
+	)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := checkResponse(rsp.HTTPResponse, rsp.Body, http.StatusOK); err != nil {
+		return fail(err)
+	}
+
+	if _, err := os.Stdout.Write(rsp.Body); err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+// printDiagnostics prints problems of the invalid
+// specification file in file:line:column form.
+func printDiagnostics(specPath string, diagnostics []client.SpecificationDiagnostic) {
+	for _, d := range diagnostics {
+		location := specPath
+
+		if d.Line != nil {
+			location += fmt.Sprintf(":%d", *d.Line)
+		}
+
+		if d.Line != nil && d.Column != nil {
+			location += fmt.Sprintf(":%d", *d.Column)
+		}
+
+		message := d.Message
+		if d.Context != nil && len(*d.Context) > 0 {
+			message = strings.Join(*d.Context, ": ") + ": " + message
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %s\n", location, message)
+	}
+}
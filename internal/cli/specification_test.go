@@ -0,0 +1,96 @@
+package cli_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/cli"
+)
+
+func TestLoadSpecification(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yml")
+	require.NoError(t, os.WriteFile(specPath, []byte("stories: {}\n"), 0o600))
+
+	testCases := []struct {
+		Name          string
+		Handler       http.HandlerFunc
+		ExpectedCode  int
+		ExpectedPrint string
+	}{
+		{
+			Name: "loaded",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if string(body) != "stories: {}\n" {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				w.Header().Set("Location", "/v1/specifications/spec")
+				w.WriteHeader(http.StatusCreated)
+			},
+			ExpectedCode:  0,
+			ExpectedPrint: "spec\n",
+		},
+		{
+			Name: "invalid_specification",
+			Handler: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+					"slug":    "invalid-specification-source",
+					"details": "no stories",
+					"diagnostics": []map[string]interface{}{
+						{"message": "no stories", "line": 1, "column": 1},
+					},
+				})
+			},
+			ExpectedCode: 1,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			flags := newServer(t, map[string]http.HandlerFunc{
+				"POST /v1/test-campaigns/campaign/specification": c.Handler,
+			})
+
+			printed, code := captureStdout(t, func() int {
+				return cli.Specification(commandArgs("load", flags, "campaign", specPath))
+			})
+
+			require.Equal(t, c.ExpectedCode, code)
+			require.Equal(t, c.ExpectedPrint, printed)
+		})
+	}
+}
+
+func TestLoadMissingSpecification(t *testing.T) {
+	flags := newServer(t, nil)
+
+	code := cli.Specification(commandArgs("load", flags, "campaign", filepath.Join(t.TempDir(), "missing.yml")))
+
+	require.Equal(t, 1, code)
+}
+
+func TestExportSpecification(t *testing.T) {
+	flags := newServer(t, map[string]http.HandlerFunc{
+		"GET /v1/specifications/spec/export": func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/x-yaml")
+			_, _ = w.Write([]byte("---\nstories: {}\n"))
+		},
+	})
+
+	printed, code := captureStdout(t, func() int {
+		return cli.Specification(commandArgs("export", flags, "spec"))
+	})
+
+	require.Equal(t, 0, code)
+	require.Equal(t, "---\nstories: {}\n", printed)
+}
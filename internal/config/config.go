@@ -22,6 +22,13 @@ type SignalBus = string
 
 const Nats SignalBus = "nats"
 
+type StepBus = string
+
+const (
+	NatsStepBus     StepBus = "nats"
+	InMemoryStepBus StepBus = "inMemory"
+)
+
 type AuthType = string
 
 type LoggerLib = string
@@ -51,7 +58,9 @@ type (
 		Auth        Auth
 		Firebase    Firebase
 		Pipeline    Pipeline
+		Scheduler   Scheduler
 		SavePerStep SavePerStep
+		Notifier    Notifier
 		Nats        NatsServer
 		Logger      Logger
 	}
@@ -70,6 +79,7 @@ type (
 		Username          string
 		Password          string
 		DisconnectTimeout time.Duration
+		MigrationTimeout  time.Duration
 	}
 
 	Auth struct {
@@ -81,16 +91,39 @@ type (
 	}
 
 	Pipeline struct {
-		FlowTimeout time.Duration
-		Policy      StepsPolicy
-		SignalBus   SignalBus
-		Workers     int
+		FlowTimeout       time.Duration
+		Policy            StepsPolicy
+		SignalBus         SignalBus
+		StepBus           StepBus
+		Workers           int
+		InstanceID        string
+		LeaseTTL          time.Duration
+		HeartbeatInterval time.Duration
+		DrainTimeout      time.Duration
+		HTTPTimeout       time.Duration
+		HTTPAllowedHosts  []string
+		StartTimeout      time.Duration
+		RequeueOnShutdown bool
+		Distributed       bool
+	}
+
+	Scheduler struct {
+		Disabled     bool
+		TickInterval time.Duration
+		LeaderTTL    time.Duration
 	}
 
 	SavePerStep struct {
 		SaveTimeout time.Duration
 	}
 
+	Notifier struct {
+		MaxAttempts    int
+		InitialBackoff time.Duration
+		Timeout        time.Duration
+		AllowedHosts   []string
+	}
+
 	NatsServer struct {
 		URL string
 	}
@@ -107,11 +140,35 @@ const (
 	defaultHTTPShutdownTimeout = 10 * time.Second
 )
 
-const defaultMongoDisconnectTimeout = 10 * time.Second
+const (
+	defaultMongoDisconnectTimeout = 10 * time.Second
+	defaultMongoMigrationTimeout  = time.Minute
+)
+
+const (
+	defaultPipelineFlowTimeout       = 24 * time.Hour
+	defaultPipelineWorkers           = 100
+	defaultPipelineStepBus           = NatsStepBus
+	defaultPipelineLeaseTTL          = time.Minute
+	defaultPipelineHeartbeatInterval = 20 * time.Second
+	defaultPipelineDrainTimeout      = 30 * time.Second
+	defaultPipelineHTTPTimeout       = 30 * time.Second
+	defaultPipelineStartTimeout      = 5 * time.Second
+)
+
+const (
+	defaultSchedulerTickInterval = 10 * time.Second
+	defaultSchedulerLeaderTTL    = time.Minute
+)
 
 const (
-	defaultPipelineFlowTimeout = 24 * time.Hour
-	defaultPipelineWorkers     = 100
+	defaultNotifierMaxAttempts    = 5
+	defaultNotifierInitialBackoff = time.Second
+	defaultNotifierTimeout        = 10 * time.Second
+
+	// maxNotifierAttempts bounds attempts, so deliveries of
+	// notifications of stopped instances are resumed in time.
+	maxNotifierAttempts = 20
 )
 
 const (
@@ -138,17 +195,94 @@ func FromPath(configsPath string) (*Config, error) {
 
 	cfg.Environment = appEnv
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
+// Validate checks values which can not be used
+// as is, e.g. intervals of periodic work.
+func (c Config) Validate() error {
+	var cmnErr error
+
+	if c.Pipeline.HeartbeatInterval <= 0 || c.Pipeline.HeartbeatInterval >= c.Pipeline.LeaseTTL {
+		cmnErr = multierr.Append(cmnErr, invalidValueError{
+			key:    "pipeline.heartbeatInterval",
+			reason: "should be positive and less than pipeline.leaseTTL",
+		})
+	}
+
+	if c.Pipeline.StartTimeout <= 0 || c.Pipeline.StartTimeout >= c.Pipeline.LeaseTTL {
+		cmnErr = multierr.Append(cmnErr, invalidValueError{
+			key:    "pipeline.startTimeout",
+			reason: "should be positive and less than pipeline.leaseTTL",
+		})
+	}
+
+	if c.Notifier.MaxAttempts < 1 || c.Notifier.MaxAttempts > maxNotifierAttempts {
+		cmnErr = multierr.Append(cmnErr, invalidValueError{
+			key:    "notifier.maxAttempts",
+			reason: fmt.Sprintf("should be from 1 to %d", maxNotifierAttempts),
+		})
+	}
+
+	if c.Notifier.InitialBackoff <= 0 {
+		cmnErr = multierr.Append(cmnErr, invalidValueError{
+			key:    "notifier.initialBackoff",
+			reason: "should be positive",
+		})
+	}
+
+	if c.Notifier.Timeout <= 0 {
+		cmnErr = multierr.Append(cmnErr, invalidValueError{
+			key:    "notifier.timeout",
+			reason: "should be positive",
+		})
+	}
+
+	if c.Scheduler.Disabled {
+		return cmnErr
+	}
+
+	if c.Scheduler.TickInterval <= 0 {
+		cmnErr = multierr.Append(cmnErr, invalidValueError{
+			key:    "scheduler.tickInterval",
+			reason: "should be positive",
+		})
+	}
+
+	if c.Scheduler.LeaderTTL <= c.Scheduler.TickInterval {
+		cmnErr = multierr.Append(cmnErr, invalidValueError{
+			key:    "scheduler.leaderTTL",
+			reason: "should be greater than scheduler.tickInterval",
+		})
+	}
+
+	return cmnErr
+}
+
 func setDefaults() {
 	viper.SetDefault("http.port", defaultHTTPPort)
 	viper.SetDefault("http.readTimeout", defaultHTTPRWTimeout)
 	viper.SetDefault("http.writeTimeout", defaultHTTPRWTimeout)
 	viper.SetDefault("http.shutdownTimeout", defaultHTTPShutdownTimeout)
 	viper.SetDefault("mongo.disconnectTimeout", defaultMongoDisconnectTimeout)
+	viper.SetDefault("mongo.migrationTimeout", defaultMongoMigrationTimeout)
 	viper.SetDefault("pipeline.flowTimeout", defaultPipelineFlowTimeout)
 	viper.SetDefault("pipeline.workers", defaultPipelineWorkers)
+	viper.SetDefault("pipeline.stepBus", defaultPipelineStepBus)
+	viper.SetDefault("pipeline.leaseTTL", defaultPipelineLeaseTTL)
+	viper.SetDefault("pipeline.heartbeatInterval", defaultPipelineHeartbeatInterval)
+	viper.SetDefault("pipeline.drainTimeout", defaultPipelineDrainTimeout)
+	viper.SetDefault("pipeline.httpTimeout", defaultPipelineHTTPTimeout)
+	viper.SetDefault("pipeline.startTimeout", defaultPipelineStartTimeout)
+	viper.SetDefault("scheduler.tickInterval", defaultSchedulerTickInterval)
+	viper.SetDefault("scheduler.leaderTTL", defaultSchedulerLeaderTTL)
+	viper.SetDefault("notifier.maxAttempts", defaultNotifierMaxAttempts)
+	viper.SetDefault("notifier.initialBackoff", defaultNotifierInitialBackoff)
+	viper.SetDefault("notifier.timeout", defaultNotifierTimeout)
 	viper.SetDefault("logger.lib", defaultLoggerLib)
 	viper.SetDefault("logger.level", defaultLoggerLevel)
 }
@@ -236,10 +370,18 @@ func unmarshal(cfg *Config) error {
 		return err
 	}
 
+	if err := viper.UnmarshalKey("scheduler", &cfg.Scheduler); err != nil {
+		return err
+	}
+
 	if err := viper.UnmarshalKey("savePerStep", &cfg.SavePerStep); err != nil {
 		return err
 	}
 
+	if err := viper.UnmarshalKey("notifier", &cfg.Notifier); err != nil {
+		return err
+	}
+
 	if err := viper.UnmarshalKey("nats", &cfg.Nats); err != nil {
 		return err
 	}
@@ -264,3 +406,12 @@ type noEnvError struct {
 func (e noEnvError) Error() string {
 	return fmt.Sprintf("no %s env", e.envKey)
 }
+
+type invalidValueError struct {
+	key    string
+	reason string
+}
+
+func (e invalidValueError) Error() string {
+	return fmt.Sprintf("%s %s", e.key, e.reason)
+}
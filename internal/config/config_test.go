@@ -63,6 +63,7 @@ func TestFromPath(t *testing.T) {
 					Username:          "admin",
 					Password:          "0000",
 					DisconnectTimeout: 13 * time.Second,
+					MigrationTimeout:  2 * time.Minute,
 				},
 				Firebase: config.Firebase{
 					ServiceAccountFile: "path/to/serviceAccount.json",
@@ -71,14 +72,31 @@ func TestFromPath(t *testing.T) {
 					With: "fake",
 				},
 				Pipeline: config.Pipeline{
-					FlowTimeout: 24 * time.Hour,
-					Policy:      config.SavePerStepPolicy,
-					SignalBus:   config.Nats,
-					Workers:     34,
+					FlowTimeout:       24 * time.Hour,
+					Policy:            config.SavePerStepPolicy,
+					SignalBus:         config.Nats,
+					StepBus:           config.NatsStepBus,
+					Workers:           34,
+					LeaseTTL:          time.Minute,
+					HeartbeatInterval: 20 * time.Second,
+					DrainTimeout:      30 * time.Second,
+					HTTPTimeout:       30 * time.Second,
+					HTTPAllowedHosts:  []string{"api.some-a.com", "*.some-b.com"},
+					StartTimeout:      5 * time.Second,
+				},
+				Scheduler: config.Scheduler{
+					TickInterval: 10 * time.Second,
+					LeaderTTL:    time.Minute,
 				},
 				SavePerStep: config.SavePerStep{
 					SaveTimeout: 30 * time.Second,
 				},
+				Notifier: config.Notifier{
+					MaxAttempts:    5,
+					InitialBackoff: time.Second,
+					Timeout:        10 * time.Second,
+					AllowedHosts:   []string{"ci.some-a.com", "*.some-b.com"},
+				},
 				Nats: config.NatsServer{
 					URL: "nats://nats:4222",
 				},
@@ -109,3 +127,194 @@ func TestFromPath(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validPipeline = config.Pipeline{
+			LeaseTTL:          time.Minute,
+			HeartbeatInterval: 20 * time.Second,
+			StartTimeout:      5 * time.Second,
+		}
+		validScheduler = config.Scheduler{
+			TickInterval: 10 * time.Second,
+			LeaderTTL:    time.Minute,
+		}
+		validNotifier = config.Notifier{
+			MaxAttempts:    5,
+			InitialBackoff: time.Second,
+			Timeout:        10 * time.Second,
+		}
+	)
+
+	testCases := []struct {
+		Name        string
+		Pipeline    config.Pipeline
+		Scheduler   config.Scheduler
+		Notifier    *config.Notifier
+		ShouldBeErr bool
+	}{
+		{
+			Name: "heartbeat_less_than_lease_ttl",
+			Pipeline: config.Pipeline{
+				LeaseTTL:          time.Minute,
+				HeartbeatInterval: 20 * time.Second,
+				StartTimeout:      5 * time.Second,
+			},
+			Scheduler:   validScheduler,
+			ShouldBeErr: false,
+		},
+		{
+			Name: "zero_heartbeat",
+			Pipeline: config.Pipeline{
+				LeaseTTL:     time.Minute,
+				StartTimeout: 5 * time.Second,
+			},
+			Scheduler:   validScheduler,
+			ShouldBeErr: true,
+		},
+		{
+			Name: "negative_heartbeat",
+			Pipeline: config.Pipeline{
+				LeaseTTL:          time.Minute,
+				HeartbeatInterval: -time.Second,
+				StartTimeout:      5 * time.Second,
+			},
+			Scheduler:   validScheduler,
+			ShouldBeErr: true,
+		},
+		{
+			Name: "heartbeat_equal_to_lease_ttl",
+			Pipeline: config.Pipeline{
+				LeaseTTL:          time.Minute,
+				HeartbeatInterval: time.Minute,
+				StartTimeout:      5 * time.Second,
+			},
+			Scheduler:   validScheduler,
+			ShouldBeErr: true,
+		},
+		{
+			Name: "zero_start_timeout",
+			Pipeline: config.Pipeline{
+				LeaseTTL:          time.Minute,
+				HeartbeatInterval: 20 * time.Second,
+			},
+			Scheduler:   validScheduler,
+			ShouldBeErr: true,
+		},
+		{
+			Name: "start_timeout_equal_to_lease_ttl",
+			Pipeline: config.Pipeline{
+				LeaseTTL:          time.Minute,
+				HeartbeatInterval: 20 * time.Second,
+				StartTimeout:      time.Minute,
+			},
+			Scheduler:   validScheduler,
+			ShouldBeErr: true,
+		},
+		{
+			Name:        "zero_tick_interval",
+			Pipeline:    validPipeline,
+			Scheduler:   config.Scheduler{LeaderTTL: time.Minute},
+			ShouldBeErr: true,
+		},
+		{
+			Name:        "negative_leader_ttl",
+			Pipeline:    validPipeline,
+			Scheduler:   config.Scheduler{TickInterval: 10 * time.Second, LeaderTTL: -time.Minute},
+			ShouldBeErr: true,
+		},
+		{
+			Name:        "leader_ttl_not_greater_than_tick_interval",
+			Pipeline:    validPipeline,
+			Scheduler:   config.Scheduler{TickInterval: time.Minute, LeaderTTL: time.Minute},
+			ShouldBeErr: true,
+		},
+		{
+			Name:        "disabled_scheduler",
+			Pipeline:    validPipeline,
+			Scheduler:   config.Scheduler{Disabled: true},
+			ShouldBeErr: false,
+		},
+		{
+			Name:      "max_notifier_attempts",
+			Pipeline:  validPipeline,
+			Scheduler: validScheduler,
+			Notifier: &config.Notifier{
+				MaxAttempts:    20,
+				InitialBackoff: time.Second,
+				Timeout:        10 * time.Second,
+			},
+			ShouldBeErr: false,
+		},
+		{
+			Name:      "zero_notifier_attempts",
+			Pipeline:  validPipeline,
+			Scheduler: validScheduler,
+			Notifier: &config.Notifier{
+				InitialBackoff: time.Second,
+				Timeout:        10 * time.Second,
+			},
+			ShouldBeErr: true,
+		},
+		{
+			Name:      "too_many_notifier_attempts",
+			Pipeline:  validPipeline,
+			Scheduler: validScheduler,
+			Notifier: &config.Notifier{
+				MaxAttempts:    64,
+				InitialBackoff: time.Second,
+				Timeout:        10 * time.Second,
+			},
+			ShouldBeErr: true,
+		},
+		{
+			Name:      "zero_notifier_backoff",
+			Pipeline:  validPipeline,
+			Scheduler: validScheduler,
+			Notifier: &config.Notifier{
+				MaxAttempts: 5,
+				Timeout:     10 * time.Second,
+			},
+			ShouldBeErr: true,
+		},
+		{
+			Name:      "zero_notifier_timeout",
+			Pipeline:  validPipeline,
+			Scheduler: validScheduler,
+			Notifier: &config.Notifier{
+				MaxAttempts:    5,
+				InitialBackoff: time.Second,
+			},
+			ShouldBeErr: true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			notifier := validNotifier
+			if c.Notifier != nil {
+				notifier = *c.Notifier
+			}
+
+			err := config.Config{
+				Pipeline:  c.Pipeline,
+				Scheduler: c.Scheduler,
+				Notifier:  notifier,
+			}.Validate()
+
+			if c.ShouldBeErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
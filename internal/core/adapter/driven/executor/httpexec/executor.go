@@ -0,0 +1,288 @@
+package httpexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/jsonpath"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/pkg/httphost"
+)
+
+var (
+	ErrUnsupportedBody       = errors.New("unsupported request body")
+	ErrCodeNotAllowed        = errors.New("response code is not allowed")
+	ErrContentTypeNotAllowed = errors.New("response content type is not allowed")
+	ErrHostNotAllowed        = errors.New("request host is not allowed")
+)
+
+const (
+	maxResponseBodySize = 10 << 20
+	// maxCapturedBodySize limits bodies kept in the
+	// flow to show them in reports.
+	maxCapturedBodySize = 64 << 10
+)
+
+// Executor sends HTTP requests of theses. The response of the
+// thesis is stored to the environment by the thesis slug as
+// {"response": {"code": ..., "headers": {...}, "body": ...}},
+// so next theses can refer to it in URL templates like
+// {{createOrder.response.headers.Location}} or assertions.
+type Executor struct {
+	client       *http.Client
+	restricted   bool
+	allowedHosts []string
+}
+
+func NewExecutor(timeout time.Duration) Executor {
+	return Executor{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+const maxRedirects = 10
+
+// NewRestrictedExecutor returns the Executor sending requests,
+// including redirected ones, only to allowedHosts. The host is
+// allowed if it equals one of allowedHosts or matches the
+// pattern like *.example.com. With no allowed hosts nothing
+// is sent.
+func NewRestrictedExecutor(timeout time.Duration, allowedHosts []string) Executor {
+	e := Executor{
+		restricted:   true,
+		allowedHosts: allowedHosts,
+	}
+
+	e.client = &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return e.checkHost(req)
+		},
+	}
+
+	return e
+}
+
+func (e Executor) Execute(
+	ctx context.Context,
+	env *pipeline.Environment,
+	thesis specification.Thesis,
+) pipeline.Result {
+	req, reqBody, err := newRequest(ctx, env, thesis.HTTP().Request())
+	if err != nil {
+		return pipeline.Crash(err)
+	}
+
+	if err := e.checkHost(req); err != nil {
+		return pipeline.Crash(err)
+	}
+
+	exchange := pipeline.HTTPExchange{
+		Method:             req.Method,
+		URL:                req.URL.String(),
+		RequestContentType: req.Header.Get("Content-Type"),
+		RequestBody:        captured(reqBody),
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return pipeline.Cancel(ctx.Err()).WithExchange(exchange)
+		}
+
+		return pipeline.Crash(err).WithExchange(exchange)
+	}
+
+	defer resp.Body.Close()
+
+	exchange.ResponseCode = resp.StatusCode
+	exchange.ResponseContentType = resp.Header.Get("Content-Type")
+
+	raw, body, err := readBody(resp)
+	if err != nil {
+		return pipeline.Crash(err).WithExchange(exchange)
+	}
+
+	exchange.ResponseBody = captured(raw)
+
+	env.Store(thesis.Slug().Thesis(), map[string]interface{}{
+		"response": map[string]interface{}{
+			"code":    resp.StatusCode,
+			"headers": headers(resp.Header),
+			"body":    body,
+		},
+	})
+
+	if err := checkResponse(resp, thesis.HTTP().Response()); err != nil {
+		return pipeline.Fail(err).WithExchange(exchange)
+	}
+
+	return pipeline.Pass().WithExchange(exchange)
+}
+
+func (e Executor) checkHost(req *http.Request) error {
+	if !e.restricted {
+		return nil
+	}
+
+	host := req.URL.Hostname()
+
+	if httphost.Allowed(host, e.allowedHosts) {
+		return nil
+	}
+
+	return errors.Wrap(ErrHostNotAllowed, host)
+}
+
+// captured returns body cut to maxCapturedBodySize.
+func captured(body []byte) string {
+	if len(body) > maxCapturedBodySize {
+		return string(body[:maxCapturedBodySize]) + "..."
+	}
+
+	return string(body)
+}
+
+func newRequest(
+	ctx context.Context,
+	env *pipeline.Environment,
+	r specification.HTTPRequest,
+) (*http.Request, []byte, error) {
+	url, err := expand(env, r.URL())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var raw []byte
+
+	if len(r.Body()) > 0 {
+		if r.ContentType() != specification.ApplicationJSON && r.ContentType() != specification.NoContentType {
+			return nil, nil, errors.Wrap(ErrUnsupportedBody, r.ContentType().String())
+		}
+
+		raw, err = json.Marshal(r.Body())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var body io.Reader
+	if raw != nil {
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method().String(), url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.ContentType() != specification.NoContentType {
+		req.Header.Set("Content-Type", r.ContentType().String())
+	} else if body != nil {
+		req.Header.Set("Content-Type", specification.ApplicationJSON.String())
+	}
+
+	return req, raw, nil
+}
+
+var templatePattern = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+// expand replaces {{path}} templates in s with
+// values found in the environment by jsonpath.Lookup.
+func expand(env *pipeline.Environment, s string) (string, error) {
+	var err error
+
+	expanded := templatePattern.ReplaceAllStringFunc(s, func(match string) string {
+		path := templatePattern.FindStringSubmatch(match)[1]
+
+		value, lookupErr := jsonpath.Lookup(env, path)
+		if lookupErr != nil {
+			err = lookupErr
+
+			return match
+		}
+
+		return fmt.Sprint(value)
+	})
+
+	return expanded, err
+}
+
+// readBody returns raw response body and
+// its decoded form stored to the environment.
+func readBody(resp *http.Response) ([]byte, interface{}, error) {
+	raw, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(raw) == 0 {
+		return raw, nil, nil
+	}
+
+	if mediaType(resp) == specification.ApplicationJSON.String() {
+		var body interface{}
+		if err := json.Unmarshal(raw, &body); err == nil {
+			return raw, body, nil
+		}
+	}
+
+	return raw, string(raw), nil
+}
+
+func headers(h http.Header) map[string]interface{} {
+	result := make(map[string]interface{}, len(h))
+	for k := range h {
+		result[k] = h.Get(k)
+	}
+
+	return result
+}
+
+func mediaType(resp *http.Response) string {
+	mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(mt)
+}
+
+func checkResponse(resp *http.Response, expected specification.HTTPResponse) error {
+	if codes := expected.AllowedCodes(); len(codes) > 0 && !contains(codes, resp.StatusCode) {
+		return errors.Wrapf(ErrCodeNotAllowed, "%d, allowed %v", resp.StatusCode, codes)
+	}
+
+	ct := expected.AllowedContentType()
+	if ct != specification.NoContentType && mediaType(resp) != ct.String() {
+		return errors.Wrapf(ErrContentTypeNotAllowed, "%q, allowed %q", mediaType(resp), ct)
+	}
+
+	return nil
+}
+
+func contains(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,334 @@
+package httpexec_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/httpexec"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sold", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["code"] != "HRN" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Content-Location", "sold/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	})
+	mux.HandleFunc("/sold/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("plain"))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	testCases := []struct {
+		Name             string
+		Context          func() context.Context
+		PrepareEnv       func(env *pipeline.Environment)
+		GivenHTTP        func(b *specification.HTTPBuilder)
+		ExpectedEvent    pipeline.Event
+		ExpectedErr      error
+		ExpectedStore    interface{}
+		ExpectedExchange pipeline.HTTPExchange
+	}{
+		{
+			Name:    "passed_with_stored_response",
+			Context: context.Background,
+			GivenHTTP: func(b *specification.HTTPBuilder) {
+				b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+					b.
+						WithMethod(specification.POST).
+						WithURL(srv.URL + "/sold").
+						WithContentType(specification.ApplicationJSON).
+						WithBody(map[string]interface{}{"code": "HRN"})
+				})
+				b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+					b.
+						WithAllowedCodes([]int{http.StatusCreated}).
+						WithAllowedContentType(specification.ApplicationJSON)
+				})
+			},
+			ExpectedEvent: pipeline.FiredPass,
+			ExpectedStore: map[string]interface{}{"id": 1.0},
+			ExpectedExchange: pipeline.HTTPExchange{
+				Method:              http.MethodPost,
+				URL:                 srv.URL + "/sold",
+				RequestContentType:  "application/json",
+				RequestBody:         `{"code":"HRN"}`,
+				ResponseCode:        http.StatusCreated,
+				ResponseContentType: "application/json; charset=utf-8",
+				ResponseBody:        `{"id":1}`,
+			},
+		},
+		{
+			Name:    "passed_with_url_template",
+			Context: context.Background,
+			PrepareEnv: func(env *pipeline.Environment) {
+				env.Store("sell", map[string]interface{}{
+					"response": map[string]interface{}{
+						"headers": map[string]interface{}{"Content-Location": "sold/1"},
+					},
+				})
+			},
+			GivenHTTP: func(b *specification.HTTPBuilder) {
+				b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+					b.
+						WithMethod(specification.GET).
+						WithURL(srv.URL + "/{{sell.response.headers.Content-Location}}")
+				})
+				b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+					b.WithAllowedCodes([]int{http.StatusOK})
+				})
+			},
+			ExpectedEvent: pipeline.FiredPass,
+			ExpectedStore: "plain",
+			ExpectedExchange: pipeline.HTTPExchange{
+				Method:              http.MethodGet,
+				URL:                 srv.URL + "/sold/1",
+				ResponseCode:        http.StatusOK,
+				ResponseContentType: "text/plain; charset=utf-8",
+				ResponseBody:        "plain",
+			},
+		},
+		{
+			Name:    "failed_by_code",
+			Context: context.Background,
+			GivenHTTP: func(b *specification.HTTPBuilder) {
+				b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+					b.
+						WithMethod(specification.POST).
+						WithURL(srv.URL + "/sold").
+						WithBody(map[string]interface{}{"code": "HVS"})
+				})
+				b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+					b.WithAllowedCodes([]int{http.StatusCreated})
+				})
+			},
+			ExpectedEvent: pipeline.FiredFail,
+			ExpectedErr:   httpexec.ErrCodeNotAllowed,
+			ExpectedExchange: pipeline.HTTPExchange{
+				Method:             http.MethodPost,
+				URL:                srv.URL + "/sold",
+				RequestContentType: "application/json",
+				RequestBody:        `{"code":"HVS"}`,
+				ResponseCode:       http.StatusBadRequest,
+			},
+		},
+		{
+			Name:    "failed_by_content_type",
+			Context: context.Background,
+			GivenHTTP: func(b *specification.HTTPBuilder) {
+				b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+					b.
+						WithMethod(specification.GET).
+						WithURL(srv.URL + "/sold/1")
+				})
+				b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+					b.WithAllowedContentType(specification.ApplicationJSON)
+				})
+			},
+			ExpectedEvent: pipeline.FiredFail,
+			ExpectedErr:   httpexec.ErrContentTypeNotAllowed,
+			ExpectedExchange: pipeline.HTTPExchange{
+				Method:              http.MethodGet,
+				URL:                 srv.URL + "/sold/1",
+				ResponseCode:        http.StatusOK,
+				ResponseContentType: "text/plain; charset=utf-8",
+				ResponseBody:        "plain",
+			},
+		},
+		{
+			Name:    "crashed_by_unknown_template",
+			Context: context.Background,
+			GivenHTTP: func(b *specification.HTTPBuilder) {
+				b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+					b.
+						WithMethod(specification.GET).
+						WithURL(srv.URL + "/{{sell.response.headers.Location}}")
+				})
+			},
+			ExpectedEvent: pipeline.FiredCrash,
+		},
+		{
+			Name:    "crashed_by_xml_body",
+			Context: context.Background,
+			GivenHTTP: func(b *specification.HTTPBuilder) {
+				b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+					b.
+						WithMethod(specification.POST).
+						WithURL(srv.URL + "/sold").
+						WithContentType(specification.ApplicationXML).
+						WithBody(map[string]interface{}{"code": "HRN"})
+				})
+			},
+			ExpectedEvent: pipeline.FiredCrash,
+			ExpectedErr:   httpexec.ErrUnsupportedBody,
+		},
+		{
+			Name: "canceled",
+			Context: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			},
+			GivenHTTP: func(b *specification.HTTPBuilder) {
+				b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+					b.
+						WithMethod(specification.GET).
+						WithURL(srv.URL + "/sold/1")
+				})
+			},
+			ExpectedEvent: pipeline.FiredCancel,
+			ExpectedExchange: pipeline.HTTPExchange{
+				Method: http.MethodGet,
+				URL:    srv.URL + "/sold/1",
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			env := pipeline.NewEnvironment(1)
+			if c.PrepareEnv != nil {
+				c.PrepareEnv(env)
+			}
+
+			var b specification.ThesisBuilder
+
+			thesis := b.
+				WithStatement(specification.When, "request").
+				WithHTTP(c.GivenHTTP).
+				Build(specification.NewThesisSlug("foo", "bar", "request"))
+
+			result := httpexec.NewExecutor(time.Second).Execute(c.Context(), env, thesis)
+
+			require.Equal(t, c.ExpectedEvent, result.Event())
+			require.Equal(t, c.ExpectedExchange, result.Exchange())
+
+			if c.ExpectedErr != nil {
+				require.True(t, errors.Is(result.Err(), c.ExpectedErr))
+			}
+
+			if c.ExpectedStore != nil {
+				stored, ok := env.Load("request")
+				require.True(t, ok)
+
+				response := stored.(map[string]interface{})["response"].(map[string]interface{})
+				require.Equal(t, c.ExpectedStore, response["body"])
+			}
+		})
+	}
+}
+
+func TestRestrictedExecute(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sold/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("plain"))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)+"/sold/1", http.StatusFound)
+	})
+
+	testCases := []struct {
+		Name          string
+		AllowedHosts  []string
+		Path          string
+		ExpectedEvent pipeline.Event
+		ExpectedErr   error
+	}{
+		{
+			Name:          "allowed_host",
+			AllowedHosts:  []string{"api.example.com", "127.0.0.1"},
+			Path:          "/sold/1",
+			ExpectedEvent: pipeline.FiredPass,
+		},
+		{
+			Name:          "not_allowed_host",
+			AllowedHosts:  []string{"api.example.com", "*.0.0.2"},
+			Path:          "/sold/1",
+			ExpectedEvent: pipeline.FiredCrash,
+			ExpectedErr:   httpexec.ErrHostNotAllowed,
+		},
+		{
+			Name:          "no_allowed_hosts",
+			Path:          "/sold/1",
+			ExpectedEvent: pipeline.FiredCrash,
+			ExpectedErr:   httpexec.ErrHostNotAllowed,
+		},
+		{
+			Name:          "redirect_to_not_allowed_host",
+			AllowedHosts:  []string{"127.0.0.1"},
+			Path:          "/redirect",
+			ExpectedEvent: pipeline.FiredCrash,
+			ExpectedErr:   httpexec.ErrHostNotAllowed,
+		},
+		{
+			Name:          "redirect_to_allowed_host",
+			AllowedHosts:  []string{"127.0.0.1", "LOCALHOST"},
+			Path:          "/redirect",
+			ExpectedEvent: pipeline.FiredPass,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var b specification.ThesisBuilder
+
+			thesis := b.
+				WithStatement(specification.When, "request").
+				WithHTTP(func(b *specification.HTTPBuilder) {
+					b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+						b.
+							WithMethod(specification.GET).
+							WithURL(srv.URL + c.Path)
+					})
+				}).
+				Build(specification.NewThesisSlug("foo", "bar", "request"))
+
+			result := httpexec.
+				NewRestrictedExecutor(time.Second, c.AllowedHosts).
+				Execute(context.Background(), pipeline.NewEnvironment(1), thesis)
+
+			require.Equal(t, c.ExpectedEvent, result.Event())
+
+			if c.ExpectedErr != nil {
+				require.True(t, errors.Is(result.Err(), c.ExpectedErr))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package jsonpath
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+var ErrUnsupportedAssertionMethod = errors.New("unsupported assertion method")
+
+// Executor executes assertions of theses with JSONPath
+// method. Actual value of each assert is looked up in
+// the environment with Lookup and compared with the
+// expected value as JSON.
+type Executor struct{}
+
+func NewExecutor() Executor {
+	return Executor{}
+}
+
+func (e Executor) Execute(
+	ctx context.Context,
+	env *pipeline.Environment,
+	thesis specification.Thesis,
+) pipeline.Result {
+	if err := ctx.Err(); err != nil {
+		return pipeline.Cancel(err)
+	}
+
+	assertion := thesis.Assertion()
+
+	if assertion.Method() != specification.JSONPath {
+		return pipeline.Crash(errors.Wrap(ErrUnsupportedAssertionMethod, assertion.Method().String()))
+	}
+
+	var err error
+
+	for _, a := range assertion.Asserts() {
+		err = multierr.Append(err, check(env, a))
+	}
+
+	if err != nil {
+		return pipeline.Fail(err)
+	}
+
+	return pipeline.Pass()
+}
+
+func check(env *pipeline.Environment, a specification.Assert) error {
+	actual, err := Lookup(env, a.Actual())
+	if err != nil {
+		return err
+	}
+
+	actualJSON, err := json.Marshal(actual)
+	if err != nil {
+		return errors.Wrap(err, a.Actual())
+	}
+
+	expectedJSON, err := json.Marshal(a.Expected())
+	if err != nil {
+		return errors.Wrap(err, a.Actual())
+	}
+
+	if !equalJSON(actualJSON, expectedJSON) {
+		return errors.Errorf("%s: expected %s, actual %s", a.Actual(), expectedJSON, actualJSON)
+	}
+
+	return nil
+}
+
+func equalJSON(a, b []byte) bool {
+	var va, vb interface{}
+
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,111 @@
+package jsonpath_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/jsonpath"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		Context       func() context.Context
+		GivenAsserts  map[string]interface{}
+		Method        specification.AssertionMethod
+		ExpectedEvent pipeline.Event
+	}{
+		{
+			Name:    "passed",
+			Context: context.Background,
+			GivenAsserts: map[string]interface{}{
+				"getProducts.response.body.products..itemsCount": []int{103, 21},
+				"getProducts.response.code":                      200,
+			},
+			Method:        specification.JSONPath,
+			ExpectedEvent: pipeline.FiredPass,
+		},
+		{
+			Name:    "failed_by_mismatch",
+			Context: context.Background,
+			GivenAsserts: map[string]interface{}{
+				"getProducts.response.body.products..itemsCount": []int{103, 20},
+			},
+			Method:        specification.JSONPath,
+			ExpectedEvent: pipeline.FiredFail,
+		},
+		{
+			Name:    "failed_by_missing_value",
+			Context: context.Background,
+			GivenAsserts: map[string]interface{}{
+				"getOrders.response.code": 200,
+			},
+			Method:        specification.JSONPath,
+			ExpectedEvent: pipeline.FiredFail,
+		},
+		{
+			Name:    "crashed_by_unsupported_method",
+			Context: context.Background,
+			GivenAsserts: map[string]interface{}{
+				"getProducts.response.code": 200,
+			},
+			Method:        specification.AssertionMethod("xpath"),
+			ExpectedEvent: pipeline.FiredCrash,
+		},
+		{
+			Name: "canceled",
+			Context: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			},
+			Method:        specification.JSONPath,
+			ExpectedEvent: pipeline.FiredCancel,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			env := pipeline.NewEnvironment(1)
+			env.Store("getProducts", map[string]interface{}{
+				"response": map[string]interface{}{
+					"code": 200,
+					"body": map[string]interface{}{
+						"products": []interface{}{
+							map[string]interface{}{"itemsCount": 103.0},
+							map[string]interface{}{"itemsCount": 21.0},
+						},
+					},
+				},
+			})
+
+			var b specification.ThesisBuilder
+
+			thesis := b.
+				WithStatement(specification.Then, "check products").
+				WithAssertion(func(b *specification.AssertionBuilder) {
+					b.WithMethod(c.Method)
+
+					for actual, expected := range c.GivenAsserts {
+						b.WithAssert(actual, expected)
+					}
+				}).
+				Build(specification.NewThesisSlug("foo", "bar", "checkProducts"))
+
+			result := jsonpath.NewExecutor().Execute(c.Context(), env, thesis)
+
+			require.Equal(t, c.ExpectedEvent, result.Event())
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package jsonpath
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+var (
+	ErrInvalidPath = errors.New("invalid path")
+	ErrNoValue     = errors.New("no value by path")
+)
+
+type stepKind int
+
+const (
+	childStep stepKind = iota
+	descendantStep
+	indexStep
+	wildcardStep
+)
+
+type step struct {
+	kind  stepKind
+	name  string
+	index int
+}
+
+// Lookup returns the value stored by previous theses in the
+// environment. The first part of the path is a thesis slug,
+// the rest is JSONPath applied to the thesis data, e.g.
+// getProducts.response.body.products..itemsCount.
+//
+// Path with recursive descent (..) or wildcard (*) returns
+// the slice of all found values, otherwise the single value
+// or ErrNoValue is returned.
+func Lookup(env *pipeline.Environment, path string) (interface{}, error) {
+	root, steps, err := parse(path)
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+
+	value, ok := env.Load(root)
+	if !ok {
+		return nil, errors.Wrap(ErrNoValue, path)
+	}
+
+	nodes := []interface{}{value}
+	definite := true
+
+	for _, s := range steps {
+		if s.kind == descendantStep || s.kind == wildcardStep {
+			definite = false
+		}
+
+		nodes = apply(nodes, s)
+	}
+
+	if !definite {
+		if nodes == nil {
+			nodes = []interface{}{}
+		}
+
+		return nodes, nil
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.Wrap(ErrNoValue, path)
+	}
+
+	return nodes[0], nil
+}
+
+func parse(path string) (string, []step, error) {
+	end := strings.IndexAny(path, ".[")
+	if end == -1 {
+		end = len(path)
+	}
+
+	root := path[:end]
+	if root == "" {
+		return "", nil, ErrInvalidPath
+	}
+
+	var steps []step
+
+	rest := path[end:]
+
+	for rest != "" {
+		var (
+			s   step
+			err error
+		)
+
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			s.kind = descendantStep
+			s.name, rest = readName(rest[2:])
+		case strings.HasPrefix(rest, "."):
+			s.kind = childStep
+			s.name, rest = readName(rest[1:])
+
+			if s.name == "*" {
+				s.kind = wildcardStep
+			}
+		case strings.HasPrefix(rest, "["):
+			s, rest, err = readBracket(rest)
+		}
+
+		if err != nil || (s.kind != indexStep && s.name == "") {
+			return "", nil, ErrInvalidPath
+		}
+
+		steps = append(steps, s)
+	}
+
+	return root, steps, nil
+}
+
+func readName(s string) (name, rest string) {
+	end := strings.IndexAny(s, ".[")
+	if end == -1 {
+		return s, ""
+	}
+
+	return s[:end], s[end:]
+}
+
+func readBracket(s string) (step, string, error) {
+	end := strings.IndexByte(s, ']')
+	if end == -1 {
+		return step{}, "", ErrInvalidPath
+	}
+
+	inner, rest := s[1:end], s[end+1:]
+
+	if inner == "*" {
+		return step{kind: wildcardStep, name: inner}, rest, nil
+	}
+
+	if isQuoted(inner) {
+		return step{kind: childStep, name: inner[1 : len(inner)-1]}, rest, nil
+	}
+
+	index, err := strconv.Atoi(inner)
+	if err != nil {
+		return step{}, "", ErrInvalidPath
+	}
+
+	return step{kind: indexStep, index: index}, rest, nil
+}
+
+func isQuoted(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+
+	return (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]
+}
+
+func apply(nodes []interface{}, s step) []interface{} {
+	var result []interface{}
+
+	for _, node := range nodes {
+		switch s.kind {
+		case childStep:
+			if obj, ok := node.(map[string]interface{}); ok {
+				if v, ok := obj[s.name]; ok {
+					result = append(result, v)
+				}
+			}
+		case descendantStep:
+			result = appendDescendants(result, node, s.name)
+		case indexStep:
+			if arr, ok := node.([]interface{}); ok {
+				i := s.index
+				if i < 0 {
+					i += len(arr)
+				}
+
+				if i >= 0 && i < len(arr) {
+					result = append(result, arr[i])
+				}
+			}
+		case wildcardStep:
+			result = append(result, children(node)...)
+		}
+	}
+
+	return result
+}
+
+func appendDescendants(result []interface{}, node interface{}, name string) []interface{} {
+	if obj, ok := node.(map[string]interface{}); ok && name != "*" {
+		if v, ok := obj[name]; ok {
+			result = append(result, v)
+		}
+	}
+
+	for _, child := range children(node) {
+		if name == "*" {
+			result = append(result, child)
+		}
+
+		result = appendDescendants(result, child, name)
+	}
+
+	return result
+}
+
+func children(node interface{}) []interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		values := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			values = append(values, v[k])
+		}
+
+		return values
+	case []interface{}:
+		return v
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package jsonpath_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/jsonpath"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	env := pipeline.NewEnvironment(1)
+	env.Store("getProducts", map[string]interface{}{
+		"response": map[string]interface{}{
+			"code": 200,
+			"headers": map[string]interface{}{
+				"Content-Location": "sold/1",
+			},
+			"body": map[string]interface{}{
+				"products": []interface{}{
+					map[string]interface{}{"code": "HRN", "itemsCount": 103.0},
+					map[string]interface{}{"code": "HVS", "itemsCount": 21.0},
+				},
+			},
+		},
+	})
+
+	testCases := []struct {
+		Name          string
+		Path          string
+		ExpectedValue interface{}
+		ShouldBeErr   bool
+		IsErr         func(err error) bool
+	}{
+		{
+			Name:          "child",
+			Path:          "getProducts.response.code",
+			ExpectedValue: 200,
+		},
+		{
+			Name:          "child_with_dash",
+			Path:          "getProducts.response.headers.Content-Location",
+			ExpectedValue: "sold/1",
+		},
+		{
+			Name:          "quoted_child",
+			Path:          "getProducts.response.headers['Content-Location']",
+			ExpectedValue: "sold/1",
+		},
+		{
+			Name:          "index",
+			Path:          "getProducts.response.body.products[1].code",
+			ExpectedValue: "HVS",
+		},
+		{
+			Name:          "negative_index",
+			Path:          "getProducts.response.body.products[-2].code",
+			ExpectedValue: "HRN",
+		},
+		{
+			Name:          "recursive_descent",
+			Path:          "getProducts.response.body.products..itemsCount",
+			ExpectedValue: []interface{}{103.0, 21.0},
+		},
+		{
+			Name:          "wildcard",
+			Path:          "getProducts.response.body.products[*].code",
+			ExpectedValue: []interface{}{"HRN", "HVS"},
+		},
+		{
+			Name:          "recursive_descent_without_values",
+			Path:          "getProducts..price",
+			ExpectedValue: []interface{}{},
+		},
+		{
+			Name:        "unknown_thesis",
+			Path:        "getOrders.response.code",
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, jsonpath.ErrNoValue)
+			},
+		},
+		{
+			Name:        "unknown_child",
+			Path:        "getProducts.response.status",
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, jsonpath.ErrNoValue)
+			},
+		},
+		{
+			Name:        "index_out_of_range",
+			Path:        "getProducts.response.body.products[2]",
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, jsonpath.ErrNoValue)
+			},
+		},
+		{
+			Name:        "empty_path",
+			Path:        "",
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, jsonpath.ErrInvalidPath)
+			},
+		},
+		{
+			Name:        "unclosed_bracket",
+			Path:        "getProducts.response.body.products[0",
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, jsonpath.ErrInvalidPath)
+			},
+		},
+		{
+			Name:        "empty_child",
+			Path:        "getProducts.response.",
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, jsonpath.ErrInvalidPath)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := jsonpath.Lookup(env, c.Path)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.ExpectedValue, value)
+		})
+	}
+}
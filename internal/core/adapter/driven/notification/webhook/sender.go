@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/pkg/httphost"
+)
+
+const (
+	EventHeader     = "X-Thestis-Event"
+	DeliveryHeader  = "X-Thestis-Delivery"
+	TimestampHeader = "X-Thestis-Timestamp"
+	SignatureHeader = "X-Thestis-Signature"
+)
+
+// Sender posts notification payloads as JSON. Each request is
+// signed the same way as inbound triggers, so receivers can
+// verify it with the subscription secret.
+type Sender struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+const maxRedirects = 10
+
+// NewSender returns Sender posting notifications, including
+// redirected ones, only to allowedHosts. The host is allowed
+// if it equals one of allowedHosts or matches the pattern
+// like *.example.com. With no allowed hosts nothing is sent.
+func NewSender(timeout time.Duration, allowedHosts []string) *Sender {
+	s := &Sender{allowedHosts: allowedHosts}
+
+	s.client = &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return s.checkHost(req)
+		},
+	}
+
+	return s
+}
+
+var (
+	ErrUnexpectedStatus = errors.New("unexpected response status")
+	ErrHostNotAllowed   = errors.New("receiver host is not allowed")
+)
+
+// SendNotification returns ErrUnexpectedStatus if the receiver
+// responds with non 2xx status and ErrHostNotAllowed if the
+// notification URL or the redirect leads to the host out
+// of allowed ones.
+func (s *Sender) SendNotification(
+	ctx context.Context,
+	n service.Notification,
+	secret string,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(n.Payload))
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkHost(req); err != nil {
+		return err
+	}
+
+	timestamp := time.Now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(n.Event))
+	req.Header.Set(DeliveryHeader, n.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(SignatureHeader, testcampaign.SignNotification(secret, n.Payload, timestamp))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrHostNotAllowed) {
+			return ErrHostNotAllowed
+		}
+
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Wrapf(ErrUnexpectedStatus, "%d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *Sender) checkHost(req *http.Request) error {
+	if httphost.Allowed(req.URL.Hostname(), s.allowedHosts) {
+		return nil
+	}
+
+	return ErrHostNotAllowed
+}
@@ -0,0 +1,272 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/notification/webhook"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+// receiverHosts allows hosts of httptest servers.
+var receiverHosts = []string{"127.0.0.1"}
+
+func TestSenderSendNotification(t *testing.T) {
+	t.Parallel()
+
+	const secret = "secret"
+
+	testCases := []struct {
+		Name           string
+		ResponseStatus int
+		ShouldBeErr    bool
+	}{
+		{
+			Name:           "delivered",
+			ResponseStatus: http.StatusOK,
+			ShouldBeErr:    false,
+		},
+		{
+			Name:           "accepted",
+			ResponseStatus: http.StatusAccepted,
+			ShouldBeErr:    false,
+		},
+		{
+			Name:           "receiver_error",
+			ResponseStatus: http.StatusInternalServerError,
+			ShouldBeErr:    true,
+		},
+		{
+			Name:           "redirect_is_not_followed",
+			ResponseStatus: http.StatusNotModified,
+			ShouldBeErr:    true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				payload  = []byte(`{"event":"failed"}`)
+				received = make(chan *http.Request, 1)
+				bodies   = make(chan []byte, 1)
+			)
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				received <- r
+				bodies <- body
+
+				w.WriteHeader(c.ResponseStatus)
+			}))
+			defer receiver.Close()
+
+			err := webhook.NewSender(time.Second, receiverHosts).SendNotification(
+				context.Background(),
+				service.Notification{
+					ID:      "notification-id",
+					URL:     receiver.URL,
+					Event:   testcampaign.FailedEvent,
+					Payload: payload,
+				},
+				secret,
+			)
+
+			if c.ShouldBeErr {
+				require.ErrorIs(t, err, webhook.ErrUnexpectedStatus)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var (
+				r    = <-received
+				body = <-bodies
+			)
+
+			unix, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+			require.NoError(t, err)
+
+			require.Equal(t, payload, body)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.Equal(t, "failed", r.Header.Get(webhook.EventHeader))
+			require.Equal(t, "notification-id", r.Header.Get(webhook.DeliveryHeader))
+			require.Equal(
+				t,
+				testcampaign.SignNotification(secret, body, time.Unix(unix, 0)),
+				r.Header.Get(webhook.SignatureHeader),
+			)
+		})
+	}
+}
+
+func TestSenderUnavailableReceiver(t *testing.T) {
+	t.Parallel()
+
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	err := webhook.NewSender(time.Second, receiverHosts).SendNotification(
+		context.Background(),
+		service.Notification{
+			ID:  "notification-id",
+			URL: receiver.URL,
+		},
+		"secret",
+	)
+
+	require.Error(t, err)
+}
+
+func TestSenderRestrictsHosts(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan struct{}, 1)
+
+	allowed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer allowed.Close()
+
+	// localhost isn't allowed, while 127.0.0.1 of the receiver is.
+	internal := strings.Replace(allowed.URL, "127.0.0.1", "localhost", 1)
+
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal, http.StatusTemporaryRedirect)
+	}))
+	defer redirecting.Close()
+
+	testCases := []struct {
+		Name string
+		URL  string
+	}{
+		{
+			Name: "host_not_allowed",
+			URL:  internal,
+		},
+		{
+			Name: "redirect_to_host_not_allowed",
+			URL:  redirecting.URL,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			err := webhook.NewSender(time.Second, receiverHosts).SendNotification(
+				context.Background(),
+				service.Notification{
+					ID:      "notification-id",
+					URL:     c.URL,
+					Payload: []byte(`{}`),
+				},
+				"secret",
+			)
+
+			require.ErrorIs(t, err, webhook.ErrHostNotAllowed)
+			require.Equal(t, webhook.ErrHostNotAllowed.Error(), err.Error(), "host shouldn't leak to delivery log")
+			require.Empty(t, received)
+		})
+	}
+}
+
+func TestNotifierDeliversToReceiver(t *testing.T) {
+	t.Parallel()
+
+	var (
+		attempts = make(chan struct{}, 2)
+		events   = make(chan string, 2)
+	)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts <- struct{}{}
+
+		if len(attempts) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		events <- r.Header.Get(webhook.EventHeader)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	var (
+		notificationRepo = mock.NewNotificationRepository()
+		tcRepo           = mock.NewTestCampaignRepository(testcampaign.MustNew(testcampaign.Params{
+			ID:      "tc",
+			OwnerID: "owner",
+			Subscriptions: []testcampaign.Subscription{
+				testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+					ID:     "subscription",
+					URL:    receiver.URL,
+					Filter: testcampaign.FailedEvent,
+					Secret: "secret",
+				}),
+			},
+		}))
+	)
+
+	notifier := service.NewWebhookNotifier(
+		tcRepo,
+		notificationRepo,
+		webhook.NewSender(time.Second, receiverHosts),
+		mock.NewMemoryLogger(),
+		3,
+		time.Millisecond,
+	)
+
+	pipe := pipeline.Trigger(
+		"pipeline",
+		(&specification.Builder{}).
+			WithTestCampaignID("tc").
+			WithStory("a", func(b *specification.StoryBuilder) {
+				b.WithScenario("b", func(b *specification.ScenarioBuilder) {
+					b.WithThesis("c", func(b *specification.ThesisBuilder) {
+						b.WithAssertion(func(b *specification.AssertionBuilder) {
+							b.WithMethod(specification.JSONPath)
+						})
+					})
+				})
+			}).
+			ErrlessBuild(),
+		pipeline.WithAssertion(pipeline.FailingExecutor()),
+	)
+
+	f := flow.Fulfill("flow", pipe)
+
+	for s := range pipe.MustStart(context.Background()) {
+		f = f.ApplyStep(s)
+	}
+
+	notifier.NotifyFlowCompleted(context.Background(), pipe, f)
+	notifier.Wait()
+
+	require.Equal(t, "failed", <-events)
+
+	notifications := notificationRepo.Notifications()
+	require.Len(t, notifications, 1)
+	require.Equal(t, service.NotificationDelivered, notifications[0].State)
+	require.Equal(t, 2, notifications[0].Attempts)
+}
@@ -0,0 +1,345 @@
+package gherkin
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+type (
+	feature struct {
+		name        string
+		position    specification.Position
+		description []string
+		asA         string
+		inOrderTo   string
+		wantTo      string
+		background  []*step
+		scenarios   []*scenario
+	}
+
+	scenario struct {
+		name        string
+		position    specification.Position
+		description []string
+		steps       []*step
+	}
+
+	step struct {
+		stage     specification.Stage
+		text      string
+		position  specification.Position
+		docString *docString
+	}
+
+	// docString is the argument of the step with
+	// details of the thesis in the YAML format.
+	docString struct {
+		content  string
+		position specification.Position
+	}
+)
+
+var (
+	errNoFeature          = errors.New("no feature")
+	errMoreThanOneFeature = errors.New("only one feature is allowed")
+	errNotSupported       = errors.New("not supported")
+	errNoPreviousStep     = errors.New("no previous step to continue")
+	errUnexpectedLine     = errors.New("unexpected line")
+	errUnclosedDocString  = errors.New("unclosed docstring")
+	errUnknownMediaType   = errors.New("unknown docstring media type")
+)
+
+const (
+	featureKeyword    = "Feature:"
+	backgroundKeyword = "Background:"
+)
+
+var scenarioKeywords = []string{"Scenario:", "Example:"}
+
+var unsupportedKeywords = []string{
+	"Scenario Outline:",
+	"Scenario Template:",
+	"Examples:",
+	"Scenarios:",
+	"Rule:",
+}
+
+var stepKeywords = map[string]specification.Stage{
+	"Given ": specification.Given,
+	"When ":  specification.When,
+	"Then ":  specification.Then,
+	"And ":   specification.NoStage,
+	"But ":   specification.NoStage,
+	"* ":     specification.NoStage,
+}
+
+var docStringDelimiters = []string{`"""`, "```"}
+
+var docStringMediaTypes = map[string]bool{
+	"":     true,
+	"yaml": true,
+	"yml":  true,
+}
+
+// narrativePrefixes are prefixes of the feature
+// description lines with the story narrative.
+var narrativePrefixes = []struct {
+	prefix string
+	set    func(f *feature, value string)
+}{
+	{"as a ", func(f *feature, value string) { f.asA = value }},
+	{"as an ", func(f *feature, value string) { f.asA = value }},
+	{"in order to ", func(f *feature, value string) { f.inOrderTo = value }},
+	{"so that ", func(f *feature, value string) { f.inOrderTo = value }},
+	{"i want to ", func(f *feature, value string) { f.wantTo = value }},
+}
+
+type documentParser struct {
+	feature    *feature
+	scenario   *scenario
+	background bool
+	lastStep   *step
+
+	docString          *docString
+	docStringDelimiter string
+	docStringIndent    int
+	docStringLines     []string
+}
+
+// parseDocument parses the feature file. Errors are
+// positioned at the line of the feature file.
+func parseDocument(reader io.Reader) (*feature, error) {
+	var (
+		p       documentParser
+		scanner = bufio.NewScanner(reader)
+		line    = 0
+	)
+
+	for scanner.Scan() {
+		line++
+
+		if err := p.parseLine(scanner.Text(), line); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if p.docString != nil {
+		return nil, specification.NewPositionedError(errUnclosedDocString, p.docString.position)
+	}
+
+	if p.feature == nil {
+		return nil, errNoFeature
+	}
+
+	return p.feature, nil
+}
+
+func (p *documentParser) parseLine(raw string, line int) error {
+	if p.docString != nil {
+		p.parseDocStringLine(raw)
+
+		return nil
+	}
+
+	text := strings.TrimSpace(raw)
+	if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "@") {
+		return nil
+	}
+
+	pos := specification.Position{
+		Line:   line,
+		Column: len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace)) + 1,
+	}
+
+	err := p.parseText(text, pos)
+	if err != nil {
+		return specification.NewPositionedError(err, pos)
+	}
+
+	return nil
+}
+
+func (p *documentParser) parseText(text string, pos specification.Position) error {
+	for _, keyword := range unsupportedKeywords {
+		if strings.HasPrefix(text, keyword) {
+			return errors.Wrap(errNotSupported, strings.TrimSuffix(keyword, ":"))
+		}
+	}
+
+	if strings.HasPrefix(text, featureKeyword) {
+		return p.startFeature(strings.TrimSpace(strings.TrimPrefix(text, featureKeyword)), pos)
+	}
+
+	if p.feature == nil {
+		return errUnexpectedLine
+	}
+
+	if strings.HasPrefix(text, backgroundKeyword) {
+		p.scenario, p.background, p.lastStep = nil, true, nil
+
+		return nil
+	}
+
+	for _, keyword := range scenarioKeywords {
+		if strings.HasPrefix(text, keyword) {
+			p.startScenario(strings.TrimSpace(strings.TrimPrefix(text, keyword)), pos)
+
+			return nil
+		}
+	}
+
+	for keyword, stage := range stepKeywords {
+		if strings.HasPrefix(text, keyword) {
+			return p.addStep(stage, strings.TrimSpace(strings.TrimPrefix(text, keyword)), pos)
+		}
+	}
+
+	for _, delimiter := range docStringDelimiters {
+		if strings.HasPrefix(text, delimiter) {
+			return p.startDocString(delimiter, strings.TrimPrefix(text, delimiter), pos)
+		}
+	}
+
+	if strings.HasPrefix(text, "|") {
+		return errors.Wrap(errNotSupported, "data tables")
+	}
+
+	return p.addDescription(text)
+}
+
+func (p *documentParser) startFeature(name string, pos specification.Position) error {
+	if p.feature != nil {
+		return errMoreThanOneFeature
+	}
+
+	p.feature = &feature{
+		name:     name,
+		position: pos,
+	}
+
+	return nil
+}
+
+func (p *documentParser) startScenario(name string, pos specification.Position) {
+	p.scenario = &scenario{
+		name:     name,
+		position: pos,
+	}
+	p.background = false
+	p.lastStep = nil
+
+	p.feature.scenarios = append(p.feature.scenarios, p.scenario)
+}
+
+func (p *documentParser) addStep(stage specification.Stage, text string, pos specification.Position) error {
+	if !p.background && p.scenario == nil {
+		return errUnexpectedLine
+	}
+
+	if stage == specification.NoStage {
+		if p.lastStep == nil {
+			return errNoPreviousStep
+		}
+
+		stage = p.lastStep.stage
+	}
+
+	p.lastStep = &step{
+		stage:    stage,
+		text:     text,
+		position: pos,
+	}
+
+	if p.background {
+		p.feature.background = append(p.feature.background, p.lastStep)
+
+		return nil
+	}
+
+	p.scenario.steps = append(p.scenario.steps, p.lastStep)
+
+	return nil
+}
+
+func (p *documentParser) addDescription(text string) error {
+	switch {
+	case p.lastStep != nil || p.background:
+		return errUnexpectedLine
+	case p.scenario != nil:
+		p.scenario.description = append(p.scenario.description, text)
+
+		return nil
+	}
+
+	lower := strings.ToLower(text)
+
+	for _, n := range narrativePrefixes {
+		if strings.HasPrefix(lower, n.prefix) {
+			n.set(p.feature, strings.TrimSpace(text[len(n.prefix):]))
+
+			return nil
+		}
+	}
+
+	p.feature.description = append(p.feature.description, text)
+
+	return nil
+}
+
+func (p *documentParser) startDocString(delimiter, mediaType string, pos specification.Position) error {
+	if p.lastStep == nil || p.lastStep.docString != nil {
+		return errUnexpectedLine
+	}
+
+	if !docStringMediaTypes[strings.TrimSpace(mediaType)] {
+		return errors.Wrap(errUnknownMediaType, strings.TrimSpace(mediaType))
+	}
+
+	p.docString = &docString{
+		position: specification.Position{
+			Line:   pos.Line + 1,
+			Column: pos.Column,
+		},
+	}
+	p.docStringDelimiter = delimiter
+	p.docStringIndent = pos.Column - 1
+	p.docStringLines = nil
+
+	return nil
+}
+
+func (p *documentParser) parseDocStringLine(raw string) {
+	if strings.TrimSpace(raw) == p.docStringDelimiter {
+		p.docString.content = strings.Join(p.docStringLines, "\n")
+		p.lastStep.docString = p.docString
+		p.docString = nil
+
+		return
+	}
+
+	p.docStringLines = append(p.docStringLines, trimIndent(raw, p.docStringIndent))
+}
+
+// trimIndent removes up to indent leading whitespaces
+// like the indentation of docstring delimiter.
+func trimIndent(s string, indent int) string {
+	for i := 0; i < indent && len(s) > 0; i++ {
+		if !unicode.IsSpace(rune(s[0])) {
+			break
+		}
+
+		s = s[1:]
+	}
+
+	return s
+}
@@ -0,0 +1,8 @@
+Feature: Test feature
+
+  Scenario: Test scenario
+    When test request is sent
+      """
+      http:
+        request: [
+      """
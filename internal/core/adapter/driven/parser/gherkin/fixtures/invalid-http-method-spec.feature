@@ -0,0 +1,10 @@
+Feature: Test feature
+
+  Scenario: Test scenario
+    When test request is sent
+      """
+      http:
+        request:
+          method: FETCH
+          url: https://something.net/test
+      """
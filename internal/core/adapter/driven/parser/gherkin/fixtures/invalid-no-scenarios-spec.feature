@@ -0,0 +1,2 @@
+Feature: Test feature
+  As a tester
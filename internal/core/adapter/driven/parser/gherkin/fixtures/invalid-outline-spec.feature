@@ -0,0 +1,4 @@
+Feature: Test feature
+
+  Scenario Outline: Test scenario
+    When test request is sent
@@ -0,0 +1,50 @@
+@smoke
+Feature: Test feature
+  Simple valid fixture specification
+
+  As a tester
+  In order to test
+  I want to test
+
+  Background:
+    Given test service is up
+      """
+      http:
+        request:
+          method: GET
+          url: https://something.net/health
+        response:
+          allowedCodes: [200]
+      """
+
+  # The only scenario of the feature
+  Scenario: Test scenario
+    Test description
+
+    When test request is sent
+      """yaml
+      slug: test
+      http:
+        request:
+          method: GET
+          url: https://something.net/test
+        response:
+          allowedCodes: [201]
+          allowedContentType: application/json
+      """
+    Then test response is checked
+      ```
+      assertion:
+        with: jsonpath
+        assert:
+          - actual: test.response.body.test
+            expected: test
+      ```
+    But test response is checked
+      """
+      assertion:
+        with: jsonpath
+        assert:
+          - actual: test.response.code
+            expected: 201
+      """
@@ -0,0 +1,285 @@
+package gherkin
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+const contentType = "text/x-gherkin"
+
+// SpecificationParser parses specification from the Gherkin feature
+// file. Feature is mapped to the story, scenarios of the feature to
+// scenarios and steps to theses. Steps of the same stage are run
+// one after another. HTTP and assertion of the thesis are written
+// in the docstring of the step as in the YAML specification, the
+// slug of the thesis can be set with slug key of the docstring.
+type SpecificationParser struct{}
+
+func NewSpecificationParser() SpecificationParser {
+	return SpecificationParser{}
+}
+
+func (s SpecificationParser) ContentType() string {
+	return contentType
+}
+
+func (s SpecificationParser) ParseSpecification(
+	reader io.Reader,
+	opts ...service.ParserOption,
+) (*specification.Specification, error) {
+	f, err := parseDocument(reader)
+	if err != nil {
+		return nil, service.WrapWithParseError(err)
+	}
+
+	positions := make(map[specification.Slug]specification.Position)
+
+	buildFn, err := buildStory(f, positions)
+	if err != nil {
+		return nil, service.WrapWithParseError(err)
+	}
+
+	var b specification.Builder
+
+	b.WithTitle(f.name)
+
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	b.WithStory(slugOf(f.name), buildFn)
+
+	spec, err := b.Build()
+	if err != nil {
+		return nil, specification.LocateBuildError(err, locator(positions))
+	}
+
+	return spec, nil
+}
+
+var (
+	errNoSlug         = errors.New("slug can't be made of the name")
+	errDuplicatedSlug = errors.New("duplicated slug")
+)
+
+func buildStory(
+	f *feature,
+	positions map[specification.Slug]specification.Position,
+) (func(b *specification.StoryBuilder), error) {
+	storySlug := slugOf(f.name)
+	if storySlug == "" {
+		return nil, specification.NewPositionedError(errNoSlug, f.position)
+	}
+
+	positions[specification.NewStorySlug(storySlug)] = f.position
+
+	scenarioFns := make(map[string]func(b *specification.ScenarioBuilder), len(f.scenarios))
+	scenarioSlugs := make([]string, 0, len(f.scenarios))
+
+	for _, s := range f.scenarios {
+		slug := slugOf(s.name)
+
+		switch {
+		case slug == "":
+			return nil, specification.NewPositionedError(errNoSlug, s.position)
+		case scenarioFns[slug] != nil:
+			return nil, specification.NewPositionedError(errors.Wrap(errDuplicatedSlug, slug), s.position)
+		}
+
+		positions[specification.NewScenarioSlug(storySlug, slug)] = s.position
+
+		fn, err := buildScenario(specification.NewScenarioSlug(storySlug, slug), s, f.background, positions)
+		if err != nil {
+			return nil, err
+		}
+
+		scenarioFns[slug] = fn
+		scenarioSlugs = append(scenarioSlugs, slug)
+	}
+
+	return func(b *specification.StoryBuilder) {
+		b.
+			WithDescription(strings.Join(f.description, "\n")).
+			WithAsA(f.asA).
+			WithInOrderTo(f.inOrderTo).
+			WithWantTo(f.wantTo)
+
+		for _, slug := range scenarioSlugs {
+			b.WithScenario(slug, scenarioFns[slug])
+		}
+	}, nil
+}
+
+type thesisFunc struct {
+	slug string
+	fn   func(b *specification.ThesisBuilder)
+}
+
+func buildScenario(
+	scenarioSlug specification.Slug,
+	s *scenario,
+	background []*step,
+	positions map[specification.Slug]specification.Position,
+) (func(b *specification.ScenarioBuilder), error) {
+	var (
+		steps    = append(append([]*step(nil), background...), s.steps...)
+		theses   = make([]thesisFunc, 0, len(steps))
+		slugs    = make(map[string]bool, len(steps))
+		previous = make(map[specification.Stage]string)
+	)
+
+	for _, st := range steps {
+		slug, explicit, err := thesisSlug(st)
+		if err != nil {
+			return nil, err
+		}
+
+		if slugs[slug] && explicit {
+			return nil, specification.NewPositionedError(errors.Wrap(errDuplicatedSlug, slug), st.position)
+		}
+
+		slug = uniqueSlug(slug, slugs)
+		slugs[slug] = true
+
+		positions[specification.NewThesisSlug(scenarioSlug.Story(), scenarioSlug.Scenario(), slug)] = st.position
+
+		fn, err := buildThesis(st, previous[st.stage])
+		if err != nil {
+			return nil, err
+		}
+
+		previous[st.stage] = slug
+
+		theses = append(theses, thesisFunc{slug: slug, fn: fn})
+	}
+
+	return func(b *specification.ScenarioBuilder) {
+		b.WithDescription(strings.Join(s.description, "\n"))
+
+		for _, t := range theses {
+			b.WithThesis(t.slug, t.fn)
+		}
+	}, nil
+}
+
+func buildThesis(st *step, after string) (func(b *specification.ThesisBuilder), error) {
+	detailsFn := func(*specification.ThesisBuilder) {}
+
+	if st.docString != nil {
+		fn, err := yaml.ThesisDetails([]byte(st.docString.content))
+		if err != nil {
+			return nil, shiftPosition(err, st.docString.position)
+		}
+
+		detailsFn = fn
+	}
+
+	return func(b *specification.ThesisBuilder) {
+		b.WithStatement(st.stage, st.text)
+
+		if after != "" {
+			b.WithDependency(after)
+		}
+
+		detailsFn(b)
+	}, nil
+}
+
+// thesisSlug returns slug set in the docstring
+// of the step or made of the step text.
+func thesisSlug(st *step) (slug string, explicit bool, err error) {
+	if st.docString != nil {
+		var details struct {
+			Slug string `yaml:"slug"`
+		}
+
+		// Invalid docstring is reported with position
+		// while decoding details of the thesis.
+		err := yamlv3.Unmarshal([]byte(st.docString.content), &details)
+
+		if err == nil && details.Slug != "" {
+			return details.Slug, true, nil
+		}
+	}
+
+	slug = slugOf(st.text)
+	if slug == "" {
+		return "", false, specification.NewPositionedError(errNoSlug, st.position)
+	}
+
+	return slug, false, nil
+}
+
+func uniqueSlug(slug string, slugs map[string]bool) string {
+	if !slugs[slug] {
+		return slug
+	}
+
+	for i := 2; ; i++ {
+		if candidate := fmt.Sprintf("%s%d", slug, i); !slugs[candidate] {
+			return candidate
+		}
+	}
+}
+
+// shiftPosition positions the error of the docstring
+// content relative to the start of the docstring.
+func shiftPosition(err error, start specification.Position) error {
+	var perr *specification.PositionedError
+
+	if !errors.As(err, &perr) {
+		return specification.NewPositionedError(err, start)
+	}
+
+	return specification.NewPositionedError(err, specification.Position{
+		Line:   start.Line + perr.Position().Line - 1,
+		Column: start.Column,
+	})
+}
+
+// slugOf makes slug in lower camel case of the name words,
+// e.g. "Sell horns and hooves" becomes "sellHornsAndHooves".
+func slugOf(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+
+	for i, word := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word))
+
+			continue
+		}
+
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+
+	return b.String()
+}
+
+func locator(positions map[specification.Slug]specification.Position) specification.Locator {
+	return func(path []*specification.BuildError, _ error) (specification.Position, bool) {
+		for i := len(path) - 1; i >= 0; i-- {
+			if slug, ok := path[i].SlugContext(); ok {
+				pos, ok := positions[slug]
+
+				return pos, ok
+			}
+		}
+
+		return specification.Position{}, false
+	}
+}
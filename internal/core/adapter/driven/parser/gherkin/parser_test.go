@@ -0,0 +1,211 @@
+package gherkin_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/gherkin"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+const (
+	fixturesPath                   = "./fixtures"
+	validSpecPath                  = fixturesPath + "/valid-spec.feature"
+	invalidHTTPMethodSpecPath      = fixturesPath + "/invalid-http-method-spec.feature"
+	invalidDocStringSpecPath       = fixturesPath + "/invalid-docstring-spec.feature"
+	invalidOutlineSpecPath         = fixturesPath + "/invalid-outline-spec.feature"
+	invalidNoScenariosSpecPath     = fixturesPath + "/invalid-no-scenarios-spec.feature"
+	exampleSpecPath                = "../../../../../../examples/specification/horns-and-hooves-test"
+	exampleFeatureSpecPath         = exampleSpecPath + ".feature"
+	exampleYAMLSpecPath            = exampleSpecPath + ".yml"
+	expectedThesesOfValidScenarios = 4
+)
+
+func parseSpecification(
+	t *testing.T,
+	parser service.SpecificationParser,
+	specPath string,
+) (*specification.Specification, error) {
+	t.Helper()
+
+	specFile, err := os.Open(specPath)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = specFile.Close()
+	})
+
+	return parser.ParseSpecification(specFile)
+}
+
+func TestParseSpecification(t *testing.T) {
+	t.Parallel()
+
+	spec, err := parseSpecification(t, gherkin.NewSpecificationParser(), validSpecPath)
+	require.NoError(t, err)
+
+	require.Equal(t, "Test feature", spec.Title())
+
+	story, ok := spec.Story("testFeature")
+	require.True(t, ok)
+	require.Equal(t, "Simple valid fixture specification", story.Description())
+	require.Equal(t, "tester", story.AsA())
+	require.Equal(t, "test", story.InOrderTo())
+	require.Equal(t, "test", story.WantTo())
+
+	scenario, ok := story.Scenario("testScenario")
+	require.True(t, ok)
+	require.Equal(t, "Test description", scenario.Description())
+	require.Len(t, scenario.Theses(), expectedThesesOfValidScenarios)
+
+	testCases := []struct {
+		Slug                 string
+		ExpectedStage        specification.Stage
+		ExpectedBehavior     string
+		ExpectedDependencies []specification.Slug
+	}{
+		{
+			Slug:             "testServiceIsUp",
+			ExpectedStage:    specification.Given,
+			ExpectedBehavior: "test service is up",
+		},
+		{
+			Slug:             "test",
+			ExpectedStage:    specification.When,
+			ExpectedBehavior: "test request is sent",
+		},
+		{
+			Slug:             "testResponseIsChecked",
+			ExpectedStage:    specification.Then,
+			ExpectedBehavior: "test response is checked",
+		},
+		{
+			Slug:             "testResponseIsChecked2",
+			ExpectedStage:    specification.Then,
+			ExpectedBehavior: "test response is checked",
+			ExpectedDependencies: []specification.Slug{
+				specification.NewThesisSlug("testFeature", "testScenario", "testResponseIsChecked"),
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		thesis, ok := scenario.Thesis(c.Slug)
+		require.True(t, ok, c.Slug)
+
+		require.Equal(t, c.ExpectedStage, thesis.Stage())
+		require.Equal(t, c.ExpectedBehavior, thesis.Behavior())
+		require.Equal(t, c.ExpectedDependencies, thesis.Dependencies())
+	}
+
+	thesis, _ := scenario.Thesis("test")
+	require.Equal(t, specification.GET, thesis.HTTP().Request().Method())
+	require.Equal(t, []int{201}, thesis.HTTP().Response().AllowedCodes())
+
+	thesis, _ = scenario.Thesis("testResponseIsChecked2")
+	require.Equal(t, specification.JSONPath, thesis.Assertion().Method())
+	require.Len(t, thesis.Assertion().Asserts(), 1)
+}
+
+func TestParseSpecificationErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name             string
+		SpecPath         string
+		IsErr            func(err error) bool
+		ExpectedPosition specification.Position
+	}{
+		{
+			Name:     "invalid_http_method",
+			SpecPath: invalidHTTPMethodSpecPath,
+			IsErr: func(err error) bool {
+				var target *specification.NotAllowedHTTPMethodError
+
+				return errors.As(err, &target)
+			},
+			ExpectedPosition: specification.Position{Line: 4, Column: 5},
+		},
+		{
+			Name:     "invalid_docstring",
+			SpecPath: invalidDocStringSpecPath,
+			IsErr: func(err error) bool {
+				var target *service.ParseError
+
+				return errors.As(err, &target)
+			},
+			ExpectedPosition: specification.Position{Line: 7, Column: 7},
+		},
+		{
+			Name:     "scenario_outline",
+			SpecPath: invalidOutlineSpecPath,
+			IsErr: func(err error) bool {
+				var target *service.ParseError
+
+				return errors.As(err, &target)
+			},
+			ExpectedPosition: specification.Position{Line: 3, Column: 3},
+		},
+		{
+			Name:     "no_scenarios",
+			SpecPath: invalidNoScenariosSpecPath,
+			IsErr: func(err error) bool {
+				return errors.Is(err, specification.ErrNoStoryScenarios)
+			},
+			ExpectedPosition: specification.Position{Line: 1, Column: 1},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseSpecification(t, gherkin.NewSpecificationParser(), c.SpecPath)
+			require.True(t, c.IsErr(err), err)
+
+			details := specification.FlattenBuildError(err)
+			require.Len(t, details, 1)
+			require.Equal(t, c.ExpectedPosition, details[0].Position)
+		})
+	}
+}
+
+func TestParseExampleSpecification(t *testing.T) {
+	t.Parallel()
+
+	featureSpec, err := parseSpecification(t, gherkin.NewSpecificationParser(), exampleFeatureSpecPath)
+	require.NoError(t, err)
+
+	yamlSpec, err := parseSpecification(t, yaml.NewSpecificationParser(), exampleYAMLSpecPath)
+	require.NoError(t, err)
+
+	featureTheses := make(map[specification.Slug]specification.Thesis)
+
+	for _, thesis := range featureSpec.Theses() {
+		featureTheses[thesis.Slug()] = thesis
+	}
+
+	require.Len(t, featureTheses, yamlSpec.ThesesCount())
+
+	for _, thesis := range yamlSpec.Theses() {
+		featureThesis, ok := featureTheses[thesis.Slug()]
+		require.True(t, ok, thesis.Slug())
+
+		require.Equal(t, thesis.Stage(), featureThesis.Stage())
+		require.Equal(t, thesis.HTTP(), featureThesis.HTTP())
+		require.Equal(t, thesis.Assertion(), featureThesis.Assertion())
+	}
+}
+
+func TestSpecificationParserContentType(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "text/x-gherkin", gherkin.NewSpecificationParser().ContentType())
+}
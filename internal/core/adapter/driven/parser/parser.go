@@ -0,0 +1,31 @@
+// Package parser selects the parser of the
+// specification file by the file extension.
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/gherkin"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+// FeatureExtension is the extension of Gherkin feature files.
+const FeatureExtension = ".feature"
+
+// IsFeature reports whether the specification
+// file with the path is the Gherkin feature file.
+func IsFeature(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), FeatureExtension)
+}
+
+// ForFile returns Gherkin parser for feature
+// files and YAML parser for other files.
+func ForFile(path string) service.SpecificationFormatParser {
+	if IsFeature(path) {
+		return gherkin.NewSpecificationParser()
+	}
+
+	return yaml.NewSpecificationParser()
+}
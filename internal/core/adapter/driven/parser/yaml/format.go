@@ -0,0 +1,293 @@
+package yaml
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+// layout describes canonical form of the specification node.
+type layout struct {
+	// keys are known keys of the mapping in canonical order.
+	// Unknown keys are kept after known ones in source order.
+	keys   []string
+	fields map[string]*layout
+	// slugs is a layout of each value of the mapping
+	// from slugs to stories, scenarios or theses.
+	slugs *layout
+	// items is a layout of each item of the sequence.
+	items *layout
+	// normalize is called before keys ordering.
+	normalize func(node *yaml.Node)
+}
+
+var (
+	assertLayout = &layout{
+		keys: []string{"actual", "expected"},
+	}
+
+	assertionLayout = &layout{
+		keys: []string{"with", "assert"},
+		fields: map[string]*layout{
+			"assert": {items: assertLayout},
+		},
+	}
+
+	httpLayout = &layout{
+		keys: []string{"request", "response"},
+		fields: map[string]*layout{
+			"request":  {keys: []string{"method", "url", "contentType", "body"}},
+			"response": {keys: []string{"allowedCodes", "allowedContentType"}},
+		},
+	}
+
+	thesisLayout = &layout{
+		keys: []string{"given", "when", "then", "after", "http", "assertion"},
+		fields: map[string]*layout{
+			"http":      httpLayout,
+			"assertion": assertionLayout,
+		},
+		normalize: normalizeStage,
+	}
+
+	scenarioLayout = &layout{
+		keys: []string{"description", "theses"},
+		fields: map[string]*layout{
+			"theses": {slugs: thesisLayout},
+		},
+	}
+
+	storyLayout = &layout{
+		keys: []string{"description", "asA", "inOrderTo", "wantTo", "scenarios"},
+		fields: map[string]*layout{
+			"scenarios": {slugs: scenarioLayout},
+		},
+	}
+
+	specificationLayout = &layout{
+		keys: []string{"author", "title", "description", "environments", "stories"},
+		fields: map[string]*layout{
+			"stories": {slugs: storyLayout},
+		},
+	}
+)
+
+// blankLine is a comment marking keys to be separated
+// with the blank line from previous ones.
+const blankLine = "# thestis:blank-line"
+
+func (l *layout) apply(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if l.slugs != nil {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if i > 0 {
+					separate(node.Content[i])
+				}
+
+				l.slugs.apply(node.Content[i+1])
+			}
+
+			return
+		}
+
+		if l.normalize != nil {
+			l.normalize(node)
+		}
+
+		l.order(node)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if field, ok := l.fields[node.Content[i].Value]; ok {
+				field.apply(node.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		if l.items == nil {
+			return
+		}
+
+		for _, item := range node.Content {
+			l.items.apply(item)
+		}
+	}
+}
+
+// order sorts key-value pairs of the mapping node in canonical order.
+func (l *layout) order(node *yaml.Node) {
+	var (
+		pairs   = make(map[string][2]*yaml.Node, len(node.Content)/2)
+		unknown []*yaml.Node
+	)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if l.isKnown(key.Value) {
+			pairs[key.Value] = [2]*yaml.Node{key, value}
+
+			continue
+		}
+
+		unknown = append(unknown, key, value)
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+
+	for _, key := range l.keys {
+		if pair, ok := pairs[key]; ok {
+			content = append(content, pair[0], pair[1])
+		}
+	}
+
+	node.Content = append(content, unknown...)
+}
+
+func (l *layout) isKnown(key string) bool {
+	for _, k := range l.keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+var stageKeys = []string{"given", "when", "then"}
+
+// normalizeStage leaves only the stage key the parser takes
+// the thesis statement from and removes other stage keys.
+func normalizeStage(node *yaml.Node) {
+	stage := ""
+
+	for _, key := range stageKeys {
+		if value, ok := mappingValue(node, key); ok && value.Value != "" {
+			stage = key
+
+			break
+		}
+	}
+
+	if stage == "" {
+		return
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if key != stage && isStageKey(key) {
+			continue
+		}
+
+		content = append(content, node.Content[i], node.Content[i+1])
+	}
+
+	node.Content = content
+}
+
+func isStageKey(key string) bool {
+	for _, k := range stageKeys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, bool) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1], true
+		}
+	}
+
+	return nil, false
+}
+
+func separate(key *yaml.Node) {
+	if key.HeadComment == "" {
+		key.HeadComment = blankLine
+
+		return
+	}
+
+	key.HeadComment = blankLine + "\n" + key.HeadComment
+}
+
+const (
+	documentStart = "---\n"
+	formatIndent  = 2
+)
+
+// encodeCanonical brings the specification document node
+// to canonical form and encodes it. Environments, stories,
+// scenarios and theses are separated with blank lines.
+func encodeCanonical(root *yaml.Node) ([]byte, error) {
+	doc := root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+
+	specificationLayout.apply(doc)
+
+	for i := 2; i+1 < len(doc.Content); i += 2 {
+		if key := doc.Content[i].Value; key == "environments" || key == "stories" {
+			separate(doc.Content[i])
+		}
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(documentStart)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(formatIndent)
+
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == blankLine {
+			lines[i] = "\n"
+		}
+	}
+
+	return []byte(strings.Join(lines, "")), nil
+}
+
+// FormatSpecification returns the specification source in canonical form:
+// keys are ordered as in the specification format, only the stage key used
+// as the thesis statement is kept, indentation is two spaces and stories,
+// scenarios and theses are separated with blank lines. Comments are preserved.
+//
+// The source is parsed first, so invalid
+// specification is returned as an error.
+func FormatSpecification(content []byte) ([]byte, error) {
+	if _, err := NewSpecificationParser().ParseSpecification(bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+
+	// Comments after the document start are attached to the first
+	// key, without it they are attached to the document as in the
+	// formatted source, so the formatting is idempotent.
+	content = bytes.TrimPrefix(content, []byte(documentStart))
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, service.WrapWithParseError(err)
+	}
+
+	return encodeCanonical(&root)
+}
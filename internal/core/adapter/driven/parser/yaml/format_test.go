@@ -0,0 +1,111 @@
+package yaml_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+const (
+	unformattedSpecPath = fixturesPath + "/unformatted-spec.yml"
+	formattedSpecPath   = fixturesPath + "/formatted-spec.yml"
+)
+
+func TestFormatSpecification(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	testCases := []struct {
+		Name         string
+		SpecPath     string
+		ExpectedPath string
+	}{
+		{
+			Name:         "unformatted_specification",
+			SpecPath:     unformattedSpecPath,
+			ExpectedPath: formattedSpecPath,
+		},
+		{
+			Name:         "formatted_specification",
+			SpecPath:     formattedSpecPath,
+			ExpectedPath: formattedSpecPath,
+		},
+		{
+			Name:         "valid_specification",
+			SpecPath:     validSpecPath,
+			ExpectedPath: validSpecPath,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := os.ReadFile(c.SpecPath)
+			require.NoError(t, err)
+
+			expected, err := os.ReadFile(c.ExpectedPath)
+			require.NoError(t, err)
+
+			formatted, err := yaml.FormatSpecification(content)
+			require.NoError(t, err)
+
+			require.Equal(t, string(expected), string(formatted))
+		})
+	}
+}
+
+func TestFormatInvalidSpecification(t *testing.T) {
+	t.Parallel()
+
+	_, err := yaml.FormatSpecification([]byte("author: foo\nstories: [\n"))
+
+	var target *service.ParseError
+
+	require.ErrorAs(t, err, &target)
+}
+
+func TestSerializeSpecification(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	parser := yaml.NewSpecificationParser()
+
+	specFile, err := os.Open(validSpecPath)
+	require.NoError(t, err)
+
+	defer specFile.Close()
+
+	spec, err := parser.ParseSpecification(specFile)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	serializer := yaml.NewSpecificationSerializer()
+
+	require.NoError(t, serializer.SerializeSpecification(&buf, spec))
+	require.Equal(t, "application/x-yaml", serializer.ContentType())
+
+	serialized := buf.Bytes()
+
+	parsed, err := parser.ParseSpecification(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	require.Equal(t, spec, parsed)
+
+	formatted, err := yaml.FormatSpecification(serialized)
+	require.NoError(t, err)
+	require.Equal(t, string(serialized), string(formatted))
+}
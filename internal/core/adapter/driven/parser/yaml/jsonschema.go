@@ -0,0 +1,146 @@
+package yaml
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+// jsonSchema is a node of the JSON Schema draft-07 document.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	MinProperties        int                    `json:"minProperties,omitempty"`
+	OneOf                []*jsonSchema          `json:"oneOf,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+}
+
+const (
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+	jsonSchemaTitle = "Thestis specification"
+)
+
+var jsonSchemaEnums = map[reflect.Type][]string{
+	reflect.TypeOf(specification.HTTPMethod("")): {
+		string(specification.GET),
+		string(specification.POST),
+		string(specification.PUT),
+		string(specification.PATCH),
+		string(specification.DELETE),
+		string(specification.OPTIONS),
+		string(specification.TRACE),
+		string(specification.CONNECT),
+		string(specification.HEAD),
+	},
+	reflect.TypeOf(specification.ContentType("")): {
+		string(specification.ApplicationJSON),
+		string(specification.ApplicationXML),
+	},
+	reflect.TypeOf(specification.AssertionMethod("")): {
+		string(specification.JSONPath),
+	},
+}
+
+// jsonSchemaConstraints are constraints of the specification
+// objects, which can't be described with field types.
+var jsonSchemaConstraints = map[reflect.Type]func(s *jsonSchema){
+	reflect.TypeOf(specificationSchema{}): func(s *jsonSchema) {
+		s.Required = []string{"stories"}
+	},
+	reflect.TypeOf(storySchema{}): func(s *jsonSchema) {
+		s.Required = []string{"scenarios"}
+	},
+	reflect.TypeOf(scenarioSchema{}): func(s *jsonSchema) {
+		s.Required = []string{"theses"}
+	},
+	reflect.TypeOf(thesisSchema{}): func(s *jsonSchema) {
+		s.OneOf = requiredAlternatives("given", "when", "then")
+		s.AnyOf = requiredAlternatives("http", "assertion")
+	},
+	reflect.TypeOf(assertSchema{}): func(s *jsonSchema) {
+		s.Required = []string{"actual", "expected"}
+	},
+}
+
+func requiredAlternatives(keys ...string) []*jsonSchema {
+	alternatives := make([]*jsonSchema, 0, len(keys))
+
+	for _, key := range keys {
+		alternatives = append(alternatives, &jsonSchema{
+			Required: []string{key},
+		})
+	}
+
+	return alternatives
+}
+
+// JSONSchema returns JSON Schema of the specification YAML format
+// generated from the types the parser decodes specification into,
+// so editors can autocomplete and validate specification files.
+func JSONSchema() ([]byte, error) {
+	s := reflectJSONSchema(reflect.TypeOf(specificationSchema{}), "")
+	s.Schema = jsonSchemaDraft
+	s.Title = jsonSchemaTitle
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+func reflectJSONSchema(t reflect.Type, description string) *jsonSchema {
+	if enum, ok := jsonSchemaEnums[t]; ok {
+		return &jsonSchema{
+			Description: description,
+			Type:        "string",
+			Enum:        enum,
+		}
+	}
+
+	s := &jsonSchema{Description: description}
+
+	switch t.Kind() {
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Int:
+		s.Type = "integer"
+	case reflect.Slice:
+		s.Type = "array"
+		s.Items = reflectJSONSchema(t.Elem(), "")
+	case reflect.Map:
+		s.Type = "object"
+
+		if t.Elem().Kind() != reflect.Interface {
+			s.AdditionalProperties = reflectJSONSchema(t.Elem(), "")
+			s.MinProperties = 1
+		}
+	case reflect.Struct:
+		s.Type = "object"
+		s.Properties = make(map[string]*jsonSchema, t.NumField())
+		s.AdditionalProperties = false
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+
+			s.Properties[key] = reflectJSONSchema(field.Type, field.Tag.Get("description"))
+		}
+
+		if constrain, ok := jsonSchemaConstraints[t]; ok {
+			constrain(s)
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,74 @@
+package yaml_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+)
+
+const jsonSchemaPath = "../../../../../../api/jsonschema/specification.json"
+
+var updateJSONSchema = flag.Bool("update-json-schema", false, "rewrite shipped JSON Schema of the specification")
+
+// TestJSONSchemaIsShipped checks that JSON Schema shipped for editors
+// is in sync with the types the parser decodes specification into.
+// Run with -update-json-schema flag to rewrite the shipped schema.
+func TestJSONSchemaIsShipped(t *testing.T) {
+	t.Parallel()
+
+	schema, err := yaml.JSONSchema()
+	require.NoError(t, err)
+
+	if *updateJSONSchema {
+		require.NoError(t, os.WriteFile(jsonSchemaPath, schema, 0o600))
+	}
+
+	shipped, err := os.ReadFile(jsonSchemaPath)
+	require.NoError(t, err)
+
+	require.Equal(t, string(shipped), string(schema))
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	content, err := yaml.JSONSchema()
+	require.NoError(t, err)
+
+	type object struct {
+		Required             []string                   `json:"required"`
+		Properties           map[string]json.RawMessage `json:"properties"`
+		AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+	}
+
+	var schema, stories, story object
+
+	require.NoError(t, json.Unmarshal(content, &schema))
+	require.NoError(t, json.Unmarshal(schema.Properties["stories"], &stories))
+	require.NoError(t, json.Unmarshal(stories.AdditionalProperties, &story))
+
+	require.Equal(t, []string{"stories"}, schema.Required)
+	require.ElementsMatch(t, []string{"author", "title", "description", "environments", "stories"}, keys(schema.Properties))
+
+	require.Equal(t, []string{"scenarios"}, story.Required)
+	require.ElementsMatch(
+		t,
+		[]string{"description", "asA", "inOrderTo", "wantTo", "scenarios"},
+		keys(story.Properties),
+	)
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	res := make([]string, 0, len(m))
+
+	for k := range m {
+		res = append(res, k)
+	}
+
+	return res
+}
@@ -15,18 +15,52 @@ func NewSpecificationParser() SpecificationParser {
 	return SpecificationParser{}
 }
 
+func (s SpecificationParser) ContentType() string {
+	return contentType
+}
+
 func (s SpecificationParser) ParseSpecification(
 	reader io.Reader,
 	opts ...service.ParserOption,
 ) (*specification.Specification, error) {
 	decoder := yaml.NewDecoder(reader)
 
-	var spec specificationSchema
-	if err := decoder.Decode(&spec); err != nil {
-		return nil, service.WrapWithParseError(err)
+	var root yaml.Node
+	if err := decoder.Decode(&root); err != nil {
+		return nil, service.WrapWithParseError(withLinePosition(err))
+	}
+
+	var schema specificationSchema
+	if err := root.Decode(&schema); err != nil {
+		return nil, service.WrapWithParseError(withLinePosition(err))
+	}
+
+	spec, err := build(schema, opts)
+	if err != nil {
+		return nil, specification.LocateBuildError(err, indexPositions(&root).locate)
+	}
+
+	return spec, nil
+}
+
+// ThesisDetails decodes HTTP and assertion of the thesis written as in
+// the YAML specification, e.g. in the docstring of the Gherkin step,
+// and returns function adding them to the thesis builder.
+func ThesisDetails(content []byte) (func(builder *specification.ThesisBuilder), error) {
+	var details struct {
+		HTTP      httpSchema      `yaml:"http"`
+		Assertion assertionSchema `yaml:"assertion"`
+	}
+
+	if err := yaml.Unmarshal(content, &details); err != nil {
+		return nil, withLinePosition(err)
 	}
 
-	return build(spec, opts)
+	return func(builder *specification.ThesisBuilder) {
+		builder.
+			WithAssertion(buildAssertion(details.Assertion)).
+			WithHTTP(buildHTTP(details.HTTP))
+	}, nil
 }
 
 func build(spec specificationSchema, opts []service.ParserOption) (*specification.Specification, error) {
@@ -41,6 +75,10 @@ func build(spec specificationSchema, opts []service.ParserOption) (*specificatio
 		opt(&b)
 	}
 
+	for name, variables := range spec.Environments {
+		b.WithEnvironment(name, variables)
+	}
+
 	for slug, story := range spec.Stories {
 		b.WithStory(slug, buildStory(story))
 	}
@@ -130,3 +168,8 @@ func buildHTTPResponse(response httpResponseSchema) func(builder *specification.
 			WithAllowedContentType(response.AllowedContentType)
 	}
 }
+
+// SpecificationSchema returns JSON Schema of the YAML specification.
+func (s SpecificationParser) SpecificationSchema() ([]byte, error) {
+	return JSONSchema()
+}
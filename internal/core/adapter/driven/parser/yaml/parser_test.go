@@ -3,6 +3,7 @@ package yaml_test
 import (
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -128,6 +129,78 @@ func TestParseSpecification(t *testing.T) {
 	}
 }
 
+func TestParseSpecificationErrorPositions(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	parser := yaml.NewSpecificationParser()
+
+	specFile, err := os.Open(invalidMixedErrorsSpecPath)
+	require.NoError(t, err)
+
+	defer specFile.Close()
+
+	_, err = parser.ParseSpecification(specFile)
+	require.Error(t, err)
+
+	positions := make(map[string]specification.Position)
+
+	for _, d := range specification.FlattenBuildError(err) {
+		positions[d.Err.Error()] = d.Position
+	}
+
+	require.Equal(t, map[string]specification.Position{
+		`HTTP method "GOT" not allowed`:                {Line: 20, Column: 17},
+		`content type "application/wrong" not allowed`: {Line: 25, Column: 17},
+		`assertion method "JAY-Z" not allowed`:         {Line: 31, Column: 15},
+		`stage "" not allowed`:                         {Line: 27, Column: 11},
+	}, positions)
+}
+
+func TestParseSpecificationSyntaxErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	parser := yaml.NewSpecificationParser()
+
+	_, err := parser.ParseSpecification(strings.NewReader("author: foo\nstories: [\n"))
+
+	var perr *specification.PositionedError
+
+	require.ErrorAs(t, err, &perr)
+	require.Equal(t, 2, perr.Position().Line)
+}
+
+func TestParseSpecificationEnvironments(t *testing.T) {
+	t.Parallel()
+
+	parser := yaml.NewSpecificationParser()
+
+	spec, err := parser.ParseSpecification(strings.NewReader(`
+environments:
+  staging:
+    baseUrl: https://staging.example.com
+stories:
+  foo:
+    scenarios:
+      bar:
+        theses:
+          baz:
+            given: test
+            http:
+              request:
+                method: GET
+                url: "{{env.baseUrl}}/products"
+`))
+	require.NoError(t, err)
+
+	env, ok := spec.Environment("staging")
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"baseUrl": "https://staging.example.com"}, env.Variables())
+}
+
 func isComplexAssertionMethodError(err error) bool {
 	var (
 		berr *specification.BuildError
@@ -0,0 +1,204 @@
+package yaml
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+const pathSeparator = "/"
+
+// positions are positions of the mapping keys and sequence
+// items of the specification source indexed by their paths
+// like stories/story/scenarios/scenario/theses/thesis/http.
+// Root mapping is indexed by the empty path.
+type positions map[string]specification.Position
+
+func indexPositions(root *yaml.Node) positions {
+	p := make(positions)
+
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	p[""] = nodePosition(root)
+	p.index(root, nil)
+
+	return p
+}
+
+func (p positions) index(node *yaml.Node, path []string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			keyPath := append(path[:len(path):len(path)], key.Value)
+
+			p[strings.Join(keyPath, pathSeparator)] = nodePosition(key)
+			p.index(value, keyPath)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemKey := strconv.Itoa(i)
+			if item.Kind == yaml.ScalarNode {
+				itemKey = item.Value
+			}
+
+			itemPath := append(path[:len(path):len(path)], itemKey)
+
+			p[strings.Join(itemPath, pathSeparator)] = nodePosition(item)
+			p.index(item, itemPath)
+		}
+	case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+	}
+}
+
+// SlugPositions returns positions of stories, scenarios and
+// theses of the YAML specification source by their slugs.
+func SlugPositions(content []byte) (map[specification.Slug]specification.Position, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, withLinePosition(err)
+	}
+
+	slugs := make(map[specification.Slug]specification.Position)
+
+	for path, pos := range indexPositions(&root) {
+		if slug, ok := pathSlug(strings.Split(path, pathSeparator)); ok {
+			slugs[slug] = pos
+		}
+	}
+
+	return slugs, nil
+}
+
+// pathSlug returns slug of the object with the path
+// like stories/story/scenarios/scenario/theses/thesis.
+func pathSlug(keys []string) (specification.Slug, bool) {
+	objectKeys := []string{"stories", "scenarios", "theses"}
+
+	if len(keys)%2 != 0 || len(keys)/2 > len(objectKeys) {
+		return specification.Slug{}, false
+	}
+
+	for i := 0; i < len(keys); i += 2 {
+		if keys[i] != objectKeys[i/2] {
+			return specification.Slug{}, false
+		}
+	}
+
+	switch len(keys) {
+	case 2:
+		return specification.NewStorySlug(keys[1]), true
+	case 4:
+		return specification.NewScenarioSlug(keys[1], keys[3]), true
+	case 6:
+		return specification.NewThesisSlug(keys[1], keys[3], keys[5]), true
+	}
+
+	return specification.Slug{}, false
+}
+
+func nodePosition(node *yaml.Node) specification.Position {
+	return specification.Position{
+		Line:   node.Line,
+		Column: node.Column,
+	}
+}
+
+// locate returns position of the field with the error
+// or position of the object if the field is missing.
+func (p positions) locate(path []*specification.BuildError, err error) (specification.Position, bool) {
+	var keys []string
+
+	for _, berr := range path {
+		keys = append(keys, schemaKeys(berr)...)
+	}
+
+	if err != nil {
+		if pos, ok := p[strings.Join(append(keys, fieldKeys(keys, err)...), pathSeparator)]; ok {
+			return pos, true
+		}
+	}
+
+	pos, ok := p[strings.Join(keys, pathSeparator)]
+
+	return pos, ok
+}
+
+var contextKeys = map[string][]string{
+	"HTTP":      {"http"},
+	"request":   {"request"},
+	"response":  {"response"},
+	"assertion": {"assertion"},
+}
+
+func schemaKeys(err *specification.BuildError) []string {
+	if slug, ok := err.SlugContext(); ok {
+		switch slug.Kind() {
+		case specification.StorySlug:
+			return []string{"stories", slug.Story()}
+		case specification.ScenarioSlug:
+			return []string{"scenarios", slug.Scenario()}
+		case specification.ThesisSlug:
+			return []string{"theses", slug.Thesis()}
+		case specification.NoSlug:
+		}
+
+		return nil
+	}
+
+	ctx, _ := err.StringContext()
+
+	return contextKeys[ctx]
+}
+
+func fieldKeys(objectKeys []string, err error) []string {
+	var (
+		methodErr      *specification.NotAllowedHTTPMethodError
+		contentTypeErr *specification.NotAllowedContentTypeError
+		assertionErr   *specification.NotAllowedAssertionMethodError
+		dependencyErr  *specification.UndefinedDependencyError
+	)
+
+	switch {
+	case errors.As(err, &methodErr):
+		return []string{"method"}
+	case errors.As(err, &contentTypeErr):
+		if len(objectKeys) > 0 && objectKeys[len(objectKeys)-1] == "response" {
+			return []string{"allowedContentType"}
+		}
+
+		return []string{"contentType"}
+	case errors.As(err, &assertionErr):
+		return []string{"with"}
+	case errors.As(err, &dependencyErr):
+		return []string{"after", dependencyErr.Slug().Thesis()}
+	}
+
+	return nil
+}
+
+var lineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// withLinePosition returns the error of yaml package
+// positioned at the line mentioned in its message.
+func withLinePosition(err error) error {
+	match := lineRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+
+	line, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return err
+	}
+
+	return specification.NewPositionedError(err, specification.Position{Line: line})
+}
@@ -0,0 +1,41 @@
+package yaml_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestSlugPositions(t *testing.T) {
+	t.Parallel()
+
+	positions, err := yaml.SlugPositions([]byte(`author: Djerys
+stories:
+  cart:
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, map[specification.Slug]specification.Position{
+		specification.NewStorySlug("cart"):                       {Line: 3, Column: 3},
+		specification.NewScenarioSlug("cart", "add"):             {Line: 5, Column: 7},
+		specification.NewThesisSlug("cart", "add", "addProduct"): {Line: 7, Column: 11},
+	}, positions)
+}
+
+func TestSlugPositionsOfInvalidSource(t *testing.T) {
+	t.Parallel()
+
+	_, err := yaml.SlugPositions([]byte("stories: [\n"))
+
+	var perr *specification.PositionedError
+
+	require.ErrorAs(t, err, &perr)
+}
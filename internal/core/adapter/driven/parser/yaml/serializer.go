@@ -0,0 +1,143 @@
+package yaml
+
+import (
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+const contentType = "application/x-yaml"
+
+type SpecificationSerializer struct{}
+
+func NewSpecificationSerializer() SpecificationSerializer {
+	return SpecificationSerializer{}
+}
+
+func (s SpecificationSerializer) ContentType() string {
+	return contentType
+}
+
+// SerializeSpecification writes the specification as the
+// YAML source in the canonical form of FormatSpecification.
+func (s SpecificationSerializer) SerializeSpecification(w io.Writer, spec *specification.Specification) error {
+	var root yaml.Node
+	if err := root.Encode(serializeSpecification(spec)); err != nil {
+		return err
+	}
+
+	content, err := encodeCanonical(&root)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(content)
+
+	return err
+}
+
+func serializeSpecification(spec *specification.Specification) specificationSchema {
+	stories := spec.Stories()
+
+	schema := specificationSchema{
+		Author:      spec.Author(),
+		Title:       spec.Title(),
+		Description: spec.Description(),
+		Stories:     make(map[string]storySchema, len(stories)),
+	}
+
+	for _, env := range spec.Environments() {
+		if schema.Environments == nil {
+			schema.Environments = make(map[string]map[string]string)
+		}
+
+		schema.Environments[env.Name()] = env.Variables()
+	}
+
+	for _, story := range stories {
+		schema.Stories[story.Slug().Story()] = serializeStory(story)
+	}
+
+	return schema
+}
+
+func serializeStory(story specification.Story) storySchema {
+	scenarios := story.Scenarios()
+
+	schema := storySchema{
+		Description: story.Description(),
+		AsA:         story.AsA(),
+		InOrderTo:   story.InOrderTo(),
+		WantTo:      story.WantTo(),
+		Scenarios:   make(map[string]scenarioSchema, len(scenarios)),
+	}
+
+	for _, scenario := range scenarios {
+		schema.Scenarios[scenario.Slug().Scenario()] = serializeScenario(scenario)
+	}
+
+	return schema
+}
+
+func serializeScenario(scenario specification.Scenario) scenarioSchema {
+	theses := scenario.Theses()
+
+	schema := scenarioSchema{
+		Description: scenario.Description(),
+		Theses:      make(map[string]thesisSchema, len(theses)),
+	}
+
+	for _, thesis := range theses {
+		schema.Theses[thesis.Slug().Thesis()] = serializeThesis(thesis)
+	}
+
+	return schema
+}
+
+func serializeThesis(thesis specification.Thesis) thesisSchema {
+	var schema thesisSchema
+
+	switch thesis.Stage() {
+	case specification.Given:
+		schema.Given = thesis.Behavior()
+	case specification.When:
+		schema.When = thesis.Behavior()
+	case specification.Then:
+		schema.Then = thesis.Behavior()
+	}
+
+	for _, dep := range thesis.Dependencies() {
+		schema.After = append(schema.After, dep.Thesis())
+	}
+
+	sort.Strings(schema.After)
+
+	schema.HTTP = httpSchema{
+		Request: httpRequestSchema{
+			Method:      thesis.HTTP().Request().Method(),
+			URL:         thesis.HTTP().Request().URL(),
+			ContentType: thesis.HTTP().Request().ContentType(),
+			Body:        thesis.HTTP().Request().Body(),
+		},
+		Response: httpResponseSchema{
+			AllowedCodes:       thesis.HTTP().Response().AllowedCodes(),
+			AllowedContentType: thesis.HTTP().Response().AllowedContentType(),
+		},
+	}
+
+	schema.Assertion = assertionSchema{
+		Method: thesis.Assertion().Method(),
+	}
+
+	for _, assert := range thesis.Assertion().Asserts() {
+		schema.Assertion.Assert = append(schema.Assertion.Assert, assertSchema{
+			Actual:   assert.Actual(),
+			Expected: assert.Expected(),
+		})
+	}
+
+	return schema
+}
@@ -4,58 +4,59 @@ import "github.com/harpyd/thestis/internal/core/entity/specification"
 
 type (
 	specificationSchema struct {
-		Author      string                 `yaml:"author"`
-		Title       string                 `yaml:"title"`
-		Description string                 `yaml:"description"`
-		Stories     map[string]storySchema `yaml:"stories"`
+		Author       string                       `yaml:"author,omitempty" description:"Author of the specification."`
+		Title        string                       `yaml:"title,omitempty" description:"Title of the specification."`
+		Description  string                       `yaml:"description,omitempty" description:"Description of the specification."`
+		Environments map[string]map[string]string `yaml:"environments,omitempty" description:"Environment profiles by their names. Variables of the profile the pipeline is started with are available as {{env.<variable>}} templates."`
+		Stories      map[string]storySchema       `yaml:"stories,omitempty" description:"Stories by their slugs."`
 	}
 
 	storySchema struct {
-		Description string                    `yaml:"description"`
-		AsA         string                    `yaml:"asA"`
-		InOrderTo   string                    `yaml:"inOrderTo"`
-		WantTo      string                    `yaml:"wantTo"`
-		Scenarios   map[string]scenarioSchema `yaml:"scenarios"`
+		Description string                    `yaml:"description,omitempty" description:"Description of the story."`
+		AsA         string                    `yaml:"asA,omitempty" description:"Role of the user in the story narrative."`
+		InOrderTo   string                    `yaml:"inOrderTo,omitempty" description:"Goal of the user in the story narrative."`
+		WantTo      string                    `yaml:"wantTo,omitempty" description:"Action of the user in the story narrative."`
+		Scenarios   map[string]scenarioSchema `yaml:"scenarios,omitempty" description:"Scenarios of the story by their slugs."`
 	}
 
 	scenarioSchema struct {
-		Description string                  `yaml:"description"`
-		Theses      map[string]thesisSchema `yaml:"theses"`
+		Description string                  `yaml:"description,omitempty" description:"Description of the scenario."`
+		Theses      map[string]thesisSchema `yaml:"theses,omitempty" description:"Theses of the scenario by their slugs."`
 	}
 
 	thesisSchema struct {
-		Given     string          `yaml:"given"`
-		When      string          `yaml:"when"`
-		Then      string          `yaml:"then"`
-		After     []string        `yaml:"after"`
-		HTTP      httpSchema      `yaml:"http"`
-		Assertion assertionSchema `yaml:"assertion"`
+		Given     string          `yaml:"given,omitempty" description:"Statement of the given stage thesis."`
+		When      string          `yaml:"when,omitempty" description:"Statement of the when stage thesis."`
+		Then      string          `yaml:"then,omitempty" description:"Statement of the then stage thesis."`
+		After     []string        `yaml:"after,omitempty" description:"Slugs of theses of the scenario to run the thesis after."`
+		HTTP      httpSchema      `yaml:"http,omitempty" description:"HTTP request of the thesis and expected response."`
+		Assertion assertionSchema `yaml:"assertion,omitempty" description:"Assertion of the collected responses."`
 	}
 
 	httpSchema struct {
-		Request  httpRequestSchema  `yaml:"request"`
-		Response httpResponseSchema `yaml:"response"`
+		Request  httpRequestSchema  `yaml:"request,omitempty" description:"HTTP request to send."`
+		Response httpResponseSchema `yaml:"response,omitempty" description:"Expected HTTP response."`
 	}
 
 	httpRequestSchema struct {
-		Method      specification.HTTPMethod  `yaml:"method"`
-		URL         string                    `yaml:"url"`
-		ContentType specification.ContentType `yaml:"contentType"`
-		Body        map[string]interface{}    `yaml:"body"`
+		Method      specification.HTTPMethod  `yaml:"method,omitempty" description:"HTTP method of the request."`
+		URL         string                    `yaml:"url,omitempty" description:"URL of the request, may refer to previous responses with {{...}} templates."`
+		ContentType specification.ContentType `yaml:"contentType,omitempty" description:"Content type of the request body."`
+		Body        map[string]interface{}    `yaml:"body,omitempty" description:"Body of the request."`
 	}
 
 	httpResponseSchema struct {
-		AllowedCodes       []int                     `yaml:"allowedCodes"`
-		AllowedContentType specification.ContentType `yaml:"allowedContentType"`
+		AllowedCodes       []int                     `yaml:"allowedCodes,omitempty" description:"Allowed status codes of the response."`
+		AllowedContentType specification.ContentType `yaml:"allowedContentType,omitempty" description:"Allowed content type of the response."`
 	}
 
 	assertionSchema struct {
-		Method specification.AssertionMethod `yaml:"with"`
-		Assert []assertSchema                `yaml:"assert"`
+		Method specification.AssertionMethod `yaml:"with,omitempty" description:"Method of the assertion."`
+		Assert []assertSchema                `yaml:"assert,omitempty" description:"Asserts of the actual values with the expected ones."`
 	}
 
 	assertSchema struct {
-		Actual   string      `yaml:"actual"`
-		Expected interface{} `yaml:"expected"`
+		Actual   string      `yaml:"actual,omitempty" description:"Path to the actual value in the collected responses."`
+		Expected interface{} `yaml:"expected" description:"Expected value."`
 	}
 )
@@ -0,0 +1,31 @@
+package mongodb
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/harpyd/thestis/internal/core/app/query"
+)
+
+// encodeCursor makes opaque URL safe token
+// from the position of the last document on a page.
+func encodeCursor(position interface{}) string {
+	raw, _ := json.Marshal(position)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor restores position encoded with encodeCursor
+// and returns query.ErrInvalidCursor if the cursor is malformed.
+func decodeCursor(cursor string, position interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return query.ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(raw, position); err != nil {
+		return query.ErrInvalidCursor
+	}
+
+	return nil
+}
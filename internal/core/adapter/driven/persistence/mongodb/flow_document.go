@@ -1,7 +1,12 @@
 package mongodb
 
 import (
+	"sort"
+	"time"
+
+	"github.com/harpyd/thestis/internal/core/app/query"
 	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 	"github.com/harpyd/thestis/internal/core/entity/specification"
 )
 
@@ -11,6 +16,7 @@ type (
 		PipelineID   string           `bson:"pipelineId"`
 		OverallState flow.State       `bson:"overallState"`
 		Statuses     []statusDocument `bson:"statuses"`
+		StartedAt    time.Time        `bson:"startedAt,omitempty"`
 	}
 
 	statusDocument struct {
@@ -22,9 +28,20 @@ type (
 	thesisStatusDocuments []thesisStatusDocument
 
 	thesisStatusDocument struct {
-		ThesisSlug   string     `bson:"thesisSlug"`
-		State        flow.State `bson:"state"`
-		OccurredErrs []string   `bson:"occurredErrs"`
+		ThesisSlug   string                `bson:"thesisSlug"`
+		State        flow.State            `bson:"state"`
+		OccurredErrs []string              `bson:"occurredErrs"`
+		Exchange     *httpExchangeDocument `bson:"exchange,omitempty"`
+	}
+
+	httpExchangeDocument struct {
+		Method              string `bson:"method"`
+		URL                 string `bson:"url"`
+		RequestContentType  string `bson:"requestContentType,omitempty"`
+		RequestBody         string `bson:"requestBody,omitempty"`
+		ResponseCode        int    `bson:"responseCode,omitempty"`
+		ResponseContentType string `bson:"responseContentType,omitempty"`
+		ResponseBody        string `bson:"responseBody,omitempty"`
 	}
 
 	scenarioSlugDocument struct {
@@ -80,6 +97,23 @@ func newThesisStatusDocument(status *flow.ThesisStatus) thesisStatusDocument {
 		ThesisSlug:   status.ThesisSlug(),
 		State:        status.State(),
 		OccurredErrs: status.OccurredErrs(),
+		Exchange:     newHTTPExchangeDocument(status.Exchange()),
+	}
+}
+
+func newHTTPExchangeDocument(x pipeline.HTTPExchange) *httpExchangeDocument {
+	if x.IsZero() {
+		return nil
+	}
+
+	return &httpExchangeDocument{
+		Method:              x.Method,
+		URL:                 x.URL,
+		RequestContentType:  x.RequestContentType,
+		RequestBody:         x.RequestBody,
+		ResponseCode:        x.ResponseCode,
+		ResponseContentType: x.ResponseContentType,
+		ResponseBody:        x.ResponseBody,
 	}
 }
 
@@ -111,12 +145,81 @@ func newScenarioSlug(d scenarioSlugDocument) specification.Slug {
 func newThesisStatuses(ds []thesisStatusDocument) []*flow.ThesisStatus {
 	statuses := make([]*flow.ThesisStatus, 0, len(ds))
 	for _, d := range ds {
-		statuses = append(statuses, flow.NewThesisStatus(
+		statuses = append(statuses, flow.NewThesisStatusWithExchange(
 			d.ThesisSlug,
 			d.State,
+			newHTTPExchange(d.Exchange),
 			d.OccurredErrs...,
 		))
 	}
 
 	return statuses
 }
+
+func newHTTPExchange(d *httpExchangeDocument) pipeline.HTTPExchange {
+	if d == nil {
+		return pipeline.HTTPExchange{}
+	}
+
+	return pipeline.HTTPExchange{
+		Method:              d.Method,
+		URL:                 d.URL,
+		RequestContentType:  d.RequestContentType,
+		RequestBody:         d.RequestBody,
+		ResponseCode:        d.ResponseCode,
+		ResponseContentType: d.ResponseContentType,
+		ResponseBody:        d.ResponseBody,
+	}
+}
+
+func newFlowView(d flowDocument) query.FlowModel {
+	f := query.FlowModel{
+		ID:           d.ID,
+		StartedAt:    d.StartedAt,
+		OverallState: d.OverallState.String(),
+		Statuses:     make([]query.StatusModel, 0, len(d.Statuses)),
+	}
+
+	for _, s := range d.Statuses {
+		f.Statuses = append(f.Statuses, newStatusView(s))
+	}
+
+	sort.Slice(f.Statuses, func(i, j int) bool {
+		return lessScenarioSlugView(f.Statuses[i].Slug, f.Statuses[j].Slug)
+	})
+
+	return f
+}
+
+func lessScenarioSlugView(a, b query.ScenarioSlugModel) bool {
+	if a.Story != b.Story {
+		return a.Story < b.Story
+	}
+
+	return a.Scenario < b.Scenario
+}
+
+func newStatusView(d statusDocument) query.StatusModel {
+	status := query.StatusModel{
+		Slug: query.ScenarioSlugModel{
+			Story:    d.Slug.Story,
+			Scenario: d.Slug.Scenario,
+		},
+		State:          d.State.String(),
+		ThesisStatuses: make([]query.ThesisStatusModel, 0, len(d.ThesisStatuses)),
+	}
+
+	for _, ts := range d.ThesisStatuses {
+		status.ThesisStatuses = append(status.ThesisStatuses, query.ThesisStatusModel{
+			ThesisSlug:   ts.ThesisSlug,
+			State:        ts.State.String(),
+			OccurredErrs: ts.OccurredErrs,
+		})
+	}
+
+	sort.Slice(status.ThesisStatuses, func(i, j int) bool {
+		return status.ThesisStatuses[i].ThesisSlug < status.ThesisStatuses[j].ThesisSlug
+	})
+
+	return status
+}
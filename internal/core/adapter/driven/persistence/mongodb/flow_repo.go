@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -46,11 +47,19 @@ func (r *FlowRepository) getFlowDocument(ctx context.Context, filter bson.M) (fl
 	return document, nil
 }
 
+// UpsertFlow inserts or updates flow. On inserting the moment of
+// the first flow saving is stored as start time of the flow.
 func (r *FlowRepository) UpsertFlow(ctx context.Context, flow *flow.Flow) error {
-	document := newFlowDocument(flow)
+	var (
+		document = newFlowDocument(flow)
+		update   = bson.M{
+			"$set":         document,
+			"$setOnInsert": bson.M{"startedAt": time.Now().UTC()},
+		}
+	)
 
-	opt := options.Replace().SetUpsert(true)
-	_, err := r.flows.ReplaceOne(ctx, bson.M{"_id": flow.ID()}, document, opt)
+	opt := options.Update().SetUpsert(true)
+	_, err := r.flows.UpdateOne(ctx, bson.M{"_id": flow.ID()}, update, opt)
 
 	return service.WrapWithDatabaseError(err)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
 	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 	"github.com/harpyd/thestis/internal/core/entity/specification"
 )
 
@@ -76,6 +77,32 @@ func (s *FlowRepositoryTestSuite) TestUpsertFlow() {
 			),
 			ShouldBeErr: false,
 		},
+		{
+			Name: "success_inserting_flow_with_exchange",
+			GivenFlow: flow.FromStatuses(
+				"3c4d5e6f-7a8b-4c9d-8e0f-1a2b3c4d5e6f",
+				"4d5e6f7a-8b9c-4d0e-9f1a-2b3c4d5e6f7a",
+				flow.NewStatus(
+					specification.NewScenarioSlug("foo", "bar"),
+					flow.Failed,
+					flow.NewThesisStatusWithExchange(
+						"baz",
+						flow.Failed,
+						pipeline.HTTPExchange{
+							Method:              "POST",
+							URL:                 "https://api.example.com/orders",
+							RequestContentType:  "application/json",
+							RequestBody:         `{"id":1}`,
+							ResponseCode:        500,
+							ResponseContentType: "text/plain",
+							ResponseBody:        "oops",
+						},
+						"response code is not allowed: 500, allowed [201]",
+					),
+				),
+			),
+			ShouldBeErr: false,
+		},
 	}
 
 	for _, c := range testCases {
@@ -0,0 +1,71 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+// LeaderLock grants the leadership to the instance with
+// instanceID for the leaseTTL. The leadership must be
+// acquired again before the lease expires, otherwise
+// another instance can take it.
+type LeaderLock struct {
+	locks      *mongo.Collection
+	instanceID string
+	leaseTTL   time.Duration
+}
+
+const leaderLockCollection = "leaderLocks"
+
+func NewLeaderLock(
+	db *mongo.Database,
+	instanceID string,
+	leaseTTL time.Duration,
+) *LeaderLock {
+	return &LeaderLock{
+		locks:      db.Collection(leaderLockCollection),
+		instanceID: instanceID,
+		leaseTTL:   leaseTTL,
+	}
+}
+
+// AcquireLeadership upserts the lock owned by the instance. Upsert
+// of the lock held by another instance fails with the duplicate key.
+func (l *LeaderLock) AcquireLeadership(ctx context.Context, name string) error {
+	now := time.Now().UTC()
+
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"owner": l.instanceID},
+			bson.M{"expiresAt": bson.M{"$lt": now}},
+		},
+	}
+
+	update := bson.M{"$set": bson.M{
+		"owner":     l.instanceID,
+		"expiresAt": now.Add(l.leaseTTL),
+	}}
+
+	_, err := l.locks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return service.ErrLeadershipTaken
+	}
+
+	return service.WrapWithDatabaseError(err)
+}
+
+func (l *LeaderLock) ReleaseLeadership(ctx context.Context, name string) error {
+	_, err := l.locks.DeleteOne(ctx, bson.M{
+		"_id":   name,
+		"owner": l.instanceID,
+	})
+
+	return service.WrapWithDatabaseError(err)
+}
@@ -0,0 +1,82 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type LeaderLockTestSuite struct {
+	MongoSuite
+
+	lock  *mongodb.LeaderLock
+	other *mongodb.LeaderLock
+}
+
+const (
+	leaderInstanceID = "0c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+	otherInstanceID  = "5f6e7d8c-9b0a-4f1e-8d2c-3b4a5f6e7d8c"
+	leadership       = "pipeline-scheduler"
+)
+
+func (s *LeaderLockTestSuite) SetupTest() {
+	s.lock = mongodb.NewLeaderLock(s.db, leaderInstanceID, time.Minute)
+	s.other = mongodb.NewLeaderLock(s.db, otherInstanceID, time.Minute)
+}
+
+func (s *LeaderLockTestSuite) TearDownTest() {
+	_, err := s.db.
+		Collection("leaderLocks").
+		DeleteMany(context.Background(), bson.D{})
+	s.Require().NoError(err)
+}
+
+func TestLeaderLock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	suite.Run(t, &LeaderLockTestSuite{})
+}
+
+func (s *LeaderLockTestSuite) TestAcquireLeadership() {
+	ctx := context.Background()
+
+	s.Require().NoError(s.lock.AcquireLeadership(ctx, leadership))
+	s.Require().NoError(s.lock.AcquireLeadership(ctx, leadership))
+
+	err := s.other.AcquireLeadership(ctx, leadership)
+	s.Require().ErrorIs(err, service.ErrLeadershipTaken)
+}
+
+func (s *LeaderLockTestSuite) TestAcquireExpiredLeadership() {
+	ctx := context.Background()
+
+	_, err := s.db.Collection("leaderLocks").InsertOne(ctx, bson.M{
+		"_id":       leadership,
+		"owner":     otherInstanceID,
+		"expiresAt": time.Now().UTC().Add(-time.Second),
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.lock.AcquireLeadership(ctx, leadership))
+}
+
+func (s *LeaderLockTestSuite) TestReleaseLeadership() {
+	ctx := context.Background()
+
+	s.Require().NoError(s.lock.AcquireLeadership(ctx, leadership))
+	s.Require().NoError(s.other.ReleaseLeadership(ctx, leadership))
+
+	err := s.other.AcquireLeadership(ctx, leadership)
+	s.Require().ErrorIs(err, service.ErrLeadershipTaken)
+
+	s.Require().NoError(s.lock.ReleaseLeadership(ctx, leadership))
+	s.Require().NoError(s.other.AcquireLeadership(ctx, leadership))
+}
@@ -0,0 +1,43 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigratePipelineTestCampaignIDs sets test campaign ID of pipelines
+// added before pipelines stored it, so such pipelines are found in
+// the history. The ID is taken from the specification of the pipeline.
+//
+// Migration is done by the single aggregation merged into pipelines
+// on the server side, so it is idempotent and bounded by ctx.
+func MigratePipelineTestCampaignIDs(ctx context.Context, db *mongo.Database) error {
+	stages := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"testCampaignId": bson.M{"$in": bson.A{nil, ""}}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         specificationCollection,
+			"localField":   "specificationId",
+			"foreignField": "id",
+			"as":           "specifications",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"testCampaignId": bson.M{"$arrayElemAt": bson.A{"$specifications.testCampaignId", 0}},
+		}}},
+		{{Key: "$match", Value: bson.M{"testCampaignId": bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           pipelineCollection,
+			"on":             "_id",
+			"whenMatched":    "merge",
+			"whenNotMatched": "discard",
+		}}},
+	}
+
+	cur, err := db.Collection(pipelineCollection).Aggregate(ctx, stages)
+	if err != nil {
+		return err
+	}
+
+	return cur.Close(ctx)
+}
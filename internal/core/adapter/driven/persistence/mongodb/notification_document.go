@@ -0,0 +1,83 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+type notificationDocument struct {
+	ID             string    `bson:"_id"`
+	TestCampaignID string    `bson:"testCampaignId"`
+	SubscriptionID string    `bson:"subscriptionId"`
+	PipelineID     string    `bson:"pipelineId"`
+	FlowID         string    `bson:"flowId"`
+	URL            string    `bson:"url"`
+	Event          string    `bson:"event"`
+	Payload        []byte    `bson:"payload"`
+	State          string    `bson:"state"`
+	Attempts       int       `bson:"attempts"`
+	LastError      string    `bson:"lastError,omitempty"`
+	CreatedAt      time.Time `bson:"createdAt"`
+	UpdatedAt      time.Time `bson:"updatedAt"`
+}
+
+func newNotificationDocument(n service.Notification) notificationDocument {
+	return notificationDocument{
+		ID:             n.ID,
+		TestCampaignID: n.TestCampaignID,
+		SubscriptionID: n.SubscriptionID,
+		PipelineID:     n.PipelineID,
+		FlowID:         n.FlowID,
+		URL:            n.URL,
+		Event:          string(n.Event),
+		Payload:        n.Payload,
+		State:          string(n.State),
+		Attempts:       n.Attempts,
+		LastError:      n.LastError,
+		CreatedAt:      n.CreatedAt,
+		UpdatedAt:      n.UpdatedAt,
+	}
+}
+
+func newNotification(d notificationDocument) service.Notification {
+	return service.Notification{
+		ID:             d.ID,
+		TestCampaignID: d.TestCampaignID,
+		SubscriptionID: d.SubscriptionID,
+		PipelineID:     d.PipelineID,
+		FlowID:         d.FlowID,
+		URL:            d.URL,
+		Event:          testcampaign.Event(d.Event),
+		Payload:        d.Payload,
+		State:          service.NotificationState(d.State),
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func newNotificationsView(documents []notificationDocument) []query.NotificationModel {
+	notifications := make([]query.NotificationModel, 0, len(documents))
+
+	for _, d := range documents {
+		notifications = append(notifications, query.NotificationModel{
+			ID:             d.ID,
+			SubscriptionID: d.SubscriptionID,
+			PipelineID:     d.PipelineID,
+			FlowID:         d.FlowID,
+			URL:            d.URL,
+			Event:          d.Event,
+			State:          d.State,
+			Attempts:       d.Attempts,
+			LastError:      d.LastError,
+			CreatedAt:      d.CreatedAt,
+			UpdatedAt:      d.UpdatedAt,
+		})
+	}
+
+	return notifications
+}
@@ -0,0 +1,150 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type NotificationRepository struct {
+	notifications *mongo.Collection
+	testCampaigns *mongo.Collection
+}
+
+const (
+	notificationCollection = "notifications"
+	notificationsLimit     = 100
+)
+
+func NewNotificationRepository(db *mongo.Database) *NotificationRepository {
+	r := &NotificationRepository{
+		notifications: db.Collection(notificationCollection),
+		testCampaigns: db.Collection(testCampaignCollection),
+	}
+
+	_, err := r.notifications.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "testCampaignId", Value: 1},
+				{Key: "createdAt", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "state", Value: 1},
+				{Key: "updatedAt", Value: 1},
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+func (r *NotificationRepository) AddNotification(ctx context.Context, n service.Notification) error {
+	_, err := r.notifications.InsertOne(ctx, newNotificationDocument(n))
+
+	return service.WrapWithDatabaseError(err)
+}
+
+func (r *NotificationRepository) UpdateNotification(ctx context.Context, n service.Notification) error {
+	_, err := r.notifications.ReplaceOne(ctx, bson.M{"_id": n.ID}, newNotificationDocument(n))
+
+	return service.WrapWithDatabaseError(err)
+}
+
+// ClaimStaleNotifications claims each stale notification with the
+// update conditioned by its read update time, so the notification
+// claimed concurrently by another instance is skipped.
+func (r *NotificationRepository) ClaimStaleNotifications(
+	ctx context.Context,
+	updatedBefore, claimedAt time.Time,
+) ([]service.Notification, error) {
+	cur, err := r.notifications.Find(ctx, bson.M{
+		"state":     string(service.NotificationPending),
+		"updatedAt": bson.M{"$lt": updatedBefore},
+	})
+	if err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []notificationDocument
+	if err := cur.All(ctx, &documents); err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	claimed := make([]service.Notification, 0, len(documents))
+
+	for _, d := range documents {
+		filter := bson.M{
+			"_id":       d.ID,
+			"state":     d.State,
+			"updatedAt": d.UpdatedAt,
+		}
+
+		res, err := r.notifications.UpdateOne(ctx, filter, bson.M{
+			"$set": bson.M{"updatedAt": claimedAt},
+		})
+		if err != nil {
+			return nil, service.WrapWithDatabaseError(err)
+		}
+
+		if res.ModifiedCount == 0 {
+			continue
+		}
+
+		d.UpdatedAt = claimedAt
+
+		claimed = append(claimed, newNotification(d))
+	}
+
+	return claimed, nil
+}
+
+// FindNotifications returns up to notificationsLimit newest
+// notifications of the user test campaign.
+func (r *NotificationRepository) FindNotifications(
+	ctx context.Context,
+	qry query.Notifications,
+) ([]query.NotificationModel, error) {
+	count, err := r.testCampaigns.CountDocuments(ctx, bson.M{
+		"_id":     qry.TestCampaignID,
+		"ownerId": qry.UserID,
+	})
+	if err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	if count == 0 {
+		return nil, service.ErrTestCampaignNotFound
+	}
+
+	filter := bson.M{"testCampaignId": qry.TestCampaignID}
+	if qry.State != "" {
+		filter["state"] = qry.State
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(notificationsLimit)
+
+	cur, err := r.notifications.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []notificationDocument
+	if err := cur.All(ctx, &documents); err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	return newNotificationsView(documents), nil
+}
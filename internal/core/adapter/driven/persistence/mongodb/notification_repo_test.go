@@ -0,0 +1,163 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+type NotificationRepositoryTestSuite struct {
+	MongoSuite
+
+	repo *mongodb.NotificationRepository
+}
+
+const (
+	notifiedTestCampaignID = "7b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e"
+	notifiedOwnerID        = "8c9d0e1f-2a3b-4c4d-9e5f-6a7b8c9d0e1f"
+)
+
+func (s *NotificationRepositoryTestSuite) SetupTest() {
+	s.repo = mongodb.NewNotificationRepository(s.db)
+
+	_, err := s.db.Collection("testCampaigns").InsertOne(context.Background(), bson.M{
+		"_id":       notifiedTestCampaignID,
+		"ownerId":   notifiedOwnerID,
+		"createdAt": time.Now().UTC(),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *NotificationRepositoryTestSuite) TearDownTest() {
+	for _, col := range []string{"testCampaigns", "notifications"} {
+		_, err := s.db.
+			Collection(col).
+			DeleteMany(context.Background(), bson.D{})
+		s.Require().NoError(err)
+	}
+}
+
+func TestNotificationRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	suite.Run(t, &NotificationRepositoryTestSuite{})
+}
+
+func (s *NotificationRepositoryTestSuite) TestAddAndUpdateNotification() {
+	var (
+		ctx = context.Background()
+		now = time.Now().UTC().Truncate(time.Millisecond)
+	)
+
+	delivered := service.Notification{
+		ID:             "delivered",
+		TestCampaignID: notifiedTestCampaignID,
+		SubscriptionID: "ci",
+		URL:            "https://ci.example.com",
+		Event:          testcampaign.FailedEvent,
+		Payload:        []byte(`{}`),
+		State:          service.NotificationPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	dead := delivered
+	dead.ID = "dead"
+	dead.CreatedAt = now.Add(time.Second)
+
+	s.Require().NoError(s.repo.AddNotification(ctx, delivered))
+	s.Require().NoError(s.repo.AddNotification(ctx, dead))
+
+	delivered.State = service.NotificationDelivered
+	delivered.Attempts = 1
+
+	dead.State = service.NotificationDead
+	dead.Attempts = 5
+	dead.LastError = "unexpected response status: 500"
+
+	s.Require().NoError(s.repo.UpdateNotification(ctx, delivered))
+	s.Require().NoError(s.repo.UpdateNotification(ctx, dead))
+
+	notifications, err := s.repo.FindNotifications(ctx, query.Notifications{
+		TestCampaignID: notifiedTestCampaignID,
+		UserID:         notifiedOwnerID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(notifications, 2)
+	s.Require().Equal("dead", notifications[0].ID)
+	s.Require().Equal("delivered", notifications[1].ID)
+	s.Require().Equal(1, notifications[1].Attempts)
+
+	notifications, err = s.repo.FindNotifications(ctx, query.Notifications{
+		TestCampaignID: notifiedTestCampaignID,
+		UserID:         notifiedOwnerID,
+		State:          "dead",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(notifications, 1)
+	s.Require().Equal(dead.LastError, notifications[0].LastError)
+}
+
+func (s *NotificationRepositoryTestSuite) TestFindNotificationsOfAnotherUser() {
+	_, err := s.repo.FindNotifications(context.Background(), query.Notifications{
+		TestCampaignID: notifiedTestCampaignID,
+		UserID:         "9d0e1f2a-3b4c-4d5e-8f6a-7b8c9d0e1f2a",
+	})
+	s.Require().ErrorIs(err, service.ErrTestCampaignNotFound)
+}
+
+func (s *NotificationRepositoryTestSuite) TestClaimStaleNotifications() {
+	var (
+		ctx = context.Background()
+		now = time.Now().UTC().Truncate(time.Millisecond)
+	)
+
+	stale := service.Notification{
+		ID:             "stale",
+		TestCampaignID: notifiedTestCampaignID,
+		SubscriptionID: "ci",
+		URL:            "https://ci.example.com",
+		Event:          testcampaign.FailedEvent,
+		Payload:        []byte(`{}`),
+		State:          service.NotificationPending,
+		Attempts:       2,
+		CreatedAt:      now.Add(-time.Hour),
+		UpdatedAt:      now.Add(-time.Hour),
+	}
+
+	fresh := stale
+	fresh.ID = "fresh"
+	fresh.UpdatedAt = now
+
+	dead := stale
+	dead.ID = "dead"
+	dead.State = service.NotificationDead
+
+	for _, n := range []service.Notification{stale, fresh, dead} {
+		s.Require().NoError(s.repo.AddNotification(ctx, n))
+	}
+
+	claimedAt := now.Add(time.Second)
+
+	claimed, err := s.repo.ClaimStaleNotifications(ctx, now.Add(-time.Minute), claimedAt)
+	s.Require().NoError(err)
+	s.Require().Len(claimed, 1)
+	s.Require().Equal("stale", claimed[0].ID)
+	s.Require().Equal(2, claimed[0].Attempts)
+	s.Require().Equal(testcampaign.FailedEvent, claimed[0].Event)
+	s.Require().True(claimedAt.Equal(claimed[0].UpdatedAt))
+
+	claimed, err = s.repo.ClaimStaleNotifications(ctx, now.Add(-time.Minute), claimedAt)
+	s.Require().NoError(err)
+	s.Require().Empty(claimed)
+}
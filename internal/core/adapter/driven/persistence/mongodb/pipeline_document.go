@@ -1,15 +1,24 @@
 package mongodb
 
 import (
+	"sort"
+	"time"
+
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 	"github.com/harpyd/thestis/internal/core/entity/specification"
 )
 
 type pipelineDocument struct {
-	ID              string `bson:"_id"`
-	OwnerID         string `bson:"ownerId"`
-	SpecificationID string `bson:"specificationId"`
-	Started         bool   `bson:"started"`
+	ID              string    `bson:"_id"`
+	OwnerID         string    `bson:"ownerId"`
+	SpecificationID string    `bson:"specificationId"`
+	TestCampaignID  string    `bson:"testCampaignId"`
+	Environment     string    `bson:"environment,omitempty"`
+	ScenarioFilter  string    `bson:"scenarioFilter,omitempty"`
+	Started         bool      `bson:"started"`
+	StartedAt       time.Time `bson:"startedAt,omitempty"`
 }
 
 func newPipelineDocument(pipe *pipeline.Pipeline) pipelineDocument {
@@ -17,6 +26,9 @@ func newPipelineDocument(pipe *pipeline.Pipeline) pipelineDocument {
 		ID:              pipe.ID(),
 		OwnerID:         pipe.OwnerID(),
 		SpecificationID: pipe.SpecificationID(),
+		TestCampaignID:  pipe.TestCampaignID(),
+		Environment:     pipe.Environment(),
+		ScenarioFilter:  pipe.ScenarioFilter().String(),
 		Started:         pipe.Started(),
 	}
 }
@@ -27,9 +39,84 @@ func newPipeline(
 	registrars []pipeline.ExecutorRegistrar,
 ) *pipeline.Pipeline {
 	return pipeline.Unmarshal(pipeline.Params{
-		ID:            d.ID,
-		Specification: spec,
-		OwnerID:       d.OwnerID,
-		Started:       d.Started,
+		ID:             d.ID,
+		Specification:  spec,
+		OwnerID:        d.OwnerID,
+		TestCampaignID: d.TestCampaignID,
+		Environment:    d.Environment,
+		ScenarioFilter: specification.ScenarioFilter(d.ScenarioFilter),
+		Started:        d.Started,
 	}, registrars...)
 }
+
+// pipelineWithFlowsDocument is a pipeline
+// document joined with all of its flows.
+type pipelineWithFlowsDocument struct {
+	pipelineDocument `bson:",inline"`
+
+	Flows []flowDocument `bson:"flows"`
+}
+
+func newSpecificPipelineView(d pipelineWithFlowsDocument) query.PipelineModel {
+	pipe := query.PipelineModel{
+		ID:              d.ID,
+		SpecificationID: d.SpecificationID,
+		Started:         d.Started,
+		StartedAt:       d.StartedAt,
+		Flows:           make([]query.FlowModel, 0, len(d.Flows)),
+	}
+
+	sort.SliceStable(d.Flows, func(i, j int) bool {
+		return d.Flows[i].StartedAt.Before(d.Flows[j].StartedAt)
+	})
+
+	for _, f := range d.Flows {
+		pipe.Flows = append(pipe.Flows, newFlowView(f))
+	}
+
+	return pipe
+}
+
+// pipelineHistoryDocument is a pipeline document
+// enriched with the overall state of its last flow.
+type pipelineHistoryDocument struct {
+	pipelineDocument `bson:",inline"`
+
+	LastState flow.State `bson:"lastState"`
+}
+
+func newGeneralPipelineView(d pipelineHistoryDocument) query.GeneralPipelineModel {
+	return query.GeneralPipelineModel{
+		ID:              d.ID,
+		SpecificationID: d.SpecificationID,
+		StartedAt:       d.StartedAt,
+		LastState:       d.LastState.String(),
+	}
+}
+
+// pipelineHistoryCursor points to the last pipeline
+// of the page in the history sorted by start time.
+type pipelineHistoryCursor struct {
+	StartedAt time.Time `json:"startedAt"`
+	ID        string    `json:"id"`
+}
+
+func newPipelineHistoryCursor(d pipelineDocument) pipelineHistoryCursor {
+	return pipelineHistoryCursor{
+		StartedAt: d.StartedAt,
+		ID:        d.ID,
+	}
+}
+
+func (c pipelineHistoryCursor) encode() string {
+	return encodeCursor(c)
+}
+
+func decodePipelineHistoryCursor(cursor string) (pipelineHistoryCursor, error) {
+	var c pipelineHistoryCursor
+	if err := decodeCursor(cursor, &c); err != nil || c.ID == "" {
+		return pipelineHistoryCursor{}, query.ErrInvalidCursor
+	}
+
+	return c, nil
+}
@@ -2,57 +2,249 @@ package mongodb
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 )
 
+// PipelineGuard leases pipelines to the instance
+// with instanceID for the leaseTTL. The lease of the
+// died instance expires, so the pipeline can be
+// acquired again.
 type PipelineGuard struct {
-	pipelines *mongo.Collection
+	pipelines  *mongo.Collection
+	flows      *mongo.Collection
+	instanceID string
+	leaseTTL   time.Duration
 }
 
-func NewPipelineGuard(db *mongo.Database) *PipelineGuard {
+func NewPipelineGuard(
+	db *mongo.Database,
+	instanceID string,
+	leaseTTL time.Duration,
+) *PipelineGuard {
 	return &PipelineGuard{
-		pipelines: db.Collection(pipelineCollection),
+		pipelines:  db.Collection(pipelineCollection),
+		flows:      db.Collection(flowCollection),
+		instanceID: instanceID,
+		leaseTTL:   leaseTTL,
 	}
 }
 
 func (g *PipelineGuard) AcquirePipeline(ctx context.Context, pipeID string) error {
-	var document pipelineDocument
+	now := time.Now().UTC()
 
-	var (
-		filter = bson.M{"_id": pipeID}
-		update = bson.M{"$set": bson.M{"started": true}}
-		opt    = options.FindOneAndUpdate().SetProjection(bson.M{"_id": 0, "started": 1})
-	)
+	filter := bson.M{
+		"_id": pipeID,
+		"$or": bson.A{
+			bson.M{"started": false},
+			bson.M{"leaseExpiresAt": bson.M{"$lt": now}},
+		},
+	}
+
+	update := bson.M{"$set": bson.M{
+		"started":        true,
+		"startedAt":      now,
+		"leaseOwner":     g.instanceID,
+		"leaseExpiresAt": now.Add(g.leaseTTL),
+	}}
+
+	res, err := g.pipelines.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if res.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := g.pipelines.CountDocuments(ctx, bson.M{"_id": pipeID})
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if count == 0 {
+		return service.ErrPipelineNotFound
+	}
+
+	return pipeline.ErrAlreadyStarted
+}
+
+// ExtendPipelineLease returns service.ErrPipelineLeaseLost
+// if the lease is taken over or the pipeline is released.
+func (g *PipelineGuard) ExtendPipelineLease(ctx context.Context, pipeID string) error {
+	filter := bson.M{
+		"_id":        pipeID,
+		"started":    true,
+		"leaseOwner": g.instanceID,
+	}
 
-	if err := g.pipelines.FindOneAndUpdate(ctx, filter, update, opt).Decode(&document); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return service.ErrPipelineNotFound
-		}
+	update := bson.M{"$set": bson.M{
+		"leaseExpiresAt": time.Now().UTC().Add(g.leaseTTL),
+	}}
 
+	res, err := g.pipelines.UpdateOne(ctx, filter, update)
+	if err != nil {
 		return service.WrapWithDatabaseError(err)
 	}
 
-	if document.Started {
-		return pipeline.ErrAlreadyStarted
+	if res.MatchedCount == 0 {
+		return service.ErrPipelineLeaseLost
 	}
 
 	return nil
 }
 
+// ReleasePipeline releases the pipeline only if it is leased
+// to this instance, so the taken over pipeline stays started.
 func (g *PipelineGuard) ReleasePipeline(ctx context.Context, pipeID string) error {
-	update := bson.M{"$set": bson.M{"started": false}}
+	filter := bson.M{
+		"_id":        pipeID,
+		"leaseOwner": g.instanceID,
+	}
 
-	_, err := g.pipelines.UpdateByID(ctx, pipeID, update)
-	if errors.Is(err, mongo.ErrNoDocuments) {
-		return service.ErrPipelineNotFound
+	update := bson.M{
+		"$set":   bson.M{"started": false},
+		"$unset": bson.M{"leaseOwner": "", "leaseExpiresAt": ""},
+	}
+
+	_, err := g.pipelines.UpdateOne(ctx, filter, update)
+
+	return service.WrapWithDatabaseError(err)
+}
+
+// HandOverPipeline releases the pipeline leased to the owner
+// instance, so this instance can acquire the pipeline handed
+// over to it.
+func (g *PipelineGuard) HandOverPipeline(ctx context.Context, pipeID, owner string) error {
+	filter := bson.M{
+		"_id":        pipeID,
+		"leaseOwner": owner,
 	}
 
+	update := bson.M{
+		"$set":   bson.M{"started": false},
+		"$unset": bson.M{"leaseOwner": "", "leaseExpiresAt": ""},
+	}
+
+	_, err := g.pipelines.UpdateOne(ctx, filter, update)
+
 	return service.WrapWithDatabaseError(err)
 }
+
+// RecoverOrphanedPipelines crashes unfinished flows of started
+// pipelines with expired or missing lease and releases them.
+func (g *PipelineGuard) RecoverOrphanedPipelines(ctx context.Context) ([]string, error) {
+	filter := bson.M{
+		"started": true,
+		"$or": bson.A{
+			bson.M{"leaseExpiresAt": bson.M{"$lt": time.Now().UTC()}},
+			bson.M{"leaseExpiresAt": bson.M{"$exists": false}},
+		},
+	}
+
+	pipeIDs, err := g.findPipelineIDs(ctx, filter)
+	if err != nil || len(pipeIDs) == 0 {
+		return nil, err
+	}
+
+	flowIDs, err := g.crashUnfinishedFlows(ctx, pipeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	filter["_id"] = bson.M{"$in": pipeIDs}
+
+	update := bson.M{
+		"$set":   bson.M{"started": false},
+		"$unset": bson.M{"leaseOwner": "", "leaseExpiresAt": ""},
+	}
+
+	if _, err := g.pipelines.UpdateMany(ctx, filter, update); err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	return flowIDs, nil
+}
+
+func (g *PipelineGuard) findPipelineIDs(ctx context.Context, filter bson.M) ([]string, error) {
+	opt := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := g.pipelines.Find(ctx, filter, opt)
+	if err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []pipelineDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	ids := make([]string, 0, len(documents))
+	for _, d := range documents {
+		ids = append(ids, d.ID)
+	}
+
+	return ids, nil
+}
+
+// crashUnfinishedFlows returns IDs of the crashed flows. Flows are
+// found before the update, so only they are crashed, even if another
+// flow of the pipelines is saved in between.
+func (g *PipelineGuard) crashUnfinishedFlows(ctx context.Context, pipeIDs []string) ([]string, error) {
+	var (
+		inProgress = bson.A{flow.Executing, flow.Paused}
+		unfinished = bson.A{flow.NotExecuted, flow.Executing, flow.Paused}
+	)
+
+	filter := bson.M{
+		"pipelineId":   bson.M{"$in": pipeIDs},
+		"overallState": bson.M{"$in": unfinished},
+	}
+
+	cursor, err := g.flows.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []flowDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	flowIDs := make([]string, 0, len(documents))
+	for _, d := range documents {
+		flowIDs = append(flowIDs, d.ID)
+	}
+
+	filter["_id"] = bson.M{"$in": flowIDs}
+
+	update := bson.M{"$set": bson.M{
+		"overallState":                           flow.Crashed,
+		"statuses.$[s].state":                    flow.Crashed,
+		"statuses.$[].thesisStatuses.$[t].state": flow.Crashed,
+	}}
+
+	opt := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{
+			bson.M{"s.state": bson.M{"$in": inProgress}},
+			bson.M{"t.state": bson.M{"$in": inProgress}},
+		},
+	})
+
+	if _, err := g.flows.UpdateMany(ctx, filter, update, opt); err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	return flowIDs, nil
+}
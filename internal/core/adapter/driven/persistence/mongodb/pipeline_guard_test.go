@@ -5,11 +5,13 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
+	"github.com/harpyd/thestis/internal/core/app/service"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 )
 
@@ -20,8 +22,13 @@ type PipelineGuardTestSuite struct {
 	pipeID string
 }
 
+const (
+	guardInstanceID = "a9d1c3a4-3c3b-4d43-8e41-44a0b6b5f0e2"
+	guardLeaseTTL   = time.Minute
+)
+
 func (s *PipelineGuardTestSuite) SetupTest() {
-	s.guard = mongodb.NewPipelineGuard(s.db)
+	s.guard = mongodb.NewPipelineGuard(s.db, guardInstanceID, guardLeaseTTL)
 
 	s.pipeID = "2db44433-7142-4080-bada-844afccfedbf"
 
@@ -36,6 +43,11 @@ func (s *PipelineGuardTestSuite) TearDownTest() {
 		Collection("pipelines").
 		DeleteMany(context.Background(), bson.D{})
 	s.Require().NoError(err)
+
+	_, err = s.db.
+		Collection("flows").
+		DeleteMany(context.Background(), bson.D{})
+	s.Require().NoError(err)
 }
 
 func TestPipelineGuard(t *testing.T) {
@@ -97,6 +109,141 @@ func (s *PipelineGuardTestSuite) TestReleasePipeline() {
 	s.Require().False(s.getPipelineStarted())
 }
 
+func (s *PipelineGuardTestSuite) TestHandOverPipeline() {
+	api := mongodb.NewPipelineGuard(s.db, "api-instance", guardLeaseTTL)
+
+	err := api.AcquirePipeline(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	err = s.guard.HandOverPipeline(context.Background(), s.pipeID, "another-instance")
+	s.Require().NoError(err)
+
+	err = s.guard.AcquirePipeline(context.Background(), s.pipeID)
+	s.Require().ErrorIs(err, pipeline.ErrAlreadyStarted)
+
+	err = s.guard.HandOverPipeline(context.Background(), s.pipeID, "api-instance")
+	s.Require().NoError(err)
+
+	err = s.guard.AcquirePipeline(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	err = api.ExtendPipelineLease(context.Background(), s.pipeID)
+	s.Require().ErrorIs(err, service.ErrPipelineLeaseLost)
+}
+
+func (s *PipelineGuardTestSuite) TestAcquireUnknownPipeline() {
+	err := s.guard.AcquirePipeline(context.Background(), "unknown")
+	s.Require().ErrorIs(err, service.ErrPipelineNotFound)
+}
+
+func (s *PipelineGuardTestSuite) TestAcquirePipelineWithExpiredLease() {
+	expired := mongodb.NewPipelineGuard(s.db, "dead-instance", -time.Minute)
+
+	err := expired.AcquirePipeline(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	err = s.guard.AcquirePipeline(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	err = expired.ExtendPipelineLease(context.Background(), s.pipeID)
+	s.Require().ErrorIs(err, service.ErrPipelineLeaseLost)
+
+	err = expired.ReleasePipeline(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	s.Require().True(s.getPipelineStarted())
+}
+
+func (s *PipelineGuardTestSuite) TestExtendPipelineLease() {
+	err := s.guard.AcquirePipeline(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	err = s.guard.ExtendPipelineLease(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	err = s.guard.ReleasePipeline(context.Background(), s.pipeID)
+	s.Require().NoError(err)
+
+	err = s.guard.ExtendPipelineLease(context.Background(), s.pipeID)
+	s.Require().ErrorIs(err, service.ErrPipelineLeaseLost)
+}
+
+func (s *PipelineGuardTestSuite) TestRecoverOrphanedPipelines() {
+	const (
+		orphanedPipeID = "f1d5e0e8-5bd5-4a6f-9c8b-7e2b5a3c6d10"
+		leasedPipeID   = "0b3c9e4e-2d9a-4a1f-8f43-b8d7a6c2e5f1"
+		flowID         = "bb7a9f7e-8c29-4a6b-9d3c-6f0c0a8f4e22"
+		passedFlowID   = "c2d3e4f5-a6b7-4c8d-9e0f-1a2b3c4d5e6f"
+	)
+
+	s.insertPipelines(
+		bson.M{
+			"_id":            orphanedPipeID,
+			"started":        true,
+			"leaseOwner":     "dead-instance",
+			"leaseExpiresAt": time.Now().UTC().Add(-time.Minute),
+		},
+		bson.M{
+			"_id":            leasedPipeID,
+			"started":        true,
+			"leaseOwner":     "alive-instance",
+			"leaseExpiresAt": time.Now().UTC().Add(time.Minute),
+		},
+	)
+
+	s.insertFlows(bson.M{
+		"_id":          flowID,
+		"pipelineId":   orphanedPipeID,
+		"overallState": "executing",
+		"statuses": bson.A{
+			bson.M{
+				"slug":  bson.M{"story": "foo", "scenario": "bar"},
+				"state": "executing",
+				"thesisStatuses": bson.A{
+					bson.M{"thesisSlug": "baz", "state": "passed"},
+					bson.M{"thesisSlug": "qux", "state": "executing"},
+				},
+			},
+		},
+	})
+
+	s.insertFlows(bson.M{
+		"_id":          passedFlowID,
+		"pipelineId":   orphanedPipeID,
+		"overallState": "passed",
+	})
+
+	crashed, err := s.guard.RecoverOrphanedPipelines(context.Background())
+	s.Require().NoError(err)
+	s.Require().Equal([]string{flowID}, crashed)
+
+	var document struct {
+		OverallState string `bson:"overallState"`
+		Statuses     []struct {
+			State          string `bson:"state"`
+			ThesisStatuses []struct {
+				State string `bson:"state"`
+			} `bson:"thesisStatuses"`
+		} `bson:"statuses"`
+	}
+
+	err = s.db.Collection("flows").
+		FindOne(context.Background(), bson.M{"_id": flowID}).
+		Decode(&document)
+	s.Require().NoError(err)
+
+	s.Require().Equal("crashed", document.OverallState)
+	s.Require().Equal("crashed", document.Statuses[0].State)
+	s.Require().Equal("passed", document.Statuses[0].ThesisStatuses[0].State)
+	s.Require().Equal("crashed", document.Statuses[0].ThesisStatuses[1].State)
+
+	err = s.guard.AcquirePipeline(context.Background(), orphanedPipeID)
+	s.Require().NoError(err)
+
+	err = s.guard.AcquirePipeline(context.Background(), leasedPipeID)
+	s.Require().ErrorIs(err, pipeline.ErrAlreadyStarted)
+}
+
 func (s *PipelineGuardTestSuite) getPipelineStarted() bool {
 	var document struct {
 		Started bool `bson:"started"`
@@ -7,19 +7,48 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/harpyd/thestis/internal/core/app/query"
 	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/user"
 )
 
 type PipelineRepository struct {
-	pipelines *mongo.Collection
+	pipelines      *mongo.Collection
+	flows          *mongo.Collection
+	specifications *mongo.Collection
 }
 
 const pipelineCollection = "pipelines"
 
 func NewPipelineRepository(db *mongo.Database) *PipelineRepository {
 	r := &PipelineRepository{
-		pipelines: db.Collection(pipelineCollection),
+		pipelines:      db.Collection(pipelineCollection),
+		flows:          db.Collection(flowCollection),
+		specifications: db.Collection(specificationCollection),
+	}
+
+	_, err := r.pipelines.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "testCampaignId", Value: 1},
+			{Key: "startedAt", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = r.flows.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "pipelineId", Value: 1},
+			{Key: "startedAt", Value: -1},
+		},
+	})
+	if err != nil {
+		panic(err)
 	}
 
 	return r
@@ -65,3 +94,231 @@ func (r *PipelineRepository) AddPipeline(ctx context.Context, pipe *pipeline.Pip
 
 	return service.WrapWithDatabaseError(err)
 }
+
+func (r *PipelineRepository) FindPipeline(
+	ctx context.Context,
+	qry query.Pipeline,
+) (query.PipelineModel, error) {
+	document, err := r.getPipelineWithFlowsDocument(ctx, qry.PipelineID)
+	if err != nil {
+		return query.PipelineModel{}, err
+	}
+
+	pipe := newPipeline(document.pipelineDocument, nil, nil)
+
+	if err := user.CanAccessPipeline(qry.UserID, pipe, user.Read); err != nil {
+		return query.PipelineModel{}, err
+	}
+
+	return newSpecificPipelineView(document), nil
+}
+
+// FindFlow returns the flow and the specification of its
+// pipeline if the user can access the pipeline.
+func (r *PipelineRepository) FindFlow(
+	ctx context.Context,
+	qry query.FlowReport,
+) (*flow.Flow, *specification.Specification, error) {
+	var document flowDocument
+	if err := r.flows.FindOne(ctx, bson.M{"_id": qry.FlowID}).Decode(&document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, service.ErrFlowNotFound
+		}
+
+		return nil, nil, service.WrapWithDatabaseError(err)
+	}
+
+	pipeDocument, err := r.getPipelineDocument(ctx, bson.M{"_id": document.PipelineID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipe := newPipeline(pipeDocument, nil, nil)
+
+	if err := user.CanAccessPipeline(qry.UserID, pipe, user.Read); err != nil {
+		return nil, nil, err
+	}
+
+	var specDocument specificationDocument
+
+	err = r.specifications.FindOne(ctx, bson.M{"id": pipeDocument.SpecificationID}).Decode(&specDocument)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, service.ErrSpecificationNotFound
+		}
+
+		return nil, nil, service.WrapWithDatabaseError(err)
+	}
+
+	return newFlow(document), newSpecification(specDocument), nil
+}
+
+func (r *PipelineRepository) getPipelineWithFlowsDocument(
+	ctx context.Context,
+	pipeID string,
+) (pipelineWithFlowsDocument, error) {
+	stages := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": pipeID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         flowCollection,
+			"localField":   "_id",
+			"foreignField": "pipelineId",
+			"as":           "flows",
+		}}},
+	}
+
+	cur, err := r.pipelines.Aggregate(ctx, stages)
+	if err != nil {
+		return pipelineWithFlowsDocument{}, service.WrapWithDatabaseError(err)
+	}
+
+	defer cur.Close(ctx)
+
+	if !cur.Next(ctx) {
+		if err := cur.Err(); err != nil {
+			return pipelineWithFlowsDocument{}, service.WrapWithDatabaseError(err)
+		}
+
+		return pipelineWithFlowsDocument{}, service.ErrPipelineNotFound
+	}
+
+	var document pipelineWithFlowsDocument
+	if err := cur.Decode(&document); err != nil {
+		return pipelineWithFlowsDocument{}, service.WrapWithDatabaseError(err)
+	}
+
+	return document, nil
+}
+
+// FindPipelineHistory returns pipelines of the test campaign sorted by
+// start time from newest to oldest. Each pipeline is enriched with the
+// overall state of the last flow. The page following the returned one
+// can be requested with query.PipelineHistoryModel NextCursor.
+func (r *PipelineRepository) FindPipelineHistory(
+	ctx context.Context,
+	qry query.PipelineHistory,
+) (query.PipelineHistoryModel, error) {
+	if qry.Limit <= 0 {
+		qry.Limit = query.DefaultPipelineHistoryLimit
+	}
+
+	match, err := pipelineHistoryFilter(qry)
+	if err != nil {
+		return query.PipelineHistoryModel{}, err
+	}
+
+	stages := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "startedAt", Value: -1},
+			{Key: "_id", Value: -1},
+		}}},
+	}
+
+	// Without the state filter the page is known before the
+	// join, so flows are looked up only for its pipelines.
+	if qry.State == "" {
+		stages = append(stages, bson.D{{Key: "$limit", Value: qry.Limit + 1}})
+	}
+
+	stages = append(stages,
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from": flowCollection,
+			"let":  bson.M{"pipeId": "$_id"},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$pipelineId", "$$pipeId"}}}}},
+				{{Key: "$sort", Value: bson.M{"startedAt": -1}}},
+				{{Key: "$limit", Value: 1}},
+				{{Key: "$project", Value: bson.M{"overallState": 1}}},
+			},
+			"as": "lastFlows",
+		}}},
+		bson.D{{Key: "$addFields", Value: bson.M{
+			"lastState": bson.M{"$ifNull": bson.A{
+				bson.M{"$arrayElemAt": bson.A{"$lastFlows.overallState", 0}},
+				flow.NoState,
+			}},
+		}}},
+	)
+
+	if qry.State != "" {
+		stages = append(stages,
+			bson.D{{Key: "$match", Value: bson.M{"lastState": qry.State}}},
+			bson.D{{Key: "$limit", Value: qry.Limit + 1}},
+		)
+	}
+
+	cur, err := r.pipelines.Aggregate(ctx, stages)
+	if err != nil {
+		return query.PipelineHistoryModel{}, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []pipelineHistoryDocument
+	if err := cur.All(ctx, &documents); err != nil {
+		return query.PipelineHistoryModel{}, service.WrapWithDatabaseError(err)
+	}
+
+	return newPipelineHistoryView(documents, qry.Limit), nil
+}
+
+func pipelineHistoryFilter(qry query.PipelineHistory) (bson.M, error) {
+	conditions := bson.A{
+		bson.M{"testCampaignId": qry.TestCampaignID},
+		bson.M{"ownerId": qry.UserID},
+	}
+
+	if qry.SpecificationID != "" {
+		conditions = append(conditions, bson.M{"specificationId": qry.SpecificationID})
+	}
+
+	if !qry.From.IsZero() {
+		conditions = append(conditions, bson.M{"startedAt": bson.M{"$gte": qry.From}})
+	}
+
+	if !qry.To.IsZero() {
+		conditions = append(conditions, bson.M{"startedAt": bson.M{"$lte": qry.To}})
+	}
+
+	if qry.Cursor != "" {
+		c, err := decodePipelineHistoryCursor(qry.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, pipelineHistoryCursorFilter(c))
+	}
+
+	return bson.M{"$and": conditions}, nil
+}
+
+// pipelineHistoryCursorFilter matches pipelines following the cursor.
+// Not started pipelines have no start time and are sorted after all
+// started ones, so they are paged by ID only.
+func pipelineHistoryCursorFilter(c pipelineHistoryCursor) bson.M {
+	if c.StartedAt.IsZero() {
+		return bson.M{"startedAt": nil, "_id": bson.M{"$lt": c.ID}}
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{"startedAt": bson.M{"$lt": c.StartedAt}},
+		bson.M{"startedAt": c.StartedAt, "_id": bson.M{"$lt": c.ID}},
+		bson.M{"startedAt": nil},
+	}}
+}
+
+func newPipelineHistoryView(documents []pipelineHistoryDocument, limit int) query.PipelineHistoryModel {
+	var history query.PipelineHistoryModel
+
+	if len(documents) > limit {
+		documents = documents[:limit]
+		history.NextCursor = newPipelineHistoryCursor(documents[limit-1].pipelineDocument).encode()
+	}
+
+	history.Pipelines = make([]query.GeneralPipelineModel, 0, len(documents))
+
+	for _, d := range documents {
+		history.Pipelines = append(history.Pipelines, newGeneralPipelineView(d))
+	}
+
+	return history
+}
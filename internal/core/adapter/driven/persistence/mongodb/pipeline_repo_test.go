@@ -3,16 +3,21 @@ package mongodb_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
+	"github.com/harpyd/thestis/internal/core/app/query"
 	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/user"
 )
 
 type PipelineRepositoryTestSuite struct {
@@ -30,6 +35,16 @@ func (s *PipelineRepositoryTestSuite) TearDownTest() {
 		Collection("pipelines").
 		DeleteMany(context.Background(), bson.D{})
 	s.Require().NoError(err)
+
+	_, err = s.db.
+		Collection("flows").
+		DeleteMany(context.Background(), bson.D{})
+	s.Require().NoError(err)
+
+	_, err = s.db.
+		Collection("specifications").
+		DeleteMany(context.Background(), bson.D{})
+	s.Require().NoError(err)
 }
 
 func TestPipelineRepository(t *testing.T) {
@@ -111,3 +126,555 @@ func (s *PipelineRepositoryTestSuite) TestAddPipeline() {
 		})
 	}
 }
+
+func (s *PipelineRepositoryTestSuite) TestFindPipeline() {
+	var (
+		startedAt      = time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+		firstFlowTime  = time.Date(2022, 4, 20, 10, 0, 1, 0, time.UTC)
+		secondFlowTime = time.Date(2022, 4, 21, 10, 0, 1, 0, time.UTC)
+	)
+
+	s.insertPipelines(bson.M{
+		"_id":             "f2e6f4b6-6d1f-4c43-9a3e-0e5b5f0e0f34",
+		"ownerId":         "a2d9a8f6-1b7e-4a5c-9f0d-7c2b1f4e8d63",
+		"specificationId": "c7c2f0e7-2c4a-4d8e-8c0a-9c1b5a0a6a21",
+		"started":         true,
+		"startedAt":       startedAt,
+	})
+
+	s.insertFlows(
+		bson.M{
+			"_id":          "4f6c0b1e-0f3f-4a0a-9bb4-2d8f1b8c4e11",
+			"pipelineId":   "f2e6f4b6-6d1f-4c43-9a3e-0e5b5f0e0f34",
+			"overallState": "failed",
+			"startedAt":    secondFlowTime,
+			"statuses": bson.A{
+				bson.M{
+					"slug":  bson.M{"story": "foo", "scenario": "bar"},
+					"state": "failed",
+					"thesisStatuses": bson.A{
+						bson.M{
+							"thesisSlug":   "baz",
+							"state":        "failed",
+							"occurredErrs": bson.A{"something wrong"},
+						},
+					},
+				},
+			},
+		},
+		bson.M{
+			"_id":          "9b1d5a3c-7e2f-4c6b-8a9d-0e1f2a3b4c5d",
+			"pipelineId":   "f2e6f4b6-6d1f-4c43-9a3e-0e5b5f0e0f34",
+			"overallState": "passed",
+			"startedAt":    firstFlowTime,
+			"statuses": bson.A{
+				bson.M{
+					"slug":  bson.M{"story": "foo", "scenario": "bar"},
+					"state": "passed",
+					"thesisStatuses": bson.A{
+						bson.M{"thesisSlug": "baz", "state": "passed"},
+					},
+				},
+			},
+		},
+		bson.M{
+			"_id":          "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+			"pipelineId":   "d1f0c2b4-3a5e-4f6d-8b7a-9c0e1d2f3a4b",
+			"overallState": "passed",
+		},
+	)
+
+	testCases := []struct {
+		Name          string
+		Query         query.Pipeline
+		ShouldBeErr   bool
+		IsErr         func(err error) bool
+		ExpectedModel query.PipelineModel
+	}{
+		{
+			Name: "pipeline_not_found",
+			Query: query.Pipeline{
+				PipelineID: "0a0b0c0d-1e1f-4a2b-8c3d-4e5f6a7b8c9d",
+				UserID:     "a2d9a8f6-1b7e-4a5c-9f0d-7c2b1f4e8d63",
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrPipelineNotFound)
+			},
+		},
+		{
+			Name: "user_cannot_see_pipeline",
+			Query: query.Pipeline{
+				PipelineID: "f2e6f4b6-6d1f-4c43-9a3e-0e5b5f0e0f34",
+				UserID:     "b8f1d2e3-4c5a-4b6d-9e7f-0a1b2c3d4e5f",
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			Name: "successfully_found_pipeline_with_flows",
+			Query: query.Pipeline{
+				PipelineID: "f2e6f4b6-6d1f-4c43-9a3e-0e5b5f0e0f34",
+				UserID:     "a2d9a8f6-1b7e-4a5c-9f0d-7c2b1f4e8d63",
+			},
+			ShouldBeErr: false,
+			ExpectedModel: query.PipelineModel{
+				ID:              "f2e6f4b6-6d1f-4c43-9a3e-0e5b5f0e0f34",
+				SpecificationID: "c7c2f0e7-2c4a-4d8e-8c0a-9c1b5a0a6a21",
+				Started:         true,
+				StartedAt:       startedAt,
+				Flows: []query.FlowModel{
+					{
+						ID:           "9b1d5a3c-7e2f-4c6b-8a9d-0e1f2a3b4c5d",
+						StartedAt:    firstFlowTime,
+						OverallState: "passed",
+						Statuses: []query.StatusModel{
+							{
+								Slug:  query.ScenarioSlugModel{Story: "foo", Scenario: "bar"},
+								State: "passed",
+								ThesisStatuses: []query.ThesisStatusModel{
+									{ThesisSlug: "baz", State: "passed"},
+								},
+							},
+						},
+					},
+					{
+						ID:           "4f6c0b1e-0f3f-4a0a-9bb4-2d8f1b8c4e11",
+						StartedAt:    secondFlowTime,
+						OverallState: "failed",
+						Statuses: []query.StatusModel{
+							{
+								Slug:  query.ScenarioSlugModel{Story: "foo", Scenario: "bar"},
+								State: "failed",
+								ThesisStatuses: []query.ThesisStatusModel{
+									{
+										ThesisSlug:   "baz",
+										State:        "failed",
+										OccurredErrs: []string{"something wrong"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		s.Run(c.Name, func() {
+			pipe, err := s.repo.FindPipeline(context.Background(), c.Query)
+
+			if c.ShouldBeErr {
+				s.Require().True(c.IsErr(err))
+
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(c.ExpectedModel, pipe)
+		})
+	}
+}
+
+func (s *PipelineRepositoryTestSuite) TestFindFlow() {
+	s.insertSpecifications(bson.M{
+		"id":      "8c7b6a5f-4e3d-4c2b-9a1f-0e9d8c7b6a5f",
+		"ownerId": "3b2a1f0e-9d8c-4b7a-8f6e-5d4c3b2a1f0e",
+		"title":   "some title",
+	})
+
+	s.insertPipelines(
+		bson.M{
+			"_id":             "6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b",
+			"ownerId":         "3b2a1f0e-9d8c-4b7a-8f6e-5d4c3b2a1f0e",
+			"specificationId": "8c7b6a5f-4e3d-4c2b-9a1f-0e9d8c7b6a5f",
+		},
+		bson.M{
+			"_id":             "5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a",
+			"ownerId":         "3b2a1f0e-9d8c-4b7a-8f6e-5d4c3b2a1f0e",
+			"specificationId": "4b3a2f1e-0d9c-4b8a-8f6e-5d4c3b2a1f0e",
+		},
+	)
+
+	s.insertFlows(
+		bson.M{
+			"_id":          "2f1e0d9c-8b7a-4f6e-8d5c-4b3a2f1e0d9c",
+			"pipelineId":   "6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b",
+			"overallState": "failed",
+			"statuses": bson.A{
+				bson.M{
+					"slug":  bson.M{"story": "foo", "scenario": "bar"},
+					"state": "failed",
+					"thesisStatuses": bson.A{
+						bson.M{
+							"thesisSlug":   "baz",
+							"state":        "failed",
+							"occurredErrs": bson.A{"something wrong"},
+						},
+					},
+				},
+			},
+		},
+		bson.M{
+			"_id":        "7a6b5c4d-3e2f-4a1b-8c0d-9e8f7a6b5c4d",
+			"pipelineId": "0f9e8d7c-6b5a-4f4e-8d3c-2b1a0f9e8d7c",
+		},
+		bson.M{
+			"_id":        "3c2b1a0f-9e8d-4c7b-8a6f-5e4d3c2b1a0f",
+			"pipelineId": "5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a",
+		},
+	)
+
+	testCases := []struct {
+		Name                    string
+		Query                   query.FlowReport
+		ShouldBeErr             bool
+		IsErr                   func(err error) bool
+		ExpectedFlow            *flow.Flow
+		ExpectedSpecificationID string
+	}{
+		{
+			Name: "flow_not_found",
+			Query: query.FlowReport{
+				FlowID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+				UserID: "3b2a1f0e-9d8c-4b7a-8f6e-5d4c3b2a1f0e",
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrFlowNotFound)
+			},
+		},
+		{
+			Name: "pipeline_of_flow_not_found",
+			Query: query.FlowReport{
+				FlowID: "7a6b5c4d-3e2f-4a1b-8c0d-9e8f7a6b5c4d",
+				UserID: "3b2a1f0e-9d8c-4b7a-8f6e-5d4c3b2a1f0e",
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrPipelineNotFound)
+			},
+		},
+		{
+			Name: "specification_of_flow_not_found",
+			Query: query.FlowReport{
+				FlowID: "3c2b1a0f-9e8d-4c7b-8a6f-5e4d3c2b1a0f",
+				UserID: "3b2a1f0e-9d8c-4b7a-8f6e-5d4c3b2a1f0e",
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrSpecificationNotFound)
+			},
+		},
+		{
+			Name: "user_cannot_see_flow",
+			Query: query.FlowReport{
+				FlowID: "2f1e0d9c-8b7a-4f6e-8d5c-4b3a2f1e0d9c",
+				UserID: "b8f1d2e3-4c5a-4b6d-9e7f-0a1b2c3d4e5f",
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			Name: "successfully_found_flow",
+			Query: query.FlowReport{
+				FlowID: "2f1e0d9c-8b7a-4f6e-8d5c-4b3a2f1e0d9c",
+				UserID: "3b2a1f0e-9d8c-4b7a-8f6e-5d4c3b2a1f0e",
+			},
+			ShouldBeErr: false,
+			ExpectedFlow: flow.FromStatuses(
+				"2f1e0d9c-8b7a-4f6e-8d5c-4b3a2f1e0d9c",
+				"6e5d4c3b-2a1f-4e0d-9c8b-7a6f5e4d3c2b",
+				flow.NewStatus(
+					specification.NewScenarioSlug("foo", "bar"),
+					flow.Failed,
+					flow.NewThesisStatus("baz", flow.Failed, "something wrong"),
+				),
+			),
+			ExpectedSpecificationID: "8c7b6a5f-4e3d-4c2b-9a1f-0e9d8c7b6a5f",
+		},
+	}
+
+	for _, c := range testCases {
+		s.Run(c.Name, func() {
+			f, spec, err := s.repo.FindFlow(context.Background(), c.Query)
+
+			if c.ShouldBeErr {
+				s.Require().True(c.IsErr(err))
+
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(c.ExpectedFlow, f)
+			s.Require().Equal(c.ExpectedSpecificationID, spec.ID())
+		})
+	}
+}
+
+func (s *PipelineRepositoryTestSuite) TestFindPipelineHistory() {
+	const (
+		ownerID        = "5f4c3b2a-1d0e-4f9a-8b7c-6d5e4f3a2b1c"
+		testCampaignID = "e3d2c1b0-a9f8-4e7d-8c6b-5a4f3e2d1c0b"
+	)
+
+	var (
+		day1 = time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+		day2 = time.Date(2022, 5, 2, 0, 0, 0, 0, time.UTC)
+		day3 = time.Date(2022, 5, 3, 0, 0, 0, 0, time.UTC)
+	)
+
+	s.insertPipelines(
+		bson.M{
+			"_id":             "00000000-0000-4000-8000-000000000001",
+			"ownerId":         ownerID,
+			"testCampaignId":  testCampaignID,
+			"specificationId": "spec-1",
+			"startedAt":       day1,
+		},
+		bson.M{
+			"_id":             "00000000-0000-4000-8000-000000000002",
+			"ownerId":         ownerID,
+			"testCampaignId":  testCampaignID,
+			"specificationId": "spec-2",
+			"startedAt":       day2,
+		},
+		bson.M{
+			"_id":             "00000000-0000-4000-8000-000000000003",
+			"ownerId":         ownerID,
+			"testCampaignId":  testCampaignID,
+			"specificationId": "spec-2",
+			"startedAt":       day3,
+		},
+		bson.M{
+			"_id":             "00000000-0000-4000-8000-000000000004",
+			"ownerId":         ownerID,
+			"testCampaignId":  "another-test-campaign",
+			"specificationId": "spec-3",
+			"startedAt":       day3,
+		},
+	)
+
+	s.insertFlows(
+		bson.M{
+			"_id":          "flow-1",
+			"pipelineId":   "00000000-0000-4000-8000-000000000001",
+			"overallState": "failed",
+			"startedAt":    day1,
+		},
+		bson.M{
+			"_id":          "flow-2",
+			"pipelineId":   "00000000-0000-4000-8000-000000000002",
+			"overallState": "failed",
+			"startedAt":    day2,
+		},
+		bson.M{
+			"_id":          "flow-3",
+			"pipelineId":   "00000000-0000-4000-8000-000000000002",
+			"overallState": "passed",
+			"startedAt":    day2.Add(time.Hour),
+		},
+	)
+
+	var (
+		first = query.GeneralPipelineModel{
+			ID:              "00000000-0000-4000-8000-000000000001",
+			SpecificationID: "spec-1",
+			StartedAt:       day1,
+			LastState:       "failed",
+		}
+		second = query.GeneralPipelineModel{
+			ID:              "00000000-0000-4000-8000-000000000002",
+			SpecificationID: "spec-2",
+			StartedAt:       day2,
+			LastState:       "passed",
+		}
+		third = query.GeneralPipelineModel{
+			ID:              "00000000-0000-4000-8000-000000000003",
+			SpecificationID: "spec-2",
+			StartedAt:       day3,
+			LastState:       "",
+		}
+	)
+
+	testCases := []struct {
+		Name              string
+		Query             query.PipelineHistory
+		ExpectedPipelines []query.GeneralPipelineModel
+		ExpectedNextPage  bool
+	}{
+		{
+			Name: "all_pipelines_of_test_campaign",
+			Query: query.PipelineHistory{
+				TestCampaignID: testCampaignID,
+				UserID:         ownerID,
+				Limit:          10,
+			},
+			ExpectedPipelines: []query.GeneralPipelineModel{third, second, first},
+		},
+		{
+			Name: "first_page",
+			Query: query.PipelineHistory{
+				TestCampaignID: testCampaignID,
+				UserID:         ownerID,
+				Limit:          2,
+			},
+			ExpectedPipelines: []query.GeneralPipelineModel{third, second},
+			ExpectedNextPage:  true,
+		},
+		{
+			Name: "filtered_by_last_state",
+			Query: query.PipelineHistory{
+				TestCampaignID: testCampaignID,
+				UserID:         ownerID,
+				State:          "failed",
+				Limit:          10,
+			},
+			ExpectedPipelines: []query.GeneralPipelineModel{first},
+		},
+		{
+			Name: "filtered_by_specification",
+			Query: query.PipelineHistory{
+				TestCampaignID:  testCampaignID,
+				UserID:          ownerID,
+				SpecificationID: "spec-2",
+				Limit:           10,
+			},
+			ExpectedPipelines: []query.GeneralPipelineModel{third, second},
+		},
+		{
+			Name: "filtered_by_date_range",
+			Query: query.PipelineHistory{
+				TestCampaignID: testCampaignID,
+				UserID:         ownerID,
+				From:           day1.Add(time.Hour),
+				To:             day2,
+				Limit:          10,
+			},
+			ExpectedPipelines: []query.GeneralPipelineModel{second},
+		},
+		{
+			Name: "user_cannot_see_foreign_pipelines",
+			Query: query.PipelineHistory{
+				TestCampaignID: testCampaignID,
+				UserID:         "8e7d6c5b-4a3f-4e2d-9c1b-0a9f8e7d6c5b",
+				Limit:          10,
+			},
+			ExpectedPipelines: []query.GeneralPipelineModel{},
+		},
+	}
+
+	for _, c := range testCases {
+		s.Run(c.Name, func() {
+			history, err := s.repo.FindPipelineHistory(context.Background(), c.Query)
+			s.Require().NoError(err)
+
+			s.Require().Equal(c.ExpectedPipelines, history.Pipelines)
+			s.Require().Equal(c.ExpectedNextPage, history.NextCursor != "")
+		})
+	}
+}
+
+func (s *PipelineRepositoryTestSuite) TestFindPipelineHistoryByCursor() {
+	const (
+		ownerID        = "0d1c2b3a-4f5e-4d6c-8b7a-9f0e1d2c3b4a"
+		testCampaignID = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+	)
+
+	startedAt := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		s.insertPipelines(bson.M{
+			"_id":            fmt.Sprintf("pipe-%d", i),
+			"ownerId":        ownerID,
+			"testCampaignId": testCampaignID,
+			"startedAt":      startedAt.Add(time.Duration(i/2) * time.Hour),
+		})
+	}
+
+	for _, id := range []string{"not-started-0", "not-started-1", "not-started-2"} {
+		s.insertPipelines(bson.M{
+			"_id":            id,
+			"ownerId":        ownerID,
+			"testCampaignId": testCampaignID,
+		})
+	}
+
+	qry := query.PipelineHistory{
+		TestCampaignID: testCampaignID,
+		UserID:         ownerID,
+		Limit:          2,
+	}
+
+	var ids []string
+
+	for {
+		history, err := s.repo.FindPipelineHistory(context.Background(), qry)
+		s.Require().NoError(err)
+
+		for _, p := range history.Pipelines {
+			ids = append(ids, p.ID)
+		}
+
+		if history.NextCursor == "" {
+			break
+		}
+
+		qry.Cursor = history.NextCursor
+	}
+
+	s.Require().Equal([]string{
+		"pipe-4", "pipe-3", "pipe-2", "pipe-1", "pipe-0",
+		"not-started-2", "not-started-1", "not-started-0",
+	}, ids)
+
+	qry.Cursor = "not a cursor"
+
+	_, err := s.repo.FindPipelineHistory(context.Background(), qry)
+	s.Require().ErrorIs(err, query.ErrInvalidCursor)
+}
+
+func (s *PipelineRepositoryTestSuite) TestMigratePipelineTestCampaignIDs() {
+	const (
+		ownerID        = "3c2b1a0f-9e8d-4c7b-a6f5-e4d3c2b1a0f9"
+		testCampaignID = "7f6e5d4c-3b2a-4190-8f7e-6d5c4b3a2f1e"
+	)
+
+	s.insertSpecifications(bson.M{
+		"id":             "legacy-spec",
+		"ownerId":        ownerID,
+		"testCampaignId": testCampaignID,
+	})
+
+	s.insertPipelines(bson.M{
+		"_id":             "legacy-pipe",
+		"ownerId":         ownerID,
+		"specificationId": "legacy-spec",
+		"startedAt":       time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	err := mongodb.MigratePipelineTestCampaignIDs(context.Background(), s.db)
+	s.Require().NoError(err)
+
+	err = mongodb.MigratePipelineTestCampaignIDs(context.Background(), s.db)
+	s.Require().NoError(err, "migration should be idempotent")
+
+	history, err := s.repo.FindPipelineHistory(context.Background(), query.PipelineHistory{
+		TestCampaignID: testCampaignID,
+		UserID:         ownerID,
+		Limit:          10,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(history.Pipelines, 1)
+	s.Require().Equal("legacy-pipe", history.Pipelines[0].ID)
+}
@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+// ScheduleFireLog keeps the last fire time of each schedule.
+// The fire time is replaced only if it is still the one the
+// instance has read, so the schedule fires once even if the
+// leadership moves to another instance during the tick.
+type ScheduleFireLog struct {
+	fires *mongo.Collection
+}
+
+const scheduleFireCollection = "scheduleFires"
+
+type scheduleFireDocument struct {
+	TestCampaignID string    `bson:"testCampaignId"`
+	ScheduleID     string    `bson:"scheduleId"`
+	FiredAt        time.Time `bson:"firedAt"`
+}
+
+func NewScheduleFireLog(db *mongo.Database) *ScheduleFireLog {
+	l := &ScheduleFireLog{
+		fires: db.Collection(scheduleFireCollection),
+	}
+
+	_, err := l.fires.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "testCampaignId", Value: 1},
+			{Key: "scheduleId", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return l
+}
+
+func (l *ScheduleFireLog) LastScheduleFire(
+	ctx context.Context,
+	tcID, scheduleID string,
+) (time.Time, error) {
+	var document scheduleFireDocument
+
+	err := l.fires.FindOne(ctx, bson.M{
+		"testCampaignId": tcID,
+		"scheduleId":     scheduleID,
+	}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, service.WrapWithDatabaseError(err)
+	}
+
+	return document.FiredAt, nil
+}
+
+// MarkScheduleFired inserts the first fire of the schedule or
+// updates the fire time equal to last. Fire inserted by another
+// instance fails with the duplicate key, updated fire time is
+// not matched.
+func (l *ScheduleFireLog) MarkScheduleFired(
+	ctx context.Context,
+	tcID, scheduleID string,
+	last, firedAt time.Time,
+) error {
+	if last.IsZero() {
+		_, err := l.fires.InsertOne(ctx, scheduleFireDocument{
+			TestCampaignID: tcID,
+			ScheduleID:     scheduleID,
+			FiredAt:        firedAt.UTC(),
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			return service.ErrScheduleAlreadyFired
+		}
+
+		return service.WrapWithDatabaseError(err)
+	}
+
+	res, err := l.fires.UpdateOne(ctx, bson.M{
+		"testCampaignId": tcID,
+		"scheduleId":     scheduleID,
+		"firedAt":        last.UTC(),
+	}, bson.M{"$set": bson.M{
+		"firedAt": firedAt.UTC(),
+	}})
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return service.ErrScheduleAlreadyFired
+	}
+
+	return nil
+}
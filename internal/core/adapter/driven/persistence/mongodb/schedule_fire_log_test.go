@@ -0,0 +1,65 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type ScheduleFireLogTestSuite struct {
+	MongoSuite
+
+	log *mongodb.ScheduleFireLog
+}
+
+func (s *ScheduleFireLogTestSuite) SetupTest() {
+	s.log = mongodb.NewScheduleFireLog(s.db)
+}
+
+func (s *ScheduleFireLogTestSuite) TearDownTest() {
+	_, err := s.db.
+		Collection("scheduleFires").
+		DeleteMany(context.Background(), bson.D{})
+	s.Require().NoError(err)
+}
+
+func TestScheduleFireLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	suite.Run(t, &ScheduleFireLogTestSuite{})
+}
+
+func (s *ScheduleFireLogTestSuite) TestMarkScheduleFired() {
+	var (
+		ctx     = context.Background()
+		firedAt = time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC)
+	)
+
+	last, err := s.log.LastScheduleFire(ctx, "tc", "quarter")
+	s.Require().NoError(err)
+	s.Require().True(last.IsZero())
+
+	s.Require().NoError(s.log.MarkScheduleFired(ctx, "tc", "quarter", last, firedAt))
+
+	err = s.log.MarkScheduleFired(ctx, "tc", "quarter", last, firedAt)
+	s.Require().ErrorIs(err, service.ErrScheduleAlreadyFired)
+
+	last, err = s.log.LastScheduleFire(ctx, "tc", "quarter")
+	s.Require().NoError(err)
+	s.Require().True(firedAt.Equal(last))
+
+	next := firedAt.Add(15 * time.Minute)
+
+	s.Require().NoError(s.log.MarkScheduleFired(ctx, "tc", "quarter", last, next))
+
+	err = s.log.MarkScheduleFired(ctx, "tc", "quarter", last, next)
+	s.Require().ErrorIs(err, service.ErrScheduleAlreadyFired)
+}
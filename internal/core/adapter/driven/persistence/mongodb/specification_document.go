@@ -9,15 +9,22 @@ import (
 
 type (
 	specificationDocument struct {
-		_              string          `bson:"_id,omitempty"`
-		ID             string          `bson:"id,omitempty"`
-		OwnerID        string          `bson:"ownerId"`
-		TestCampaignID string          `bson:"testCampaignId"`
-		LoadedAt       time.Time       `bson:"loadedAt"`
-		Author         string          `bson:"author"`
-		Title          string          `bson:"title"`
-		Description    string          `bson:"description"`
-		Stories        []storyDocument `bson:"stories"`
+		_              string                `bson:"_id,omitempty"`
+		ID             string                `bson:"id,omitempty"`
+		OwnerID        string                `bson:"ownerId"`
+		TestCampaignID string                `bson:"testCampaignId"`
+		LoadedAt       time.Time             `bson:"loadedAt"`
+		ActivatedAt    time.Time             `bson:"activatedAt,omitempty"`
+		Author         string                `bson:"author"`
+		Title          string                `bson:"title"`
+		Description    string                `bson:"description"`
+		Environments   []environmentDocument `bson:"environments,omitempty"`
+		Stories        []storyDocument       `bson:"stories"`
+	}
+
+	environmentDocument struct {
+		Name      string            `bson:"name"`
+		Variables map[string]string `bson:"variables"`
 	}
 
 	storyDocument struct {
@@ -87,10 +94,28 @@ func newSpecificationDocument(spec *specification.Specification) specificationDo
 		Author:         spec.Author(),
 		Title:          spec.Title(),
 		Description:    spec.Description(),
+		Environments:   newEnvironmentDocuments(spec.Environments()),
 		Stories:        newStoryDocuments(stories),
 	}
 }
 
+func newEnvironmentDocuments(envs []specification.Environment) []environmentDocument {
+	if len(envs) == 0 {
+		return nil
+	}
+
+	documents := make([]environmentDocument, 0, len(envs))
+
+	for _, env := range envs {
+		documents = append(documents, environmentDocument{
+			Name:      env.Name(),
+			Variables: env.Variables(),
+		})
+	}
+
+	return documents
+}
+
 func newStoryDocuments(stories []specification.Story) []storyDocument {
 	documents := make([]storyDocument, 0, len(stories))
 
@@ -200,6 +225,10 @@ func newSpecification(d specificationDocument) *specification.Specification {
 		WithTitle(d.Title).
 		WithDescription(d.Description)
 
+	for _, env := range d.Environments {
+		b.WithEnvironment(env.Name, env.Variables)
+	}
+
 	for _, story := range d.Stories {
 		b.WithStory(story.Slug, newStoryBuildFn(story))
 	}
@@ -388,3 +417,13 @@ func newAssertView(d assertDocument) query.AssertModel {
 		Expected: d.Expected,
 	}
 }
+
+func newGeneralSpecificationView(d specificationDocument, activeSpecID string) query.GeneralSpecificationModel {
+	return query.GeneralSpecificationModel{
+		ID:       d.ID,
+		LoadedAt: d.LoadedAt,
+		Author:   d.Author,
+		Title:    d.Title,
+		Active:   d.ID == activeSpecID,
+	}
+}
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -30,6 +31,16 @@ func NewSpecificationRepository(db *mongo.Database) *SpecificationRepository {
 		panic(err)
 	}
 
+	_, err = col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "testCampaignId", Value: 1},
+			{Key: "activatedAt", Value: -1},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
 	return &SpecificationRepository{
 		specifications: col,
 	}
@@ -47,16 +58,23 @@ func (r *SpecificationRepository) GetSpecification(
 	return newSpecification(document), nil
 }
 
+// activeSpecificationSort orders specifications of the test campaign so
+// that the active one goes first. Specification becomes active when it is
+// loaded or activated, so the last activated specification is the active
+// one. Specifications loaded before activation was introduced have no
+// activation time and are ordered by insertion.
+var activeSpecificationSort = bson.D{
+	{Key: "activatedAt", Value: -1},
+	{Key: "_id", Value: -1},
+}
+
 func (r *SpecificationRepository) GetActiveSpecificationByTestCampaignID(
 	ctx context.Context,
 	testCampaignID string,
 ) (*specification.Specification, error) {
-	var (
-		filter = bson.M{"testCampaignId": testCampaignID}
-		sort   = bson.M{"_id": -1}
-	)
+	filter := bson.M{"testCampaignId": testCampaignID}
 
-	document, err := r.getSpecificationDocument(ctx, filter, sort)
+	document, err := r.getSpecificationDocument(ctx, filter, activeSpecificationSort)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +82,23 @@ func (r *SpecificationRepository) GetActiveSpecificationByTestCampaignID(
 	return newSpecification(document), nil
 }
 
+func (r *SpecificationRepository) ActivateSpecification(ctx context.Context, specID string) error {
+	res, err := r.specifications.UpdateOne(
+		ctx,
+		bson.M{"id": specID},
+		bson.M{"$set": bson.M{"activatedAt": time.Now().UTC()}},
+	)
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return service.ErrSpecificationNotFound
+	}
+
+	return nil
+}
+
 func (r *SpecificationRepository) FindSpecification(
 	ctx context.Context,
 	qry query.Specification,
@@ -81,10 +116,59 @@ func (r *SpecificationRepository) FindSpecification(
 	return newSpecificSpecificationView(document), nil
 }
 
+// FindSpecificationHistory returns specifications of the test campaign
+// sorted by loading time from newest to oldest. The active specification
+// is marked with query.GeneralSpecificationModel Active.
+func (r *SpecificationRepository) FindSpecificationHistory(
+	ctx context.Context,
+	qry query.SpecificationHistory,
+) (query.SpecificationHistoryModel, error) {
+	filter := bson.M{
+		"testCampaignId": qry.TestCampaignID,
+		"ownerId":        qry.UserID,
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{
+			{Key: "loadedAt", Value: -1},
+			{Key: "_id", Value: -1},
+		}).
+		SetProjection(bson.M{"stories": 0})
+
+	cur, err := r.specifications.Find(ctx, filter, opts)
+	if err != nil {
+		return query.SpecificationHistoryModel{}, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []specificationDocument
+	if err := cur.All(ctx, &documents); err != nil {
+		return query.SpecificationHistoryModel{}, service.WrapWithDatabaseError(err)
+	}
+
+	history := query.SpecificationHistoryModel{
+		Specifications: make([]query.GeneralSpecificationModel, 0, len(documents)),
+	}
+
+	if len(documents) == 0 {
+		return history, nil
+	}
+
+	active, err := r.getSpecificationDocument(ctx, filter, activeSpecificationSort)
+	if err != nil {
+		return query.SpecificationHistoryModel{}, err
+	}
+
+	for _, d := range documents {
+		history.Specifications = append(history.Specifications, newGeneralSpecificationView(d, active.ID))
+	}
+
+	return history, nil
+}
+
 func (r *SpecificationRepository) getSpecificationDocument(
 	ctx context.Context,
 	filter bson.M,
-	sort bson.M,
+	sort interface{},
 ) (specificationDocument, error) {
 	opt := options.FindOne().SetSort(sort)
 
@@ -101,7 +185,10 @@ func (r *SpecificationRepository) getSpecificationDocument(
 }
 
 func (r *SpecificationRepository) AddSpecification(ctx context.Context, spec *specification.Specification) error {
-	_, err := r.specifications.InsertOne(ctx, newSpecificationDocument(spec))
+	document := newSpecificationDocument(spec)
+	document.ActivatedAt = time.Now().UTC()
+
+	_, err := r.specifications.InsertOne(ctx, document)
 
 	return service.WrapWithDatabaseError(err)
 }
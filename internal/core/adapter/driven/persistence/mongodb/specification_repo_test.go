@@ -251,6 +251,152 @@ func (s *SpecificationRepositoryTestSuite) TestGetActiveSpecificationByTestCampa
 	}
 }
 
+func (s *SpecificationRepositoryTestSuite) TestActivateSpecification() {
+	const testCampaignID = "f4e5d6c7-b8a9-4f0e-9d1c-2b3a4f5e6d7c"
+
+	s.insertSpecifications(
+		bson.M{
+			"id":             "1e2d3c4b-5a69-4788-97a6-b5c4d3e2f1a0",
+			"testCampaignId": testCampaignID,
+		},
+		bson.M{
+			"id":             "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+			"testCampaignId": testCampaignID,
+		},
+	)
+
+	testCases := []struct {
+		Name             string
+		SpecificationID  string
+		ExpectedActiveID string
+		ShouldBeErr      bool
+		IsErr            func(err error) bool
+	}{
+		{
+			Name:             "non_existing_specification",
+			SpecificationID:  "0f1e2d3c-4b5a-4697-88a9-b0c1d2e3f4a5",
+			ExpectedActiveID: "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+			ShouldBeErr:      true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrSpecificationNotFound)
+			},
+		},
+		{
+			Name:             "activate_older_specification",
+			SpecificationID:  "1e2d3c4b-5a69-4788-97a6-b5c4d3e2f1a0",
+			ExpectedActiveID: "1e2d3c4b-5a69-4788-97a6-b5c4d3e2f1a0",
+			ShouldBeErr:      false,
+		},
+		{
+			Name:             "activate_newer_specification_again",
+			SpecificationID:  "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+			ExpectedActiveID: "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+			ShouldBeErr:      false,
+		},
+	}
+
+	for _, c := range testCases {
+		s.Run(c.Name, func() {
+			ctx := context.Background()
+
+			err := s.repo.ActivateSpecification(ctx, c.SpecificationID)
+
+			if c.ShouldBeErr {
+				s.Require().True(c.IsErr(err))
+			} else {
+				s.Require().NoError(err)
+			}
+
+			active, err := s.repo.GetActiveSpecificationByTestCampaignID(ctx, testCampaignID)
+			s.Require().NoError(err)
+			s.Require().Equal(c.ExpectedActiveID, active.ID())
+		})
+	}
+}
+
+func (s *SpecificationRepositoryTestSuite) TestFindSpecificationHistory() {
+	const (
+		testCampaignID = "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f"
+		ownerID        = "8d9e0f1a-2b3c-4d4e-9f5a-6b7c8d9e0f1a"
+	)
+
+	loadedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	s.insertSpecifications(
+		bson.M{
+			"id":             "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+			"testCampaignId": testCampaignID,
+			"ownerId":        ownerID,
+			"author":         "Djerys",
+			"title":          "first",
+			"loadedAt":       loadedAt.Add(-2 * time.Hour),
+			"activatedAt":    loadedAt,
+		},
+		bson.M{
+			"id":             "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e",
+			"testCampaignId": testCampaignID,
+			"ownerId":        ownerID,
+			"author":         "Djerys",
+			"title":          "second",
+			"loadedAt":       loadedAt.Add(-1 * time.Hour),
+			"activatedAt":    loadedAt.Add(-1 * time.Hour),
+		},
+		bson.M{
+			"id":             "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f",
+			"testCampaignId": testCampaignID,
+			"ownerId":        "e5f6a7b8-c9d0-4e1f-8a3b-4c5d6e7f8a9b",
+			"loadedAt":       loadedAt,
+		},
+	)
+
+	testCases := []struct {
+		Name     string
+		Query    query.SpecificationHistory
+		Expected []query.GeneralSpecificationModel
+	}{
+		{
+			Name: "by_non_owner",
+			Query: query.SpecificationHistory{
+				TestCampaignID: testCampaignID,
+				UserID:         "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d",
+			},
+			Expected: []query.GeneralSpecificationModel{},
+		},
+		{
+			Name: "with_activated_older_specification",
+			Query: query.SpecificationHistory{
+				TestCampaignID: testCampaignID,
+				UserID:         ownerID,
+			},
+			Expected: []query.GeneralSpecificationModel{
+				{
+					ID:       "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e",
+					LoadedAt: loadedAt.Add(-1 * time.Hour),
+					Author:   "Djerys",
+					Title:    "second",
+					Active:   false,
+				},
+				{
+					ID:       "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+					LoadedAt: loadedAt.Add(-2 * time.Hour),
+					Author:   "Djerys",
+					Title:    "first",
+					Active:   true,
+				},
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		s.Run(c.Name, func() {
+			history, err := s.repo.FindSpecificationHistory(context.Background(), c.Query)
+			s.Require().NoError(err)
+
+			s.Require().Equal(c.Expected, history.Specifications)
+		})
+	}
+}
+
 func (s *SpecificationRepositoryTestSuite) TestAddSpecification() {
 	testCases := []struct {
 		Name                        string
@@ -3,38 +3,187 @@ package mongodb
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/harpyd/thestis/internal/core/app/query"
 	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
 )
 
-type testCampaignDocument struct {
-	ID        string    `bson:"_id,omitempty"`
-	ViewName  string    `bson:"viewName"`
-	Summary   string    `bson:"summary"`
-	OwnerID   string    `bson:"ownerId"`
-	CreatedAt time.Time `bson:"createdAt"`
-}
+type (
+	testCampaignDocument struct {
+		ID            string                 `bson:"_id,omitempty"`
+		ViewName      string                 `bson:"viewName"`
+		Summary       string                 `bson:"summary"`
+		OwnerID       string                 `bson:"ownerId"`
+		CreatedAt     time.Time              `bson:"createdAt"`
+		Schedules     []scheduleDocument     `bson:"schedules,omitempty"`
+		TriggerToken  string                 `bson:"triggerToken,omitempty"`
+		Subscriptions []subscriptionDocument `bson:"subscriptions,omitempty"`
+		LastOutcome   string                 `bson:"lastOutcome,omitempty"`
+	}
+
+	scheduleDocument struct {
+		ID          string `bson:"id"`
+		Cron        string `bson:"cron"`
+		Timezone    string `bson:"timezone"`
+		Environment string `bson:"environment"`
+		Filter      string `bson:"filter"`
+	}
+
+	subscriptionDocument struct {
+		ID     string `bson:"id"`
+		URL    string `bson:"url"`
+		Filter string `bson:"filter"`
+		Secret string `bson:"secret"`
+	}
+)
 
 func newTestCampaignDocument(tc *testcampaign.TestCampaign) testCampaignDocument {
 	return testCampaignDocument{
-		ID:        tc.ID(),
-		ViewName:  tc.ViewName(),
-		Summary:   tc.Summary(),
-		OwnerID:   tc.OwnerID(),
-		CreatedAt: tc.CreatedAt(),
+		ID:            tc.ID(),
+		ViewName:      tc.ViewName(),
+		Summary:       tc.Summary(),
+		OwnerID:       tc.OwnerID(),
+		CreatedAt:     tc.CreatedAt(),
+		Schedules:     newScheduleDocuments(tc.Schedules()),
+		TriggerToken:  tc.TriggerToken(),
+		Subscriptions: newSubscriptionDocuments(tc.Subscriptions()),
+		LastOutcome:   string(tc.LastOutcome()),
 	}
 }
 
-func newTestCampaign(d testCampaignDocument) *testcampaign.TestCampaign {
-	tc, _ := testcampaign.New(testcampaign.Params{
-		ID:        d.ID,
-		ViewName:  d.ViewName,
-		Summary:   d.Summary,
-		OwnerID:   d.OwnerID,
-		CreatedAt: d.CreatedAt,
+func newScheduleDocuments(schedules []testcampaign.Schedule) []scheduleDocument {
+	documents := make([]scheduleDocument, 0, len(schedules))
+
+	for _, s := range schedules {
+		documents = append(documents, scheduleDocument{
+			ID:          s.ID(),
+			Cron:        s.Cron(),
+			Timezone:    s.Timezone(),
+			Environment: s.Environment(),
+			Filter:      s.Filter(),
+		})
+	}
+
+	return documents
+}
+
+// newTestCampaign returns error if any of stored schedules
+// can't be parsed, so the test campaign is never saved back
+// without it.
+func newTestCampaign(d testCampaignDocument) (*testcampaign.TestCampaign, error) {
+	schedules, err := newSchedules(d.Schedules)
+	if err != nil {
+		return nil, errors.Wrapf(err, "test campaign %s", d.ID)
+	}
+
+	return testcampaign.New(testcampaign.Params{
+		ID:            d.ID,
+		ViewName:      d.ViewName,
+		Summary:       d.Summary,
+		OwnerID:       d.OwnerID,
+		CreatedAt:     d.CreatedAt,
+		Schedules:     schedules,
+		TriggerToken:  d.TriggerToken,
+		Subscriptions: newSubscriptions(d.Subscriptions),
+		LastOutcome:   testcampaign.Event(d.LastOutcome),
+	})
+}
+
+func newSchedules(documents []scheduleDocument) ([]testcampaign.Schedule, error) {
+	schedules := make([]testcampaign.Schedule, 0, len(documents))
+
+	for _, d := range documents {
+		s, err := newSchedule(d)
+		if err != nil {
+			return nil, errors.Wrapf(err, "schedule %s", d.ID)
+		}
+
+		schedules = append(schedules, s)
+	}
+
+	return schedules, nil
+}
+
+func newSchedule(d scheduleDocument) (testcampaign.Schedule, error) {
+	return testcampaign.NewSchedule(testcampaign.ScheduleParams{
+		ID:          d.ID,
+		Cron:        d.Cron,
+		Timezone:    d.Timezone,
+		Environment: d.Environment,
+		Filter:      d.Filter,
 	})
+}
+
+func newSchedulesView(documents []scheduleDocument, now time.Time) []query.ScheduleModel {
+	schedules := make([]query.ScheduleModel, 0, len(documents))
+
+	for _, d := range documents {
+		model := query.ScheduleModel{
+			ID:          d.ID,
+			Cron:        d.Cron,
+			Timezone:    d.Timezone,
+			Environment: d.Environment,
+			Filter:      d.Filter,
+		}
+
+		if s, err := newSchedule(d); err == nil {
+			model.NextRunAt = s.Next(now)
+		}
 
-	return tc
+		schedules = append(schedules, model)
+	}
+
+	return schedules
+}
+
+func newSubscriptionDocuments(subscriptions []testcampaign.Subscription) []subscriptionDocument {
+	documents := make([]subscriptionDocument, 0, len(subscriptions))
+
+	for _, s := range subscriptions {
+		documents = append(documents, subscriptionDocument{
+			ID:     s.ID(),
+			URL:    s.URL(),
+			Filter: string(s.Filter()),
+			Secret: s.Secret(),
+		})
+	}
+
+	return documents
+}
+
+func newSubscriptions(documents []subscriptionDocument) []testcampaign.Subscription {
+	subscriptions := make([]testcampaign.Subscription, 0, len(documents))
+
+	for _, d := range documents {
+		s, err := testcampaign.NewSubscription(testcampaign.SubscriptionParams{
+			ID:     d.ID,
+			URL:    d.URL,
+			Filter: testcampaign.Event(d.Filter),
+			Secret: d.Secret,
+		})
+		if err != nil {
+			continue
+		}
+
+		subscriptions = append(subscriptions, s)
+	}
+
+	return subscriptions
+}
+
+func newSubscriptionsView(documents []subscriptionDocument) []query.SubscriptionModel {
+	subscriptions := make([]query.SubscriptionModel, 0, len(documents))
+
+	for _, d := range documents {
+		subscriptions = append(subscriptions, query.SubscriptionModel{
+			ID:     d.ID,
+			URL:    d.URL,
+			Filter: d.Filter,
+		})
+	}
+
+	return subscriptions
 }
 
 func newSpecificTestCampaignView(d testCampaignDocument) query.TestCampaignModel {
@@ -45,3 +194,47 @@ func newSpecificTestCampaignView(d testCampaignDocument) query.TestCampaignModel
 		CreatedAt: d.CreatedAt,
 	}
 }
+
+func newTestCampaignsView(documents []testCampaignDocument, limit int) query.TestCampaignsModel {
+	var tcs query.TestCampaignsModel
+
+	if len(documents) > limit {
+		documents = documents[:limit]
+		tcs.NextCursor = newTestCampaignsCursor(documents[limit-1]).encode()
+	}
+
+	tcs.TestCampaigns = make([]query.TestCampaignModel, 0, len(documents))
+
+	for _, d := range documents {
+		tcs.TestCampaigns = append(tcs.TestCampaigns, newSpecificTestCampaignView(d))
+	}
+
+	return tcs
+}
+
+// testCampaignsCursor points to the last test campaign
+// of the page in the list sorted by creation time.
+type testCampaignsCursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+}
+
+func newTestCampaignsCursor(d testCampaignDocument) testCampaignsCursor {
+	return testCampaignsCursor{
+		CreatedAt: d.CreatedAt,
+		ID:        d.ID,
+	}
+}
+
+func (c testCampaignsCursor) encode() string {
+	return encodeCursor(c)
+}
+
+func decodeTestCampaignsCursor(cursor string) (testCampaignsCursor, error) {
+	var c testCampaignsCursor
+	if err := decodeCursor(cursor, &c); err != nil || c.ID == "" {
+		return testCampaignsCursor{}, query.ErrInvalidCursor
+	}
+
+	return c, nil
+}
@@ -2,9 +2,14 @@ package mongodb
 
 import (
 	"context"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -20,9 +25,22 @@ type TestCampaignRepository struct {
 const testCampaignCollection = "testCampaigns"
 
 func NewTestCampaignRepository(db *mongo.Database) *TestCampaignRepository {
-	return &TestCampaignRepository{
+	r := &TestCampaignRepository{
 		testCampaigns: db.Collection(testCampaignCollection),
 	}
+
+	_, err := r.testCampaigns.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "ownerId", Value: 1},
+			{Key: "createdAt", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return r
 }
 
 func (r *TestCampaignRepository) GetTestCampaign(
@@ -34,7 +52,7 @@ func (r *TestCampaignRepository) GetTestCampaign(
 		return nil, err
 	}
 
-	return newTestCampaign(document), nil
+	return newTestCampaign(document)
 }
 
 func (r *TestCampaignRepository) FindTestCampaign(
@@ -52,6 +70,134 @@ func (r *TestCampaignRepository) FindTestCampaign(
 	return newSpecificTestCampaignView(document), nil
 }
 
+// FindTestCampaigns returns test campaigns of the user sorted by
+// creation time from newest to oldest. The page following the returned
+// one can be requested with query.TestCampaignsModel NextCursor.
+func (r *TestCampaignRepository) FindTestCampaigns(
+	ctx context.Context,
+	qry query.TestCampaigns,
+) (query.TestCampaignsModel, error) {
+	if qry.Limit <= 0 {
+		qry.Limit = query.DefaultTestCampaignsLimit
+	}
+
+	filter, err := testCampaignsFilter(qry)
+	if err != nil {
+		return query.TestCampaignsModel{}, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{
+			{Key: "createdAt", Value: -1},
+			{Key: "_id", Value: -1},
+		}).
+		SetLimit(int64(qry.Limit + 1))
+
+	cur, err := r.testCampaigns.Find(ctx, filter, opts)
+	if err != nil {
+		return query.TestCampaignsModel{}, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []testCampaignDocument
+	if err := cur.All(ctx, &documents); err != nil {
+		return query.TestCampaignsModel{}, service.WrapWithDatabaseError(err)
+	}
+
+	return newTestCampaignsView(documents, qry.Limit), nil
+}
+
+// FindSchedules returns schedules of the user test campaign
+// with the next run time of each schedule.
+func (r *TestCampaignRepository) FindSchedules(
+	ctx context.Context,
+	qry query.Schedules,
+) ([]query.ScheduleModel, error) {
+	document, err := r.getTestCampaignDocument(ctx, bson.M{
+		"_id":     qry.TestCampaignID,
+		"ownerId": qry.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchedulesView(document.Schedules, time.Now()), nil
+}
+
+// FindSubscriptions returns subscriptions of the
+// user test campaign without their secrets.
+func (r *TestCampaignRepository) FindSubscriptions(
+	ctx context.Context,
+	qry query.Subscriptions,
+) ([]query.SubscriptionModel, error) {
+	document, err := r.getTestCampaignDocument(ctx, bson.M{
+		"_id":     qry.TestCampaignID,
+		"ownerId": qry.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSubscriptionsView(document.Subscriptions), nil
+}
+
+func (r *TestCampaignRepository) FindScheduledTestCampaigns(
+	ctx context.Context,
+) ([]*testcampaign.TestCampaign, error) {
+	cur, err := r.testCampaigns.Find(ctx, bson.M{"schedules.0": bson.M{"$exists": true}})
+	if err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	var documents []testCampaignDocument
+	if err := cur.All(ctx, &documents); err != nil {
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	tcs := make([]*testcampaign.TestCampaign, 0, len(documents))
+	for _, d := range documents {
+		tc, err := newTestCampaign(d)
+		if err != nil {
+			return nil, err
+		}
+
+		tcs = append(tcs, tc)
+	}
+
+	return tcs, nil
+}
+
+func testCampaignsFilter(qry query.TestCampaigns) (bson.M, error) {
+	conditions := bson.A{
+		bson.M{"ownerId": qry.UserID},
+	}
+
+	if qry.Search != "" {
+		pattern := primitive.Regex{
+			Pattern: regexp.QuoteMeta(qry.Search),
+			Options: "i",
+		}
+
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"viewName": pattern},
+			bson.M{"summary": pattern},
+		}})
+	}
+
+	if qry.Cursor != "" {
+		c, err := decodeTestCampaignsCursor(qry.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"createdAt": bson.M{"$lt": c.CreatedAt}},
+			bson.M{"createdAt": c.CreatedAt, "_id": bson.M{"$lt": c.ID}},
+		}})
+	}
+
+	return bson.M{"$and": conditions}, nil
+}
+
 func (r *TestCampaignRepository) getTestCampaignDocument(
 	ctx context.Context,
 	filter bson.M,
@@ -86,9 +232,9 @@ func (r *TestCampaignRepository) UpdateTestCampaign(
 
 	defer session.EndSession(ctx)
 
-	_, err = session.WithTransaction(ctx, func(_ mongo.SessionContext) (interface{}, error) {
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		var document testCampaignDocument
-		if err := r.testCampaigns.FindOne(ctx, bson.M{"_id": tcID}).Decode(&document); err != nil {
+		if err := r.testCampaigns.FindOne(sessCtx, bson.M{"_id": tcID}).Decode(&document); err != nil {
 			if errors.Is(err, mongo.ErrNoDocuments) {
 				return nil, service.ErrTestCampaignNotFound
 			}
@@ -96,17 +242,26 @@ func (r *TestCampaignRepository) UpdateTestCampaign(
 			return nil, service.WrapWithDatabaseError(err)
 		}
 
-		tc := newTestCampaign(document)
-		updatedTestCampaign, err := updater(ctx, tc)
+		tc, err := newTestCampaign(document)
 		if err != nil {
 			return nil, err
 		}
 
-		updatedDocument := newTestCampaignDocument(updatedTestCampaign)
+		// Updater changes the test campaign in place,
+		// so the document is taken before the update.
+		original := newTestCampaignDocument(tc)
 
-		replaceOpt := options.Replace().SetUpsert(true)
-		filter := bson.M{"_id": updatedDocument.ID}
-		if _, err := r.testCampaigns.ReplaceOne(ctx, filter, updatedDocument, replaceOpt); err != nil {
+		updatedTestCampaign, err := updater(sessCtx, tc)
+		if err != nil {
+			return nil, err
+		}
+
+		update := testCampaignUpdate(original, newTestCampaignDocument(updatedTestCampaign))
+		if len(update) == 0 {
+			return nil, nil
+		}
+
+		if _, err := r.testCampaigns.UpdateOne(sessCtx, bson.M{"_id": tcID}, update); err != nil {
 			return nil, service.WrapWithDatabaseError(err)
 		}
 
@@ -115,3 +270,226 @@ func (r *TestCampaignRepository) UpdateTestCampaign(
 
 	return err
 }
+
+// testCampaignUpdate returns update of the fields changed by the
+// updater only, so the fields updated concurrently, like the last
+// outcome of completed flows, aren't overwritten with stale values
+// and stored values, which can't be read, aren't dropped.
+func testCampaignUpdate(before, after testCampaignDocument) bson.M {
+	fields := []struct {
+		key           string
+		before, after interface{}
+		empty         bool
+	}{
+		{"viewName", before.ViewName, after.ViewName, false},
+		{"summary", before.Summary, after.Summary, false},
+		{"ownerId", before.OwnerID, after.OwnerID, false},
+		{"createdAt", before.CreatedAt, after.CreatedAt, false},
+		{"schedules", before.Schedules, after.Schedules, len(after.Schedules) == 0},
+		{"triggerToken", before.TriggerToken, after.TriggerToken, after.TriggerToken == ""},
+		{"subscriptions", before.Subscriptions, after.Subscriptions, len(after.Subscriptions) == 0},
+		{"lastOutcome", before.LastOutcome, after.LastOutcome, after.LastOutcome == ""},
+	}
+
+	var (
+		set   = bson.M{}
+		unset = bson.M{}
+	)
+
+	for _, f := range fields {
+		if reflect.DeepEqual(f.before, f.after) {
+			continue
+		}
+
+		// Empty values are omitted on insert, so they are unset.
+		if f.empty {
+			unset[f.key] = ""
+		} else {
+			set[f.key] = f.after
+		}
+	}
+
+	update := bson.M{}
+
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	return update
+}
+
+// SwapLastOutcome sets the last outcome with the single update, so
+// concurrently completed flows of the test campaign don't overwrite
+// each other, unlike replacing the whole document.
+func (r *TestCampaignRepository) SwapLastOutcome(
+	ctx context.Context,
+	tcID string,
+	outcome testcampaign.Event,
+) (*testcampaign.TestCampaign, error) {
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	update := bson.M{"$set": bson.M{"lastOutcome": string(outcome)}}
+
+	var document testCampaignDocument
+	if err := r.testCampaigns.FindOneAndUpdate(ctx, bson.M{"_id": tcID}, update, opt).Decode(&document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, service.ErrTestCampaignNotFound
+		}
+
+		return nil, service.WrapWithDatabaseError(err)
+	}
+
+	return newTestCampaign(document)
+}
+
+const archivedCollectionPrefix = "archived"
+
+func (r *TestCampaignRepository) RemoveTestCampaign(ctx context.Context, tcID string) error {
+	return r.removeTestCampaign(ctx, tcID, false)
+}
+
+func (r *TestCampaignRepository) ArchiveTestCampaign(ctx context.Context, tcID string) error {
+	return r.removeTestCampaign(ctx, tcID, true)
+}
+
+// removeTestCampaign deletes test campaign with its specifications,
+// pipelines, flows and notifications. If archive is true, deleted documents
+// are copied to the collections with archivedCollectionPrefix.
+//
+// Multi-document transactions are not available on a standalone server,
+// so children are removed first and the test campaign last. Removal
+// interrupted on the half can be repeated, archiving is idempotent.
+func (r *TestCampaignRepository) removeTestCampaign(
+	ctx context.Context,
+	tcID string,
+	archive bool,
+) error {
+	db := r.testCampaigns.Database()
+
+	count, err := r.testCampaigns.CountDocuments(ctx, bson.M{"_id": tcID})
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if count == 0 {
+		return service.ErrTestCampaignNotFound
+	}
+
+	pipelines := db.Collection(pipelineCollection)
+
+	if err := checkNoStartedPipelines(ctx, pipelines, tcID); err != nil {
+		return err
+	}
+
+	pipeIDs, err := pipelines.Distinct(ctx, "_id", bson.M{
+		"testCampaignId": tcID,
+		"started":        bson.M{"$ne": true},
+	})
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if pipeIDs == nil {
+		pipeIDs = []interface{}{}
+	}
+
+	removals := []documentsRemoval{
+		{db.Collection(flowCollection), bson.M{"pipelineId": bson.M{"$in": pipeIDs}}},
+		{db.Collection(notificationCollection), bson.M{"testCampaignId": tcID}},
+		{pipelines, bson.M{"_id": bson.M{"$in": pipeIDs}, "started": bson.M{"$ne": true}}},
+	}
+
+	if err := removeDocuments(ctx, removals, archive); err != nil {
+		return err
+	}
+
+	// Pipeline started after the first check is
+	// kept with its test campaign and specifications.
+	if err := checkNoStartedPipelines(ctx, pipelines, tcID); err != nil {
+		return err
+	}
+
+	return removeDocuments(ctx, []documentsRemoval{
+		{db.Collection(specificationCollection), bson.M{"testCampaignId": tcID}},
+		{r.testCampaigns, bson.M{"_id": tcID}},
+	}, archive)
+}
+
+func checkNoStartedPipelines(ctx context.Context, pipelines *mongo.Collection, tcID string) error {
+	started, err := pipelines.CountDocuments(ctx, bson.M{
+		"testCampaignId": tcID,
+		"started":        true,
+	})
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if started > 0 {
+		return service.ErrTestCampaignHasStartedPipelines
+	}
+
+	return nil
+}
+
+type documentsRemoval struct {
+	collection *mongo.Collection
+	filter     bson.M
+}
+
+func removeDocuments(ctx context.Context, removals []documentsRemoval, archive bool) error {
+	for _, rm := range removals {
+		if archive {
+			if err := archiveDocuments(ctx, rm.collection, rm.filter); err != nil {
+				return err
+			}
+		}
+
+		if _, err := rm.collection.DeleteMany(ctx, rm.filter); err != nil {
+			return service.WrapWithDatabaseError(err)
+		}
+	}
+
+	return nil
+}
+
+// archiveDocuments copies documents matched by the filter to the archived
+// collection. Documents archived already are replaced, so archiving of the
+// interrupted removal can be repeated.
+func archiveDocuments(ctx context.Context, col *mongo.Collection, filter bson.M) error {
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	var documents []bson.M
+	if err := cur.All(ctx, &documents); err != nil {
+		return service.WrapWithDatabaseError(err)
+	}
+
+	if len(documents) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(documents))
+
+	for _, d := range documents {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": d["_id"]}).
+			SetReplacement(d).
+			SetUpsert(true),
+		)
+	}
+
+	archived := col.Database().Collection(archivedCollectionName(col.Name()))
+
+	_, err = archived.BulkWrite(ctx, models)
+
+	return service.WrapWithDatabaseError(err)
+}
+
+func archivedCollectionName(name string) string {
+	return archivedCollectionPrefix + strings.ToUpper(name[:1]) + name[1:]
+}
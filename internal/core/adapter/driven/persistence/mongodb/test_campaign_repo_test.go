@@ -3,6 +3,7 @@ package mongodb_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -27,10 +28,23 @@ func (s *TestCampaignRepositoryTestSuite) SetupTest() {
 }
 
 func (s *TestCampaignRepositoryTestSuite) TearDownTest() {
-	_, err := s.db.
-		Collection("testCampaigns").
-		DeleteMany(context.Background(), bson.D{})
-	s.Require().NoError(err)
+	for _, col := range []string{
+		"testCampaigns",
+		"specifications",
+		"pipelines",
+		"flows",
+		"notifications",
+		"archivedTestCampaigns",
+		"archivedSpecifications",
+		"archivedPipelines",
+		"archivedFlows",
+		"archivedNotifications",
+	} {
+		_, err := s.db.
+			Collection(col).
+			DeleteMany(context.Background(), bson.D{})
+		s.Require().NoError(err)
+	}
 }
 
 func TestCampaignRepository(t *testing.T) {
@@ -118,6 +132,267 @@ func (s *TestCampaignRepositoryTestSuite) TestFindTestCampaign() {
 	}
 }
 
+func (s *TestCampaignRepositoryTestSuite) TestFindTestCampaigns() {
+	const ownerID = "b1a7d0f2-2a7e-4a4e-9a0f-6e0f9c1b2d3e"
+
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	s.insertTestCampaigns(
+		bson.M{
+			"_id":       "9d7c1e6a-54b3-4d8c-a3f1-1e8f6c0b2a47",
+			"viewName":  "Payments API",
+			"summary":   "checkout flows",
+			"ownerId":   ownerID,
+			"createdAt": createdAt.Add(-3 * time.Minute),
+		},
+		bson.M{
+			"_id":       "4f2a8b1c-6d3e-4a5f-8b7c-9d0e1f2a3b4c",
+			"viewName":  "Users API",
+			"summary":   "registration and payments",
+			"ownerId":   ownerID,
+			"createdAt": createdAt.Add(-2 * time.Minute),
+		},
+		bson.M{
+			"_id":       "e3c5a7b9-1d2f-4e6a-8c0b-2d4f6a8c0e1f",
+			"viewName":  "Orders API",
+			"summary":   "",
+			"ownerId":   ownerID,
+			"createdAt": createdAt.Add(-1 * time.Minute),
+		},
+		bson.M{
+			"_id":       "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+			"viewName":  "Payments API",
+			"summary":   "foreign",
+			"ownerId":   "7c8d9e0f-1a2b-4c3d-9e4f-5a6b7c8d9e0f",
+			"createdAt": createdAt,
+		},
+	)
+
+	testCases := []struct {
+		Name               string
+		Query              query.TestCampaigns
+		ExpectedIDs        []string
+		ExpectedNextCursor bool
+		ShouldBeErr        bool
+		IsErr              func(err error) bool
+	}{
+		{
+			Name: "all_owner_test_campaigns",
+			Query: query.TestCampaigns{
+				UserID: ownerID,
+			},
+			ExpectedIDs: []string{
+				"e3c5a7b9-1d2f-4e6a-8c0b-2d4f6a8c0e1f",
+				"4f2a8b1c-6d3e-4a5f-8b7c-9d0e1f2a3b4c",
+				"9d7c1e6a-54b3-4d8c-a3f1-1e8f6c0b2a47",
+			},
+			ExpectedNextCursor: false,
+		},
+		{
+			Name: "search_by_view_name_and_summary",
+			Query: query.TestCampaigns{
+				UserID: ownerID,
+				Search: "PAYMENTS",
+			},
+			ExpectedIDs: []string{
+				"4f2a8b1c-6d3e-4a5f-8b7c-9d0e1f2a3b4c",
+				"9d7c1e6a-54b3-4d8c-a3f1-1e8f6c0b2a47",
+			},
+			ExpectedNextCursor: false,
+		},
+		{
+			Name: "with_limit",
+			Query: query.TestCampaigns{
+				UserID: ownerID,
+				Limit:  2,
+			},
+			ExpectedIDs: []string{
+				"e3c5a7b9-1d2f-4e6a-8c0b-2d4f6a8c0e1f",
+				"4f2a8b1c-6d3e-4a5f-8b7c-9d0e1f2a3b4c",
+			},
+			ExpectedNextCursor: true,
+		},
+		{
+			Name: "by_invalid_cursor",
+			Query: query.TestCampaigns{
+				UserID: ownerID,
+				Cursor: "not a cursor",
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, query.ErrInvalidCursor)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		s.Run(c.Name, func() {
+			tcs, err := s.repo.FindTestCampaigns(context.Background(), c.Query)
+
+			if c.ShouldBeErr {
+				s.Require().True(c.IsErr(err))
+
+				return
+			}
+
+			s.Require().NoError(err)
+
+			ids := make([]string, 0, len(tcs.TestCampaigns))
+			for _, tc := range tcs.TestCampaigns {
+				ids = append(ids, tc.ID)
+			}
+
+			s.Require().Equal(c.ExpectedIDs, ids)
+			s.Require().Equal(c.ExpectedNextCursor, tcs.NextCursor != "")
+		})
+	}
+}
+
+func (s *TestCampaignRepositoryTestSuite) TestFindTestCampaignsByCursor() {
+	const ownerID = "5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d"
+
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	expectedIDs := make([]string, 0, 5)
+
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("00000000-0000-4000-8000-00000000000%d", i)
+
+		s.insertTestCampaigns(bson.M{
+			"_id":       id,
+			"ownerId":   ownerID,
+			"createdAt": createdAt.Add(-time.Duration(i) * time.Minute),
+		})
+
+		expectedIDs = append(expectedIDs, id)
+	}
+
+	var (
+		ids    []string
+		cursor string
+	)
+
+	for {
+		tcs, err := s.repo.FindTestCampaigns(context.Background(), query.TestCampaigns{
+			UserID: ownerID,
+			Cursor: cursor,
+			Limit:  2,
+		})
+		s.Require().NoError(err)
+
+		for _, tc := range tcs.TestCampaigns {
+			ids = append(ids, tc.ID)
+		}
+
+		if tcs.NextCursor == "" {
+			break
+		}
+
+		cursor = tcs.NextCursor
+	}
+
+	s.Require().Equal(expectedIDs, ids)
+}
+
+func (s *TestCampaignRepositoryTestSuite) TestRemoveTestCampaign() {
+	testCases := []struct {
+		Name             string
+		Archive          bool
+		PipelineStarted  bool
+		TestCampaignID   string
+		ExpectedArchived int64
+		ShouldBeErr      bool
+		IsErr            func(err error) bool
+	}{
+		{
+			Name:           "non_existing_test_campaign",
+			TestCampaignID: "6b5c4d3e-2f1a-4b0c-9d8e-7f6a5b4c3d2e",
+			ShouldBeErr:    true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTestCampaignNotFound)
+			},
+		},
+		{
+			Name:            "test_campaign_with_started_pipeline",
+			TestCampaignID:  "a3b2c1d0-e9f8-4a7b-86c5-d4e3f2a1b0c9",
+			PipelineStarted: true,
+			ShouldBeErr:     true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTestCampaignHasStartedPipelines)
+			},
+		},
+		{
+			Name:             "remove_test_campaign",
+			TestCampaignID:   "a3b2c1d0-e9f8-4a7b-86c5-d4e3f2a1b0c9",
+			ExpectedArchived: 0,
+		},
+		{
+			Name:             "archive_test_campaign",
+			TestCampaignID:   "a3b2c1d0-e9f8-4a7b-86c5-d4e3f2a1b0c9",
+			Archive:          true,
+			ExpectedArchived: 1,
+		},
+	}
+
+	for _, c := range testCases {
+		s.Run(c.Name, func() {
+			s.TearDownTest()
+
+			s.insertTestCampaigns(bson.M{
+				"_id":       "a3b2c1d0-e9f8-4a7b-86c5-d4e3f2a1b0c9",
+				"ownerId":   "f0e1d2c3-b4a5-4968-8776-655443322110",
+				"createdAt": time.Now().UTC(),
+			})
+			s.insertSpecifications(bson.M{
+				"id":             "c9d8e7f6-a5b4-4c3d-92e1-f0a9b8c7d6e5",
+				"testCampaignId": "a3b2c1d0-e9f8-4a7b-86c5-d4e3f2a1b0c9",
+			})
+			s.insertPipelines(bson.M{
+				"_id":             "1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9",
+				"testCampaignId":  "a3b2c1d0-e9f8-4a7b-86c5-d4e3f2a1b0c9",
+				"specificationId": "c9d8e7f6-a5b4-4c3d-92e1-f0a9b8c7d6e5",
+				"started":         c.PipelineStarted,
+			})
+			s.insertFlows(bson.M{
+				"_id":        "8e7d6c5b-4a39-4281-9f0e-d1c2b3a49586",
+				"pipelineId": "1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9",
+			})
+
+			ctx := context.Background()
+
+			var err error
+			if c.Archive {
+				err = s.repo.ArchiveTestCampaign(ctx, c.TestCampaignID)
+			} else {
+				err = s.repo.RemoveTestCampaign(ctx, c.TestCampaignID)
+			}
+
+			if c.ShouldBeErr {
+				s.Require().True(c.IsErr(err))
+				s.requireDocumentsNumber("testCampaigns", 1)
+				s.requireDocumentsNumber("flows", 1)
+
+				return
+			}
+
+			s.Require().NoError(err)
+
+			for _, col := range []string{"testCampaigns", "specifications", "pipelines", "flows"} {
+				s.requireDocumentsNumber(col, 0)
+			}
+
+			for _, col := range []string{
+				"archivedTestCampaigns",
+				"archivedSpecifications",
+				"archivedPipelines",
+				"archivedFlows",
+			} {
+				s.requireDocumentsNumber(col, c.ExpectedArchived)
+			}
+		})
+	}
+}
+
 func (s *TestCampaignRepositoryTestSuite) TestAddTestCampaign() {
 	testCases := []struct {
 		Name                 string
@@ -245,6 +520,27 @@ func (s *TestCampaignRepositoryTestSuite) TestUpdateTestCampaign() {
 			},
 			ShouldBeErr: false,
 		},
+		{
+			Name:                   "add_schedule",
+			TestCampaignIDToUpdate: "0b723635-4691-4eae-aca8-79b230989f9d",
+			Update: func(_ context.Context, tc *testcampaign.TestCampaign) (*testcampaign.TestCampaign, error) {
+				err := tc.AddSchedule(testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+					ID:       "nightly",
+					Cron:     "0 3 * * *",
+					Timezone: "Europe/Moscow",
+				}))
+
+				return tc, err
+			},
+			TestCampaignUpdated: func(tc *testcampaign.TestCampaign) bool {
+				schedule, err := tc.Schedule("nightly")
+
+				return err == nil &&
+					schedule.Cron() == "0 3 * * *" &&
+					schedule.Timezone() == "Europe/Moscow"
+			},
+			ShouldBeErr: false,
+		},
 		{
 			Name:                   "update_view_name",
 			TestCampaignIDToUpdate: "0b723635-4691-4eae-aca8-79b230989f9d",
@@ -280,6 +576,208 @@ func (s *TestCampaignRepositoryTestSuite) TestUpdateTestCampaign() {
 	}
 }
 
+func (s *TestCampaignRepositoryTestSuite) TestUpdateTestCampaignSetsOnlyChangedFields() {
+	s.insertTestCampaigns(bson.M{
+		"_id":         "7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e0f",
+		"ownerId":     "8d9e0f1a-2b3c-4d4e-9f5a-6b7c8d9e0f1a",
+		"summary":     "summary",
+		"lastOutcome": "failed",
+		"createdAt":   time.Now().UTC(),
+		"subscriptions": bson.A{
+			bson.M{"id": "slack", "url": "https://hooks.some-a.com/slack", "filter": "failed"},
+		},
+		"unknownField": "kept",
+	})
+
+	err := s.repo.UpdateTestCampaign(
+		context.Background(),
+		"7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e0f",
+		func(_ context.Context, tc *testcampaign.TestCampaign) (*testcampaign.TestCampaign, error) {
+			tc.SetSummary("new summary")
+
+			return tc, nil
+		},
+	)
+	s.Require().NoError(err)
+
+	var document bson.M
+
+	err = s.db.Collection("testCampaigns").
+		FindOne(context.Background(), bson.M{"_id": "7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e0f"}).
+		Decode(&document)
+	s.Require().NoError(err)
+
+	s.Require().Equal("new summary", document["summary"])
+	s.Require().Equal("failed", document["lastOutcome"])
+	s.Require().Equal("kept", document["unknownField"])
+	s.Require().Len(document["subscriptions"], 1)
+}
+
+func (s *TestCampaignRepositoryTestSuite) TestSwapLastOutcome() {
+	s.insertTestCampaigns(bson.M{
+		"_id":         "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b",
+		"ownerId":     "5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b8c",
+		"summary":     "summary",
+		"lastOutcome": "failed",
+		"createdAt":   time.Now().UTC(),
+	})
+
+	ctx := context.Background()
+
+	previous, err := s.repo.SwapLastOutcome(ctx, "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b", testcampaign.PassedEvent)
+	s.Require().NoError(err)
+	s.Require().Equal(testcampaign.FailedEvent, previous.LastOutcome())
+	s.Require().Equal("summary", previous.Summary())
+
+	tc := s.getTestCampaign("4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b")
+	s.Require().Equal(testcampaign.PassedEvent, tc.LastOutcome())
+	s.Require().Equal("summary", tc.Summary())
+
+	_, err = s.repo.SwapLastOutcome(ctx, "6a7b8c9d-0e1f-4a2b-9c3d-4e5f6a7b8c9d", testcampaign.PassedEvent)
+	s.Require().ErrorIs(err, service.ErrTestCampaignNotFound)
+}
+
+func (s *TestCampaignRepositoryTestSuite) TestFindSchedules() {
+	s.insertTestCampaigns(bson.M{
+		"_id":       "5d3b9a0e-4f0c-4b8e-9a7d-2b6c1e0f3a4d",
+		"ownerId":   "8e1c2f3a-6b7d-4c9e-a0f1-2d3e4f5a6b7c",
+		"viewName":  "scheduled",
+		"createdAt": time.Now().UTC(),
+		"schedules": bson.A{
+			bson.M{
+				"id":          "quarter",
+				"cron":        "*/15 * * * *",
+				"timezone":    "Europe/Moscow",
+				"environment": "",
+				"filter":      "",
+			},
+		},
+	})
+
+	schedules, err := s.repo.FindSchedules(context.Background(), query.Schedules{
+		TestCampaignID: "5d3b9a0e-4f0c-4b8e-9a7d-2b6c1e0f3a4d",
+		UserID:         "8e1c2f3a-6b7d-4c9e-a0f1-2d3e4f5a6b7c",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(schedules, 1)
+	s.Require().Equal("quarter", schedules[0].ID)
+	s.Require().Equal("Europe/Moscow", schedules[0].Timezone)
+	s.Require().True(schedules[0].NextRunAt.After(time.Now()))
+
+	_, err = s.repo.FindSchedules(context.Background(), query.Schedules{
+		TestCampaignID: "5d3b9a0e-4f0c-4b8e-9a7d-2b6c1e0f3a4d",
+		UserID:         "0f9e8d7c-6b5a-4f3e-9d2c-1b0a9f8e7d6c",
+	})
+	s.Require().ErrorIs(err, service.ErrTestCampaignNotFound)
+}
+
+func (s *TestCampaignRepositoryTestSuite) TestFindSubscriptions() {
+	s.insertTestCampaigns(bson.M{
+		"_id":       "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b",
+		"ownerId":   "5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b8c",
+		"viewName":  "subscribed",
+		"createdAt": time.Now().UTC(),
+		"subscriptions": bson.A{
+			bson.M{
+				"id":     "ci",
+				"url":    "https://ci.example.com/hooks",
+				"filter": "failed",
+				"secret": "secret",
+			},
+		},
+	})
+
+	subscriptions, err := s.repo.FindSubscriptions(context.Background(), query.Subscriptions{
+		TestCampaignID: "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b",
+		UserID:         "5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b8c",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]query.SubscriptionModel{
+		{
+			ID:     "ci",
+			URL:    "https://ci.example.com/hooks",
+			Filter: "failed",
+		},
+	}, subscriptions)
+
+	tc, err := s.repo.GetTestCampaign(context.Background(), "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b")
+	s.Require().NoError(err)
+	s.Require().Len(tc.Subscriptions(), 1)
+	s.Require().Equal("secret", tc.Subscriptions()[0].Secret())
+
+	_, err = s.repo.FindSubscriptions(context.Background(), query.Subscriptions{
+		TestCampaignID: "4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b",
+		UserID:         "6a7b8c9d-0e1f-4a2b-9c3d-4e5f6a7b8c9d",
+	})
+	s.Require().ErrorIs(err, service.ErrTestCampaignNotFound)
+}
+
+func (s *TestCampaignRepositoryTestSuite) TestFindScheduledTestCampaigns() {
+	s.insertTestCampaigns(
+		bson.M{
+			"_id":       "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			"ownerId":   "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+			"createdAt": time.Now().UTC(),
+			"schedules": bson.A{
+				bson.M{"id": "nightly", "cron": "@daily"},
+			},
+		},
+		bson.M{
+			"_id":       "3c4d5e6f-7a8b-4c9d-8e1f-2a3b4c5d6e7f",
+			"ownerId":   "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+			"createdAt": time.Now().UTC(),
+		},
+	)
+
+	tcs, err := s.repo.FindScheduledTestCampaigns(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(tcs, 1)
+	s.Require().Equal("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", tcs[0].ID())
+	s.Require().Len(tcs[0].Schedules(), 1)
+}
+
+func (s *TestCampaignRepositoryTestSuite) TestGetTestCampaignWithInvalidSchedule() {
+	s.insertTestCampaigns(bson.M{
+		"_id":       "9b8a7f6e-5d4c-4b3a-8f2e-1d0c9b8a7f6e",
+		"ownerId":   "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+		"createdAt": time.Now().UTC(),
+		"schedules": bson.A{
+			bson.M{"id": "nightly", "cron": "@daily"},
+			bson.M{"id": "broken", "cron": "@daily", "timezone": "Mars/Olympus"},
+		},
+	})
+
+	_, err := s.repo.GetTestCampaign(context.Background(), "9b8a7f6e-5d4c-4b3a-8f2e-1d0c9b8a7f6e")
+	s.Require().ErrorIs(err, testcampaign.ErrInvalidTimezone)
+
+	err = s.repo.UpdateTestCampaign(
+		context.Background(),
+		"9b8a7f6e-5d4c-4b3a-8f2e-1d0c9b8a7f6e",
+		func(_ context.Context, tc *testcampaign.TestCampaign) (*testcampaign.TestCampaign, error) {
+			return tc, nil
+		},
+	)
+	s.Require().ErrorIs(err, testcampaign.ErrInvalidTimezone)
+
+	var document struct {
+		Schedules []bson.M `bson:"schedules"`
+	}
+
+	err = s.db.Collection("testCampaigns").
+		FindOne(context.Background(), bson.M{"_id": "9b8a7f6e-5d4c-4b3a-8f2e-1d0c9b8a7f6e"}).
+		Decode(&document)
+	s.Require().NoError(err)
+	s.Require().Len(document.Schedules, 2)
+}
+
+func (s *TestCampaignRepositoryTestSuite) requireDocumentsNumber(collection string, expected int64) {
+	s.T().Helper()
+
+	count, err := s.db.Collection(collection).CountDocuments(context.Background(), bson.D{})
+	s.Require().NoError(err)
+	s.Require().Equal(expected, count, collection)
+}
+
 func (s *TestCampaignRepositoryTestSuite) getTestCampaign(tcID string) *testcampaign.TestCampaign {
 	s.T().Helper()
 
@@ -0,0 +1,53 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+// TriggerReplayGuard keeps keys of accepted triggers
+// until they expire, expired keys are removed by the
+// TTL index.
+type TriggerReplayGuard struct {
+	triggers *mongo.Collection
+}
+
+const triggerCollection = "triggers"
+
+func NewTriggerReplayGuard(db *mongo.Database) *TriggerReplayGuard {
+	g := &TriggerReplayGuard{
+		triggers: db.Collection(triggerCollection),
+	}
+
+	_, err := g.triggers.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+func (g *TriggerReplayGuard) RememberTrigger(
+	ctx context.Context,
+	key string,
+	expiresAt time.Time,
+) error {
+	_, err := g.triggers.InsertOne(ctx, bson.M{
+		"_id":       key,
+		"expiresAt": expiresAt.UTC(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return service.ErrTriggerReplayed
+	}
+
+	return service.WrapWithDatabaseError(err)
+}
@@ -0,0 +1,51 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type TriggerReplayGuardTestSuite struct {
+	MongoSuite
+
+	guard *mongodb.TriggerReplayGuard
+}
+
+func (s *TriggerReplayGuardTestSuite) SetupTest() {
+	s.guard = mongodb.NewTriggerReplayGuard(s.db)
+}
+
+func (s *TriggerReplayGuardTestSuite) TearDownTest() {
+	_, err := s.db.
+		Collection("triggers").
+		DeleteMany(context.Background(), bson.D{})
+	s.Require().NoError(err)
+}
+
+func TestTriggerReplayGuard(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	suite.Run(t, &TriggerReplayGuardTestSuite{})
+}
+
+func (s *TriggerReplayGuardTestSuite) TestRememberTrigger() {
+	var (
+		ctx       = context.Background()
+		expiresAt = time.Now().Add(time.Minute)
+	)
+
+	s.Require().NoError(s.guard.RememberTrigger(ctx, "tc:sha256=a", expiresAt))
+	s.Require().NoError(s.guard.RememberTrigger(ctx, "tc:sha256=b", expiresAt))
+
+	err := s.guard.RememberTrigger(ctx, "tc:sha256=a", expiresAt)
+	s.Require().ErrorIs(err, service.ErrTriggerReplayed)
+}
@@ -0,0 +1,117 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type (
+	// PipelineStepBus fans out pipeline steps to the subscribers
+	// of the same process. It is suitable only for single replica
+	// deployments. Publishing never blocks: subscriber with the full
+	// buffer of steps is disconnected and its channel is closed.
+	PipelineStepBus struct {
+		mu          sync.Mutex
+		subscribers map[string]map[*stepSubscription]struct{}
+	}
+
+	stepSubscription struct {
+		steps  chan service.StepMessage
+		closed chan struct{}
+	}
+)
+
+const stepBufferSize = 64
+
+func NewPipelineStepBus() *PipelineStepBus {
+	return &PipelineStepBus{
+		subscribers: make(map[string]map[*stepSubscription]struct{}),
+	}
+}
+
+func (b *PipelineStepBus) PublishPipelineStep(pipeID string, msg service.StepMessage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers[pipeID] {
+		select {
+		case sub.steps <- msg:
+		default:
+			// Subscriber not keeping up with steps is disconnected,
+			// so it does not stall the running pipeline.
+			b.unsubscribe(pipeID, sub)
+		}
+	}
+
+	return nil
+}
+
+func (b *PipelineStepBus) PublishPipelineCompleted(pipeID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers[pipeID] {
+		b.unsubscribe(pipeID, sub)
+	}
+
+	return nil
+}
+
+func (b *PipelineStepBus) SubscribePipelineSteps(
+	ctx context.Context,
+	pipeID string,
+) (<-chan service.StepMessage, error) {
+	sub := &stepSubscription{
+		steps:  make(chan service.StepMessage, stepBufferSize),
+		closed: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+
+	if _, ok := b.subscribers[pipeID]; !ok {
+		b.subscribers[pipeID] = make(map[*stepSubscription]struct{})
+	}
+
+	b.subscribers[pipeID][sub] = struct{}{}
+
+	b.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-sub.closed:
+			return
+		}
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.unsubscribe(pipeID, sub)
+	}()
+
+	return sub.steps, nil
+}
+
+// unsubscribe removes subscription and closes its channel
+// if it is not removed yet. It must be called with the lock held.
+func (b *PipelineStepBus) unsubscribe(pipeID string, sub *stepSubscription) {
+	subs, ok := b.subscribers[pipeID]
+	if !ok {
+		return
+	}
+
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+
+	if len(subs) == 0 {
+		delete(b.subscribers, pipeID)
+	}
+
+	close(sub.closed)
+	close(sub.steps)
+}
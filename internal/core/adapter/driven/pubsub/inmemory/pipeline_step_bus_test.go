@@ -0,0 +1,135 @@
+package inmemory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/pubsub/inmemory"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestPipelineStepBusPublishesStepsUntilCompleted(t *testing.T) {
+	t.Parallel()
+
+	const pipeID = "b8e1bd23-6a1a-4ca3-a4ff-3e5c3f0a4b1a"
+
+	bus := inmemory.NewPipelineStepBus()
+
+	steps, err := bus.SubscribePipelineSteps(context.Background(), pipeID)
+	require.NoError(t, err)
+
+	msg := service.StepMessage{
+		Step: pipeline.NewThesisStep(
+			specification.NewThesisSlug("foo", "bar", "baz"),
+			pipeline.HTTPExecutor,
+			pipeline.FiredExecute,
+		),
+		OccurredAt: time.Date(2022, time.May, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, bus.PublishPipelineStep(pipeID, msg))
+	require.NoError(t, bus.PublishPipelineCompleted(pipeID))
+
+	received, ok := <-steps
+	require.True(t, ok)
+	require.Equal(t, msg, received)
+
+	_, ok = <-steps
+	require.False(t, ok)
+}
+
+func TestPipelineStepBusIgnoresOtherPipelines(t *testing.T) {
+	t.Parallel()
+
+	bus := inmemory.NewPipelineStepBus()
+
+	steps, err := bus.SubscribePipelineSteps(context.Background(), "a")
+	require.NoError(t, err)
+
+	err = bus.PublishPipelineStep("b", service.StepMessage{
+		Step: pipeline.NewScenarioStep(
+			specification.NewScenarioSlug("foo", "bar"),
+			pipeline.FiredExecute,
+		),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, bus.PublishPipelineCompleted("a"))
+
+	_, ok := <-steps
+	require.False(t, ok)
+}
+
+func TestPipelineStepBusClosesStepsWhenContextIsDone(t *testing.T) {
+	t.Parallel()
+
+	bus := inmemory.NewPipelineStepBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	steps, err := bus.SubscribePipelineSteps(ctx, "a")
+	require.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, ok := <-steps:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		require.Fail(t, "steps channel is not closed")
+	}
+
+	require.NoError(t, bus.PublishPipelineStep("a", service.StepMessage{}))
+}
+
+func TestPipelineStepBusDisconnectsSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	const pipeID = "4f0e2a0c-1a5e-4c8e-9b7b-2d5b4e1f6a3c"
+
+	bus := inmemory.NewPipelineStepBus()
+
+	slow, err := bus.SubscribePipelineSteps(context.Background(), pipeID)
+	require.NoError(t, err)
+
+	msg := service.StepMessage{
+		Step: pipeline.NewScenarioStep(
+			specification.NewScenarioSlug("foo", "bar"),
+			pipeline.FiredExecute,
+		),
+	}
+
+	var (
+		published  = make(chan struct{})
+		publishErr error
+	)
+
+	go func() {
+		defer close(published)
+
+		for i := 0; i < 100 && publishErr == nil; i++ {
+			publishErr = bus.PublishPipelineStep(pipeID, msg)
+		}
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		require.FailNow(t, "publishing is blocked by the slow subscriber")
+	}
+
+	require.NoError(t, publishErr)
+
+	received := 0
+
+	for range slow {
+		received++
+	}
+
+	require.Less(t, received, 100)
+}
@@ -1,6 +1,7 @@
 package natsio
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nats-io/nats.go"
@@ -22,7 +23,10 @@ func (p PipelineCancelSignalBus) PublishPipelineCancel(pipeID string) error {
 	return service.WrapWithPublishCancelError(p.conn.Publish(subject(pipeID), []byte{}))
 }
 
-func (p PipelineCancelSignalBus) SubscribePipelineCancel(pipeID string) (<-chan service.CancelSignal, error) {
+func (p PipelineCancelSignalBus) SubscribePipelineCancel(
+	ctx context.Context,
+	pipeID string,
+) (<-chan service.CancelSignal, error) {
 	canceled := make(chan service.CancelSignal)
 
 	sub, err := p.conn.Subscribe(subject(pipeID), func(msg *nats.Msg) {
@@ -33,9 +37,19 @@ func (p PipelineCancelSignalBus) SubscribePipelineCancel(pipeID string) (<-chan
 	}
 
 	if err := sub.AutoUnsubscribe(1); err != nil {
+		_ = sub.Unsubscribe()
+
 		return nil, service.WrapWithSubscribeCancelError(err)
 	}
 
+	go func() {
+		<-ctx.Done()
+
+		// Subscription is already removed
+		// if the signal is received.
+		_ = sub.Unsubscribe()
+	}()
+
 	return canceled, nil
 }
 
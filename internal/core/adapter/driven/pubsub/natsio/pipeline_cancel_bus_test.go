@@ -1,6 +1,7 @@
 package natsio_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/nats-io/nats.go"
@@ -21,7 +22,10 @@ func TestPipelineCancelSignalBus(t *testing.T) {
 
 	natsBus := natsio.NewPipelineCancelSignalBus(natsConn)
 
-	canceled, err := natsBus.SubscribePipelineCancel("d54e2b7c-0edb-4367-b819-d166ca0edd9e")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	canceled, err := natsBus.SubscribePipelineCancel(ctx, "d54e2b7c-0edb-4367-b819-d166ca0edd9e")
 	require.NoError(t, err)
 	require.NotNil(t, canceled)
 
@@ -0,0 +1,74 @@
+package natsio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type (
+	PipelinePauseSignalBus struct {
+		conn *nats.Conn
+	}
+)
+
+const pauseSignalBufferSize = 8
+
+func NewPipelinePauseSignalBus(conn *nats.Conn) PipelinePauseSignalBus {
+	return PipelinePauseSignalBus{conn: conn}
+}
+
+func (p PipelinePauseSignalBus) PublishPipelinePause(pipeID string) error {
+	return p.publish(pipeID, service.Paused)
+}
+
+func (p PipelinePauseSignalBus) PublishPipelineResume(pipeID string) error {
+	return p.publish(pipeID, service.Resumed)
+}
+
+func (p PipelinePauseSignalBus) publish(pipeID string, signal service.PauseSignal) error {
+	return service.WrapWithPublishPauseError(p.conn.Publish(pauseSubject(pipeID), []byte(signal)))
+}
+
+func (p PipelinePauseSignalBus) SubscribePipelinePause(
+	ctx context.Context,
+	pipeID string,
+) (<-chan service.PauseSignal, error) {
+	msgs := make(chan *nats.Msg, pauseSignalBufferSize)
+
+	sub, err := p.conn.ChanSubscribe(pauseSubject(pipeID), msgs)
+	if err != nil {
+		return nil, service.WrapWithSubscribePauseError(err)
+	}
+
+	signals := make(chan service.PauseSignal)
+
+	go func() {
+		defer close(signals)
+		defer func() {
+			_ = sub.Unsubscribe()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-msgs:
+				select {
+				case signals <- service.PauseSignal(msg.Data):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return signals, nil
+}
+
+func pauseSubject(pipeID string) string {
+	return fmt.Sprintf("pipeline.pause.%s", pipeID)
+}
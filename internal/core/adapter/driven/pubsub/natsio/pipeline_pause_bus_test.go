@@ -0,0 +1,48 @@
+package natsio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/pubsub/natsio"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+func TestPipelinePauseSignalBus(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	natsConn, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+
+	natsBus := natsio.NewPipelinePauseSignalBus(natsConn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals, err := natsBus.SubscribePipelinePause(ctx, "7a0f7d8e-0b4e-4bd3-9b5c-4a8f2f0d5f11")
+	require.NoError(t, err)
+	require.NotNil(t, signals)
+
+	go func() {
+		err = natsBus.PublishPipelinePause("7a0f7d8e-0b4e-4bd3-9b5c-4a8f2f0d5f11")
+		require.NoError(t, err)
+
+		err = natsBus.PublishPipelineResume("7a0f7d8e-0b4e-4bd3-9b5c-4a8f2f0d5f11")
+		require.NoError(t, err)
+	}()
+
+	require.Equal(t, service.Paused, <-signals)
+	require.Equal(t, service.Resumed, <-signals)
+
+	cancel()
+
+	_, ok := <-signals
+	require.False(t, ok)
+}
@@ -0,0 +1,54 @@
+package natsio
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// pipelineQueue delivers pipeline IDs published to the
+// subject to the only subscriber of the queue group.
+type pipelineQueue struct {
+	conn    *nats.Conn
+	subject string
+	queue   string
+}
+
+const pipelineQueueBufferSize = 64
+
+func (q pipelineQueue) publish(pipeID string) error {
+	return q.conn.Publish(q.subject, []byte(pipeID))
+}
+
+func (q pipelineQueue) subscribe(ctx context.Context) (<-chan string, error) {
+	msgs := make(chan *nats.Msg, pipelineQueueBufferSize)
+
+	sub, err := q.conn.ChanQueueSubscribe(q.subject, q.queue, msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeIDs := make(chan string)
+
+	go func() {
+		defer close(pipeIDs)
+		defer func() {
+			_ = sub.Unsubscribe()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-msgs:
+				select {
+				case pipeIDs <- string(msg.Data):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return pipeIDs, nil
+}
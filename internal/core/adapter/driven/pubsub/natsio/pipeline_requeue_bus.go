@@ -0,0 +1,33 @@
+package natsio
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type PipelineRequeueBus struct {
+	queue pipelineQueue
+}
+
+func NewPipelineRequeueBus(conn *nats.Conn) PipelineRequeueBus {
+	return PipelineRequeueBus{queue: pipelineQueue{
+		conn:    conn,
+		subject: "pipeline.requeue",
+		queue:   "pipeline.requeue.runners",
+	}}
+}
+
+func (b PipelineRequeueBus) PublishPipelineRequeue(pipeID string) error {
+	return service.WrapWithPublishRequeueError(b.queue.publish(pipeID))
+}
+
+// SubscribePipelineRequeue joins the queue group,
+// so each requeued pipeline is received by the only instance.
+func (b PipelineRequeueBus) SubscribePipelineRequeue(ctx context.Context) (<-chan string, error) {
+	pipeIDs, err := b.queue.subscribe(ctx)
+
+	return pipeIDs, service.WrapWithSubscribeRequeueError(err)
+}
@@ -0,0 +1,46 @@
+package natsio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/pubsub/natsio"
+)
+
+func TestPipelineRequeueBus(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	natsConn, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+
+	natsBus := natsio.NewPipelineRequeueBus(natsConn)
+
+	const pipeID = "0e2f9b8e-8d3c-4d5e-b0d4-6a1f2c3e4b5a"
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pipeIDs, err := natsBus.SubscribePipelineRequeue(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, pipeIDs)
+
+	go func() {
+		err = natsBus.PublishPipelineRequeue(pipeID)
+		require.NoError(t, err)
+	}()
+
+	received, ok := <-pipeIDs
+	require.True(t, ok)
+	require.Equal(t, pipeID, received)
+
+	cancel()
+
+	_, ok = <-pipeIDs
+	require.False(t, ok)
+}
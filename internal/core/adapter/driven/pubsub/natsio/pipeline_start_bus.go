@@ -0,0 +1,181 @@
+package natsio
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+type (
+	// PipelineStartBus sends start requests of pipelines to the
+	// queue group of workers and waits for the reply of the worker,
+	// which received the request.
+	PipelineStartBus struct {
+		conn    *nats.Conn
+		timeout time.Duration
+	}
+
+	startMessage struct {
+		PipelineID string `json:"pipelineId"`
+		Priority   int    `json:"priority"`
+		LeaseOwner string `json:"leaseOwner,omitempty"`
+	}
+
+	startReply struct {
+		Code  string `json:"code,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+)
+
+const (
+	startSubject = "pipeline.start"
+	startQueue   = "pipeline.start.workers"
+)
+
+const (
+	alreadyStartedCode = "already-started"
+	notFoundCode       = "not-found"
+	rejectedCode       = "rejected"
+)
+
+// NewPipelineStartBus returns bus, which waits for the
+// reply of the worker no longer than the timeout.
+func NewPipelineStartBus(conn *nats.Conn, timeout time.Duration) PipelineStartBus {
+	return PipelineStartBus{
+		conn:    conn,
+		timeout: timeout,
+	}
+}
+
+// PublishPipelineStart returns service.ErrNoPipelineWorkers if
+// there are no subscribed workers or none of them replied in time.
+func (b PipelineStartBus) PublishPipelineStart(ctx context.Context, msg service.PipelineStartMessage) error {
+	data, err := json.Marshal(startMessage{
+		PipelineID: msg.PipelineID,
+		Priority:   int(msg.Priority),
+		LeaseOwner: msg.LeaseOwner,
+	})
+	if err != nil {
+		return service.WrapWithPublishStartError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+
+	rsp, err := b.conn.RequestWithContext(ctx, startSubject, data)
+	if errors.Is(err, nats.ErrNoResponders) || errors.Is(err, context.DeadlineExceeded) {
+		return service.WrapWithPublishStartError(service.ErrNoPipelineWorkers)
+	}
+
+	if err != nil {
+		return service.WrapWithPublishStartError(err)
+	}
+
+	var reply startReply
+	if err := json.Unmarshal(rsp.Data, &reply); err != nil {
+		return service.WrapWithPublishStartError(err)
+	}
+
+	return reply.err()
+}
+
+// SubscribePipelineStart joins the queue group of workers,
+// so each pipeline is started by the only worker.
+func (b PipelineStartBus) SubscribePipelineStart(ctx context.Context) (<-chan service.PipelineStartRequest, error) {
+	msgs := make(chan *nats.Msg, pipelineQueueBufferSize)
+
+	sub, err := b.conn.ChanQueueSubscribe(startSubject, startQueue, msgs)
+	if err != nil {
+		return nil, service.WrapWithSubscribeStartError(err)
+	}
+
+	requests := make(chan service.PipelineStartRequest)
+
+	go func() {
+		defer close(requests)
+		defer func() {
+			_ = sub.Unsubscribe()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw := <-msgs:
+				req, err := newPipelineStartRequest(raw)
+				if err != nil {
+					_ = respondStart(raw, err)
+
+					continue
+				}
+
+				select {
+				case requests <- req:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return requests, nil
+}
+
+func newPipelineStartRequest(raw *nats.Msg) (service.PipelineStartRequest, error) {
+	var msg startMessage
+	if err := json.Unmarshal(raw.Data, &msg); err != nil {
+		return service.PipelineStartRequest{}, err
+	}
+
+	return service.PipelineStartRequest{
+		PipelineStartMessage: service.PipelineStartMessage{
+			PipelineID: msg.PipelineID,
+			Priority:   service.Priority(msg.Priority),
+			LeaseOwner: msg.LeaseOwner,
+		},
+		Reply: func(err error) error {
+			return respondStart(raw, err)
+		},
+	}, nil
+}
+
+func respondStart(raw *nats.Msg, err error) error {
+	data, marshalErr := json.Marshal(newStartReply(err))
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	return raw.Respond(data)
+}
+
+func newStartReply(err error) startReply {
+	switch {
+	case err == nil:
+		return startReply{}
+	case errors.Is(err, pipeline.ErrAlreadyStarted):
+		return startReply{Code: alreadyStartedCode, Error: err.Error()}
+	case errors.Is(err, service.ErrPipelineNotFound):
+		return startReply{Code: notFoundCode, Error: err.Error()}
+	}
+
+	return startReply{Code: rejectedCode, Error: err.Error()}
+}
+
+func (r startReply) err() error {
+	switch r.Code {
+	case "":
+		return nil
+	case alreadyStartedCode:
+		return pipeline.ErrAlreadyStarted
+	case notFoundCode:
+		return service.ErrPipelineNotFound
+	}
+
+	return service.WrapWithPublishStartError(errors.Errorf("rejected by worker: %s", r.Error))
+}
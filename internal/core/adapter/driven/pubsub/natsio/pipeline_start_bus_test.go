@@ -0,0 +1,130 @@
+package natsio_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/pubsub/natsio"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+func TestPipelineStartBus(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	testCases := []struct {
+		Name        string
+		PipelineID  string
+		WorkerErr   error
+		ShouldBeErr bool
+		IsErr       func(err error) bool
+	}{
+		{
+			Name:        "pipeline_accepted",
+			PipelineID:  "7b1e2d3c-4f5a-4b6c-8d7e-9f0a1b2c3d4e",
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "pipeline_already_started",
+			PipelineID:  "1f2e3d4c-5b6a-4978-8a9b-0c1d2e3f4a5b",
+			WorkerErr:   pipeline.ErrAlreadyStarted,
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, pipeline.ErrAlreadyStarted)
+			},
+		},
+		{
+			Name:        "pipeline_not_found",
+			PipelineID:  "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d",
+			WorkerErr:   service.ErrPipelineNotFound,
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrPipelineNotFound)
+			},
+		},
+		{
+			Name:        "pipeline_rejected",
+			PipelineID:  "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f",
+			WorkerErr:   errors.New("foo"),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *service.PublishStartError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	natsConn, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+
+	natsBus := natsio.NewPipelineStartBus(natsConn, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reqs, err := natsBus.SubscribePipelineStart(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, reqs)
+
+	for _, c := range testCases {
+		msg := service.PipelineStartMessage{
+			PipelineID: c.PipelineID,
+			Priority:   service.ManualPriority,
+			LeaseOwner: "api",
+		}
+
+		published := make(chan error, 1)
+
+		go func() {
+			published <- natsBus.PublishPipelineStart(context.Background(), msg)
+		}()
+
+		req, ok := <-reqs
+		require.True(t, ok, c.Name)
+		require.Equal(t, msg, req.PipelineStartMessage, c.Name)
+		require.NoError(t, req.Reply(c.WorkerErr), c.Name)
+
+		err := <-published
+
+		if c.ShouldBeErr {
+			require.True(t, c.IsErr(err), c.Name)
+
+			continue
+		}
+
+		require.NoError(t, err, c.Name)
+	}
+
+	cancel()
+
+	_, ok := <-reqs
+	require.False(t, ok)
+}
+
+// TestPipelineStartBusWithoutWorkers isn't parallel, so it runs
+// before workers of other tests subscribe to start requests.
+func TestPipelineStartBusWithoutWorkers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	natsConn, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+
+	natsBus := natsio.NewPipelineStartBus(natsConn, time.Second)
+
+	err = natsBus.PublishPipelineStart(context.Background(), service.PipelineStartMessage{
+		PipelineID: "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f8a",
+	})
+	require.ErrorIs(t, err, service.ErrNoPipelineWorkers)
+}
@@ -0,0 +1,207 @@
+package natsio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+type (
+	PipelineStepBus struct {
+		conn *nats.Conn
+	}
+
+	stepMessage struct {
+		Completed    bool      `json:"completed,omitempty"`
+		SlugKind     string    `json:"slugKind,omitempty"`
+		Story        string    `json:"story,omitempty"`
+		Scenario     string    `json:"scenario,omitempty"`
+		Thesis       string    `json:"thesis,omitempty"`
+		ExecutorType string    `json:"executorType,omitempty"`
+		Event        string    `json:"event,omitempty"`
+		Err          string    `json:"err,omitempty"`
+		OccurredAt   time.Time `json:"occurredAt,omitempty"`
+
+		Exchange *stepExchange `json:"exchange,omitempty"`
+	}
+
+	stepExchange struct {
+		Method              string `json:"method,omitempty"`
+		URL                 string `json:"url,omitempty"`
+		RequestContentType  string `json:"requestContentType,omitempty"`
+		RequestBody         string `json:"requestBody,omitempty"`
+		ResponseCode        int    `json:"responseCode,omitempty"`
+		ResponseContentType string `json:"responseContentType,omitempty"`
+		ResponseBody        string `json:"responseBody,omitempty"`
+	}
+)
+
+const stepBufferSize = 64
+
+func NewPipelineStepBus(conn *nats.Conn) PipelineStepBus {
+	return PipelineStepBus{conn: conn}
+}
+
+func (p PipelineStepBus) PublishPipelineStep(pipeID string, msg service.StepMessage) error {
+	data, err := json.Marshal(newStepMessage(msg))
+	if err != nil {
+		return service.WrapWithPublishStepError(err)
+	}
+
+	return service.WrapWithPublishStepError(p.conn.Publish(stepSubject(pipeID), data))
+}
+
+func (p PipelineStepBus) PublishPipelineCompleted(pipeID string) error {
+	data, err := json.Marshal(stepMessage{Completed: true})
+	if err != nil {
+		return service.WrapWithPublishStepError(err)
+	}
+
+	return service.WrapWithPublishStepError(p.conn.Publish(stepSubject(pipeID), data))
+}
+
+func (p PipelineStepBus) SubscribePipelineSteps(
+	ctx context.Context,
+	pipeID string,
+) (<-chan service.StepMessage, error) {
+	msgs := make(chan *nats.Msg, stepBufferSize)
+
+	sub, err := p.conn.ChanSubscribe(stepSubject(pipeID), msgs)
+	if err != nil {
+		return nil, service.WrapWithSubscribeStepError(err)
+	}
+
+	// Flush makes sure the server has processed the subscription,
+	// so steps published right after subscribing are not lost.
+	if err := p.conn.Flush(); err != nil {
+		_ = sub.Unsubscribe()
+
+		return nil, service.WrapWithSubscribeStepError(err)
+	}
+
+	steps := make(chan service.StepMessage)
+
+	go func() {
+		defer close(steps)
+		defer func() {
+			_ = sub.Unsubscribe()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw := <-msgs:
+				var msg stepMessage
+
+				if err := json.Unmarshal(raw.Data, &msg); err != nil {
+					continue
+				}
+
+				if msg.Completed {
+					return
+				}
+
+				select {
+				case steps <- msg.toService():
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return steps, nil
+}
+
+func newStepMessage(msg service.StepMessage) stepMessage {
+	var (
+		step = msg.Step
+		slug = step.Slug()
+	)
+
+	m := stepMessage{
+		SlugKind:     string(slug.Kind()),
+		Story:        slug.Story(),
+		Scenario:     slug.Scenario(),
+		Thesis:       slug.Thesis(),
+		ExecutorType: string(step.ExecutorType()),
+		Event:        string(step.Event()),
+		OccurredAt:   msg.OccurredAt,
+	}
+
+	if err := step.Err(); err != nil {
+		m.Err = err.Error()
+	}
+
+	if x := step.Exchange(); !x.IsZero() {
+		m.Exchange = &stepExchange{
+			Method:              x.Method,
+			URL:                 x.URL,
+			RequestContentType:  x.RequestContentType,
+			RequestBody:         x.RequestBody,
+			ResponseCode:        x.ResponseCode,
+			ResponseContentType: x.ResponseContentType,
+			ResponseBody:        x.ResponseBody,
+		}
+	}
+
+	return m
+}
+
+func (m stepMessage) toService() service.StepMessage {
+	var err error
+	if m.Err != "" {
+		err = errors.New(m.Err)
+	}
+
+	var step pipeline.Step
+
+	if specification.SlugKind(m.SlugKind) == specification.ThesisSlug {
+		step = pipeline.NewThesisStepWithErr(
+			err,
+			specification.NewThesisSlug(m.Story, m.Scenario, m.Thesis),
+			pipeline.ExecutorType(m.ExecutorType),
+			pipeline.Event(m.Event),
+		).WithExchange(m.Exchange.toPipeline())
+	} else {
+		step = pipeline.NewScenarioStepWithErr(
+			err,
+			specification.NewScenarioSlug(m.Story, m.Scenario),
+			pipeline.Event(m.Event),
+		)
+	}
+
+	return service.StepMessage{
+		Step:       step,
+		OccurredAt: m.OccurredAt,
+	}
+}
+
+func (x *stepExchange) toPipeline() pipeline.HTTPExchange {
+	if x == nil {
+		return pipeline.HTTPExchange{}
+	}
+
+	return pipeline.HTTPExchange{
+		Method:              x.Method,
+		URL:                 x.URL,
+		RequestContentType:  x.RequestContentType,
+		RequestBody:         x.RequestBody,
+		ResponseCode:        x.ResponseCode,
+		ResponseContentType: x.ResponseContentType,
+		ResponseBody:        x.ResponseBody,
+	}
+}
+
+func stepSubject(pipeID string) string {
+	return fmt.Sprintf("pipeline.steps.%s", pipeID)
+}
@@ -0,0 +1,66 @@
+package natsio_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/pubsub/natsio"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestPipelineStepBus(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("Integration tests are skipped")
+	}
+
+	natsConn, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+
+	natsBus := natsio.NewPipelineStepBus(natsConn)
+
+	const pipeID = "5c4b7d0e-7b7f-4d0b-93a4-1f3c6f4e1f36"
+
+	steps, err := natsBus.SubscribePipelineSteps(context.Background(), pipeID)
+	require.NoError(t, err)
+	require.NotNil(t, steps)
+
+	msg := service.StepMessage{
+		Step: pipeline.NewThesisStep(
+			specification.NewThesisSlug("foo", "bar", "baz"),
+			pipeline.HTTPExecutor,
+			pipeline.FiredPass,
+		).WithExchange(pipeline.HTTPExchange{
+			Method:              "POST",
+			URL:                 "https://api.some-a.com/products",
+			RequestContentType:  "application/json",
+			RequestBody:         `{"name":"apple"}`,
+			ResponseCode:        201,
+			ResponseContentType: "application/json",
+			ResponseBody:        `{"id":1}`,
+		}),
+		OccurredAt: time.Date(2022, time.May, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	go func() {
+		err = natsBus.PublishPipelineStep(pipeID, msg)
+		require.NoError(t, err)
+
+		err = natsBus.PublishPipelineCompleted(pipeID)
+		require.NoError(t, err)
+	}()
+
+	received, ok := <-steps
+	require.True(t, ok)
+	require.Equal(t, msg, received)
+
+	_, ok = <-steps
+	require.False(t, ok)
+}
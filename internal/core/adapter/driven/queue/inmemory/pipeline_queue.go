@@ -0,0 +1,194 @@
+package inmemory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+// PipelineQueue runs jobs with the fixed number of workers.
+// Jobs waiting for the free worker are ordered by priority,
+// so they can be shown with position and estimated time
+// before running, and can be removed before running.
+type PipelineQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	jobs    []queuedJob
+	seq     uint64
+	stopped bool
+
+	workers     int
+	wg          sync.WaitGroup
+	avgDuration time.Duration
+}
+
+type queuedJob struct {
+	job        service.Job
+	seq        uint64
+	enqueuedAt time.Time
+}
+
+// durationWeight smooths the moving average of run durations,
+// the last run duration contributes 1/durationWeight of it.
+const durationWeight = 5
+
+func NewPipelineQueue(workers int) *PipelineQueue {
+	if workers < 1 {
+		workers = 1
+	}
+
+	q := &PipelineQueue{workers: workers}
+	q.cond = sync.NewCond(&q.mu)
+
+	q.wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+
+	return q
+}
+
+// Enqueue discards the job if the queue is stopped.
+func (q *PipelineQueue) Enqueue(job service.Job) {
+	q.mu.Lock()
+
+	if q.stopped {
+		q.mu.Unlock()
+		job.Discard()
+
+		return
+	}
+
+	q.seq++
+
+	qj := queuedJob{
+		job:        job,
+		seq:        q.seq,
+		enqueuedAt: time.Now().UTC(),
+	}
+
+	i := sort.Search(len(q.jobs), func(i int) bool {
+		return q.jobs[i].job.Priority < job.Priority
+	})
+
+	q.jobs = append(q.jobs, queuedJob{})
+	copy(q.jobs[i+1:], q.jobs[i:])
+	q.jobs[i] = qj
+
+	q.mu.Unlock()
+
+	q.cond.Signal()
+}
+
+func (q *PipelineQueue) QueuedPipelines() ([]service.QueuedPipeline, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pipes := make([]service.QueuedPipeline, 0, len(q.jobs))
+
+	for i, qj := range q.jobs {
+		position := i + 1
+
+		pipes = append(pipes, service.QueuedPipeline{
+			PipelineID: qj.job.PipelineID,
+			OwnerID:    qj.job.OwnerID,
+			Priority:   qj.job.Priority,
+			Position:   position,
+			EnqueuedAt: qj.enqueuedAt,
+			ETA:        q.eta(position),
+		})
+	}
+
+	return pipes, nil
+}
+
+func (q *PipelineQueue) RemovePipeline(pipeID string) error {
+	q.mu.Lock()
+
+	for i, qj := range q.jobs {
+		if qj.job.PipelineID != pipeID {
+			continue
+		}
+
+		q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+		q.mu.Unlock()
+
+		qj.job.Discard()
+
+		return nil
+	}
+
+	q.mu.Unlock()
+
+	return service.ErrPipelineNotQueued
+}
+
+// StopWait stops accepting new jobs and waits
+// until all of the queued jobs are run.
+func (q *PipelineQueue) StopWait() {
+	q.mu.Lock()
+	q.stopped = true
+	q.mu.Unlock()
+
+	q.cond.Broadcast()
+	q.wg.Wait()
+}
+
+func (q *PipelineQueue) work() {
+	defer q.wg.Done()
+
+	for {
+		job, ok := q.next()
+		if !ok {
+			return
+		}
+
+		start := time.Now()
+
+		job.Run()
+
+		q.observe(time.Since(start))
+	}
+}
+
+func (q *PipelineQueue) next() (service.Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.jobs) == 0 && !q.stopped {
+		q.cond.Wait()
+	}
+
+	if len(q.jobs) == 0 {
+		return service.Job{}, false
+	}
+
+	qj := q.jobs[0]
+	q.jobs = q.jobs[1:]
+
+	return qj.job, true
+}
+
+func (q *PipelineQueue) observe(d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.avgDuration == 0 {
+		q.avgDuration = d
+
+		return
+	}
+
+	q.avgDuration += (d - q.avgDuration) / durationWeight
+}
+
+// eta estimates time before the job at position is run
+// assuming that all workers are busy with the average job.
+func (q *PipelineQueue) eta(position int) time.Duration {
+	rounds := (position + q.workers - 1) / q.workers
+
+	return time.Duration(rounds) * q.avgDuration
+}
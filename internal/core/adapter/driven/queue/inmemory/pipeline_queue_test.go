@@ -0,0 +1,170 @@
+package inmemory_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/queue/inmemory"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+func TestPipelineQueueRunsJobsByPriority(t *testing.T) {
+	t.Parallel()
+
+	queue := inmemory.NewPipelineQueue(1)
+
+	var (
+		mu  sync.Mutex
+		ran []string
+	)
+
+	job := func(pipeID string, priority service.Priority, run func()) service.Job {
+		return service.Job{
+			PipelineID: pipeID,
+			Priority:   priority,
+			Run: func() {
+				if run != nil {
+					run()
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				ran = append(ran, pipeID)
+			},
+			Discard: func() {},
+		}
+	}
+
+	release := make(chan struct{})
+
+	queue.Enqueue(job("busy", service.ManualPriority, func() { <-release }))
+
+	require.Eventually(t, func() bool {
+		return queuedCount(queue) == 0
+	}, time.Second, time.Millisecond)
+
+	queue.Enqueue(job("foo", service.ScheduledPriority, nil))
+	queue.Enqueue(job("bar", service.ManualPriority, nil))
+	queue.Enqueue(job("baz", service.ScheduledPriority, nil))
+	queue.Enqueue(job("qux", service.ManualPriority, nil))
+
+	queued, err := queue.QueuedPipelines()
+	require.NoError(t, err)
+	require.Len(t, queued, 4)
+
+	for i, pipeID := range []string{"bar", "qux", "foo", "baz"} {
+		require.Equal(t, pipeID, queued[i].PipelineID)
+		require.Equal(t, i+1, queued[i].Position)
+	}
+
+	close(release)
+	queue.StopWait()
+
+	require.Equal(t, []string{"busy", "bar", "qux", "foo", "baz"}, ran)
+}
+
+func TestPipelineQueueRemovesQueuedPipeline(t *testing.T) {
+	t.Parallel()
+
+	queue := inmemory.NewPipelineQueue(1)
+
+	release := make(chan struct{})
+
+	queue.Enqueue(service.Job{
+		PipelineID: "busy",
+		Run:        func() { <-release },
+		Discard:    func() {},
+	})
+
+	require.Eventually(t, func() bool {
+		return queuedCount(queue) == 0
+	}, time.Second, time.Millisecond)
+
+	var ran, discarded bool
+
+	queue.Enqueue(service.Job{
+		PipelineID: "foo",
+		Run:        func() { ran = true },
+		Discard:    func() { discarded = true },
+	})
+
+	require.NoError(t, queue.RemovePipeline("foo"))
+	require.ErrorIs(t, queue.RemovePipeline("foo"), service.ErrPipelineNotQueued)
+	require.ErrorIs(t, queue.RemovePipeline("busy"), service.ErrPipelineNotQueued)
+
+	close(release)
+	queue.StopWait()
+
+	require.True(t, discarded)
+	require.False(t, ran)
+}
+
+func TestPipelineQueueEstimatesTimeBeforeRunning(t *testing.T) {
+	t.Parallel()
+
+	queue := inmemory.NewPipelineQueue(2)
+
+	queue.Enqueue(service.Job{
+		PipelineID: "fast",
+		Run:        func() { time.Sleep(10 * time.Millisecond) },
+		Discard:    func() {},
+	})
+
+	require.Eventually(t, func() bool {
+		return queuedCount(queue) == 0
+	}, time.Second, time.Millisecond)
+
+	release := make(chan struct{})
+
+	for _, pipeID := range []string{"busy1", "busy2", "foo", "bar", "baz"} {
+		queue.Enqueue(service.Job{
+			PipelineID: pipeID,
+			Run:        func() { <-release },
+			Discard:    func() {},
+		})
+	}
+
+	require.Eventually(t, func() bool {
+		return queuedCount(queue) == 3
+	}, time.Second, time.Millisecond)
+
+	queued, err := queue.QueuedPipelines()
+	require.NoError(t, err)
+
+	require.Positive(t, queued[0].ETA)
+	require.Equal(t, queued[0].ETA, queued[1].ETA)
+	require.Equal(t, 2*queued[0].ETA, queued[2].ETA)
+
+	close(release)
+	queue.StopWait()
+}
+
+func TestPipelineQueueDiscardsJobsAfterStop(t *testing.T) {
+	t.Parallel()
+
+	queue := inmemory.NewPipelineQueue(1)
+	queue.StopWait()
+
+	var discarded bool
+
+	queue.Enqueue(service.Job{
+		PipelineID: "foo",
+		Run:        func() {},
+		Discard:    func() { discarded = true },
+	})
+
+	require.True(t, discarded)
+}
+
+func queuedCount(queue *inmemory.PipelineQueue) int {
+	queued, err := queue.QueuedPipelines()
+	if err != nil {
+		return -1
+	}
+
+	return len(queued)
+}
@@ -0,0 +1,334 @@
+package html
+
+import (
+	"bytes"
+	// embed is used to keep report template next to the reporter.
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+const Format = "html"
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+// Reporter renders flow as a single HTML page readable by
+// analysts who wrote the specification. Every story is shown
+// with its narrative, every scenario with its theses grouped
+// by given, when and then stages, the HTTP request actually
+// sent and the response received next to the expected one,
+// and occurred errors. The request of the specification is
+// shown for theses that sent nothing.
+type Reporter struct {
+	tmpl *template.Template
+}
+
+func NewReporter() Reporter {
+	return Reporter{
+		tmpl: template.Must(
+			template.New("report").
+				Funcs(template.FuncMap{"stateClass": stateClass}).
+				Parse(reportTemplate),
+		),
+	}
+}
+
+func (r Reporter) Format() string {
+	return Format
+}
+
+func (r Reporter) ContentType() string {
+	return "text/html; charset=utf-8"
+}
+
+// WriteFlowReport renders flow statuses along with the specification
+// objects. Scenarios of the specification missing in the flow are
+// reported as not executed.
+func (r Reporter) WriteFlowReport(w io.Writer, f *flow.Flow, spec *specification.Specification) error {
+	return r.tmpl.Execute(w, newReport(f, spec))
+}
+
+type (
+	report struct {
+		FlowID       string
+		Title        string
+		Description  string
+		Author       string
+		OverallState string
+		Stories      []story
+	}
+
+	story struct {
+		Slug        string
+		Description string
+		AsA         string
+		InOrderTo   string
+		WantTo      string
+		Scenarios   []scenario
+	}
+
+	scenario struct {
+		Slug        string
+		Description string
+		State       string
+		Theses      []thesis
+	}
+
+	thesis struct {
+		Slug      string
+		Stage     string
+		Behavior  string
+		State     string
+		Request   string
+		Response  string
+		Received  string
+		Assertion string
+		Errors    []string
+	}
+)
+
+func newReport(f *flow.Flow, spec *specification.Specification) report {
+	statuses := make(map[specification.Slug]*flow.Status)
+	for _, s := range f.Statuses() {
+		statuses[s.Slug()] = s
+	}
+
+	r := report{
+		FlowID:       f.ID(),
+		OverallState: stateOf(f.OverallState()),
+	}
+
+	if spec == nil {
+		return r
+	}
+
+	r.Title = spec.Title()
+	r.Description = spec.Description()
+	r.Author = spec.Author()
+
+	stories := spec.Stories()
+	sort.Slice(stories, func(i, j int) bool {
+		return stories[i].Slug().Story() < stories[j].Slug().Story()
+	})
+
+	for _, s := range stories {
+		r.Stories = append(r.Stories, newStory(s, statuses))
+	}
+
+	return r
+}
+
+func newStory(s specification.Story, statuses map[specification.Slug]*flow.Status) story {
+	scenarios := s.Scenarios()
+	sort.Slice(scenarios, func(i, j int) bool {
+		return scenarios[i].Slug().Scenario() < scenarios[j].Slug().Scenario()
+	})
+
+	result := story{
+		Slug:        s.Slug().Story(),
+		Description: s.Description(),
+		AsA:         s.AsA(),
+		InOrderTo:   s.InOrderTo(),
+		WantTo:      s.WantTo(),
+		Scenarios:   make([]scenario, 0, len(scenarios)),
+	}
+
+	for _, sc := range scenarios {
+		result.Scenarios = append(result.Scenarios, newScenario(sc, statuses[sc.Slug()]))
+	}
+
+	return result
+}
+
+var stageOrder = map[specification.Stage]int{
+	specification.Given: 0,
+	specification.When:  1,
+	specification.Then:  2,
+}
+
+func newScenario(sc specification.Scenario, status *flow.Status) scenario {
+	theses := sc.Theses()
+	sort.Slice(theses, func(i, j int) bool {
+		a, b := theses[i], theses[j]
+		if a.Stage() != b.Stage() {
+			return stageOrder[a.Stage()] < stageOrder[b.Stage()]
+		}
+
+		return a.Slug().Thesis() < b.Slug().Thesis()
+	})
+
+	thesisStatuses := make(map[string]*flow.ThesisStatus)
+
+	result := scenario{
+		Slug:        sc.Slug().Scenario(),
+		Description: sc.Description(),
+		State:       stateOf(flow.NotExecuted),
+		Theses:      make([]thesis, 0, len(theses)),
+	}
+
+	if status != nil {
+		result.State = stateOf(status.State())
+
+		for _, ts := range status.ThesisStatuses() {
+			thesisStatuses[ts.ThesisSlug()] = ts
+		}
+	}
+
+	for _, t := range theses {
+		result.Theses = append(result.Theses, newThesis(t, thesisStatuses[t.Slug().Thesis()]))
+	}
+
+	return result
+}
+
+func newThesis(t specification.Thesis, status *flow.ThesisStatus) thesis {
+	result := thesis{
+		Slug:      t.Slug().Thesis(),
+		Stage:     t.Stage().String(),
+		Behavior:  t.Behavior(),
+		State:     stateOf(flow.NotExecuted),
+		Request:   formatRequest(t.HTTP().Request()),
+		Response:  formatResponse(t.HTTP().Response()),
+		Assertion: formatAssertion(t.Assertion()),
+	}
+
+	if status != nil {
+		result.State = stateOf(status.State())
+		result.Errors = status.OccurredErrs()
+
+		if x := status.Exchange(); !x.IsZero() {
+			result.Request = formatSentRequest(x)
+			result.Received = formatReceivedResponse(x)
+		}
+	}
+
+	return result
+}
+
+func stateOf(s flow.State) string {
+	if s == flow.NoState {
+		return string(flow.NotExecuted)
+	}
+
+	return string(s)
+}
+
+func stateClass(state string) string {
+	return strings.ReplaceAll(state, " ", "-")
+}
+
+func formatRequest(r specification.HTTPRequest) string {
+	if r.IsZero() {
+		return ""
+	}
+
+	var b strings.Builder
+
+	_, _ = fmt.Fprintf(&b, "%s %s", r.Method(), r.URL())
+
+	if r.ContentType() != specification.NoContentType {
+		_, _ = fmt.Fprintf(&b, "\nContent-Type: %s", r.ContentType())
+	}
+
+	if body := r.Body(); len(body) > 0 {
+		raw, err := json.MarshalIndent(body, "", "  ")
+		if err == nil {
+			_, _ = fmt.Fprintf(&b, "\n\n%s", raw)
+		}
+	}
+
+	return b.String()
+}
+
+func formatSentRequest(x pipeline.HTTPExchange) string {
+	var b strings.Builder
+
+	_, _ = fmt.Fprintf(&b, "%s %s", x.Method, x.URL)
+
+	if x.RequestContentType != "" {
+		_, _ = fmt.Fprintf(&b, "\nContent-Type: %s", x.RequestContentType)
+	}
+
+	if x.RequestBody != "" {
+		_, _ = fmt.Fprintf(&b, "\n\n%s", indentJSON(x.RequestBody))
+	}
+
+	return b.String()
+}
+
+func formatReceivedResponse(x pipeline.HTTPExchange) string {
+	if x.ResponseCode == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	_, _ = fmt.Fprintf(&b, "%d %s", x.ResponseCode, http.StatusText(x.ResponseCode))
+
+	if x.ResponseContentType != "" {
+		_, _ = fmt.Fprintf(&b, "\nContent-Type: %s", x.ResponseContentType)
+	}
+
+	if x.ResponseBody != "" {
+		_, _ = fmt.Fprintf(&b, "\n\n%s", indentJSON(x.ResponseBody))
+	}
+
+	return b.String()
+}
+
+// indentJSON returns body as is if it isn't JSON.
+func indentJSON(body string) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(body), "", "  "); err != nil {
+		return body
+	}
+
+	return b.String()
+}
+
+func formatResponse(r specification.HTTPResponse) string {
+	if r.IsZero() {
+		return ""
+	}
+
+	var lines []string
+
+	if codes := r.AllowedCodes(); len(codes) > 0 {
+		values := make([]string, 0, len(codes))
+		for _, c := range codes {
+			values = append(values, fmt.Sprint(c))
+		}
+
+		lines = append(lines, "Allowed codes: "+strings.Join(values, ", "))
+	}
+
+	if r.AllowedContentType() != specification.NoContentType {
+		lines = append(lines, "Allowed content type: "+r.AllowedContentType().String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func formatAssertion(a specification.Assertion) string {
+	if a.IsZero() {
+		return ""
+	}
+
+	lines := []string{a.Method().String()}
+
+	for _, assert := range a.Asserts() {
+		lines = append(lines, fmt.Sprintf("%s = %v", assert.Actual(), assert.Expected()))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+package html_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/report/html"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestWriteFlowReport(t *testing.T) {
+	t.Parallel()
+
+	spec := (&specification.Builder{}).
+		WithTitle("Horns & hooves").
+		WithAuthor("Ostap Bender").
+		WithStory("buyHorns", func(b *specification.StoryBuilder) {
+			b.
+				WithAsA("customer").
+				WithInOrderTo("sell horns").
+				WithWantTo("buy them first")
+			b.WithScenario("buyOne", func(b *specification.ScenarioBuilder) {
+				b.WithDescription("Customer buys one pair of horns")
+				b.WithThesis("addToCart", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.When, "add horns to the cart")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.
+								WithMethod(specification.POST).
+								WithURL("https://api.hornsandhooves.com/cart").
+								WithContentType(specification.ApplicationJSON).
+								WithBody(map[string]interface{}{"count": 1})
+						})
+						b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+							b.WithAllowedCodes([]int{201})
+						})
+					})
+				})
+				b.WithThesis("checkCart", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Then, "cart has horns")
+				})
+			})
+			b.WithScenario("buyNone", func(b *specification.ScenarioBuilder) {
+				b.WithThesis("open", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Given, "shop is opened")
+				})
+			})
+		}).
+		ErrlessBuild()
+
+	f := flow.FromStatuses(
+		"flow",
+		"pipe",
+		flow.NewStatus(
+			specification.NewScenarioSlug("buyHorns", "buyOne"),
+			flow.Failed,
+			flow.NewThesisStatusWithExchange("addToCart", flow.Passed, pipeline.HTTPExchange{
+				Method:              "POST",
+				URL:                 "https://api.hornsandhooves.com/cart?session=42",
+				RequestContentType:  "application/json",
+				RequestBody:         `{"count":1}`,
+				ResponseCode:        201,
+				ResponseContentType: "application/json",
+				ResponseBody:        `{"cartId":7}`,
+			}),
+			flow.NewThesisStatus("checkCart", flow.Failed, "expected <script>2</script>"),
+		),
+	)
+
+	var buf bytes.Buffer
+
+	err := html.NewReporter().WriteFlowReport(&buf, f, spec)
+	require.NoError(t, err)
+
+	report := buf.String()
+
+	for _, expected := range []string{
+		"<title>Horns &amp; hooves</title>",
+		"specification by Ostap Bender",
+		"As a customer",
+		"In order to sell horns",
+		"I want to buy them first",
+		"Customer buys one pair of horns",
+		`Scenario buyOne <span class="state state-failed">failed</span>`,
+		`Scenario buyNone <span class="state state-not-executed">not executed</span>`,
+		"POST https://api.hornsandhooves.com/cart?session=42",
+		"201 Created",
+		"&#34;cartId&#34;: 7",
+		"Allowed codes: 201",
+		"expected &lt;script&gt;2&lt;/script&gt;",
+	} {
+		require.Contains(t, report, expected)
+	}
+
+	require.Less(
+		t,
+		bytes.Index(buf.Bytes(), []byte("add horns to the cart")),
+		bytes.Index(buf.Bytes(), []byte("cart has horns")),
+		"when thesis should go before then thesis",
+	)
+}
@@ -0,0 +1,100 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"io"
+	"sort"
+
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+const Format = "json"
+
+// Reporter renders flow statuses as JSON sorted by slugs.
+type Reporter struct{}
+
+func NewReporter() Reporter {
+	return Reporter{}
+}
+
+func (r Reporter) Format() string {
+	return Format
+}
+
+func (r Reporter) ContentType() string {
+	return "application/json"
+}
+
+// WriteFlowReport renders flow only, so the specification
+// is not required.
+func (r Reporter) WriteFlowReport(w io.Writer, f *flow.Flow, _ *specification.Specification) error {
+	encoder := stdjson.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(newReport(f))
+}
+
+type (
+	report struct {
+		FlowID     string     `json:"flowId"`
+		PipelineID string     `json:"pipelineId"`
+		State      string     `json:"state"`
+		Scenarios  []scenario `json:"scenarios"`
+	}
+
+	scenario struct {
+		Slug   string   `json:"slug"`
+		State  string   `json:"state"`
+		Theses []thesis `json:"theses"`
+	}
+
+	thesis struct {
+		Slug   string   `json:"slug"`
+		State  string   `json:"state"`
+		Errors []string `json:"errors,omitempty"`
+	}
+)
+
+func newReport(f *flow.Flow) report {
+	statuses := f.Statuses()
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Slug().String() < statuses[j].Slug().String()
+	})
+
+	r := report{
+		FlowID:     f.ID(),
+		PipelineID: f.PipelineID(),
+		State:      f.OverallState().String(),
+		Scenarios:  make([]scenario, 0, len(statuses)),
+	}
+
+	for _, s := range statuses {
+		r.Scenarios = append(r.Scenarios, newScenario(s))
+	}
+
+	return r
+}
+
+func newScenario(s *flow.Status) scenario {
+	theses := s.ThesisStatuses()
+	sort.Slice(theses, func(i, j int) bool {
+		return theses[i].ThesisSlug() < theses[j].ThesisSlug()
+	})
+
+	result := scenario{
+		Slug:   s.Slug().String(),
+		State:  s.State().String(),
+		Theses: make([]thesis, 0, len(theses)),
+	}
+
+	for _, t := range theses {
+		result.Theses = append(result.Theses, thesis{
+			Slug:   t.ThesisSlug(),
+			State:  t.State().String(),
+			Errors: t.OccurredErrs(),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package json_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/report/json"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestWriteFlowReport(t *testing.T) {
+	t.Parallel()
+
+	f := flow.FromStatuses(
+		"flow",
+		"pipe",
+		flow.NewStatus(
+			specification.NewScenarioSlug("foo", "bar"),
+			flow.Failed,
+			flow.NewThesisStatus("baz", flow.Failed, "expected 200, got 500"),
+			flow.NewThesisStatus("bad", flow.Passed),
+		),
+		flow.NewStatus(
+			specification.NewScenarioSlug("foo", "ban"),
+			flow.Passed,
+		),
+	)
+
+	var buf bytes.Buffer
+
+	err := json.NewReporter().WriteFlowReport(&buf, f, nil)
+	require.NoError(t, err)
+
+	require.JSONEq(t, `{
+		"flowId": "flow",
+		"pipelineId": "pipe",
+		"state": "failed",
+		"scenarios": [
+			{"slug": "foo.ban", "state": "passed", "theses": []},
+			{
+				"slug": "foo.bar",
+				"state": "failed",
+				"theses": [
+					{"slug": "bad", "state": "passed"},
+					{"slug": "baz", "state": "failed", "errors": ["expected 200, got 500"]}
+				]
+			}
+		]
+	}`, buf.String())
+}
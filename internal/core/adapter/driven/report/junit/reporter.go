@@ -0,0 +1,190 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+const Format = "junit"
+
+// Reporter renders flow as JUnit XML. Stories are
+// mapped to test suites, scenarios to test cases and
+// errors occurred in theses to failure messages.
+type Reporter struct{}
+
+func NewReporter() Reporter {
+	return Reporter{}
+}
+
+func (r Reporter) Format() string {
+	return Format
+}
+
+func (r Reporter) ContentType() string {
+	return "application/xml"
+}
+
+// WriteFlowReport renders flow only, so the specification
+// is not required.
+func (r Reporter) WriteFlowReport(w io.Writer, f *flow.Flow, _ *specification.Specification) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(newTestSuites(f)); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+type (
+	testSuites struct {
+		XMLName  xml.Name    `xml:"testsuites"`
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Errors   int         `xml:"errors,attr"`
+		Skipped  int         `xml:"skipped,attr"`
+		Suites   []testSuite `xml:"testsuite"`
+	}
+
+	testSuite struct {
+		Name     string     `xml:"name,attr"`
+		Tests    int        `xml:"tests,attr"`
+		Failures int        `xml:"failures,attr"`
+		Errors   int        `xml:"errors,attr"`
+		Skipped  int        `xml:"skipped,attr"`
+		Cases    []testCase `xml:"testcase"`
+	}
+
+	testCase struct {
+		Name      string   `xml:"name,attr"`
+		ClassName string   `xml:"classname,attr"`
+		Failure   *message `xml:"failure,omitempty"`
+		Error     *message `xml:"error,omitempty"`
+		Skipped   *message `xml:"skipped,omitempty"`
+	}
+
+	message struct {
+		Message string `xml:"message,attr,omitempty"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+func newTestSuites(f *flow.Flow) testSuites {
+	suites := testSuites{
+		Name: f.ID(),
+	}
+
+	for _, statuses := range statusesByStory(f.Statuses()) {
+		suite := newTestSuite(statuses)
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	return suites
+}
+
+func statusesByStory(statuses []*flow.Status) [][]*flow.Status {
+	sort.Slice(statuses, func(i, j int) bool {
+		a, b := statuses[i].Slug(), statuses[j].Slug()
+		if a.Story() != b.Story() {
+			return a.Story() < b.Story()
+		}
+
+		return a.Scenario() < b.Scenario()
+	})
+
+	var grouped [][]*flow.Status
+
+	for i, s := range statuses {
+		if i == 0 || statuses[i-1].Slug().Story() != s.Slug().Story() {
+			grouped = append(grouped, nil)
+		}
+
+		grouped[len(grouped)-1] = append(grouped[len(grouped)-1], s)
+	}
+
+	return grouped
+}
+
+func newTestSuite(statuses []*flow.Status) testSuite {
+	suite := testSuite{
+		Name:  statuses[0].Slug().Story(),
+		Tests: len(statuses),
+		Cases: make([]testCase, 0, len(statuses)),
+	}
+
+	for _, s := range statuses {
+		c := newTestCase(s)
+
+		switch {
+		case c.Failure != nil:
+			suite.Failures++
+		case c.Error != nil:
+			suite.Errors++
+		case c.Skipped != nil:
+			suite.Skipped++
+		}
+
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	return suite
+}
+
+func newTestCase(status *flow.Status) testCase {
+	c := testCase{
+		Name:      status.Slug().Scenario(),
+		ClassName: status.Slug().Story(),
+	}
+
+	switch status.State() {
+	case flow.Passed:
+	case flow.Failed:
+		c.Failure = newMessage("scenario failed", status)
+	case flow.Crashed:
+		c.Error = newMessage("scenario crashed", status)
+	default:
+		c.Skipped = &message{Message: fmt.Sprintf("scenario %s", status.State())}
+	}
+
+	return c
+}
+
+func newMessage(summary string, status *flow.Status) *message {
+	theses := status.ThesisStatuses()
+
+	sort.Slice(theses, func(i, j int) bool {
+		return theses[i].ThesisSlug() < theses[j].ThesisSlug()
+	})
+
+	var text strings.Builder
+
+	for _, t := range theses {
+		for _, err := range t.OccurredErrs() {
+			_, _ = fmt.Fprintf(&text, "%s: %s\n", t.ThesisSlug(), err)
+		}
+	}
+
+	return &message{
+		Message: summary,
+		Text:    text.String(),
+	}
+}
@@ -0,0 +1,91 @@
+package junit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/report/junit"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestWriteFlowReport(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name           string
+		GivenFlow      *flow.Flow
+		ExpectedReport string
+	}{
+		{
+			Name:      "empty_flow",
+			GivenFlow: flow.FromStatuses("flow", "pipe"),
+			ExpectedReport: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="flow" tests="0" failures="0" errors="0" skipped="0"></testsuites>
+`,
+		},
+		{
+			Name: "stories_with_passed_failed_crashed_and_canceled_scenarios",
+			GivenFlow: flow.FromStatuses(
+				"flow",
+				"pipe",
+				flow.NewStatus(
+					specification.NewScenarioSlug("foo", "bar"),
+					flow.Failed,
+					flow.NewThesisStatus("baz", flow.Failed, "expected 200, got 500"),
+					flow.NewThesisStatus("bad", flow.Passed),
+				),
+				flow.NewStatus(
+					specification.NewScenarioSlug("foo", "ban"),
+					flow.Passed,
+					flow.NewThesisStatus("baz", flow.Passed),
+				),
+				flow.NewStatus(
+					specification.NewScenarioSlug("koo", "boo"),
+					flow.Crashed,
+					flow.NewThesisStatus("zoo", flow.Crashed, "connection refused"),
+				),
+				flow.NewStatus(
+					specification.NewScenarioSlug("koo", "doo"),
+					flow.Canceled,
+					flow.NewThesisStatus("zoo", flow.Canceled),
+				),
+			),
+			ExpectedReport: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="flow" tests="4" failures="1" errors="1" skipped="1">
+  <testsuite name="foo" tests="2" failures="1" errors="0" skipped="0">
+    <testcase name="ban" classname="foo"></testcase>
+    <testcase name="bar" classname="foo">
+      <failure message="scenario failed">baz: expected 200, got 500&#xA;</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="koo" tests="2" failures="0" errors="1" skipped="1">
+    <testcase name="boo" classname="koo">
+      <error message="scenario crashed">zoo: connection refused&#xA;</error>
+    </testcase>
+    <testcase name="doo" classname="koo">
+      <skipped message="scenario canceled"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			err := junit.NewReporter().WriteFlowReport(&buf, c.GivenFlow, nil)
+			require.NoError(t, err)
+
+			require.Equal(t, c.ExpectedReport, buf.String())
+		})
+	}
+}
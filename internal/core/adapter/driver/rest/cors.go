@@ -1,6 +1,12 @@
 package rest
 
-import "github.com/go-chi/cors"
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/cors"
+)
 
 const maxAge = 300
 
@@ -14,3 +20,51 @@ func CORSMiddleware(allowedOrigins []string) Middleware {
 		MaxAge:           maxAge,
 	}).Handler
 }
+
+// CheckOrigin returns the origin check of WebSocket upgrades
+// allowing the same origins as CORSMiddleware: exact origins,
+// origins with one * wildcard or any origin with *.
+//
+// Requests without Origin header are sent by non-browser clients
+// and same-origin requests are always allowed. Unlike CORS, empty
+// allowed origins allow only same-origin WebSocket connections.
+func CheckOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	origins := make([]string, 0, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		origins = append(origins, strings.ToLower(origin))
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+
+		origin = strings.ToLower(origin)
+
+		for _, allowed := range origins {
+			if originMatches(allowed, origin) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func originMatches(allowed, origin string) bool {
+	i := strings.IndexByte(allowed, '*')
+	if i < 0 {
+		return allowed == origin
+	}
+
+	prefix, suffix := allowed[:i], allowed[i+1:]
+
+	return len(origin) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(origin, prefix) &&
+		strings.HasSuffix(origin, suffix)
+}
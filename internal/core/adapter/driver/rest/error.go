@@ -30,6 +30,14 @@ func UnprocessableEntity(slug string, err error, w http.ResponseWriter, r *http.
 	httpRespondWithError(err, slug, w, r, http.StatusUnprocessableEntity)
 }
 
+func NotImplemented(slug string, err error, w http.ResponseWriter, r *http.Request) {
+	httpRespondWithError(err, slug, w, r, http.StatusNotImplemented)
+}
+
+func ServiceUnavailable(slug string, err error, w http.ResponseWriter, r *http.Request) {
+	httpRespondWithError(err, slug, w, r, http.StatusServiceUnavailable)
+}
+
 func InternalServerError(slug string, err error, w http.ResponseWriter, r *http.Request) {
 	httpRespondWithError(err, slug, w, r, http.StatusInternalServerError)
 }
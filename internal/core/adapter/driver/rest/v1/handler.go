@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
 
 	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
 	"github.com/harpyd/thestis/internal/core/app"
@@ -11,13 +12,18 @@ import (
 )
 
 type handler struct {
-	app    *app.Application
-	logger service.Logger
+	app      *app.Application
+	logger   service.Logger
+	upgrader websocket.Upgrader
 }
 
+// NewHandler returns handler of the v1 API. Pipeline sockets
+// are upgraded only from allowedOrigins, the same as allowed
+// origins of CORS.
 func NewHandler(
 	application *app.Application,
 	logger service.Logger,
+	allowedOrigins []string,
 	middlewares ...rest.Middleware,
 ) http.Handler {
 	r := chi.NewRouter()
@@ -28,5 +34,8 @@ func NewHandler(
 	return HandlerFromMux(handler{
 		app:    application,
 		logger: logger,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: rest.CheckOrigin(allowedOrigins),
+		},
 	}, r)
 }
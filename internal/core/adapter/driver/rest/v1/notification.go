@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func (h handler) CreateSubscription(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	cmd, ok := decodeCreateSubscriptionCommand(w, r, testCampaignID, uuid.New().String())
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.CreateSubscription.Handle(r.Context(), cmd)
+	if err == nil {
+		w.Header().Set(
+			"Location",
+			fmt.Sprintf("/test-campaigns/%s/subscriptions/%s", cmd.TestCampaignID, cmd.SubscriptionID),
+		)
+		w.WriteHeader(http.StatusCreated)
+
+		return
+	}
+
+	renderSubscriptionError(w, r, err)
+}
+
+func (h handler) GetSubscriptions(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	qry, ok := decodeSubscriptionsQuery(w, r, testCampaignID)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.app.Queries.Subscriptions.Handle(r.Context(), qry)
+	if err == nil {
+		renderSubscriptionsResponse(w, r, subscriptions)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) RemoveSubscription(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	subscriptionID string,
+) {
+	cmd, ok := decodeRemoveSubscriptionCommand(w, r, testCampaignID, subscriptionID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.RemoveSubscription.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	renderSubscriptionError(w, r, err)
+}
+
+func (h handler) GetNotifications(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	params GetNotificationsParams,
+) {
+	qry, ok := decodeNotificationsQuery(w, r, testCampaignID, params)
+	if !ok {
+		return
+	}
+
+	notifications, err := h.app.Queries.Notifications.Handle(r.Context(), qry)
+	if err == nil {
+		renderNotificationsResponse(w, r, notifications)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func renderSubscriptionError(w http.ResponseWriter, r *http.Request, err error) {
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeTestCampaign), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, testcampaign.ErrSubscriptionNotFound) {
+		rest.NotFound(string(ErrorSlugSubscriptionNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, testcampaign.ErrInvalidSubscriptionURL) ||
+		errors.Is(err, testcampaign.ErrInvalidSubscriptionEvent) ||
+		errors.Is(err, testcampaign.ErrEmptySubscriptionSecret) ||
+		errors.Is(err, testcampaign.ErrSubscriptionHostNotAllowed) {
+		rest.BadRequest(string(ErrorSlugInvalidSubscription), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+func decodeCreateSubscriptionCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	subscriptionID string,
+) (cmd command.CreateSubscription, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	var rb CreateSubscriptionRequest
+
+	if ok = decode(w, r, &rb); !ok {
+		return
+	}
+
+	cmd = command.CreateSubscription{
+		SubscriptionID: subscriptionID,
+		TestCampaignID: testCampaignID,
+		CreatedByID:    user.UUID,
+		URL:            rb.Url,
+		Secret:         rb.Secret,
+	}
+
+	if rb.Filter != nil {
+		cmd.Filter = testcampaign.Event(*rb.Filter)
+	}
+
+	return cmd, true
+}
+
+func decodeRemoveSubscriptionCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	subscriptionID string,
+) (cmd command.RemoveSubscription, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return command.RemoveSubscription{
+		SubscriptionID: subscriptionID,
+		TestCampaignID: testCampaignID,
+		RemovedByID:    user.UUID,
+	}, true
+}
+
+func decodeSubscriptionsQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+) (qry query.Subscriptions, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.Subscriptions{
+		TestCampaignID: testCampaignID,
+		UserID:         user.UUID,
+	}, true
+}
+
+func decodeNotificationsQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	params GetNotificationsParams,
+) (qry query.Notifications, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	qry = query.Notifications{
+		TestCampaignID: testCampaignID,
+		UserID:         user.UUID,
+	}
+
+	if params.State != nil {
+		qry.State = string(*params.State)
+	}
+
+	return qry, true
+}
+
+func renderSubscriptionsResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	subscriptions []query.SubscriptionModel,
+) {
+	response := SubscriptionsResponse{
+		Subscriptions: make([]SubscriptionResponse, 0, len(subscriptions)),
+	}
+
+	for _, s := range subscriptions {
+		response.Subscriptions = append(response.Subscriptions, SubscriptionResponse{
+			Id:     s.ID,
+			Url:    s.URL,
+			Filter: NotificationFilter(s.Filter),
+		})
+	}
+
+	render.Respond(w, r, response)
+}
+
+func renderNotificationsResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	notifications []query.NotificationModel,
+) {
+	response := NotificationsResponse{
+		Notifications: make([]NotificationResponse, 0, len(notifications)),
+	}
+
+	for _, n := range notifications {
+		response.Notifications = append(response.Notifications, NotificationResponse{
+			Id:             n.ID,
+			SubscriptionId: n.SubscriptionID,
+			PipelineId:     n.PipelineID,
+			FlowId:         n.FlowID,
+			Url:            n.URL,
+			Event:          NotificationEvent(n.Event),
+			State:          NotificationState(n.State),
+			Attempts:       n.Attempts,
+			LastError:      stringOrNil(n.LastError),
+			CreatedAt:      n.CreatedAt,
+			UpdatedAt:      n.UpdatedAt,
+		})
+	}
+
+	render.Respond(w, r, response)
+}
@@ -13,6 +13,18 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Returns report of the flow with such ID.
+	// (GET /flows/{flowId}/report)
+	GetFlowReport(w http.ResponseWriter, r *http.Request, flowId string, params GetFlowReportParams)
+	// Asynchronously starts pipeline of test campaign's active specification by webhook.
+	// (POST /hooks/{testCampaignId})
+	TriggerPipeline(w http.ResponseWriter, r *http.Request, testCampaignId string, params TriggerPipelineParams)
+	// Returns queued pipelines of the user.
+	// (GET /pipelines/queue)
+	GetPipelineQueue(w http.ResponseWriter, r *http.Request)
+	// Removes queued pipeline before it starts.
+	// (DELETE /pipelines/queue/{pipelineId})
+	DequeuePipeline(w http.ResponseWriter, r *http.Request, pipelineId string)
 	// Returns pipeline with such ID.
 	// (GET /pipelines/{pipelineId})
 	GetPipeline(w http.ResponseWriter, r *http.Request, pipelineId string)
@@ -22,30 +34,93 @@ type ServerInterface interface {
 	// Cancels pipeline with such ID.
 	// (PUT /pipelines/{pipelineId}/canceled)
 	CancelPipeline(w http.ResponseWriter, r *http.Request, pipelineId string)
+	// Streams steps of the running pipeline with such ID.
+	// (GET /pipelines/{pipelineId}/events)
+	GetPipelineEvents(w http.ResponseWriter, r *http.Request, pipelineId string)
+	// Pauses pipeline with such ID before its next theses.
+	// (PUT /pipelines/{pipelineId}/paused)
+	PausePipeline(w http.ResponseWriter, r *http.Request, pipelineId string)
+	// Resumes paused pipeline with such ID.
+	// (PUT /pipelines/{pipelineId}/resumed)
+	ResumePipeline(w http.ResponseWriter, r *http.Request, pipelineId string)
+	// Opens WebSocket to stream steps and control pipeline with such ID.
+	// (GET /pipelines/{pipelineId}/ws)
+	ConnectPipelineSocket(w http.ResponseWriter, r *http.Request, pipelineId string)
+	// Returns JSON Schema of the specification YAML format.
+	// (GET /schemas/specification.json)
+	GetSpecificationSchema(w http.ResponseWriter, r *http.Request)
+	// Checks style and quality of the specification source.
+	// (POST /specifications/lint)
+	LintSpecification(w http.ResponseWriter, r *http.Request)
 	// Returns specification with such ID.
 	// (GET /specifications/{specificationId})
 	GetSpecification(w http.ResponseWriter, r *http.Request, specificationId string)
+	// Makes specification with such ID active in its test campaign.
+	// (PUT /specifications/{specificationId}/active)
+	ActivateSpecification(w http.ResponseWriter, r *http.Request, specificationId string)
+	// Returns structural diff between two specifications.
+	// (GET /specifications/{specificationId}/diff)
+	GetSpecificationDiff(w http.ResponseWriter, r *http.Request, specificationId string, params GetSpecificationDiffParams)
+	// Returns specification with such ID as YAML source.
+	// (GET /specifications/{specificationId}/export)
+	ExportSpecification(w http.ResponseWriter, r *http.Request, specificationId string, params ExportSpecificationParams)
 	// Returns test campaigns.
 	// (GET /test-campaigns)
-	GetTestCampaigns(w http.ResponseWriter, r *http.Request)
+	GetTestCampaigns(w http.ResponseWriter, r *http.Request, params GetTestCampaignsParams)
 	// Creates test campaign for testing services logic using BDD specification style.
 	// (POST /test-campaigns)
 	CreateTestCampaign(w http.ResponseWriter, r *http.Request)
 	// Removes test campaign with such ID.
 	// (DELETE /test-campaigns/{testCampaignId})
-	RemoveTestCampaign(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	RemoveTestCampaign(w http.ResponseWriter, r *http.Request, testCampaignId string, params RemoveTestCampaignParams)
 	// Returns test campaign with such ID.
 	// (GET /test-campaigns/{testCampaignId})
 	GetTestCampaign(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Updates view name and summary of test campaign with such ID.
+	// (PATCH /test-campaigns/{testCampaignId})
+	UpdateTestCampaign(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Returns delivery log of test campaign notifications.
+	// (GET /test-campaigns/{testCampaignId}/notifications)
+	GetNotifications(w http.ResponseWriter, r *http.Request, testCampaignId string, params GetNotificationsParams)
 	// Asynchronously starts pipeline of test campaign's active specification.
 	// (POST /test-campaigns/{testCampaignId}/pipeline)
 	StartPipeline(w http.ResponseWriter, r *http.Request, testCampaignId string)
 	// Returns pipeline history.
 	// (GET /test-campaigns/{testCampaignId}/pipelines)
-	GetPipelineHistory(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	GetPipelineHistory(w http.ResponseWriter, r *http.Request, testCampaignId string, params GetPipelineHistoryParams)
+	// Returns schedules of test campaign with such ID.
+	// (GET /test-campaigns/{testCampaignId}/schedules)
+	GetSchedules(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Creates schedule of pipeline runs of test campaign with such ID.
+	// (POST /test-campaigns/{testCampaignId}/schedules)
+	CreateSchedule(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Removes schedule with such ID.
+	// (DELETE /test-campaigns/{testCampaignId}/schedules/{scheduleId})
+	RemoveSchedule(w http.ResponseWriter, r *http.Request, testCampaignId string, scheduleId string)
+	// Updates schedule with such ID.
+	// (PATCH /test-campaigns/{testCampaignId}/schedules/{scheduleId})
+	UpdateSchedule(w http.ResponseWriter, r *http.Request, testCampaignId string, scheduleId string)
 	// Loads specification to test campaign.
 	// (POST /test-campaigns/{testCampaignId}/specification)
 	LoadSpecification(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Returns specification history.
+	// (GET /test-campaigns/{testCampaignId}/specifications)
+	GetSpecificationHistory(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Returns subscriptions of test campaign with such ID.
+	// (GET /test-campaigns/{testCampaignId}/subscriptions)
+	GetSubscriptions(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Subscribes URL to notifications about completed flows of test campaign with such ID.
+	// (POST /test-campaigns/{testCampaignId}/subscriptions)
+	CreateSubscription(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Removes subscription with such ID.
+	// (DELETE /test-campaigns/{testCampaignId}/subscriptions/{subscriptionId})
+	RemoveSubscription(w http.ResponseWriter, r *http.Request, testCampaignId string, subscriptionId string)
+	// Revokes webhook trigger token of test campaign with such ID.
+	// (DELETE /test-campaigns/{testCampaignId}/trigger-token)
+	RevokeTriggerToken(w http.ResponseWriter, r *http.Request, testCampaignId string)
+	// Issues new webhook trigger token of test campaign with such ID.
+	// (PUT /test-campaigns/{testCampaignId}/trigger-token)
+	IssueTriggerToken(w http.ResponseWriter, r *http.Request, testCampaignId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -57,23 +132,742 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
 
+// GetFlowReport operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "flowId" -------------
+	var flowId string
+
+	err = runtime.BindStyledParameter("simple", false, "flowId", chi.URLParam(r, "flowId"), &flowId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flowId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlowReportParams
+
+	// ------------- Required query parameter "format" -------------
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "download" -------------
+	if paramValue := r.URL.Query().Get("download"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "download", r.URL.Query(), &params.Download)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "download", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlowReport(w, r, flowId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// TriggerPipeline operation middleware
+func (siw *ServerInterfaceWrapper) TriggerPipeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TriggerPipelineParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Thestis-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Thestis-Timestamp")]; found {
+		var XThestisTimestamp int64
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Thestis-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Thestis-Timestamp", runtime.ParamLocationHeader, valueList[0], &XThestisTimestamp)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Thestis-Timestamp", Err: err})
+			return
+		}
+
+		params.XThestisTimestamp = XThestisTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Thestis-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Thestis-Timestamp", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Thestis-Signature" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Thestis-Signature")]; found {
+		var XThestisSignature string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Thestis-Signature", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Thestis-Signature", runtime.ParamLocationHeader, valueList[0], &XThestisSignature)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Thestis-Signature", Err: err})
+			return
+		}
+
+		params.XThestisSignature = XThestisSignature
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Thestis-Signature is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Thestis-Signature", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TriggerPipeline(w, r, testCampaignId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPipelineQueue operation middleware
+func (siw *ServerInterfaceWrapper) GetPipelineQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPipelineQueue(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DequeuePipeline operation middleware
+func (siw *ServerInterfaceWrapper) DequeuePipeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DequeuePipeline(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetPipeline operation middleware
 func (siw *ServerInterfaceWrapper) GetPipeline(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "pipelineId" -------------
-	var pipelineId string
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPipeline(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RestartPipeline operation middleware
+func (siw *ServerInterfaceWrapper) RestartPipeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestartPipeline(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CancelPipeline operation middleware
+func (siw *ServerInterfaceWrapper) CancelPipeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelPipeline(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPipelineEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetPipelineEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPipelineEvents(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PausePipeline operation middleware
+func (siw *ServerInterfaceWrapper) PausePipeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PausePipeline(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ResumePipeline operation middleware
+func (siw *ServerInterfaceWrapper) ResumePipeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResumePipeline(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ConnectPipelineSocket operation middleware
+func (siw *ServerInterfaceWrapper) ConnectPipelineSocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "pipelineId" -------------
+	var pipelineId string
+
+	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConnectPipelineSocket(w, r, pipelineId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSpecificationSchema operation middleware
+func (siw *ServerInterfaceWrapper) GetSpecificationSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSpecificationSchema(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// LintSpecification operation middleware
+func (siw *ServerInterfaceWrapper) LintSpecification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LintSpecification(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSpecification operation middleware
+func (siw *ServerInterfaceWrapper) GetSpecification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "specificationId" -------------
+	var specificationId string
+
+	err = runtime.BindStyledParameter("simple", false, "specificationId", chi.URLParam(r, "specificationId"), &specificationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specificationId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSpecification(w, r, specificationId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ActivateSpecification operation middleware
+func (siw *ServerInterfaceWrapper) ActivateSpecification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "specificationId" -------------
+	var specificationId string
+
+	err = runtime.BindStyledParameter("simple", false, "specificationId", chi.URLParam(r, "specificationId"), &specificationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specificationId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ActivateSpecification(w, r, specificationId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSpecificationDiff operation middleware
+func (siw *ServerInterfaceWrapper) GetSpecificationDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "specificationId" -------------
+	var specificationId string
+
+	err = runtime.BindStyledParameter("simple", false, "specificationId", chi.URLParam(r, "specificationId"), &specificationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specificationId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSpecificationDiffParams
+
+	// ------------- Required query parameter "against" -------------
+	if paramValue := r.URL.Query().Get("against"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "against"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "against", r.URL.Query(), &params.Against)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "against", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSpecificationDiff(w, r, specificationId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ExportSpecification operation middleware
+func (siw *ServerInterfaceWrapper) ExportSpecification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "specificationId" -------------
+	var specificationId string
+
+	err = runtime.BindStyledParameter("simple", false, "specificationId", chi.URLParam(r, "specificationId"), &specificationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specificationId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportSpecificationParams
+
+	// ------------- Optional query parameter "download" -------------
+	if paramValue := r.URL.Query().Get("download"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "download", r.URL.Query(), &params.Download)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "download", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportSpecification(w, r, specificationId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTestCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) GetTestCampaigns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTestCampaignsParams
+
+	// ------------- Optional query parameter "search" -------------
+	if paramValue := r.URL.Query().Get("search"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "search", r.URL.Query(), &params.Search)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "search", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := r.URL.Query().Get("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTestCampaigns(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateTestCampaign operation middleware
+func (siw *ServerInterfaceWrapper) CreateTestCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTestCampaign(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RemoveTestCampaign operation middleware
+func (siw *ServerInterfaceWrapper) RemoveTestCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveTestCampaignParams
+
+	// ------------- Optional query parameter "archive" -------------
+	if paramValue := r.URL.Query().Get("archive"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "archive", r.URL.Query(), &params.Archive)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "archive", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveTestCampaign(w, r, testCampaignId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTestCampaign operation middleware
+func (siw *ServerInterfaceWrapper) GetTestCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTestCampaign(w, r, testCampaignId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdateTestCampaign operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTestCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTestCampaign(w, r, testCampaignId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsParams
+
+	// ------------- Optional query parameter "state" -------------
+	if paramValue := r.URL.Query().Get("state"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNotifications(w, r, testCampaignId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// StartPipeline operation middleware
+func (siw *ServerInterfaceWrapper) StartPipeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
 
-	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
 		return
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPipeline(w, r, pipelineId)
+		siw.Handler.StartPipeline(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -83,23 +877,92 @@ func (siw *ServerInterfaceWrapper) GetPipeline(w http.ResponseWriter, r *http.Re
 	handler(w, r.WithContext(ctx))
 }
 
-// RestartPipeline operation middleware
-func (siw *ServerInterfaceWrapper) RestartPipeline(w http.ResponseWriter, r *http.Request) {
+// GetPipelineHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetPipelineHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "pipelineId" -------------
-	var pipelineId string
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
 
-	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPipelineHistoryParams
+
+	// ------------- Optional query parameter "specificationId" -------------
+	if paramValue := r.URL.Query().Get("specificationId"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "specificationId", r.URL.Query(), &params.SpecificationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specificationId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+	if paramValue := r.URL.Query().Get("state"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := r.URL.Query().Get("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RestartPipeline(w, r, pipelineId)
+		siw.Handler.GetPipelineHistory(w, r, testCampaignId, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -109,23 +972,23 @@ func (siw *ServerInterfaceWrapper) RestartPipeline(w http.ResponseWriter, r *htt
 	handler(w, r.WithContext(ctx))
 }
 
-// CancelPipeline operation middleware
-func (siw *ServerInterfaceWrapper) CancelPipeline(w http.ResponseWriter, r *http.Request) {
+// GetSchedules operation middleware
+func (siw *ServerInterfaceWrapper) GetSchedules(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "pipelineId" -------------
-	var pipelineId string
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
 
-	err = runtime.BindStyledParameter("simple", false, "pipelineId", chi.URLParam(r, "pipelineId"), &pipelineId)
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pipelineId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
 		return
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CancelPipeline(w, r, pipelineId)
+		siw.Handler.GetSchedules(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -135,23 +998,23 @@ func (siw *ServerInterfaceWrapper) CancelPipeline(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// GetSpecification operation middleware
-func (siw *ServerInterfaceWrapper) GetSpecification(w http.ResponseWriter, r *http.Request) {
+// CreateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchedule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "specificationId" -------------
-	var specificationId string
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
 
-	err = runtime.BindStyledParameter("simple", false, "specificationId", chi.URLParam(r, "specificationId"), &specificationId)
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specificationId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
 		return
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSpecification(w, r, specificationId)
+		siw.Handler.CreateSchedule(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -161,12 +1024,32 @@ func (siw *ServerInterfaceWrapper) GetSpecification(w http.ResponseWriter, r *ht
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTestCampaigns operation middleware
-func (siw *ServerInterfaceWrapper) GetTestCampaigns(w http.ResponseWriter, r *http.Request) {
+// RemoveSchedule operation middleware
+func (siw *ServerInterfaceWrapper) RemoveSchedule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId string
+
+	err = runtime.BindStyledParameter("simple", false, "scheduleId", chi.URLParam(r, "scheduleId"), &scheduleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduleId", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTestCampaigns(w, r)
+		siw.Handler.RemoveSchedule(w, r, testCampaignId, scheduleId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -176,12 +1059,58 @@ func (siw *ServerInterfaceWrapper) GetTestCampaigns(w http.ResponseWriter, r *ht
 	handler(w, r.WithContext(ctx))
 }
 
-// CreateTestCampaign operation middleware
-func (siw *ServerInterfaceWrapper) CreateTestCampaign(w http.ResponseWriter, r *http.Request) {
+// UpdateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId string
+
+	err = runtime.BindStyledParameter("simple", false, "scheduleId", chi.URLParam(r, "scheduleId"), &scheduleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduleId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateSchedule(w, r, testCampaignId, scheduleId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// LoadSpecification operation middleware
+func (siw *ServerInterfaceWrapper) LoadSpecification(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateTestCampaign(w, r)
+		siw.Handler.LoadSpecification(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -191,8 +1120,8 @@ func (siw *ServerInterfaceWrapper) CreateTestCampaign(w http.ResponseWriter, r *
 	handler(w, r.WithContext(ctx))
 }
 
-// RemoveTestCampaign operation middleware
-func (siw *ServerInterfaceWrapper) RemoveTestCampaign(w http.ResponseWriter, r *http.Request) {
+// GetSpecificationHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetSpecificationHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -207,7 +1136,7 @@ func (siw *ServerInterfaceWrapper) RemoveTestCampaign(w http.ResponseWriter, r *
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemoveTestCampaign(w, r, testCampaignId)
+		siw.Handler.GetSpecificationHistory(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -217,8 +1146,8 @@ func (siw *ServerInterfaceWrapper) RemoveTestCampaign(w http.ResponseWriter, r *
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTestCampaign operation middleware
-func (siw *ServerInterfaceWrapper) GetTestCampaign(w http.ResponseWriter, r *http.Request) {
+// GetSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -233,7 +1162,7 @@ func (siw *ServerInterfaceWrapper) GetTestCampaign(w http.ResponseWriter, r *htt
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTestCampaign(w, r, testCampaignId)
+		siw.Handler.GetSubscriptions(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -243,8 +1172,8 @@ func (siw *ServerInterfaceWrapper) GetTestCampaign(w http.ResponseWriter, r *htt
 	handler(w, r.WithContext(ctx))
 }
 
-// StartPipeline operation middleware
-func (siw *ServerInterfaceWrapper) StartPipeline(w http.ResponseWriter, r *http.Request) {
+// CreateSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -259,7 +1188,7 @@ func (siw *ServerInterfaceWrapper) StartPipeline(w http.ResponseWriter, r *http.
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.StartPipeline(w, r, testCampaignId)
+		siw.Handler.CreateSubscription(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -269,8 +1198,8 @@ func (siw *ServerInterfaceWrapper) StartPipeline(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
-// GetPipelineHistory operation middleware
-func (siw *ServerInterfaceWrapper) GetPipelineHistory(w http.ResponseWriter, r *http.Request) {
+// RemoveSubscription operation middleware
+func (siw *ServerInterfaceWrapper) RemoveSubscription(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -284,8 +1213,17 @@ func (siw *ServerInterfaceWrapper) GetPipelineHistory(w http.ResponseWriter, r *
 		return
 	}
 
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId string
+
+	err = runtime.BindStyledParameter("simple", false, "subscriptionId", chi.URLParam(r, "subscriptionId"), &subscriptionId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionId", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPipelineHistory(w, r, testCampaignId)
+		siw.Handler.RemoveSubscription(w, r, testCampaignId, subscriptionId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -295,8 +1233,8 @@ func (siw *ServerInterfaceWrapper) GetPipelineHistory(w http.ResponseWriter, r *
 	handler(w, r.WithContext(ctx))
 }
 
-// LoadSpecification operation middleware
-func (siw *ServerInterfaceWrapper) LoadSpecification(w http.ResponseWriter, r *http.Request) {
+// RevokeTriggerToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeTriggerToken(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -311,7 +1249,33 @@ func (siw *ServerInterfaceWrapper) LoadSpecification(w http.ResponseWriter, r *h
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LoadSpecification(w, r, testCampaignId)
+		siw.Handler.RevokeTriggerToken(w, r, testCampaignId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// IssueTriggerToken operation middleware
+func (siw *ServerInterfaceWrapper) IssueTriggerToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "testCampaignId" -------------
+	var testCampaignId string
+
+	err = runtime.BindStyledParameter("simple", false, "testCampaignId", chi.URLParam(r, "testCampaignId"), &testCampaignId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "testCampaignId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.IssueTriggerToken(w, r, testCampaignId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -434,6 +1398,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/flows/{flowId}/report", wrapper.GetFlowReport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/hooks/{testCampaignId}", wrapper.TriggerPipeline)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pipelines/queue", wrapper.GetPipelineQueue)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/pipelines/queue/{pipelineId}", wrapper.DequeuePipeline)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/pipelines/{pipelineId}", wrapper.GetPipeline)
 	})
@@ -443,9 +1419,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/pipelines/{pipelineId}/canceled", wrapper.CancelPipeline)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pipelines/{pipelineId}/events", wrapper.GetPipelineEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/pipelines/{pipelineId}/paused", wrapper.PausePipeline)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/pipelines/{pipelineId}/resumed", wrapper.ResumePipeline)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pipelines/{pipelineId}/ws", wrapper.ConnectPipelineSocket)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/schemas/specification.json", wrapper.GetSpecificationSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/specifications/lint", wrapper.LintSpecification)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/specifications/{specificationId}", wrapper.GetSpecification)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/specifications/{specificationId}/active", wrapper.ActivateSpecification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/specifications/{specificationId}/diff", wrapper.GetSpecificationDiff)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/specifications/{specificationId}/export", wrapper.ExportSpecification)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/test-campaigns", wrapper.GetTestCampaigns)
 	})
@@ -458,15 +1461,51 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/test-campaigns/{testCampaignId}", wrapper.GetTestCampaign)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/test-campaigns/{testCampaignId}", wrapper.UpdateTestCampaign)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/test-campaigns/{testCampaignId}/notifications", wrapper.GetNotifications)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/test-campaigns/{testCampaignId}/pipeline", wrapper.StartPipeline)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/test-campaigns/{testCampaignId}/pipelines", wrapper.GetPipelineHistory)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/test-campaigns/{testCampaignId}/schedules", wrapper.GetSchedules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/test-campaigns/{testCampaignId}/schedules", wrapper.CreateSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/test-campaigns/{testCampaignId}/schedules/{scheduleId}", wrapper.RemoveSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/test-campaigns/{testCampaignId}/schedules/{scheduleId}", wrapper.UpdateSchedule)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/test-campaigns/{testCampaignId}/specification", wrapper.LoadSpecification)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/test-campaigns/{testCampaignId}/specifications", wrapper.GetSpecificationHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/test-campaigns/{testCampaignId}/subscriptions", wrapper.GetSubscriptions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/test-campaigns/{testCampaignId}/subscriptions", wrapper.CreateSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/test-campaigns/{testCampaignId}/subscriptions/{subscriptionId}", wrapper.RemoveSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/test-campaigns/{testCampaignId}/trigger-token", wrapper.RevokeTriggerToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/test-campaigns/{testCampaignId}/trigger-token", wrapper.IssueTriggerToken)
+	})
 
 	return r
 }
@@ -4,6 +4,8 @@
 package v1
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -18,28 +20,60 @@ const (
 
 	ErrorSlugEmptyBearerToken ErrorSlug = "empty-bearer-token"
 
+	ErrorSlugFlowNotFound ErrorSlug = "flow-not-found"
+
+	ErrorSlugInvalidControlMessage ErrorSlug = "invalid-control-message"
+
+	ErrorSlugInvalidCursor ErrorSlug = "invalid-cursor"
+
 	ErrorSlugInvalidJson ErrorSlug = "invalid-json"
 
+	ErrorSlugInvalidLintConfig ErrorSlug = "invalid-lint-config"
+
+	ErrorSlugInvalidSchedule ErrorSlug = "invalid-schedule"
+
 	ErrorSlugInvalidSpecificationSource ErrorSlug = "invalid-specification-source"
 
+	ErrorSlugInvalidSubscription ErrorSlug = "invalid-subscription"
+
+	ErrorSlugInvalidTriggerSignature ErrorSlug = "invalid-trigger-signature"
+
+	ErrorSlugNoPipelineWorkers ErrorSlug = "no-pipeline-workers"
+
 	ErrorSlugPipelineAlreadyStarted ErrorSlug = "pipeline-already-started"
 
 	ErrorSlugPipelineNotFound ErrorSlug = "pipeline-not-found"
 
+	ErrorSlugPipelineNotQueued ErrorSlug = "pipeline-not-queued"
+
 	ErrorSlugPipelineNotStarted ErrorSlug = "pipeline-not-started"
 
+	ErrorSlugPipelineQueueUnavailable ErrorSlug = "pipeline-queue-unavailable"
+
+	ErrorSlugScheduleNotFound ErrorSlug = "schedule-not-found"
+
 	ErrorSlugSpecificationNotFound ErrorSlug = "specification-not-found"
 
+	ErrorSlugSubscriptionNotFound ErrorSlug = "subscription-not-found"
+
+	ErrorSlugTestCampaignHasStartedPipelines ErrorSlug = "test-campaign-has-started-pipelines"
+
 	ErrorSlugTestCampaignNotFound ErrorSlug = "test-campaign-not-found"
 
+	ErrorSlugTriggerReplayed ErrorSlug = "trigger-replayed"
+
 	ErrorSlugUnableToVerifyJwt ErrorSlug = "unable-to-verify-jwt"
 
 	ErrorSlugUnauthorizedUser ErrorSlug = "unauthorized-user"
 
 	ErrorSlugUnexpectedError ErrorSlug = "unexpected-error"
 
+	ErrorSlugUnknownReportFormat ErrorSlug = "unknown-report-format"
+
 	ErrorSlugUserCantSeePipeline ErrorSlug = "user-cant-see-pipeline"
 
+	ErrorSlugUserCantSeeSpecification ErrorSlug = "user-cant-see-specification"
+
 	ErrorSlugUserCantSeeTestCampaign ErrorSlug = "user-cant-see-test-campaign"
 )
 
@@ -64,6 +98,81 @@ const (
 	HttpMethodTRACE HttpMethod = "TRACE"
 )
 
+// Defines values for LintProblemRule.
+const (
+	LintProblemRuleDuplicateRequestBody LintProblemRule = "duplicate-request-body"
+
+	LintProblemRuleHardcodedHost LintProblemRule = "hardcoded-host"
+
+	LintProblemRuleMissingNarrative LintProblemRule = "missing-narrative"
+
+	LintProblemRuleNoAllowedCodes LintProblemRule = "no-allowed-codes"
+
+	LintProblemRuleThenWithoutAssertion LintProblemRule = "then-without-assertion"
+
+	LintProblemRuleUnusedResponse LintProblemRule = "unused-response"
+)
+
+// Defines values for LintProblemSlugKind.
+const (
+	LintProblemSlugKindScenario LintProblemSlugKind = "scenario"
+
+	LintProblemSlugKindStory LintProblemSlugKind = "story"
+
+	LintProblemSlugKindThesis LintProblemSlugKind = "thesis"
+)
+
+// Defines values for LintSeverity.
+const (
+	LintSeverityError LintSeverity = "error"
+
+	LintSeverityInfo LintSeverity = "info"
+
+	LintSeverityOff LintSeverity = "off"
+
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// Defines values for NotificationEvent.
+const (
+	NotificationEventCanceled NotificationEvent = "canceled"
+
+	NotificationEventCrashed NotificationEvent = "crashed"
+
+	NotificationEventFailed NotificationEvent = "failed"
+
+	NotificationEventPassed NotificationEvent = "passed"
+
+	NotificationEventRecovered NotificationEvent = "recovered"
+)
+
+// Defines values for NotificationFilter.
+const (
+	NotificationFilterAll NotificationFilter = "all"
+
+	NotificationFilterCrashed NotificationFilter = "crashed"
+
+	NotificationFilterFailed NotificationFilter = "failed"
+
+	NotificationFilterRecovered NotificationFilter = "recovered"
+)
+
+// Defines values for NotificationState.
+const (
+	NotificationStateDead NotificationState = "dead"
+
+	NotificationStateDelivered NotificationState = "delivered"
+
+	NotificationStatePending NotificationState = "pending"
+)
+
+// Defines values for PipelinePriority.
+const (
+	PipelinePriorityMANUAL PipelinePriority = "MANUAL"
+
+	PipelinePrioritySCHEDULED PipelinePriority = "SCHEDULED"
+)
+
 // Defines values for PipelineState.
 const (
 	PipelineStateCANCELED PipelineState = "CANCELED"
@@ -80,9 +189,60 @@ const (
 
 	PipelineStatePASSED PipelineState = "PASSED"
 
+	PipelineStatePAUSED PipelineState = "PAUSED"
+
 	PipelineStateQUEUED PipelineState = "QUEUED"
 )
 
+// Defines values for PipelineStepEvent.
+const (
+	PipelineStepEventCancel PipelineStepEvent = "cancel"
+
+	PipelineStepEventCrash PipelineStepEvent = "crash"
+
+	PipelineStepEventExecute PipelineStepEvent = "execute"
+
+	PipelineStepEventFail PipelineStepEvent = "fail"
+
+	PipelineStepEventPass PipelineStepEvent = "pass"
+
+	PipelineStepEventPause PipelineStepEvent = "pause"
+)
+
+// Defines values for PipelineStepSlugKind.
+const (
+	PipelineStepSlugKindScenario PipelineStepSlugKind = "scenario"
+
+	PipelineStepSlugKindThesis PipelineStepSlugKind = "thesis"
+)
+
+// Defines values for ReportFormat.
+const (
+	ReportFormatHtml ReportFormat = "html"
+
+	ReportFormatJson ReportFormat = "json"
+
+	ReportFormatJunit ReportFormat = "junit"
+)
+
+// Defines values for SpecificationChangeKind.
+const (
+	SpecificationChangeKindAdded SpecificationChangeKind = "added"
+
+	SpecificationChangeKindModified SpecificationChangeKind = "modified"
+
+	SpecificationChangeKindRemoved SpecificationChangeKind = "removed"
+)
+
+// Defines values for SpecificationChangeSlugKind.
+const (
+	SpecificationChangeSlugKindScenario SpecificationChangeSlugKind = "scenario"
+
+	SpecificationChangeSlugKindStory SpecificationChangeSlugKind = "story"
+
+	SpecificationChangeSlugKindThesis SpecificationChangeSlugKind = "thesis"
+)
+
 // Assert defines model for Assert.
 type Assert struct {
 	Actual   string `json:"actual"`
@@ -98,6 +258,33 @@ type Assertion struct {
 // AssertionMethod defines model for AssertionMethod.
 type AssertionMethod string
 
+// CreateScheduleRequest defines model for CreateScheduleRequest.
+type CreateScheduleRequest struct {
+	// Standard cron expression or descriptor like @daily.
+	Cron string `json:"cron"`
+
+	// Name of the environment profile of the active specification the scheduled runs use. Run fails if the profile is missing.
+	Environment *string `json:"environment,omitempty"`
+
+	// Comma-separated patterns of scenarios the scheduled runs execute, e.g. "checkout, cart.add*". Pattern without a dot selects the whole story. Empty filter selects all scenarios.
+	Filter *string `json:"filter,omitempty"`
+
+	// IANA timezone of the cron expression, UTC by default.
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// CreateSubscriptionRequest defines model for CreateSubscriptionRequest.
+type CreateSubscriptionRequest struct {
+	// Events to notify about, recovered is the passed flow following the failed or crashed one. All by default.
+	Filter *NotificationFilter `json:"filter,omitempty"`
+
+	// Secret signing notifications, receivers verify it to trust them.
+	Secret string `json:"secret"`
+
+	// Absolute HTTP(S) URL receiving notifications.
+	Url string `json:"url"`
+}
+
 // CreateTestCampaignRequest defines model for CreateTestCampaignRequest.
 type CreateTestCampaignRequest struct {
 	Summary  *string `json:"summary,omitempty"`
@@ -113,9 +300,18 @@ type Error struct {
 // ErrorSlug defines model for ErrorSlug.
 type ErrorSlug string
 
+// FieldChange defines model for FieldChange.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
 // Flow defines model for Flow.
 type Flow struct {
+	Id           string        `json:"id"`
 	OverallState PipelineState `json:"overallState"`
+	StartedAt    *time.Time    `json:"startedAt,omitempty"`
 	Statuses     []Status      `json:"statuses"`
 }
 
@@ -127,6 +323,15 @@ type GeneralPipelineResponse struct {
 	StartedAt       time.Time     `json:"startedAt"`
 }
 
+// GeneralSpecificationResponse defines model for GeneralSpecificationResponse.
+type GeneralSpecificationResponse struct {
+	Active   bool      `json:"active"`
+	Author   *string   `json:"author,omitempty"`
+	Id       string    `json:"id"`
+	LoadedAt time.Time `json:"loadedAt"`
+	Title    *string   `json:"title,omitempty"`
+}
+
 // Http defines model for Http.
 type Http struct {
 	Request  *HttpRequest  `json:"request,omitempty"`
@@ -150,9 +355,124 @@ type HttpResponse struct {
 	AllowedContentType *string `json:"allowedContentType,omitempty"`
 }
 
+// LintProblem defines model for LintProblem.
+type LintProblem struct {
+	Message  string              `json:"message"`
+	Rule     LintProblemRule     `json:"rule"`
+	Severity LintSeverity        `json:"severity"`
+	Slug     string              `json:"slug"`
+	SlugKind LintProblemSlugKind `json:"slugKind"`
+}
+
+// LintProblemRule defines model for LintProblem.Rule.
+type LintProblemRule string
+
+// LintProblemSlugKind defines model for LintProblem.SlugKind.
+type LintProblemSlugKind string
+
+// LintProblemsResponse defines model for LintProblemsResponse.
+type LintProblemsResponse struct {
+	Problems []LintProblem `json:"problems"`
+}
+
+// LintSeverity defines model for LintSeverity.
+type LintSeverity string
+
+// LintSpecificationRequest defines model for LintSpecificationRequest.
+type LintSpecificationRequest struct {
+	// Severities of lint rules by their IDs.
+	Rules *LintSpecificationRequest_Rules `json:"rules,omitempty"`
+
+	// Declarative specification source in YAML.
+	Source string `json:"source"`
+}
+
+// Severities of lint rules by their IDs.
+type LintSpecificationRequest_Rules struct {
+	AdditionalProperties map[string]LintSeverity `json:"-"`
+}
+
+// NotificationEvent defines model for NotificationEvent.
+type NotificationEvent string
+
+// Events to notify about, recovered is the passed flow following the failed or crashed one. All by default.
+type NotificationFilter string
+
+// NotificationResponse defines model for NotificationResponse.
+type NotificationResponse struct {
+	Attempts  int               `json:"attempts"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Event     NotificationEvent `json:"event"`
+	FlowId    string            `json:"flowId"`
+	Id        string            `json:"id"`
+
+	// Error of the last failed attempt.
+	LastError  *string `json:"lastError,omitempty"`
+	PipelineId string  `json:"pipelineId"`
+
+	// Dead notification is not delivered in all attempts.
+	State          NotificationState `json:"state"`
+	SubscriptionId string            `json:"subscriptionId"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+	Url            string            `json:"url"`
+}
+
+// Dead notification is not delivered in all attempts.
+type NotificationState string
+
+// NotificationsResponse defines model for NotificationsResponse.
+type NotificationsResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+}
+
+// PipelineHistoryResponse defines model for PipelineHistoryResponse.
+type PipelineHistoryResponse struct {
+	// Cursor of the next page, absent on the last page.
+	NextCursor *string                   `json:"nextCursor,omitempty"`
+	Pipelines  []GeneralPipelineResponse `json:"pipelines"`
+}
+
+// PipelinePriority defines model for PipelinePriority.
+type PipelinePriority string
+
+// PipelineQueueResponse defines model for PipelineQueueResponse.
+type PipelineQueueResponse struct {
+	Pipelines []QueuedPipeline `json:"pipelines"`
+}
+
 // PipelineState defines model for PipelineState.
 type PipelineState string
 
+// PipelineStep defines model for PipelineStep.
+type PipelineStep struct {
+	Error        *string              `json:"error,omitempty"`
+	Event        PipelineStepEvent    `json:"event"`
+	ExecutorType *string              `json:"executorType,omitempty"`
+	OccurredAt   time.Time            `json:"occurredAt"`
+	Slug         string               `json:"slug"`
+	SlugKind     PipelineStepSlugKind `json:"slugKind"`
+}
+
+// PipelineStepEvent defines model for PipelineStep.Event.
+type PipelineStepEvent string
+
+// PipelineStepSlugKind defines model for PipelineStep.SlugKind.
+type PipelineStepSlugKind string
+
+// QueuedPipeline defines model for QueuedPipeline.
+type QueuedPipeline struct {
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+
+	// Estimated time of running, absent if there is no estimate yet.
+	Eta        *time.Time       `json:"eta,omitempty"`
+	PipelineId string           `json:"pipelineId"`
+	Position   int              `json:"position"`
+	Priority   PipelinePriority `json:"priority"`
+}
+
+// ReportFormat defines model for ReportFormat.
+type ReportFormat string
+
 // Scenario defines model for Scenario.
 type Scenario struct {
 	Description *string  `json:"description,omitempty"`
@@ -160,11 +480,31 @@ type Scenario struct {
 	Theses      []Thesis `json:"theses"`
 }
 
+// ScheduleResponse defines model for ScheduleResponse.
+type ScheduleResponse struct {
+	Cron        string     `json:"cron"`
+	Environment string     `json:"environment"`
+	Filter      string     `json:"filter"`
+	Id          string     `json:"id"`
+	NextRunAt   *time.Time `json:"nextRunAt,omitempty"`
+	Timezone    string     `json:"timezone"`
+}
+
+// SchedulesResponse defines model for SchedulesResponse.
+type SchedulesResponse struct {
+	Schedules []ScheduleResponse `json:"schedules"`
+}
+
 // SpecificPipelineResponse defines model for SpecificPipelineResponse.
 type SpecificPipelineResponse struct {
-	Flows           interface{} `json:"flows"`
-	Id              string      `json:"id"`
-	SpecificationId string      `json:"specificationId"`
+	Flows []Flow `json:"flows"`
+	Id    string `json:"id"`
+
+	// Pipeline is started, but waits for the free worker. Always false with enabled pipeline.distributed.
+	Queued          bool       `json:"queued"`
+	SpecificationId string     `json:"specificationId"`
+	Started         bool       `json:"started"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
 }
 
 // Specification defines model for Specification.
@@ -178,6 +518,45 @@ type Specification struct {
 	Title          *string   `json:"title,omitempty"`
 }
 
+// SpecificationChange defines model for SpecificationChange.
+type SpecificationChange struct {
+	Fields   []FieldChange               `json:"fields"`
+	Kind     SpecificationChangeKind     `json:"kind"`
+	Slug     string                      `json:"slug"`
+	SlugKind SpecificationChangeSlugKind `json:"slugKind"`
+}
+
+// SpecificationChangeKind defines model for SpecificationChange.Kind.
+type SpecificationChangeKind string
+
+// SpecificationChangeSlugKind defines model for SpecificationChange.SlugKind.
+type SpecificationChangeSlugKind string
+
+// SpecificationDiagnostic defines model for SpecificationDiagnostic.
+type SpecificationDiagnostic struct {
+	// 1-based column of the problem, absent if unknown.
+	Column *int `json:"column,omitempty"`
+
+	// Slugs and fields from the root of the specification to the object with the problem.
+	Context *[]string `json:"context,omitempty"`
+
+	// 1-based line of the problem, absent if unknown.
+	Line    *int   `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// SpecificationDiffResponse defines model for SpecificationDiffResponse.
+type SpecificationDiffResponse struct {
+	AgainstId       string                `json:"againstId"`
+	Changes         []SpecificationChange `json:"changes"`
+	SpecificationId string                `json:"specificationId"`
+}
+
+// SpecificationHistoryResponse defines model for SpecificationHistoryResponse.
+type SpecificationHistoryResponse struct {
+	Specifications []GeneralSpecificationResponse `json:"specifications"`
+}
+
 // SpecificationResponse defines model for SpecificationResponse.
 type SpecificationResponse struct {
 	SourceUri     string        `json:"sourceUri"`
@@ -194,6 +573,13 @@ type SpecificationSlug struct {
 // SpecificationSource defines model for SpecificationSource.
 type SpecificationSource string
 
+// SpecificationSourceError defines model for SpecificationSourceError.
+type SpecificationSourceError struct {
+	Details     string                    `json:"details"`
+	Diagnostics []SpecificationDiagnostic `json:"diagnostics"`
+	Slug        ErrorSlug                 `json:"slug"`
+}
+
 // StartPipelineRequest defines model for StartPipelineRequest.
 type StartPipelineRequest struct {
 	ScenarioSlugs *interface{} `json:"scenarioSlugs,omitempty"`
@@ -209,7 +595,7 @@ type Statement struct {
 type Status struct {
 	Slug           SpecificationSlug `json:"slug"`
 	State          PipelineState     `json:"state"`
-	ThesisStatuses interface{}       `json:"thesisStatuses"`
+	ThesisStatuses []ThesisStatus    `json:"thesisStatuses"`
 }
 
 // Story defines model for Story.
@@ -222,6 +608,19 @@ type Story struct {
 	WantTo      *string    `json:"wantTo,omitempty"`
 }
 
+// SubscriptionResponse defines model for SubscriptionResponse.
+type SubscriptionResponse struct {
+	// Events to notify about, recovered is the passed flow following the failed or crashed one. All by default.
+	Filter NotificationFilter `json:"filter"`
+	Id     string             `json:"id"`
+	Url    string             `json:"url"`
+}
+
+// SubscriptionsResponse defines model for SubscriptionsResponse.
+type SubscriptionsResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
+
 // TestCampaignResponse defines model for TestCampaignResponse.
 type TestCampaignResponse struct {
 	CreatedAt      time.Time `json:"createdAt"`
@@ -231,6 +630,13 @@ type TestCampaignResponse struct {
 	ViewName       string    `json:"viewName"`
 }
 
+// TestCampaignsResponse defines model for TestCampaignsResponse.
+type TestCampaignsResponse struct {
+	// Cursor of the next page, absent on the last page.
+	NextCursor    *string                `json:"nextCursor,omitempty"`
+	TestCampaigns []TestCampaignResponse `json:"testCampaigns"`
+}
+
 // Thesis defines model for Thesis.
 type Thesis struct {
 	After     []string   `json:"after"`
@@ -242,19 +648,208 @@ type Thesis struct {
 
 // ThesisStatus defines model for ThesisStatus.
 type ThesisStatus struct {
-	OccurredErrors interface{}   `json:"occurredErrors"`
+	OccurredErrors []string      `json:"occurredErrors"`
 	State          PipelineState `json:"state"`
 	ThesisSlug     string        `json:"thesisSlug"`
 }
 
+// Only priority of the run can be chosen, requests with other parameters are rejected.
+type TriggerPipelineRequest struct {
+	Priority *PipelinePriority `json:"priority,omitempty"`
+}
+
+// TriggerTokenResponse defines model for TriggerTokenResponse.
+type TriggerTokenResponse struct {
+	// Trigger token, it is shown only once.
+	Token string `json:"token"`
+}
+
+// UpdateScheduleRequest defines model for UpdateScheduleRequest.
+type UpdateScheduleRequest struct {
+	Cron        *string `json:"cron,omitempty"`
+	Environment *string `json:"environment,omitempty"`
+	Filter      *string `json:"filter,omitempty"`
+	Timezone    *string `json:"timezone,omitempty"`
+}
+
+// UpdateTestCampaignRequest defines model for UpdateTestCampaignRequest.
+type UpdateTestCampaignRequest struct {
+	Summary  *string `json:"summary,omitempty"`
+	ViewName *string `json:"viewName,omitempty"`
+}
+
+// GetFlowReportParams defines parameters for GetFlowReport.
+type GetFlowReportParams struct {
+	// Format of the report.
+	Format ReportFormat `json:"format"`
+
+	// Returns report as an attachment to save it as a file.
+	Download *bool `json:"download,omitempty"`
+}
+
+// TriggerPipelineJSONBody defines parameters for TriggerPipeline.
+type TriggerPipelineJSONBody TriggerPipelineRequest
+
+// TriggerPipelineParams defines parameters for TriggerPipeline.
+type TriggerPipelineParams struct {
+	// Unix time of the request signing.
+	XThestisTimestamp int64 `json:"X-Thestis-Timestamp"`
+
+	// Signature of the request body.
+	XThestisSignature string `json:"X-Thestis-Signature"`
+}
+
+// LintSpecificationJSONBody defines parameters for LintSpecification.
+type LintSpecificationJSONBody LintSpecificationRequest
+
+// GetSpecificationDiffParams defines parameters for GetSpecificationDiff.
+type GetSpecificationDiffParams struct {
+	// Specification ID to compare against.
+	Against string `json:"against"`
+}
+
+// ExportSpecificationParams defines parameters for ExportSpecification.
+type ExportSpecificationParams struct {
+	// Returns specification as an attachment to save it as a file.
+	Download *bool `json:"download,omitempty"`
+}
+
+// GetTestCampaignsParams defines parameters for GetTestCampaigns.
+type GetTestCampaignsParams struct {
+	// Returns only test campaigns with view name or summary containing this text.
+	Search *string `json:"search,omitempty"`
+
+	// Cursor of the page returned as nextCursor of the previous page.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Maximum number of test campaigns on the page.
+	Limit *int `json:"limit,omitempty"`
+}
+
 // CreateTestCampaignJSONBody defines parameters for CreateTestCampaign.
 type CreateTestCampaignJSONBody CreateTestCampaignRequest
 
+// RemoveTestCampaignParams defines parameters for RemoveTestCampaign.
+type RemoveTestCampaignParams struct {
+	// Moves test campaign to the archive instead of permanent deletion.
+	Archive *bool `json:"archive,omitempty"`
+}
+
+// UpdateTestCampaignJSONBody defines parameters for UpdateTestCampaign.
+type UpdateTestCampaignJSONBody UpdateTestCampaignRequest
+
+// GetNotificationsParams defines parameters for GetNotifications.
+type GetNotificationsParams struct {
+	// Returns only notifications in such state, e.g. dead ones.
+	State *NotificationState `json:"state,omitempty"`
+}
+
 // StartPipelineJSONBody defines parameters for StartPipeline.
 type StartPipelineJSONBody StartPipelineRequest
 
+// GetPipelineHistoryParams defines parameters for GetPipelineHistory.
+type GetPipelineHistoryParams struct {
+	// Returns only pipelines of specification with such ID.
+	SpecificationId *string `json:"specificationId,omitempty"`
+
+	// Returns only pipelines with such overall state of the last flow.
+	State *PipelineState `json:"state,omitempty"`
+
+	// Returns only pipelines started at or after this time.
+	From *time.Time `json:"from,omitempty"`
+
+	// Returns only pipelines started at or before this time.
+	To *time.Time `json:"to,omitempty"`
+
+	// Cursor of the page returned as nextCursor of the previous page.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Maximum number of pipelines on the page.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// CreateScheduleJSONBody defines parameters for CreateSchedule.
+type CreateScheduleJSONBody CreateScheduleRequest
+
+// UpdateScheduleJSONBody defines parameters for UpdateSchedule.
+type UpdateScheduleJSONBody UpdateScheduleRequest
+
+// CreateSubscriptionJSONBody defines parameters for CreateSubscription.
+type CreateSubscriptionJSONBody CreateSubscriptionRequest
+
+// TriggerPipelineJSONRequestBody defines body for TriggerPipeline for application/json ContentType.
+type TriggerPipelineJSONRequestBody TriggerPipelineJSONBody
+
+// LintSpecificationJSONRequestBody defines body for LintSpecification for application/json ContentType.
+type LintSpecificationJSONRequestBody LintSpecificationJSONBody
+
 // CreateTestCampaignJSONRequestBody defines body for CreateTestCampaign for application/json ContentType.
 type CreateTestCampaignJSONRequestBody CreateTestCampaignJSONBody
 
+// UpdateTestCampaignJSONRequestBody defines body for UpdateTestCampaign for application/json ContentType.
+type UpdateTestCampaignJSONRequestBody UpdateTestCampaignJSONBody
+
 // StartPipelineJSONRequestBody defines body for StartPipeline for application/json ContentType.
 type StartPipelineJSONRequestBody StartPipelineJSONBody
+
+// CreateScheduleJSONRequestBody defines body for CreateSchedule for application/json ContentType.
+type CreateScheduleJSONRequestBody CreateScheduleJSONBody
+
+// UpdateScheduleJSONRequestBody defines body for UpdateSchedule for application/json ContentType.
+type UpdateScheduleJSONRequestBody UpdateScheduleJSONBody
+
+// CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
+type CreateSubscriptionJSONRequestBody CreateSubscriptionJSONBody
+
+// Getter for additional properties for LintSpecificationRequest_Rules. Returns the specified
+// element and whether it was found
+func (a LintSpecificationRequest_Rules) Get(fieldName string) (value LintSeverity, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for LintSpecificationRequest_Rules
+func (a *LintSpecificationRequest_Rules) Set(fieldName string, value LintSeverity) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]LintSeverity)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for LintSpecificationRequest_Rules to handle AdditionalProperties
+func (a *LintSpecificationRequest_Rules) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]LintSeverity)
+		for fieldName, fieldBuf := range object {
+			var fieldVal LintSeverity
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for LintSpecificationRequest_Rules to handle AdditionalProperties
+func (a LintSpecificationRequest_Rules) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
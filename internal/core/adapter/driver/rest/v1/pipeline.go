@@ -8,9 +8,11 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app/query"
 	"github.com/harpyd/thestis/internal/core/app/service"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 	"github.com/harpyd/thestis/internal/core/entity/user"
+	"github.com/harpyd/thestis/pkg/httpconn"
 )
 
 func (h handler) StartPipeline(w http.ResponseWriter, r *http.Request, testCampaignID string) {
@@ -41,6 +43,12 @@ func (h handler) StartPipeline(w http.ResponseWriter, r *http.Request, testCampa
 		return
 	}
 
+	if errors.Is(err, service.ErrNoPipelineWorkers) {
+		rest.ServiceUnavailable(string(ErrorSlugNoPipelineWorkers), err, w, r)
+
+		return
+	}
+
 	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
@@ -77,6 +85,12 @@ func (h handler) RestartPipeline(w http.ResponseWriter, r *http.Request, pipelin
 		return
 	}
 
+	if errors.Is(err, service.ErrNoPipelineWorkers) {
+		rest.ServiceUnavailable(string(ErrorSlugNoPipelineWorkers), err, w, r)
+
+		return
+	}
+
 	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
@@ -116,10 +130,242 @@ func (h handler) CancelPipeline(w http.ResponseWriter, r *http.Request, pipeline
 	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
-func (h handler) GetPipelineHistory(w http.ResponseWriter, _ *http.Request, _ string) {
-	w.WriteHeader(http.StatusNotImplemented)
+func (h handler) PausePipeline(w http.ResponseWriter, r *http.Request, pipelineID string) {
+	cmd, ok := decodePausePipelineCommand(w, r, pipelineID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.PausePipeline.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeePipeline), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotFound) {
+		rest.NotFound(string(ErrorSlugPipelineNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, pipeline.ErrNotStarted) {
+		rest.Conflict(string(ErrorSlugPipelineNotStarted), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
-func (h handler) GetPipeline(w http.ResponseWriter, _ *http.Request, _ string) {
-	w.WriteHeader(http.StatusNotImplemented)
+func (h handler) ResumePipeline(w http.ResponseWriter, r *http.Request, pipelineID string) {
+	cmd, ok := decodeResumePipelineCommand(w, r, pipelineID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.ResumePipeline.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeePipeline), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotFound) {
+		rest.NotFound(string(ErrorSlugPipelineNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, pipeline.ErrNotStarted) {
+		rest.Conflict(string(ErrorSlugPipelineNotStarted), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) GetPipelineHistory(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	params GetPipelineHistoryParams,
+) {
+	qry, ok := decodePipelineHistoryQuery(w, r, testCampaignID, params)
+	if !ok {
+		return
+	}
+
+	history, err := h.app.Queries.PipelineHistory.Handle(r.Context(), qry)
+	if err == nil {
+		renderPipelineHistoryResponse(w, r, history)
+
+		return
+	}
+
+	if errors.Is(err, query.ErrInvalidCursor) {
+		rest.BadRequest(string(ErrorSlugInvalidCursor), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) GetPipeline(w http.ResponseWriter, r *http.Request, pipelineID string) {
+	qry, ok := decodeSpecificPipelineQuery(w, r, pipelineID)
+	if !ok {
+		return
+	}
+
+	pipe, err := h.app.Queries.Pipeline.Handle(r.Context(), qry)
+	if err == nil {
+		renderPipelineResponse(w, r, pipe)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeePipeline), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotFound) {
+		rest.NotFound(string(ErrorSlugPipelineNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) GetPipelineEvents(w http.ResponseWriter, r *http.Request, pipelineID string) {
+	qry, ok := decodePipelineStepsQuery(w, r, pipelineID)
+	if !ok {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		rest.InternalServerError(string(ErrorSlugUnexpectedError), errStreamingUnsupported, w, r)
+
+		return
+	}
+
+	steps, err := h.app.Queries.PipelineSteps.Handle(r.Context(), qry)
+	if err == nil {
+		// Events are streamed as long as the pipeline
+		// is running, so server write timeout is lifted.
+		_ = httpconn.ClearWriteDeadline(r.Context())
+
+		streamPipelineSteps(w, flusher, steps)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeePipeline), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotFound) {
+		rest.NotFound(string(ErrorSlugPipelineNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, pipeline.ErrNotStarted) {
+		rest.Conflict(string(ErrorSlugPipelineNotStarted), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) GetPipelineQueue(w http.ResponseWriter, r *http.Request) {
+	qry, ok := decodePipelineQueueQuery(w, r)
+	if !ok {
+		return
+	}
+
+	pipes, err := h.app.Queries.PipelineQueue.Handle(r.Context(), qry)
+	if err == nil {
+		renderPipelineQueueResponse(w, r, pipes)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineQueueUnavailable) {
+		rest.NotImplemented(string(ErrorSlugPipelineQueueUnavailable), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) DequeuePipeline(w http.ResponseWriter, r *http.Request, pipelineID string) {
+	cmd, ok := decodeDequeuePipelineCommand(w, r, pipelineID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.DequeuePipeline.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeePipeline), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotFound) {
+		rest.NotFound(string(ErrorSlugPipelineNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotQueued) {
+		rest.NotFound(string(ErrorSlugPipelineNotQueued), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineQueueUnavailable) {
+		rest.NotImplemented(string(ErrorSlugPipelineQueueUnavailable), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
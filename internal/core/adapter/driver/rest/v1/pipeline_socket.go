@@ -0,0 +1,185 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app"
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func (h handler) ConnectPipelineSocket(w http.ResponseWriter, r *http.Request, pipelineID string) {
+	qry, ok := decodePipelineStepsQuery(w, r, pipelineID)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	steps, err := h.app.Queries.PipelineSteps.Handle(ctx, qry)
+	if err != nil {
+		renderPipelineSocketError(w, r, err)
+
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrader has already replied to the client.
+		h.logger.Warn("Pipeline socket upgrade failed", "error", err)
+
+		return
+	}
+	defer conn.Close()
+
+	// Hijacked connection keeps server read and write deadlines,
+	// but the socket lives as long as the pipeline is running.
+	_ = conn.UnderlyingConn().SetDeadline(time.Time{})
+
+	s := &pipelineSocket{
+		conn:       conn,
+		app:        h.app,
+		pipelineID: qry.PipelineID,
+		userID:     qry.UserID,
+	}
+
+	go func() {
+		// Client disconnection stops streaming of steps.
+		defer cancel()
+
+		s.readControlMessages(ctx)
+	}()
+
+	s.writeSteps(steps)
+}
+
+func renderPipelineSocketError(w http.ResponseWriter, r *http.Request, err error) {
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeePipeline), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotFound) {
+		rest.NotFound(string(ErrorSlugPipelineNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, pipeline.ErrNotStarted) {
+		rest.Conflict(string(ErrorSlugPipelineNotStarted), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+// pipelineSocket serializes writes to the connection,
+// because steps and control errors are written concurrently.
+type pipelineSocket struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+
+	app        *app.Application
+	pipelineID string
+	userID     string
+}
+
+func (s *pipelineSocket) writeSteps(steps <-chan query.StepModel) {
+	for step := range steps {
+		response := newPipelineStepResponse(step)
+
+		if err := s.write(pipelineSocketMessage{
+			Type: stepSocketMessage,
+			Step: &response,
+		}); err != nil {
+			return
+		}
+	}
+
+	_ = s.write(pipelineSocketMessage{Type: completedSocketMessage})
+	_ = s.writeClose()
+}
+
+func (s *pipelineSocket) readControlMessages(ctx context.Context) {
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		action, err := decodePipelineControlAction(data)
+		if err != nil {
+			_ = s.writeError(ErrorSlugInvalidControlMessage, err)
+
+			continue
+		}
+
+		if err := s.control(ctx, action); err != nil {
+			_ = s.writeError(pipelineControlErrorSlug(err), err)
+		}
+	}
+}
+
+func (s *pipelineSocket) control(ctx context.Context, action pipelineControlAction) error {
+	switch action {
+	case cancelControlAction:
+		return s.app.Commands.CancelPipeline.Handle(ctx, command.CancelPipeline{
+			PipelineID:   s.pipelineID,
+			CanceledByID: s.userID,
+		})
+	case pauseControlAction:
+		return s.app.Commands.PausePipeline.Handle(ctx, command.PausePipeline{
+			PipelineID: s.pipelineID,
+			PausedByID: s.userID,
+		})
+	case resumeControlAction:
+		return s.app.Commands.ResumePipeline.Handle(ctx, command.ResumePipeline{
+			PipelineID:  s.pipelineID,
+			ResumedByID: s.userID,
+		})
+	}
+
+	return errors.Errorf("unknown control action %q", action)
+}
+
+func (s *pipelineSocket) writeError(slug ErrorSlug, err error) error {
+	return s.write(pipelineSocketMessage{
+		Type: errorSocketMessage,
+		Error: &Error{
+			Slug:    slug,
+			Details: err.Error(),
+		},
+	})
+}
+
+func (s *pipelineSocket) write(msg pipelineSocketMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn.WriteJSON(msg)
+}
+
+func (s *pipelineSocket) writeClose() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn.WriteMessage(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+	)
+}
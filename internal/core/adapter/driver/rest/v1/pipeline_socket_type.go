@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+// Socket messages are described by PipelineControlMessage and
+// PipelineSocketMessage schemas of the OpenAPI specification.
+// They aren't generated, because they aren't used in operations.
+type (
+	pipelineControlMessage struct {
+		Action pipelineControlAction `json:"action"`
+	}
+
+	pipelineControlAction string
+
+	pipelineSocketMessage struct {
+		Type  pipelineSocketMessageType `json:"type"`
+		Step  *PipelineStep             `json:"step,omitempty"`
+		Error *Error                    `json:"error,omitempty"`
+	}
+
+	pipelineSocketMessageType string
+)
+
+const (
+	cancelControlAction pipelineControlAction = "cancel"
+	pauseControlAction  pipelineControlAction = "pause"
+	resumeControlAction pipelineControlAction = "resume"
+)
+
+const (
+	stepSocketMessage      pipelineSocketMessageType = "step"
+	errorSocketMessage     pipelineSocketMessageType = "error"
+	completedSocketMessage pipelineSocketMessageType = "completed"
+)
+
+func decodePipelineControlAction(data []byte) (pipelineControlAction, error) {
+	var msg pipelineControlMessage
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return "", err
+	}
+
+	switch msg.Action {
+	case cancelControlAction, pauseControlAction, resumeControlAction:
+		return msg.Action, nil
+	}
+
+	return "", errors.Errorf("unknown control action %q", msg.Action)
+}
+
+func pipelineControlErrorSlug(err error) ErrorSlug {
+	var aerr *user.AccessError
+
+	switch {
+	case errors.As(err, &aerr):
+		return ErrorSlugUserCantSeePipeline
+	case errors.Is(err, service.ErrPipelineNotFound):
+		return ErrorSlugPipelineNotFound
+	case errors.Is(err, pipeline.ErrNotStarted):
+		return ErrorSlugPipelineNotStarted
+	}
+
+	return ErrorSlugUnexpectedError
+}
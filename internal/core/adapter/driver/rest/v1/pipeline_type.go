@@ -1,9 +1,19 @@
 package v1
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
 	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
 )
 
 func decodeStartPipelineCommand(
@@ -19,6 +29,7 @@ func decodeStartPipelineCommand(
 		PipelineID:     pipelineID,
 		TestCampaignID: testCampaignID,
 		StartedByID:    user.UUID,
+		Priority:       service.ManualPriority,
 	}, true
 }
 
@@ -35,6 +46,7 @@ func decodeRestartPipelineCommand(
 	return command.RestartPipeline{
 		PipelineID:  pipelineID,
 		StartedByID: user.UUID,
+		Priority:    service.ManualPriority,
 	}, true
 }
 
@@ -53,3 +65,366 @@ func decodeCancelPipelineCommand(
 		CanceledByID: user.UUID,
 	}, true
 }
+
+func decodePausePipelineCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipelineID string,
+) (cmd command.PausePipeline, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return command.PausePipeline{
+		PipelineID: pipelineID,
+		PausedByID: user.UUID,
+	}, true
+}
+
+func decodeResumePipelineCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipelineID string,
+) (cmd command.ResumePipeline, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return command.ResumePipeline{
+		PipelineID:  pipelineID,
+		ResumedByID: user.UUID,
+	}, true
+}
+
+func decodeSpecificPipelineQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipelineID string,
+) (qry query.Pipeline, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.Pipeline{
+		PipelineID: pipelineID,
+		UserID:     user.UUID,
+	}, true
+}
+
+func decodePipelineQueueQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+) (qry query.PipelineQueue, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.PipelineQueue{UserID: user.UUID}, true
+}
+
+func decodeDequeuePipelineCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipelineID string,
+) (cmd command.DequeuePipeline, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return command.DequeuePipeline{
+		PipelineID:   pipelineID,
+		DequeuedByID: user.UUID,
+	}, true
+}
+
+func decodePipelineStepsQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipelineID string,
+) (qry query.PipelineSteps, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.PipelineSteps{
+		PipelineID: pipelineID,
+		UserID:     user.UUID,
+	}, true
+}
+
+func decodePipelineHistoryQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	params GetPipelineHistoryParams,
+) (qry query.PipelineHistory, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	qry = query.PipelineHistory{
+		TestCampaignID: testCampaignID,
+		UserID:         user.UUID,
+	}
+
+	if params.SpecificationId != nil {
+		qry.SpecificationID = *params.SpecificationId
+	}
+
+	if params.State != nil {
+		state, ok := newFlowState(*params.State)
+		if !ok {
+			rest.BadRequest(
+				string(ErrorSlugBadRequest),
+				errors.Errorf("unknown pipeline state %q", *params.State),
+				w, r,
+			)
+
+			return query.PipelineHistory{}, false
+		}
+
+		qry.State = state
+	}
+
+	if params.From != nil {
+		qry.From = *params.From
+	}
+
+	if params.To != nil {
+		qry.To = *params.To
+	}
+
+	if params.Cursor != nil {
+		qry.Cursor = *params.Cursor
+	}
+
+	if params.Limit != nil {
+		qry.Limit = *params.Limit
+	}
+
+	return qry, true
+}
+
+func renderPipelineHistoryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	history query.PipelineHistoryModel,
+) {
+	response := PipelineHistoryResponse{
+		Pipelines:  make([]GeneralPipelineResponse, 0, len(history.Pipelines)),
+		NextCursor: stringOrNil(history.NextCursor),
+	}
+
+	for _, p := range history.Pipelines {
+		response.Pipelines = append(response.Pipelines, GeneralPipelineResponse{
+			Id:              p.ID,
+			SpecificationId: p.SpecificationID,
+			StartedAt:       p.StartedAt,
+			LastState:       newPipelineState(p.LastState),
+		})
+	}
+
+	render.Respond(w, r, response)
+}
+
+func renderPipelineResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipe query.PipelineModel,
+) {
+	response := SpecificPipelineResponse{
+		Id:              pipe.ID,
+		SpecificationId: pipe.SpecificationID,
+		Started:         pipe.Started,
+		Queued:          pipe.Queued,
+		StartedAt:       timeOrNil(pipe.StartedAt),
+		Flows:           make([]Flow, 0, len(pipe.Flows)),
+	}
+
+	for _, f := range pipe.Flows {
+		response.Flows = append(response.Flows, newFlow(f))
+	}
+
+	render.Respond(w, r, response)
+}
+
+func renderPipelineQueueResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipes []query.QueuedPipelineModel,
+) {
+	response := PipelineQueueResponse{
+		Pipelines: make([]QueuedPipeline, 0, len(pipes)),
+	}
+
+	now := time.Now().UTC()
+
+	for _, p := range pipes {
+		qp := QueuedPipeline{
+			PipelineId: p.PipelineID,
+			Priority:   newPipelinePriority(p.Priority),
+			Position:   p.Position,
+			EnqueuedAt: p.EnqueuedAt,
+		}
+
+		if p.ETA > 0 {
+			eta := now.Add(p.ETA)
+			qp.Eta = &eta
+		}
+
+		response.Pipelines = append(response.Pipelines, qp)
+	}
+
+	render.Respond(w, r, response)
+}
+
+func newPipelinePriority(priority string) PipelinePriority {
+	if priority == service.ScheduledPriority.String() {
+		return PipelinePrioritySCHEDULED
+	}
+
+	return PipelinePriorityMANUAL
+}
+
+func newFlow(f query.FlowModel) Flow {
+	res := Flow{
+		Id:           f.ID,
+		StartedAt:    timeOrNil(f.StartedAt),
+		OverallState: newPipelineState(f.OverallState),
+		Statuses:     make([]Status, 0, len(f.Statuses)),
+	}
+
+	for _, s := range f.Statuses {
+		res.Statuses = append(res.Statuses, newStatus(s))
+	}
+
+	return res
+}
+
+func newStatus(status query.StatusModel) Status {
+	res := Status{
+		Slug: SpecificationSlug{
+			Story:    &status.Slug.Story,
+			Scenario: &status.Slug.Scenario,
+		},
+		State:          newPipelineState(status.State),
+		ThesisStatuses: make([]ThesisStatus, 0, len(status.ThesisStatuses)),
+	}
+
+	for _, ts := range status.ThesisStatuses {
+		res.ThesisStatuses = append(res.ThesisStatuses, newThesisStatus(ts))
+	}
+
+	return res
+}
+
+func newThesisStatus(status query.ThesisStatusModel) ThesisStatus {
+	occurredErrs := status.OccurredErrs
+	if occurredErrs == nil {
+		occurredErrs = []string{}
+	}
+
+	return ThesisStatus{
+		ThesisSlug:     status.ThesisSlug,
+		State:          newPipelineState(status.State),
+		OccurredErrors: occurredErrs,
+	}
+}
+
+func newPipelineState(state string) PipelineState {
+	switch flow.State(state) {
+	case flow.NoState:
+		return PipelineStateNOSTATE
+	case flow.NotExecuted:
+		return PipelineStateNOTEXECUTED
+	case flow.Executing:
+		return PipelineStateEXECUTING
+	case flow.Passed:
+		return PipelineStatePASSED
+	case flow.Failed:
+		return PipelineStateFAILED
+	case flow.Crashed:
+		return PipelineStateCRASHED
+	case flow.Canceled:
+		return PipelineStateCANCELED
+	case flow.Paused:
+		return PipelineStatePAUSED
+	}
+
+	return PipelineStateNOSTATE
+}
+
+func newFlowState(state PipelineState) (string, bool) {
+	switch state {
+	case PipelineStateNOSTATE:
+		return flow.NoState.String(), true
+	case PipelineStateNOTEXECUTED:
+		return flow.NotExecuted.String(), true
+	case PipelineStateEXECUTING:
+		return flow.Executing.String(), true
+	case PipelineStatePASSED:
+		return flow.Passed.String(), true
+	case PipelineStateFAILED:
+		return flow.Failed.String(), true
+	case PipelineStateCRASHED:
+		return flow.Crashed.String(), true
+	case PipelineStateCANCELED:
+		return flow.Canceled.String(), true
+	case PipelineStatePAUSED:
+		return flow.Paused.String(), true
+	case PipelineStateQUEUED:
+	}
+
+	return "", false
+}
+
+var errStreamingUnsupported = errors.New("streaming unsupported")
+
+const (
+	stepEvent      = "step"
+	completedEvent = "completed"
+)
+
+// streamPipelineSteps writes steps as Server-Sent Events
+// and finishes the stream with the completed event when
+// the steps channel is closed.
+func streamPipelineSteps(w http.ResponseWriter, flusher http.Flusher, steps <-chan query.StepModel) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for step := range steps {
+		data, err := json.Marshal(newPipelineStepResponse(step))
+		if err != nil {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", stepEvent, data); err != nil {
+			return
+		}
+
+		flusher.Flush()
+	}
+
+	_, _ = fmt.Fprintf(w, "event: %s\ndata: {}\n\n", completedEvent)
+	flusher.Flush()
+}
+
+func newPipelineStepResponse(step query.StepModel) PipelineStep {
+	return PipelineStep{
+		Slug:         step.Slug,
+		SlugKind:     PipelineStepSlugKind(step.SlugKind),
+		ExecutorType: stringOrNil(step.ExecutorType),
+		Event:        PipelineStepEvent(step.Event),
+		Error:        stringOrNil(step.Err),
+		OccurredAt:   step.OccurredAt,
+	}
+}
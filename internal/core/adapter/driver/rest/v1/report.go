@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func (h handler) GetFlowReport(
+	w http.ResponseWriter,
+	r *http.Request,
+	flowID string,
+	params GetFlowReportParams,
+) {
+	qry, ok := decodeFlowReportQuery(w, r, flowID, params)
+	if !ok {
+		return
+	}
+
+	report, err := h.app.Queries.FlowReport.Handle(r.Context(), qry)
+	if err == nil {
+		renderFlowReportResponse(w, report, params)
+
+		return
+	}
+
+	if errors.Is(err, query.ErrUnknownReportFormat) {
+		rest.BadRequest(string(ErrorSlugUnknownReportFormat), err, w, r)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeePipeline), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrFlowNotFound) {
+		rest.NotFound(string(ErrorSlugFlowNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrPipelineNotFound) {
+		rest.NotFound(string(ErrorSlugPipelineNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harpyd/thestis/internal/core/app/query"
+)
+
+func decodeFlowReportQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	flowID string,
+	params GetFlowReportParams,
+) (qry query.FlowReport, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.FlowReport{
+		FlowID: flowID,
+		UserID: user.UUID,
+		Format: string(params.Format),
+	}, true
+}
+
+var reportExtensions = map[string]string{
+	string(ReportFormatJunit): "xml",
+	string(ReportFormatHtml):  "html",
+	string(ReportFormatJson):  "json",
+}
+
+func renderFlowReportResponse(
+	w http.ResponseWriter,
+	report query.FlowReportModel,
+	params GetFlowReportParams,
+) {
+	if params.Download != nil && *params.Download {
+		w.Header().Set(
+			"Content-Disposition",
+			fmt.Sprintf(`attachment; filename="flow-%s.%s"`, report.FlowID, reportExtensions[report.Format]),
+		)
+	}
+
+	w.Header().Set("Content-Type", report.ContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(report.Content)
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func (h handler) CreateSchedule(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	cmd, ok := decodeCreateScheduleCommand(w, r, testCampaignID, uuid.New().String())
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.CreateSchedule.Handle(r.Context(), cmd)
+	if err == nil {
+		w.Header().Set(
+			"Location",
+			fmt.Sprintf("/test-campaigns/%s/schedules/%s", cmd.TestCampaignID, cmd.ScheduleID),
+		)
+		w.WriteHeader(http.StatusCreated)
+
+		return
+	}
+
+	renderScheduleError(w, r, err)
+}
+
+func (h handler) GetSchedules(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	qry, ok := decodeSchedulesQuery(w, r, testCampaignID)
+	if !ok {
+		return
+	}
+
+	schedules, err := h.app.Queries.Schedules.Handle(r.Context(), qry)
+	if err == nil {
+		renderSchedulesResponse(w, r, schedules)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) UpdateSchedule(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	scheduleID string,
+) {
+	cmd, ok := decodeUpdateScheduleCommand(w, r, testCampaignID, scheduleID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.UpdateSchedule.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	renderScheduleError(w, r, err)
+}
+
+func (h handler) RemoveSchedule(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	scheduleID string,
+) {
+	cmd, ok := decodeRemoveScheduleCommand(w, r, testCampaignID, scheduleID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.RemoveSchedule.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	renderScheduleError(w, r, err)
+}
+
+func renderScheduleError(w http.ResponseWriter, r *http.Request, err error) {
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeTestCampaign), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, testcampaign.ErrScheduleNotFound) {
+		rest.NotFound(string(ErrorSlugScheduleNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, testcampaign.ErrInvalidCron) ||
+		errors.Is(err, testcampaign.ErrInvalidTimezone) ||
+		errors.Is(err, specification.ErrInvalidScenarioFilter) {
+		rest.BadRequest(string(ErrorSlugInvalidSchedule), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
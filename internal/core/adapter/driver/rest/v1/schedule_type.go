@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/query"
+)
+
+func decodeCreateScheduleCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	scheduleID string,
+) (cmd command.CreateSchedule, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	var rb CreateScheduleRequest
+
+	if ok = decode(w, r, &rb); !ok {
+		return
+	}
+
+	cmd = command.CreateSchedule{
+		ScheduleID:     scheduleID,
+		TestCampaignID: testCampaignID,
+		CreatedByID:    user.UUID,
+		Cron:           rb.Cron,
+	}
+
+	if rb.Timezone != nil {
+		cmd.Timezone = *rb.Timezone
+	}
+
+	if rb.Environment != nil {
+		cmd.Environment = *rb.Environment
+	}
+
+	if rb.Filter != nil {
+		cmd.Filter = *rb.Filter
+	}
+
+	return cmd, true
+}
+
+func decodeUpdateScheduleCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	scheduleID string,
+) (cmd command.UpdateSchedule, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	var rb UpdateScheduleRequest
+
+	if ok = decode(w, r, &rb); !ok {
+		return
+	}
+
+	return command.UpdateSchedule{
+		ScheduleID:     scheduleID,
+		TestCampaignID: testCampaignID,
+		UpdatedByID:    user.UUID,
+		Cron:           rb.Cron,
+		Timezone:       rb.Timezone,
+		Environment:    rb.Environment,
+		Filter:         rb.Filter,
+	}, true
+}
+
+func decodeRemoveScheduleCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	scheduleID string,
+) (cmd command.RemoveSchedule, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return command.RemoveSchedule{
+		ScheduleID:     scheduleID,
+		TestCampaignID: testCampaignID,
+		RemovedByID:    user.UUID,
+	}, true
+}
+
+func decodeSchedulesQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+) (qry query.Schedules, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.Schedules{
+		TestCampaignID: testCampaignID,
+		UserID:         user.UUID,
+	}, true
+}
+
+func renderSchedulesResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	schedules []query.ScheduleModel,
+) {
+	response := SchedulesResponse{
+		Schedules: make([]ScheduleResponse, 0, len(schedules)),
+	}
+
+	for _, s := range schedules {
+		response.Schedules = append(response.Schedules, ScheduleResponse{
+			Id:          s.ID,
+			Cron:        s.Cron,
+			Timezone:    s.Timezone,
+			Environment: s.Environment,
+			Filter:      s.Filter,
+			NextRunAt:   timeOrNil(s.NextRunAt),
+		})
+	}
+
+	render.Respond(w, r, response)
+}
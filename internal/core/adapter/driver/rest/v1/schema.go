@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app"
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+// NewSchemaHandler returns handler of format schemas. Editors
+// fetch schemas without the user authentication, so the
+// handler is mounted without auth middleware.
+func NewSchemaHandler(application *app.Application, logger service.Logger) http.Handler {
+	wrapper := ServerInterfaceWrapper{
+		Handler: handler{
+			app:    application,
+			logger: logger,
+		},
+	}
+
+	r := chi.NewRouter()
+	r.Get("/specification.json", wrapper.GetSpecificationSchema)
+
+	return r
+}
+
+const schemaContentType = "application/schema+json"
+
+func (h handler) GetSpecificationSchema(w http.ResponseWriter, r *http.Request) {
+	schema, err := h.app.Queries.SpecificationSchema.Handle(r.Context(), query.SpecificationSchema{})
+	if err != nil {
+		rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", schemaContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(schema)
+}
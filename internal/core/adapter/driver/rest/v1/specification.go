@@ -33,10 +33,13 @@ func (h handler) LoadSpecification(w http.ResponseWriter, r *http.Request, testC
 		return
 	}
 
-	var berr *specification.BuildError
+	var (
+		berr *specification.BuildError
+		perr *service.ParseError
+	)
 
-	if errors.As(err, &berr) {
-		rest.UnprocessableEntity(string(ErrorSlugInvalidSpecificationSource), err, w, r)
+	if errors.As(err, &berr) || errors.As(err, &perr) {
+		renderSpecificationSourceError(w, r, err)
 
 		return
 	}
@@ -52,6 +55,44 @@ func (h handler) LoadSpecification(w http.ResponseWriter, r *http.Request, testC
 	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
+func (h handler) LintSpecification(w http.ResponseWriter, r *http.Request) {
+	qry, ok := decodeSpecificationLintQuery(w, r)
+	if !ok {
+		return
+	}
+
+	problems, err := h.app.Queries.SpecificationLint.Handle(r.Context(), qry)
+	if err == nil {
+		renderLintProblemsResponse(w, r, problems)
+
+		return
+	}
+
+	var (
+		rerr *specification.UnknownLintRuleError
+		serr *specification.NotAllowedSeverityError
+	)
+
+	if errors.As(err, &rerr) || errors.As(err, &serr) {
+		rest.BadRequest(string(ErrorSlugInvalidLintConfig), err, w, r)
+
+		return
+	}
+
+	var (
+		berr *specification.BuildError
+		perr *service.ParseError
+	)
+
+	if errors.As(err, &berr) || errors.As(err, &perr) {
+		renderSpecificationSourceError(w, r, err)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
 func (h handler) GetSpecification(w http.ResponseWriter, r *http.Request, specificationID string) {
 	qry, ok := decodeSpecificSpecificationQuery(w, r, specificationID)
 	if !ok {
@@ -73,3 +114,119 @@ func (h handler) GetSpecification(w http.ResponseWriter, r *http.Request, specif
 
 	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
+
+func (h handler) GetSpecificationHistory(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	qry, ok := decodeSpecificationHistoryQuery(w, r, testCampaignID)
+	if !ok {
+		return
+	}
+
+	history, err := h.app.Queries.SpecificationHistory.Handle(r.Context(), qry)
+	if err == nil {
+		renderSpecificationHistoryResponse(w, r, history)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) ActivateSpecification(w http.ResponseWriter, r *http.Request, specificationID string) {
+	cmd, ok := decodeActivateSpecificationCommand(w, r, specificationID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.ActivateSpecification.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeSpecification), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrSpecificationNotFound) {
+		rest.NotFound(string(ErrorSlugSpecificationNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) GetSpecificationDiff(
+	w http.ResponseWriter,
+	r *http.Request,
+	specificationID string,
+	params GetSpecificationDiffParams,
+) {
+	qry, ok := decodeSpecificationDiffQuery(w, r, specificationID, params)
+	if !ok {
+		return
+	}
+
+	diff, err := h.app.Queries.SpecificationDiff.Handle(r.Context(), qry)
+	if err == nil {
+		renderSpecificationDiffResponse(w, r, diff)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeSpecification), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrSpecificationNotFound) {
+		rest.NotFound(string(ErrorSlugSpecificationNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) ExportSpecification(
+	w http.ResponseWriter,
+	r *http.Request,
+	specificationID string,
+	params ExportSpecificationParams,
+) {
+	qry, ok := decodeSpecificationExportQuery(w, r, specificationID)
+	if !ok {
+		return
+	}
+
+	export, err := h.app.Queries.SpecificationExport.Handle(r.Context(), qry)
+	if err == nil {
+		renderSpecificationExportResponse(w, export, params)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeSpecification), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrSpecificationNotFound) {
+		rest.NotFound(string(ErrorSlugSpecificationNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 
 	"github.com/go-chi/render"
@@ -10,6 +11,7 @@ import (
 	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
 	"github.com/harpyd/thestis/internal/core/app/command"
 	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
 )
 
 func decodeSpecificationSourceCommand(
@@ -29,14 +31,200 @@ func decodeSpecificationSourceCommand(
 		return
 	}
 
+	// Unknown content type is parsed by the default parser.
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
 	return command.LoadSpecification{
 		SpecificationID: specificationID,
 		TestCampaignID:  testCampaignID,
 		LoadedByID:      user.UUID,
+		ContentType:     contentType,
 		Content:         content,
 	}, true
 }
 
+func decodeSpecificationLintQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+) (qry query.SpecificationLint, ok bool) {
+	if _, ok = authorize(w, r); !ok {
+		return
+	}
+
+	var rb LintSpecificationRequest
+	if ok = decode(w, r, &rb); !ok {
+		return
+	}
+
+	qry.Content = []byte(rb.Source)
+
+	if rb.Rules != nil {
+		qry.Rules = make(map[string]string, len(rb.Rules.AdditionalProperties))
+
+		for rule, severity := range rb.Rules.AdditionalProperties {
+			qry.Rules[rule] = string(severity)
+		}
+	}
+
+	return qry, true
+}
+
+func decodeActivateSpecificationCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	specificationID string,
+) (cmd command.ActivateSpecification, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return command.ActivateSpecification{
+		SpecificationID: specificationID,
+		ActivatedByID:   user.UUID,
+	}, true
+}
+
+func decodeSpecificationHistoryQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+) (qry query.SpecificationHistory, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.SpecificationHistory{
+		TestCampaignID: testCampaignID,
+		UserID:         user.UUID,
+	}, true
+}
+
+func renderSpecificationHistoryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	history query.SpecificationHistoryModel,
+) {
+	response := SpecificationHistoryResponse{
+		Specifications: make([]GeneralSpecificationResponse, 0, len(history.Specifications)),
+	}
+
+	for _, s := range history.Specifications {
+		response.Specifications = append(response.Specifications, GeneralSpecificationResponse{
+			Id:       s.ID,
+			LoadedAt: s.LoadedAt,
+			Author:   stringOrNil(s.Author),
+			Title:    stringOrNil(s.Title),
+			Active:   s.Active,
+		})
+	}
+
+	render.Respond(w, r, response)
+}
+
+func decodeSpecificationDiffQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	specificationID string,
+	params GetSpecificationDiffParams,
+) (qry query.SpecificationDiff, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.SpecificationDiff{
+		SpecificationID: specificationID,
+		AgainstID:       params.Against,
+		UserID:          user.UUID,
+	}, true
+}
+
+func decodeSpecificationExportQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	specificationID string,
+) (qry query.SpecificationExport, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return query.SpecificationExport{
+		SpecificationID: specificationID,
+		UserID:          user.UUID,
+	}, true
+}
+
+func renderSpecificationExportResponse(
+	w http.ResponseWriter,
+	export query.SpecificationExportModel,
+	params ExportSpecificationParams,
+) {
+	if params.Download != nil && *params.Download {
+		w.Header().Set(
+			"Content-Disposition",
+			fmt.Sprintf(`attachment; filename="specification-%s.yml"`, export.SpecificationID),
+		)
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(export.Content)
+}
+
+func renderSpecificationDiffResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	diff query.SpecificationDiffModel,
+) {
+	response := SpecificationDiffResponse{
+		SpecificationId: diff.SpecificationID,
+		AgainstId:       diff.AgainstID,
+		Changes:         make([]SpecificationChange, 0, len(diff.Changes)),
+	}
+
+	for _, c := range diff.Changes {
+		change := SpecificationChange{
+			Slug:     c.Slug,
+			SlugKind: SpecificationChangeSlugKind(c.SlugKind),
+			Kind:     SpecificationChangeKind(c.Kind),
+			Fields:   make([]FieldChange, 0, len(c.Fields)),
+		}
+
+		for _, f := range c.Fields {
+			change.Fields = append(change.Fields, FieldChange{
+				Field: f.Field,
+				From:  newFieldChangeValue(f.From),
+				To:    newFieldChangeValue(f.To),
+			})
+		}
+
+		response.Changes = append(response.Changes, change)
+	}
+
+	render.Respond(w, r, response)
+}
+
+func newFieldChangeValue(v interface{}) interface{} {
+	asserts, ok := v.([]query.AssertModel)
+	if !ok {
+		return v
+	}
+
+	values := make([]map[string]interface{}, 0, len(asserts))
+
+	for _, a := range asserts {
+		values = append(values, map[string]interface{}{
+			"actual":   a.Actual,
+			"expected": a.Expected,
+		})
+	}
+
+	return values
+}
+
 func decodeSpecificSpecificationQuery(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -53,6 +241,58 @@ func decodeSpecificSpecificationQuery(
 	}, true
 }
 
+func renderLintProblemsResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	problems []query.LintProblemModel,
+) {
+	response := LintProblemsResponse{
+		Problems: make([]LintProblem, 0, len(problems)),
+	}
+
+	for _, p := range problems {
+		response.Problems = append(response.Problems, LintProblem{
+			Rule:     LintProblemRule(p.Rule),
+			Severity: LintSeverity(p.Severity),
+			Slug:     p.Slug,
+			SlugKind: LintProblemSlugKind(p.SlugKind),
+			Message:  p.Message,
+		})
+	}
+
+	render.Respond(w, r, response)
+}
+
+// renderSpecificationSourceError responds with diagnostics
+// of the build error tree, one for each nested error.
+func renderSpecificationSourceError(w http.ResponseWriter, r *http.Request, err error) {
+	details := specification.FlattenBuildError(err)
+
+	response := SpecificationSourceError{
+		Slug:        ErrorSlugInvalidSpecificationSource,
+		Details:     err.Error(),
+		Diagnostics: make([]SpecificationDiagnostic, 0, len(details)),
+	}
+
+	for _, d := range details {
+		diagnostic := SpecificationDiagnostic{
+			Message: d.Err.Error(),
+			Line:    intOrNil(d.Position.Line),
+			Column:  intOrNil(d.Position.Column),
+		}
+
+		if len(d.Contexts) > 0 {
+			contexts := d.Contexts
+			diagnostic.Context = &contexts
+		}
+
+		response.Diagnostics = append(response.Diagnostics, diagnostic)
+	}
+
+	render.Status(r, http.StatusUnprocessableEntity)
+	render.Respond(w, r, response)
+}
+
 func renderSpecificationResponse(
 	w http.ResponseWriter,
 	r *http.Request,
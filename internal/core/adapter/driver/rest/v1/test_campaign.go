@@ -8,7 +8,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app/query"
 	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
 )
 
 func (h handler) CreateTestCampaign(w http.ResponseWriter, r *http.Request) {
@@ -31,8 +33,26 @@ func (h handler) CreateTestCampaign(w http.ResponseWriter, r *http.Request) {
 	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
-func (h handler) GetTestCampaigns(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+func (h handler) GetTestCampaigns(w http.ResponseWriter, r *http.Request, params GetTestCampaignsParams) {
+	qry, ok := decodeTestCampaignsQuery(w, r, params)
+	if !ok {
+		return
+	}
+
+	tcs, err := h.app.Queries.TestCampaigns.Handle(r.Context(), qry)
+	if err == nil {
+		renderTestCampaignsResponse(w, r, tcs)
+
+		return
+	}
+
+	if errors.Is(err, query.ErrInvalidCursor) {
+		rest.BadRequest(string(ErrorSlugInvalidCursor), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
 func (h handler) GetTestCampaign(w http.ResponseWriter, r *http.Request, testCampaignID string) {
@@ -57,6 +77,73 @@ func (h handler) GetTestCampaign(w http.ResponseWriter, r *http.Request, testCam
 	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
 
-func (h handler) RemoveTestCampaign(w http.ResponseWriter, _ *http.Request, _ string) {
-	w.WriteHeader(http.StatusNotImplemented)
+func (h handler) UpdateTestCampaign(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	cmd, ok := decodeUpdateTestCampaignCommand(w, r, testCampaignID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.UpdateTestCampaign.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeTestCampaign), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) RemoveTestCampaign(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	params RemoveTestCampaignParams,
+) {
+	cmd, ok := decodeRemoveTestCampaignCommand(w, r, testCampaignID, params)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.RemoveTestCampaign.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeTestCampaign), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignHasStartedPipelines) {
+		rest.Conflict(string(ErrorSlugTestCampaignHasStartedPipelines), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
 }
@@ -38,6 +38,82 @@ func decodeCreateTestCampaignCommand(
 	}, true
 }
 
+func decodeUpdateTestCampaignCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+) (cmd command.UpdateTestCampaign, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	var rb UpdateTestCampaignRequest
+
+	if ok = decode(w, r, &rb); !ok {
+		return
+	}
+
+	return command.UpdateTestCampaign{
+		TestCampaignID: testCampaignID,
+		UpdatedByID:    user.UUID,
+		ViewName:       rb.ViewName,
+		Summary:        rb.Summary,
+	}, true
+}
+
+func decodeRemoveTestCampaignCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	params RemoveTestCampaignParams,
+) (cmd command.RemoveTestCampaign, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	cmd = command.RemoveTestCampaign{
+		TestCampaignID: testCampaignID,
+		RemovedByID:    user.UUID,
+	}
+
+	if params.Archive != nil {
+		cmd.Archive = *params.Archive
+	}
+
+	return cmd, true
+}
+
+func decodeTestCampaignsQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	params GetTestCampaignsParams,
+) (qry query.TestCampaigns, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	qry = query.TestCampaigns{
+		UserID: user.UUID,
+	}
+
+	if params.Search != nil {
+		qry.Search = *params.Search
+	}
+
+	if params.Cursor != nil {
+		qry.Cursor = *params.Cursor
+	}
+
+	if params.Limit != nil {
+		qry.Limit = *params.Limit
+	}
+
+	return qry, true
+}
+
 func decodeSpecificTestCampaignQuery(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -59,12 +135,31 @@ func renderTestCampaignResponse(
 	r *http.Request,
 	tc query.TestCampaignModel,
 ) {
-	response := TestCampaignResponse{
+	render.Respond(w, r, newTestCampaignResponse(tc))
+}
+
+func renderTestCampaignsResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	tcs query.TestCampaignsModel,
+) {
+	response := TestCampaignsResponse{
+		TestCampaigns: make([]TestCampaignResponse, 0, len(tcs.TestCampaigns)),
+		NextCursor:    stringOrNil(tcs.NextCursor),
+	}
+
+	for _, tc := range tcs.TestCampaigns {
+		response.TestCampaigns = append(response.TestCampaigns, newTestCampaignResponse(tc))
+	}
+
+	render.Respond(w, r, response)
+}
+
+func newTestCampaignResponse(tc query.TestCampaignModel) TestCampaignResponse {
+	return TestCampaignResponse{
 		Id:        tc.ID,
 		ViewName:  tc.ViewName,
 		Summary:   &tc.Summary,
 		CreatedAt: tc.CreatedAt,
 	}
-
-	render.Respond(w, r, response)
 }
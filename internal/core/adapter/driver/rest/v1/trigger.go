@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+// NewWebhookHandler returns handler of pipeline triggering webhooks.
+// Webhooks are signed with trigger tokens instead of the user
+// authentication, so the handler is mounted without auth middleware.
+func NewWebhookHandler(application *app.Application, logger service.Logger) http.Handler {
+	wrapper := ServerInterfaceWrapper{
+		Handler: handler{
+			app:    application,
+			logger: logger,
+		},
+		ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			rest.BadRequest(string(ErrorSlugBadRequest), err, w, r)
+		},
+	}
+
+	r := chi.NewRouter()
+	r.Post("/{testCampaignId}", wrapper.TriggerPipeline)
+
+	return r
+}
+
+func (h handler) IssueTriggerToken(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	cmd, ok := decodeIssueTriggerTokenCommand(w, r, testCampaignID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.IssueTriggerToken.Handle(r.Context(), cmd)
+	if err == nil {
+		render.Respond(w, r, TriggerTokenResponse{Token: cmd.Token})
+
+		return
+	}
+
+	renderTriggerTokenError(w, r, err)
+}
+
+func (h handler) RevokeTriggerToken(w http.ResponseWriter, r *http.Request, testCampaignID string) {
+	cmd, ok := decodeRevokeTriggerTokenCommand(w, r, testCampaignID)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.IssueTriggerToken.Handle(r.Context(), cmd)
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	renderTriggerTokenError(w, r, err)
+}
+
+func renderTriggerTokenError(w http.ResponseWriter, r *http.Request, err error) {
+	var aerr *user.AccessError
+
+	if errors.As(err, &aerr) {
+		rest.Forbidden(string(ErrorSlugUserCantSeeTestCampaign), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTestCampaignNotFound) {
+		rest.NotFound(string(ErrorSlugTestCampaignNotFound), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
+
+func (h handler) TriggerPipeline(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+	params TriggerPipelineParams,
+) {
+	cmd, ok := decodeTriggerPipelineCommand(w, r, uuid.New().String(), testCampaignID, params)
+	if !ok {
+		return
+	}
+
+	err := h.app.Commands.TriggerPipeline.Handle(r.Context(), cmd)
+	if err == nil {
+		w.Header().Set("Location", fmt.Sprintf("/pipelines/%s", cmd.PipelineID))
+		w.WriteHeader(http.StatusAccepted)
+
+		return
+	}
+
+	// Unknown test campaign and test campaign without trigger
+	// token are reported as invalid signature, so the webhook
+	// doesn't reveal which test campaigns exist.
+	if errors.Is(err, service.ErrTestCampaignNotFound) ||
+		errors.Is(err, testcampaign.ErrTriggerTokenNotIssued) ||
+		errors.Is(err, testcampaign.ErrInvalidTriggerSignature) {
+		rest.Unauthorized(string(ErrorSlugInvalidTriggerSignature), testcampaign.ErrInvalidTriggerSignature, w, r)
+
+		return
+	}
+
+	if errors.Is(err, testcampaign.ErrTriggerTimestampOutOfDate) {
+		rest.Unauthorized(string(ErrorSlugInvalidTriggerSignature), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrTriggerReplayed) {
+		rest.Conflict(string(ErrorSlugTriggerReplayed), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrSpecificationNotFound) {
+		rest.NotFound(string(ErrorSlugSpecificationNotFound), err, w, r)
+
+		return
+	}
+
+	if errors.Is(err, service.ErrNoPipelineWorkers) {
+		rest.ServiceUnavailable(string(ErrorSlugNoPipelineWorkers), err, w, r)
+
+		return
+	}
+
+	rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+const (
+	triggerTokenSize      = 32
+	maxTriggerPayloadSize = 1 << 20
+)
+
+func decodeIssueTriggerTokenCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+) (cmd command.IssueTriggerToken, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	token := make([]byte, triggerTokenSize)
+
+	if _, err := rand.Read(token); err != nil {
+		rest.InternalServerError(string(ErrorSlugUnexpectedError), err, w, r)
+
+		return cmd, false
+	}
+
+	return command.IssueTriggerToken{
+		TestCampaignID: testCampaignID,
+		IssuedByID:     user.UUID,
+		Token:          hex.EncodeToString(token),
+	}, true
+}
+
+func decodeRevokeTriggerTokenCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	testCampaignID string,
+) (cmd command.IssueTriggerToken, ok bool) {
+	user, ok := authorize(w, r)
+	if !ok {
+		return
+	}
+
+	return command.IssueTriggerToken{
+		TestCampaignID: testCampaignID,
+		IssuedByID:     user.UUID,
+	}, true
+}
+
+func decodeTriggerPipelineCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	pipelineID string,
+	testCampaignID string,
+	params TriggerPipelineParams,
+) (cmd command.TriggerPipeline, ok bool) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTriggerPayloadSize))
+	if err != nil {
+		rest.BadRequest(string(ErrorSlugBadRequest), err, w, r)
+
+		return cmd, false
+	}
+
+	var rb TriggerPipelineRequest
+
+	if len(payload) > 0 {
+		// Only priority of the run can be chosen by the trigger,
+		// other parameters are rejected instead of being ignored.
+		dec := json.NewDecoder(bytes.NewReader(payload))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&rb); err != nil {
+			rest.BadRequest(string(ErrorSlugBadRequest), err, w, r)
+
+			return cmd, false
+		}
+	}
+
+	priority := service.ManualPriority
+	if rb.Priority != nil && *rb.Priority == PipelinePrioritySCHEDULED {
+		priority = service.ScheduledPriority
+	}
+
+	return command.TriggerPipeline{
+		PipelineID:     pipelineID,
+		TestCampaignID: testCampaignID,
+		Payload:        payload,
+		Signature:      params.XThestisSignature,
+		Timestamp:      time.Unix(params.XThestisTimestamp, 0),
+		Priority:       priority,
+	}, true
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/render"
 
@@ -17,3 +18,27 @@ func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
 
 	return true
 }
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func intOrNil(i int) *int {
+	if i == 0 {
+		return nil
+	}
+
+	return &i
+}
@@ -12,16 +12,43 @@ type (
 	}
 
 	Commands struct {
-		CreateTestCampaign command.CreateTestCampaignHandler
-		LoadSpecification  command.LoadSpecificationHandler
-		StartPipeline      command.StartPipelineHandler
-		RestartPipeline    command.RestartPipelineHandler
-		CancelPipeline     command.CancelPipelineHandler
+		CreateTestCampaign    command.CreateTestCampaignHandler
+		UpdateTestCampaign    command.UpdateTestCampaignHandler
+		RemoveTestCampaign    command.RemoveTestCampaignHandler
+		CreateSchedule        command.CreateScheduleHandler
+		UpdateSchedule        command.UpdateScheduleHandler
+		RemoveSchedule        command.RemoveScheduleHandler
+		IssueTriggerToken     command.IssueTriggerTokenHandler
+		CreateSubscription    command.CreateSubscriptionHandler
+		RemoveSubscription    command.RemoveSubscriptionHandler
+		LoadSpecification     command.LoadSpecificationHandler
+		ActivateSpecification command.ActivateSpecificationHandler
+		StartPipeline         command.StartPipelineHandler
+		TriggerPipeline       command.TriggerPipelineHandler
+		RestartPipeline       command.RestartPipelineHandler
+		RunPipeline           command.RunPipelineHandler
+		CancelPipeline        command.CancelPipelineHandler
+		PausePipeline         command.PausePipelineHandler
+		ResumePipeline        command.ResumePipelineHandler
+		DequeuePipeline       command.DequeuePipelineHandler
 	}
 
 	Queries struct {
-		TestCampaign  query.TestCampaignHandler
-		Specification query.SpecificationHandler
-		Pipeline      query.PipelineHandler
+		TestCampaign         query.TestCampaignHandler
+		TestCampaigns        query.TestCampaignsHandler
+		Schedules            query.SchedulesHandler
+		Subscriptions        query.SubscriptionsHandler
+		Notifications        query.NotificationsHandler
+		Specification        query.SpecificationHandler
+		SpecificationHistory query.SpecificationHistoryHandler
+		SpecificationDiff    query.SpecificationDiffHandler
+		SpecificationLint    query.SpecificationLintHandler
+		SpecificationExport  query.SpecificationExportHandler
+		SpecificationSchema  query.SpecificationSchemaHandler
+		Pipeline             query.PipelineHandler
+		PipelineHistory      query.PipelineHistoryHandler
+		PipelineSteps        query.PipelineStepsHandler
+		PipelineQueue        query.PipelineQueueHandler
+		FlowReport           query.FlowReportHandler
 	}
 )
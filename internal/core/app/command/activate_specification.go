@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type ActivateSpecification struct {
+	SpecificationID string
+	ActivatedByID   string
+}
+
+type ActivateSpecificationHandler interface {
+	Handle(ctx context.Context, cmd ActivateSpecification) error
+}
+
+type activateSpecificationHandler struct {
+	specRepo service.SpecificationRepository
+}
+
+func NewActivateSpecificationHandler(specRepo service.SpecificationRepository) ActivateSpecificationHandler {
+	if specRepo == nil {
+		panic("specification repository is nil")
+	}
+
+	return activateSpecificationHandler{specRepo: specRepo}
+}
+
+func (h activateSpecificationHandler) Handle(
+	ctx context.Context,
+	cmd ActivateSpecification,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "specification activation")
+	}()
+
+	spec, err := h.specRepo.GetSpecification(ctx, cmd.SpecificationID)
+	if err != nil {
+		return err
+	}
+
+	if err := user.CanAccessSpecification(cmd.ActivatedByID, spec, user.Write); err != nil {
+		return err
+	}
+
+	return h.specRepo.ActivateSpecification(ctx, spec.ID())
+}
@@ -0,0 +1,149 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewActivateSpecificationHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		GivenSpecRepo service.SpecificationRepository
+		ShouldPanic   bool
+		PanicMessage  string
+	}{
+		{
+			Name:          "all_dependencies_are_not_nil",
+			GivenSpecRepo: mock.NewSpecificationRepository(),
+			ShouldPanic:   false,
+		},
+		{
+			Name:          "all_dependencies_are_nil",
+			GivenSpecRepo: nil,
+			ShouldPanic:   true,
+			PanicMessage:  "specification repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewActivateSpecificationHandler(c.GivenSpecRepo)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleActivateSpecification(t *testing.T) {
+	t.Parallel()
+
+	const testCampaignID = "6a0f3e1d-2c4b-4a59-8e7d-1f0a9b8c7d6e"
+
+	var (
+		olderSpec = (&specification.Builder{}).
+				WithID("0c1d2e3f-4a5b-4c6d-9e7f-8a9b0c1d2e3f").
+				WithTestCampaignID(testCampaignID).
+				WithOwnerID("3b4c5d6e-7f8a-4b9c-8d0e-1f2a3b4c5d6e").
+				ErrlessBuild()
+		newerSpec = (&specification.Builder{}).
+				WithID("9f8e7d6c-5b4a-4392-a1f0-e9d8c7b6a5f4").
+				WithTestCampaignID(testCampaignID).
+				WithOwnerID("3b4c5d6e-7f8a-4b9c-8d0e-1f2a3b4c5d6e").
+				ErrlessBuild()
+	)
+
+	testCases := []struct {
+		Name             string
+		Command          command.ActivateSpecification
+		ExpectedActiveID string
+		ShouldBeErr      bool
+		IsErr            func(err error) bool
+	}{
+		{
+			Name: "activate_older_specification",
+			Command: command.ActivateSpecification{
+				SpecificationID: "0c1d2e3f-4a5b-4c6d-9e7f-8a9b0c1d2e3f",
+				ActivatedByID:   "3b4c5d6e-7f8a-4b9c-8d0e-1f2a3b4c5d6e",
+			},
+			ExpectedActiveID: "0c1d2e3f-4a5b-4c6d-9e7f-8a9b0c1d2e3f",
+			ShouldBeErr:      false,
+		},
+		{
+			Name: "specification_not_found",
+			Command: command.ActivateSpecification{
+				SpecificationID: "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f8a",
+				ActivatedByID:   "3b4c5d6e-7f8a-4b9c-8d0e-1f2a3b4c5d6e",
+			},
+			ExpectedActiveID: "9f8e7d6c-5b4a-4392-a1f0-e9d8c7b6a5f4",
+			ShouldBeErr:      true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrSpecificationNotFound)
+			},
+		},
+		{
+			Name: "user_cant_activate_specification",
+			Command: command.ActivateSpecification{
+				SpecificationID: "0c1d2e3f-4a5b-4c6d-9e7f-8a9b0c1d2e3f",
+				ActivatedByID:   "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b",
+			},
+			ExpectedActiveID: "9f8e7d6c-5b4a-4392-a1f0-e9d8c7b6a5f4",
+			ShouldBeErr:      true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			specRepo := mock.NewSpecificationRepository()
+			require.NoError(t, specRepo.AddSpecification(ctx, olderSpec))
+			require.NoError(t, specRepo.AddSpecification(ctx, newerSpec))
+
+			handler := command.NewActivateSpecificationHandler(specRepo)
+
+			err := handler.Handle(ctx, c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+			} else {
+				require.NoError(t, err)
+			}
+
+			active, err := specRepo.GetActiveSpecificationByTestCampaignID(ctx, testCampaignID)
+			require.NoError(t, err)
+			require.Equal(t, c.ExpectedActiveID, active.ID())
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type CreateSchedule struct {
+	ScheduleID     string
+	TestCampaignID string
+	CreatedByID    string
+	Cron           string
+	Timezone       string
+	Environment    string
+	Filter         string
+}
+
+type CreateScheduleHandler interface {
+	Handle(ctx context.Context, cmd CreateSchedule) error
+}
+
+type createScheduleHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+}
+
+func NewCreateScheduleHandler(repo service.TestCampaignRepository) CreateScheduleHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	return createScheduleHandler{testCampaignRepo: repo}
+}
+
+func (h createScheduleHandler) Handle(
+	ctx context.Context,
+	cmd CreateSchedule,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "schedule creation")
+	}()
+
+	schedule, err := testcampaign.NewSchedule(testcampaign.ScheduleParams{
+		ID:          cmd.ScheduleID,
+		Cron:        cmd.Cron,
+		Timezone:    cmd.Timezone,
+		Environment: cmd.Environment,
+		Filter:      cmd.Filter,
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.testCampaignRepo.UpdateTestCampaign(
+		ctx,
+		cmd.TestCampaignID,
+		func(
+			_ context.Context,
+			tc *testcampaign.TestCampaign,
+		) (*testcampaign.TestCampaign, error) {
+			if err := user.CanAccessTestCampaign(cmd.CreatedByID, tc, user.Write); err != nil {
+				return nil, err
+			}
+
+			if err := tc.AddSchedule(schedule); err != nil {
+				return nil, err
+			}
+
+			return tc, nil
+		},
+	)
+}
@@ -0,0 +1,217 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewCreateScheduleHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "all_dependencies_are_nil",
+			GivenTestCampaignRepo: nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewCreateScheduleHandler(c.GivenTestCampaignRepo)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleCreateSchedule(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		Command      command.CreateSchedule
+		TestCampaign *testcampaign.TestCampaign
+		ShouldBeErr  bool
+		IsErr        func(err error) bool
+	}{
+		{
+			Name: "successful_creating",
+			Command: command.CreateSchedule{
+				ScheduleID:     "b1a7c5de-8f0e-4b1b-9a6e-3c2d1e0f9a8b",
+				TestCampaignID: "3f6a2c1d-7e8b-4a9c-b0d1-e2f3a4b5c6d7",
+				CreatedByID:    "c4d5e6f7-a8b9-4c0d-8e1f-2a3b4c5d6e7f",
+				Cron:           "*/15 * * * *",
+				Timezone:       "Europe/Moscow",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "3f6a2c1d-7e8b-4a9c-b0d1-e2f3a4b5c6d7",
+				OwnerID: "c4d5e6f7-a8b9-4c0d-8e1f-2a3b4c5d6e7f",
+			}),
+			ShouldBeErr: false,
+		},
+		{
+			Name: "invalid_cron",
+			Command: command.CreateSchedule{
+				ScheduleID:     "0d9c8b7a-6f5e-4d3c-b2a1-0f9e8d7c6b5a",
+				TestCampaignID: "8a7b6c5d-4e3f-4a2b-9c1d-0e9f8a7b6c5d",
+				CreatedByID:    "1f2e3d4c-5b6a-4978-8e6f-5d4c3b2a1f0e",
+				Cron:           "every night",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "8a7b6c5d-4e3f-4a2b-9c1d-0e9f8a7b6c5d",
+				OwnerID: "1f2e3d4c-5b6a-4978-8e6f-5d4c3b2a1f0e",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrInvalidCron)
+			},
+		},
+		{
+			Name: "invalid_filter",
+			Command: command.CreateSchedule{
+				ScheduleID:     "2e1f0a9b-8c7d-4e6f-a5b4-c3d2e1f0a9b8",
+				TestCampaignID: "3f2a1b0c-9d8e-4f7a-b6c5-d4e3f2a1b0c9",
+				CreatedByID:    "c4d5e6f7-a8b9-4c0d-8e1f-2a3b4c5d6e7f",
+				Cron:           "@daily",
+				Environment:    "staging",
+				Filter:         "payments,,checkout",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "3f2a1b0c-9d8e-4f7a-b6c5-d4e3f2a1b0c9",
+				OwnerID: "c4d5e6f7-a8b9-4c0d-8e1f-2a3b4c5d6e7f",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, specification.ErrInvalidScenarioFilter)
+			},
+		},
+		{
+			Name: "test_campaign_not_found",
+			Command: command.CreateSchedule{
+				ScheduleID:     "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d",
+				TestCampaignID: "9e8d7c6b-5a4f-4e3d-a2c1-b0a9f8e7d6c5",
+				CreatedByID:    "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+				Cron:           "@daily",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "4d5e6f7a-8b9c-4d0e-af1b-2c3d4e5f6a7b",
+				OwnerID: "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTestCampaignNotFound)
+			},
+		},
+		{
+			Name: "user_cant_create_schedule",
+			Command: command.CreateSchedule{
+				ScheduleID:     "6c5b4a3f-2e1d-4c0b-9a8f-7e6d5c4b3a2f",
+				TestCampaignID: "7f8e9d0c-1b2a-4f3e-8d4c-5b6a7f8e9d0c",
+				CreatedByID:    "3a4b5c6d-7e8f-4a0b-9c1d-2e3f4a5b6c7d",
+				Cron:           "@daily",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "7f8e9d0c-1b2a-4f3e-8d4c-5b6a7f8e9d0c",
+				OwnerID: "e5f6a7b8-c9d0-4e1f-a2b3-c4d5e6f7a8b9",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			Name: "schedule_already_exists",
+			Command: command.CreateSchedule{
+				ScheduleID:     "a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d",
+				TestCampaignID: "f0e1d2c3-b4a5-4968-8776-a5b4c3d2e1f0",
+				CreatedByID:    "0a1b2c3d-4e5f-4a7b-8c9d-0e1f2a3b4c5d",
+				Cron:           "@daily",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "f0e1d2c3-b4a5-4968-8776-a5b4c3d2e1f0",
+				OwnerID: "0a1b2c3d-4e5f-4a7b-8c9d-0e1f2a3b4c5d",
+				Schedules: []testcampaign.Schedule{
+					testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+						ID:   "a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d",
+						Cron: "@hourly",
+					}),
+				},
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrScheduleDuplicated)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				repo    = mock.NewTestCampaignRepository(c.TestCampaign)
+				handler = command.NewCreateScheduleHandler(repo)
+			)
+
+			ctx := context.Background()
+
+			err := handler.Handle(ctx, c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tc, err := repo.GetTestCampaign(ctx, c.Command.TestCampaignID)
+			require.NoError(t, err)
+
+			schedule, err := tc.Schedule(c.Command.ScheduleID)
+			require.NoError(t, err)
+
+			require.Equal(t, c.Command.Cron, schedule.Cron())
+			require.Equal(t, c.Command.Timezone, schedule.Timezone())
+			require.Equal(t, c.Command.Environment, schedule.Environment())
+		})
+	}
+}
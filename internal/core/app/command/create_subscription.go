@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type CreateSubscription struct {
+	SubscriptionID string
+	TestCampaignID string
+	CreatedByID    string
+	URL            string
+	Filter         testcampaign.Event
+	Secret         string
+}
+
+type CreateSubscriptionHandler interface {
+	Handle(ctx context.Context, cmd CreateSubscription) error
+}
+
+type createSubscriptionHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+	allowedHosts     []string
+}
+
+// NewCreateSubscriptionHandler returns handler creating subscriptions
+// only to URLs with allowedHosts, see testcampaign.Subscription CheckHost.
+func NewCreateSubscriptionHandler(
+	repo service.TestCampaignRepository,
+	allowedHosts []string,
+) CreateSubscriptionHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	return createSubscriptionHandler{
+		testCampaignRepo: repo,
+		allowedHosts:     allowedHosts,
+	}
+}
+
+func (h createSubscriptionHandler) Handle(
+	ctx context.Context,
+	cmd CreateSubscription,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "subscription creation")
+	}()
+
+	subscription, err := testcampaign.NewSubscription(testcampaign.SubscriptionParams{
+		ID:     cmd.SubscriptionID,
+		URL:    cmd.URL,
+		Filter: cmd.Filter,
+		Secret: cmd.Secret,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := subscription.CheckHost(h.allowedHosts); err != nil {
+		return err
+	}
+
+	return h.testCampaignRepo.UpdateTestCampaign(
+		ctx,
+		cmd.TestCampaignID,
+		func(
+			_ context.Context,
+			tc *testcampaign.TestCampaign,
+		) (*testcampaign.TestCampaign, error) {
+			if err := user.CanAccessTestCampaign(cmd.CreatedByID, tc, user.Write); err != nil {
+				return nil, err
+			}
+
+			if err := tc.AddSubscription(subscription); err != nil {
+				return nil, err
+			}
+
+			return tc, nil
+		},
+	)
+}
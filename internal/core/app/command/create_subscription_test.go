@@ -0,0 +1,258 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewCreateSubscriptionHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "all_dependencies_are_nil",
+			GivenTestCampaignRepo: nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewCreateSubscriptionHandler(c.GivenTestCampaignRepo, nil)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleCreateSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		Command      command.CreateSubscription
+		TestCampaign *testcampaign.TestCampaign
+		ShouldBeErr  bool
+		IsErr        func(err error) bool
+	}{
+		{
+			Name: "successful_creating",
+			Command: command.CreateSubscription{
+				SubscriptionID: "2c4e6a8b-0d1f-4a3c-9e5b-7d9f1b3d5f7a",
+				TestCampaignID: "4e6a8c0d-2f3b-4c5e-8a7d-9f1b3d5f7a9c",
+				CreatedByID:    "6a8c0e2f-4b5d-4e7a-9c9f-1b3d5f7a9c1e",
+				URL:            "https://ci.example.com/hooks/thestis",
+				Filter:         testcampaign.FailedEvent,
+				Secret:         "secret",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "4e6a8c0d-2f3b-4c5e-8a7d-9f1b3d5f7a9c",
+				OwnerID: "6a8c0e2f-4b5d-4e7a-9c9f-1b3d5f7a9c1e",
+			}),
+			ShouldBeErr: false,
+		},
+		{
+			Name: "invalid_url",
+			Command: command.CreateSubscription{
+				SubscriptionID: "8c0e2a4b-6d7f-4a9c-8e1b-3d5f7a9c1e3a",
+				TestCampaignID: "0e2a4c6d-8f9b-4c1e-9a3d-5f7a9c1e3a5c",
+				CreatedByID:    "2a4c6e8f-0b1d-4e3a-8c5f-7a9c1e3a5c7e",
+				URL:            "ci.example.com",
+				Secret:         "secret",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "0e2a4c6d-8f9b-4c1e-9a3d-5f7a9c1e3a5c",
+				OwnerID: "2a4c6e8f-0b1d-4e3a-8c5f-7a9c1e3a5c7e",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrInvalidSubscriptionURL)
+			},
+		},
+		{
+			Name: "invalid_filter",
+			Command: command.CreateSubscription{
+				SubscriptionID: "4c6e8a0b-2d3f-4a5c-9e7b-9c1e3a5c7e9a",
+				TestCampaignID: "6e8a0c2d-4f5b-4c7e-8a9d-1e3a5c7e9a1c",
+				CreatedByID:    "8a0c2e4f-6b7d-4e9a-9c1f-3a5c7e9a1c3e",
+				URL:            "https://ci.example.com",
+				Filter:         "succeeded",
+				Secret:         "secret",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "6e8a0c2d-4f5b-4c7e-8a9d-1e3a5c7e9a1c",
+				OwnerID: "8a0c2e4f-6b7d-4e9a-9c1f-3a5c7e9a1c3e",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrInvalidSubscriptionEvent)
+			},
+		},
+		{
+			Name: "empty_secret",
+			Command: command.CreateSubscription{
+				SubscriptionID: "1b3d5f7a-9c1e-4a3c-8e5b-7d9f1b3d5f7b",
+				TestCampaignID: "3d5f7a9c-1e3a-4c5e-9a7d-9f1b3d5f7a9d",
+				CreatedByID:    "5f7a9c1e-3a5c-4e7a-8c9f-1b3d5f7a9c1f",
+				URL:            "https://ci.example.com",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "3d5f7a9c-1e3a-4c5e-9a7d-9f1b3d5f7a9d",
+				OwnerID: "5f7a9c1e-3a5c-4e7a-8c9f-1b3d5f7a9c1f",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrEmptySubscriptionSecret)
+			},
+		},
+		{
+			Name: "host_not_allowed",
+			Command: command.CreateSubscription{
+				SubscriptionID: "7a9c1e3a-5c7e-4a9c-9e1b-3d5f7a9c1e3b",
+				TestCampaignID: "9c1e3a5c-7e9a-4c1e-8a3d-5f7a9c1e3a5d",
+				CreatedByID:    "1e3a5c7e-9a1c-4e3a-9c5f-7a9c1e3a5c7f",
+				URL:            "http://10.0.0.1:2379/v2/keys",
+				Secret:         "secret",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "9c1e3a5c-7e9a-4c1e-8a3d-5f7a9c1e3a5d",
+				OwnerID: "1e3a5c7e-9a1c-4e3a-9c5f-7a9c1e3a5c7f",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrSubscriptionHostNotAllowed)
+			},
+		},
+		{
+			Name: "test_campaign_not_found",
+			Command: command.CreateSubscription{
+				SubscriptionID: "0c2e4a6b-8d9f-4a1c-8e3b-5c7e9a1c3e5a",
+				TestCampaignID: "2e4a6c8d-0f1b-4c3e-9a5d-7e9a1c3e5a7c",
+				CreatedByID:    "4a6c8e0f-2b3d-4e5a-8c7f-9a1c3e5a7c9e",
+				URL:            "https://ci.example.com",
+				Secret:         "secret",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "6c8e0a2b-4d5f-4a7c-9e9b-1c3e5a7c9e1a",
+				OwnerID: "4a6c8e0f-2b3d-4e5a-8c7f-9a1c3e5a7c9e",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTestCampaignNotFound)
+			},
+		},
+		{
+			Name: "user_cant_create_subscription",
+			Command: command.CreateSubscription{
+				SubscriptionID: "8e0a2c4d-6f7b-4c9e-8a1d-3e5a7c9e1a3c",
+				TestCampaignID: "0a2c4e6f-8b9d-4e1a-9c3f-5a7c9e1a3c5e",
+				CreatedByID:    "2c4e6a8b-0d1f-4a3c-8e5b-7c9e1a3c5e7a",
+				URL:            "https://ci.example.com",
+				Secret:         "secret",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "0a2c4e6f-8b9d-4e1a-9c3f-5a7c9e1a3c5e",
+				OwnerID: "4e6a8c0d-2f3b-4c5e-9a7d-9e1a3c5e7a9c",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			Name: "subscription_already_exists",
+			Command: command.CreateSubscription{
+				SubscriptionID: "6a8c0e2f-4b5d-4e7a-8c9f-1e3a5c7e9a1b",
+				TestCampaignID: "8c0e2a4b-6d7f-4a9c-9e1b-3a5c7e9a1b3d",
+				CreatedByID:    "0e2a4c6d-8f9b-4c1e-8a3d-5c7e9a1b3d5f",
+				URL:            "https://ci.example.com",
+				Secret:         "secret",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "8c0e2a4b-6d7f-4a9c-9e1b-3a5c7e9a1b3d",
+				OwnerID: "0e2a4c6d-8f9b-4c1e-8a3d-5c7e9a1b3d5f",
+				Subscriptions: []testcampaign.Subscription{
+					testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+						ID:     "6a8c0e2f-4b5d-4e7a-8c9f-1e3a5c7e9a1b",
+						URL:    "https://another.example.com",
+						Secret: "another-secret",
+					}),
+				},
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrSubscriptionDuplicated)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				repo    = mock.NewTestCampaignRepository(c.TestCampaign)
+				handler = command.NewCreateSubscriptionHandler(repo, []string{"*.example.com"})
+			)
+
+			ctx := context.Background()
+
+			err := handler.Handle(ctx, c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tc, err := repo.GetTestCampaign(ctx, c.Command.TestCampaignID)
+			require.NoError(t, err)
+
+			subscriptions := tc.Subscriptions()
+			require.Len(t, subscriptions, 1)
+
+			require.Equal(t, c.Command.SubscriptionID, subscriptions[0].ID())
+			require.Equal(t, c.Command.URL, subscriptions[0].URL())
+			require.Equal(t, c.Command.Filter, subscriptions[0].Filter())
+			require.Equal(t, c.Command.Secret, subscriptions[0].Secret())
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type DequeuePipeline struct {
+	PipelineID   string
+	DequeuedByID string
+}
+
+type DequeuePipelineHandler interface {
+	Handle(ctx context.Context, cmd DequeuePipeline) error
+}
+
+type dequeuePipelineHandler struct {
+	pipeRepo service.PipelineRepository
+	queue    service.PipelineQueue
+}
+
+func NewDequeuePipelineHandler(
+	pipeRepo service.PipelineRepository,
+	queue service.PipelineQueue,
+) DequeuePipelineHandler {
+	if pipeRepo == nil {
+		panic("pipeline repository is nil")
+	}
+
+	if queue == nil {
+		panic("pipeline queue is nil")
+	}
+
+	return dequeuePipelineHandler{
+		pipeRepo: pipeRepo,
+		queue:    queue,
+	}
+}
+
+func (h dequeuePipelineHandler) Handle(ctx context.Context, cmd DequeuePipeline) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "pipeline dequeuing")
+	}()
+
+	pipe, err := h.pipeRepo.GetPipeline(ctx, cmd.PipelineID, service.WithoutSpecification())
+	if err != nil {
+		return err
+	}
+
+	if err := user.CanAccessPipeline(cmd.DequeuedByID, pipe, user.Read); err != nil {
+		return err
+	}
+
+	return h.queue.RemovePipeline(pipe.ID())
+}
@@ -0,0 +1,210 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewDequeuePipelineHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		GivenPipeRepo service.PipelineRepository
+		GivenQueue    service.PipelineQueue
+		ShouldPanic   bool
+		PanicMessage  string
+	}{
+		{
+			Name:          "all_dependencies_are_not_nil",
+			GivenPipeRepo: mock.NewPipelineRepository(),
+			GivenQueue:    mock.NewPipelineQueue(),
+			ShouldPanic:   false,
+		},
+		{
+			Name:          "pipeline_repository_is_nil",
+			GivenPipeRepo: nil,
+			GivenQueue:    mock.NewPipelineQueue(),
+			ShouldPanic:   true,
+			PanicMessage:  "pipeline repository is nil",
+		},
+		{
+			Name:          "pipeline_queue_is_nil",
+			GivenPipeRepo: mock.NewPipelineRepository(),
+			GivenQueue:    nil,
+			ShouldPanic:   true,
+			PanicMessage:  "pipeline queue is nil",
+		},
+		{
+			Name:          "all_dependencies_are_nil",
+			GivenPipeRepo: nil,
+			GivenQueue:    nil,
+			ShouldPanic:   true,
+			PanicMessage:  "pipeline repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewDequeuePipelineHandler(
+					c.GivenPipeRepo,
+					c.GivenQueue,
+				)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleDequeuePipeline(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                string
+		Command             command.DequeuePipeline
+		Pipeline            *pipeline.Pipeline
+		Queued              []service.QueuedPipeline
+		ExpectedRemoveCalls int
+		ShouldBeErr         bool
+		IsErr               func(err error) bool
+	}{
+		{
+			Name: "pipeline_not_found",
+			Command: command.DequeuePipeline{
+				PipelineID:   "0c6f6f0e-4b8b-4f47-8f7b-1a2b3c4d5e6f",
+				DequeuedByID: "c89ba386-0976-4671-913d-9252ba29aca4",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "4abf2481-0546-4f1e-873f-b6859bbe9bf5",
+				OwnerID: "c89ba386-0976-4671-913d-9252ba29aca4",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrPipelineNotFound)
+			},
+			ExpectedRemoveCalls: 0,
+		},
+		{
+			Name: "user_cannot_see_pipeline",
+			Command: command.DequeuePipeline{
+				PipelineID:   "1ada8d28-dbdc-425b-b829-dbb45cdae2b3",
+				DequeuedByID: "5e1484b4-90ea-4684-bf20-d597446d3eb4",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "1ada8d28-dbdc-425b-b829-dbb45cdae2b3",
+				OwnerID: "759cf65b-547b-4523-a9f4-9dd4f12188d2",
+			}),
+			Queued: []service.QueuedPipeline{
+				{PipelineID: "1ada8d28-dbdc-425b-b829-dbb45cdae2b3"},
+			},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+			ExpectedRemoveCalls: 0,
+		},
+		{
+			Name: "pipeline_not_queued",
+			Command: command.DequeuePipeline{
+				PipelineID:   "b4e252a1-7b94-46b0-84f0-40f92a6d2ee5",
+				DequeuedByID: "93a6224c-3788-49db-a673-ca8683a469ce",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "b4e252a1-7b94-46b0-84f0-40f92a6d2ee5",
+				OwnerID: "93a6224c-3788-49db-a673-ca8683a469ce",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrPipelineNotQueued)
+			},
+			ExpectedRemoveCalls: 1,
+		},
+		{
+			Name: "success_pipeline_dequeuing",
+			Command: command.DequeuePipeline{
+				PipelineID:   "e0c2e511-fc31-4fc4-804b-ceb91de4179f",
+				DequeuedByID: "c73e888a-21f2-42c7-84f7-111c4b155be8",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "e0c2e511-fc31-4fc4-804b-ceb91de4179f",
+				OwnerID: "c73e888a-21f2-42c7-84f7-111c4b155be8",
+			}),
+			Queued: []service.QueuedPipeline{
+				{PipelineID: "e0c2e511-fc31-4fc4-804b-ceb91de4179f"},
+			},
+			ShouldBeErr:         false,
+			ExpectedRemoveCalls: 1,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				pipeRepo = mock.NewPipelineRepository(c.Pipeline)
+				queue    = mock.NewPipelineQueue(c.Queued...)
+				handler  = command.NewDequeuePipelineHandler(pipeRepo, queue)
+			)
+
+			err := handler.Handle(context.Background(), c.Command)
+
+			require.Equal(t, c.ExpectedRemoveCalls, queue.RemoveCalls())
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			queued, err := queue.QueuedPipelines()
+			require.NoError(t, err)
+			require.Empty(t, queued)
+		})
+	}
+}
+
+func TestHandleDequeuePipelineWithoutQueue(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pipeRepo = mock.NewPipelineRepository(pipeline.Unmarshal(pipeline.Params{
+			ID:      "8d2f6a4e-0b1c-4d3e-9f5a-7b8c9d0e1f2a",
+			OwnerID: "c73e888a-21f2-42c7-84f7-111c4b155be8",
+		}))
+		handler = command.NewDequeuePipelineHandler(pipeRepo, service.WithoutPipelineQueue())
+	)
+
+	err := handler.Handle(context.Background(), command.DequeuePipeline{
+		PipelineID:   "8d2f6a4e-0b1c-4d3e-9f5a-7b8c9d0e1f2a",
+		DequeuedByID: "c73e888a-21f2-42c7-84f7-111c4b155be8",
+	})
+	require.ErrorIs(t, err, service.ErrPipelineQueueUnavailable)
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+// IssueTriggerToken issues new trigger token of the test
+// campaign, the previous token stops working. Empty token
+// revokes the previous one without issuing new.
+type IssueTriggerToken struct {
+	TestCampaignID string
+	IssuedByID     string
+	Token          string
+}
+
+type IssueTriggerTokenHandler interface {
+	Handle(ctx context.Context, cmd IssueTriggerToken) error
+}
+
+type issueTriggerTokenHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+}
+
+func NewIssueTriggerTokenHandler(repo service.TestCampaignRepository) IssueTriggerTokenHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	return issueTriggerTokenHandler{testCampaignRepo: repo}
+}
+
+func (h issueTriggerTokenHandler) Handle(
+	ctx context.Context,
+	cmd IssueTriggerToken,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "trigger token issuing")
+	}()
+
+	return h.testCampaignRepo.UpdateTestCampaign(
+		ctx,
+		cmd.TestCampaignID,
+		func(
+			_ context.Context,
+			tc *testcampaign.TestCampaign,
+		) (*testcampaign.TestCampaign, error) {
+			if err := user.CanAccessTestCampaign(cmd.IssuedByID, tc, user.Write); err != nil {
+				return nil, err
+			}
+
+			tc.SetTriggerToken(cmd.Token)
+
+			return tc, nil
+		},
+	)
+}
@@ -0,0 +1,147 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewIssueTriggerTokenHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "all_dependencies_are_nil",
+			GivenTestCampaignRepo: nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewIssueTriggerTokenHandler(c.GivenTestCampaignRepo)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleIssueTriggerToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		Command      command.IssueTriggerToken
+		TestCampaign *testcampaign.TestCampaign
+		ShouldBeErr  bool
+		IsErr        func(err error) bool
+	}{
+		{
+			Name: "issue_new_token",
+			Command: command.IssueTriggerToken{
+				TestCampaignID: "6f1e9b4a-8c7d-4a3f-9e2b-4d0c8a7f9e1b",
+				IssuedByID:     "7a2f0c5b-9d8e-4b4a-8f3c-5e1d9b8a0f2c",
+				Token:          "new-token",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:           "6f1e9b4a-8c7d-4a3f-9e2b-4d0c8a7f9e1b",
+				OwnerID:      "7a2f0c5b-9d8e-4b4a-8f3c-5e1d9b8a0f2c",
+				TriggerToken: "old-token",
+			}),
+			ShouldBeErr: false,
+		},
+		{
+			Name: "revoke_token",
+			Command: command.IssueTriggerToken{
+				TestCampaignID: "8b3a1d6c-0e9f-4c5b-9a4d-6f2e0c9b1a3d",
+				IssuedByID:     "9c4b2e7d-1f0a-4d6c-8b5e-7a3f1d0c2b4e",
+				Token:          "",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:           "8b3a1d6c-0e9f-4c5b-9a4d-6f2e0c9b1a3d",
+				OwnerID:      "9c4b2e7d-1f0a-4d6c-8b5e-7a3f1d0c2b4e",
+				TriggerToken: "old-token",
+			}),
+			ShouldBeErr: false,
+		},
+		{
+			Name: "user_cant_issue_token",
+			Command: command.IssueTriggerToken{
+				TestCampaignID: "0d5c3f8e-2a1b-4e7d-9c6f-8b4a2e1d3c5f",
+				IssuedByID:     "1e6d4a9f-3b2c-4f8e-8d7a-9c5b3f2e4d6a",
+				Token:          "new-token",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "0d5c3f8e-2a1b-4e7d-9c6f-8b4a2e1d3c5f",
+				OwnerID: "2f7e5b0a-4c3d-4a9f-9e8b-0d6c4a3f5e7b",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				repo    = mock.NewTestCampaignRepository(c.TestCampaign)
+				handler = command.NewIssueTriggerTokenHandler(repo)
+			)
+
+			ctx := context.Background()
+
+			err := handler.Handle(ctx, c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tc, err := repo.GetTestCampaign(ctx, c.Command.TestCampaignID)
+			require.NoError(t, err)
+
+			require.Equal(t, c.Command.Token, tc.TriggerToken())
+		})
+	}
+}
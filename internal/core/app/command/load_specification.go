@@ -15,6 +15,7 @@ type LoadSpecification struct {
 	SpecificationID string
 	TestCampaignID  string
 	LoadedByID      string
+	ContentType     string
 	Content         []byte
 }
 
@@ -26,12 +27,17 @@ type loadSpecificationHandler struct {
 	specRepo          service.SpecificationRepository
 	testCampaignRepo  service.TestCampaignRepository
 	specParserService service.SpecificationParser
+	formatParsers     map[string]service.SpecificationParser
 }
 
+// NewLoadSpecificationHandler returns handler parsing the
+// specification with the format parser of the command content
+// type or with the default specParser if there is no such one.
 func NewLoadSpecificationHandler(
 	specRepo service.SpecificationRepository,
 	testCampaignRepo service.TestCampaignRepository,
 	specParser service.SpecificationParser,
+	formatParsers ...service.SpecificationFormatParser,
 ) LoadSpecificationHandler {
 	if specRepo == nil {
 		panic("specification repository is nil")
@@ -45,11 +51,22 @@ func NewLoadSpecificationHandler(
 		panic("specification parser is nil")
 	}
 
-	return loadSpecificationHandler{
+	h := loadSpecificationHandler{
 		specRepo:          specRepo,
 		testCampaignRepo:  testCampaignRepo,
 		specParserService: specParser,
+		formatParsers:     make(map[string]service.SpecificationParser, len(formatParsers)),
 	}
+
+	for _, p := range formatParsers {
+		if p == nil {
+			panic("specification format parser is nil")
+		}
+
+		h.formatParsers[p.ContentType()] = p
+	}
+
+	return h
 }
 
 func (h loadSpecificationHandler) Handle(
@@ -69,7 +86,7 @@ func (h loadSpecificationHandler) Handle(
 		return err
 	}
 
-	spec, err := h.specParserService.ParseSpecification(
+	spec, err := h.specParser(cmd.ContentType).ParseSpecification(
 		bytes.NewReader(cmd.Content),
 		service.WithSpecificationID(cmd.SpecificationID),
 		service.WithSpecificationTestCampaignID(tc.ID()),
@@ -82,3 +99,11 @@ func (h loadSpecificationHandler) Handle(
 
 	return h.specRepo.AddSpecification(ctx, spec)
 }
+
+func (h loadSpecificationHandler) specParser(contentType string) service.SpecificationParser {
+	if p, ok := h.formatParsers[contentType]; ok {
+		return p
+	}
+
+	return h.specParserService
+}
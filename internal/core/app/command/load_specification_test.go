@@ -202,3 +202,73 @@ func TestHandleLoadSpecification(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleLoadSpecificationWithFormatParser(t *testing.T) {
+	t.Parallel()
+
+	const gherkinContentType = "text/x-gherkin"
+
+	testCases := []struct {
+		Name        string
+		ContentType string
+		ShouldBeErr bool
+	}{
+		{
+			Name:        "format_parser_of_content_type",
+			ContentType: gherkinContentType,
+			ShouldBeErr: true,
+		},
+		{
+			Name:        "default_parser_of_unknown_content_type",
+			ContentType: "application/x-yaml",
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "default_parser_of_empty_content_type",
+			ContentType: "",
+			ShouldBeErr: false,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tc := testcampaign.MustNew(testcampaign.Params{
+				ID:      "8a3d3c5b-4a71-4bc8-8e0d-1bd9b1c2dfb2",
+				OwnerID: "0c6a1a43-c7ee-4d2f-bd30-7ba0ba1d3b79",
+			})
+
+			var (
+				specRepo = mock.NewSpecificationRepository()
+				tcsRepo  = mock.NewTestCampaignRepository(tc)
+				handler  = command.NewLoadSpecificationHandler(
+					specRepo,
+					tcsRepo,
+					mock.NewSpecificationParserService(false),
+					mock.NewSpecificationFormatParserService(gherkinContentType, true),
+				)
+			)
+
+			err := handler.Handle(context.Background(), command.LoadSpecification{
+				SpecificationID: "b1d3e0a8-5a0c-4b9e-9d5e-3b0b8f8e1f11",
+				TestCampaignID:  tc.ID(),
+				LoadedByID:      tc.OwnerID(),
+				ContentType:     c.ContentType,
+				Content:         []byte(spec),
+			})
+
+			if c.ShouldBeErr {
+				var target *specification.BuildError
+
+				require.ErrorAs(t, err, &target)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type PausePipeline struct {
+	PipelineID string
+	PausedByID string
+}
+
+type PausePipelineHandler interface {
+	Handle(ctx context.Context, cmd PausePipeline) error
+}
+
+type pausePipelineHandler struct {
+	pipeRepo  service.PipelineRepository
+	publisher service.PipelinePausePublisher
+}
+
+func NewPausePipelineHandler(
+	pipeRepo service.PipelineRepository,
+	pausePub service.PipelinePausePublisher,
+) PausePipelineHandler {
+	if pipeRepo == nil {
+		panic("pipeline repository is nil")
+	}
+
+	if pausePub == nil {
+		panic("pipeline pause publisher is nil")
+	}
+
+	return pausePipelineHandler{
+		pipeRepo:  pipeRepo,
+		publisher: pausePub,
+	}
+}
+
+func (h pausePipelineHandler) Handle(ctx context.Context, cmd PausePipeline) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "pipeline pausing")
+	}()
+
+	pipe, err := h.pipeRepo.GetPipeline(ctx, cmd.PipelineID, service.WithoutSpecification())
+	if err != nil {
+		return err
+	}
+
+	if err := user.CanAccessPipeline(cmd.PausedByID, pipe, user.Read); err != nil {
+		return err
+	}
+
+	if err := pipe.ShouldBeStarted(); err != nil {
+		return err
+	}
+
+	return h.publisher.PublishPipelinePause(pipe.ID())
+}
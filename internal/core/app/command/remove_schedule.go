@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type RemoveSchedule struct {
+	ScheduleID     string
+	TestCampaignID string
+	RemovedByID    string
+}
+
+type RemoveScheduleHandler interface {
+	Handle(ctx context.Context, cmd RemoveSchedule) error
+}
+
+type removeScheduleHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+}
+
+func NewRemoveScheduleHandler(repo service.TestCampaignRepository) RemoveScheduleHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	return removeScheduleHandler{testCampaignRepo: repo}
+}
+
+func (h removeScheduleHandler) Handle(
+	ctx context.Context,
+	cmd RemoveSchedule,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "schedule removing")
+	}()
+
+	return h.testCampaignRepo.UpdateTestCampaign(
+		ctx,
+		cmd.TestCampaignID,
+		func(
+			_ context.Context,
+			tc *testcampaign.TestCampaign,
+		) (*testcampaign.TestCampaign, error) {
+			if err := user.CanAccessTestCampaign(cmd.RemovedByID, tc, user.Write); err != nil {
+				return nil, err
+			}
+
+			if err := tc.RemoveSchedule(cmd.ScheduleID); err != nil {
+				return nil, err
+			}
+
+			return tc, nil
+		},
+	)
+}
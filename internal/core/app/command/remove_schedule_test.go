@@ -0,0 +1,164 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewRemoveScheduleHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "all_dependencies_are_nil",
+			GivenTestCampaignRepo: nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewRemoveScheduleHandler(c.GivenTestCampaignRepo)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleRemoveSchedule(t *testing.T) {
+	t.Parallel()
+
+	newTestCampaign := func(tcID, ownerID, scheduleID string) *testcampaign.TestCampaign {
+		return testcampaign.MustNew(testcampaign.Params{
+			ID:      tcID,
+			OwnerID: ownerID,
+			Schedules: []testcampaign.Schedule{
+				testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+					ID:   scheduleID,
+					Cron: "@daily",
+				}),
+			},
+		})
+	}
+
+	testCases := []struct {
+		Name         string
+		Command      command.RemoveSchedule
+		TestCampaign *testcampaign.TestCampaign
+		ShouldBeErr  bool
+		IsErr        func(err error) bool
+	}{
+		{
+			Name: "successful_removing",
+			Command: command.RemoveSchedule{
+				ScheduleID:     "5f6a7b8c-9d0e-4f1a-8b3c-4d5e6f7a8b9c",
+				TestCampaignID: "6a7b8c9d-0e1f-4a2b-9c4d-5e6f7a8b9c0d",
+				RemovedByID:    "7b8c9d0e-1f2a-4b3c-8d5e-6f7a8b9c0d1e",
+			},
+			TestCampaign: newTestCampaign(
+				"6a7b8c9d-0e1f-4a2b-9c4d-5e6f7a8b9c0d",
+				"7b8c9d0e-1f2a-4b3c-8d5e-6f7a8b9c0d1e",
+				"5f6a7b8c-9d0e-4f1a-8b3c-4d5e6f7a8b9c",
+			),
+			ShouldBeErr: false,
+		},
+		{
+			Name: "schedule_not_found",
+			Command: command.RemoveSchedule{
+				ScheduleID:     "8c9d0e1f-2a3b-4c4d-9e6f-7a8b9c0d1e2f",
+				TestCampaignID: "9d0e1f2a-3b4c-4d5e-8f7a-8b9c0d1e2f3a",
+				RemovedByID:    "0e1f2a3b-4c5d-4e6f-9a8b-9c0d1e2f3a4b",
+			},
+			TestCampaign: newTestCampaign(
+				"9d0e1f2a-3b4c-4d5e-8f7a-8b9c0d1e2f3a",
+				"0e1f2a3b-4c5d-4e6f-9a8b-9c0d1e2f3a4b",
+				"1f2a3b4c-5d6e-4f7a-8b9c-0d1e2f3a4b5c",
+			),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrScheduleNotFound)
+			},
+		},
+		{
+			Name: "user_cant_remove_schedule",
+			Command: command.RemoveSchedule{
+				ScheduleID:     "2a3b4c5d-6e7f-4a8b-9c0d-1e2f3a4b5c6d",
+				TestCampaignID: "3b4c5d6e-7f8a-4b9c-8d1e-2f3a4b5c6d7e",
+				RemovedByID:    "4c5d6e7f-8a9b-4c0d-9e2f-3a4b5c6d7e8f",
+			},
+			TestCampaign: newTestCampaign(
+				"3b4c5d6e-7f8a-4b9c-8d1e-2f3a4b5c6d7e",
+				"5d6e7f8a-9b0c-4d1e-8f3a-4b5c6d7e8f9a",
+				"2a3b4c5d-6e7f-4a8b-9c0d-1e2f3a4b5c6d",
+			),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				repo    = mock.NewTestCampaignRepository(c.TestCampaign)
+				handler = command.NewRemoveScheduleHandler(repo)
+			)
+
+			ctx := context.Background()
+
+			err := handler.Handle(ctx, c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tc, err := repo.GetTestCampaign(ctx, c.Command.TestCampaignID)
+			require.NoError(t, err)
+
+			require.Empty(t, tc.Schedules())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type RemoveSubscription struct {
+	SubscriptionID string
+	TestCampaignID string
+	RemovedByID    string
+}
+
+type RemoveSubscriptionHandler interface {
+	Handle(ctx context.Context, cmd RemoveSubscription) error
+}
+
+type removeSubscriptionHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+}
+
+func NewRemoveSubscriptionHandler(repo service.TestCampaignRepository) RemoveSubscriptionHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	return removeSubscriptionHandler{testCampaignRepo: repo}
+}
+
+func (h removeSubscriptionHandler) Handle(
+	ctx context.Context,
+	cmd RemoveSubscription,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "subscription removing")
+	}()
+
+	return h.testCampaignRepo.UpdateTestCampaign(
+		ctx,
+		cmd.TestCampaignID,
+		func(
+			_ context.Context,
+			tc *testcampaign.TestCampaign,
+		) (*testcampaign.TestCampaign, error) {
+			if err := user.CanAccessTestCampaign(cmd.RemovedByID, tc, user.Write); err != nil {
+				return nil, err
+			}
+
+			if err := tc.RemoveSubscription(cmd.SubscriptionID); err != nil {
+				return nil, err
+			}
+
+			return tc, nil
+		},
+	)
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type RemoveTestCampaign struct {
+	TestCampaignID string
+	RemovedByID    string
+	Archive        bool
+}
+
+type RemoveTestCampaignHandler interface {
+	Handle(ctx context.Context, cmd RemoveTestCampaign) error
+}
+
+type removeTestCampaignHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+	remover          service.TestCampaignRemover
+}
+
+func NewRemoveTestCampaignHandler(
+	repo service.TestCampaignRepository,
+	remover service.TestCampaignRemover,
+) RemoveTestCampaignHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	if remover == nil {
+		panic("test campaign remover is nil")
+	}
+
+	return removeTestCampaignHandler{
+		testCampaignRepo: repo,
+		remover:          remover,
+	}
+}
+
+func (h removeTestCampaignHandler) Handle(
+	ctx context.Context,
+	cmd RemoveTestCampaign,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "test campaign removing")
+	}()
+
+	tc, err := h.testCampaignRepo.GetTestCampaign(ctx, cmd.TestCampaignID)
+	if err != nil {
+		return err
+	}
+
+	if err := user.CanAccessTestCampaign(cmd.RemovedByID, tc, user.Write); err != nil {
+		return err
+	}
+
+	if cmd.Archive {
+		return h.remover.ArchiveTestCampaign(ctx, tc.ID())
+	}
+
+	return h.remover.RemoveTestCampaign(ctx, tc.ID())
+}
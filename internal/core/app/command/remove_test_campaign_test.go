@@ -0,0 +1,177 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewRemoveTestCampaignHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		GivenRemover          service.TestCampaignRemover
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			GivenRemover:          mock.NewTestCampaignRepository(),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "test_campaign_repository_is_nil",
+			GivenTestCampaignRepo: nil,
+			GivenRemover:          mock.NewTestCampaignRepository(),
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+		{
+			Name:                  "test_campaign_remover_is_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			GivenRemover:          nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign remover is nil",
+		},
+		{
+			Name:                  "all_dependencies_are_nil",
+			GivenTestCampaignRepo: nil,
+			GivenRemover:          nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewRemoveTestCampaignHandler(
+					c.GivenTestCampaignRepo,
+					c.GivenRemover,
+				)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleRemoveTestCampaign(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name             string
+		Command          command.RemoveTestCampaign
+		TestCampaign     *testcampaign.TestCampaign
+		ExpectedArchived int
+		ShouldBeErr      bool
+		IsErr            func(err error) bool
+	}{
+		{
+			Name: "remove_test_campaign",
+			Command: command.RemoveTestCampaign{
+				TestCampaignID: "3f5c7e9a-1b2d-4f6a-8c0e-2a4b6c8d0e1f",
+				RemovedByID:    "6d8f0a2c-4e6a-4c8e-a0b2-4c6d8e0f2a3b",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "3f5c7e9a-1b2d-4f6a-8c0e-2a4b6c8d0e1f",
+				OwnerID: "6d8f0a2c-4e6a-4c8e-a0b2-4c6d8e0f2a3b",
+			}),
+			ExpectedArchived: 0,
+			ShouldBeErr:      false,
+		},
+		{
+			Name: "archive_test_campaign",
+			Command: command.RemoveTestCampaign{
+				TestCampaignID: "8b0d2f4a-6c8e-4a0c-b2d4-6e8f0a2c4e5d",
+				RemovedByID:    "1a3c5e7f-9b1d-4f3a-85c7-9e1a3c5e7f9b",
+				Archive:        true,
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "8b0d2f4a-6c8e-4a0c-b2d4-6e8f0a2c4e5d",
+				OwnerID: "1a3c5e7f-9b1d-4f3a-85c7-9e1a3c5e7f9b",
+			}),
+			ExpectedArchived: 1,
+			ShouldBeErr:      false,
+		},
+		{
+			Name: "test_campaign_not_found",
+			Command: command.RemoveTestCampaign{
+				TestCampaignID: "5c7e9a1b-3d5f-4a7c-9e1b-3d5f7a9c1e3b",
+				RemovedByID:    "2e4a6c8e-0a2c-4e6a-8c0e-2a4c6e8a0c2e",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "f1a3c5e7-9b1d-4f3a-a5c7-9e1b3d5f7a9c",
+				OwnerID: "2e4a6c8e-0a2c-4e6a-8c0e-2a4c6e8a0c2e",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTestCampaignNotFound)
+			},
+		},
+		{
+			Name: "user_cant_remove_test_campaign",
+			Command: command.RemoveTestCampaign{
+				TestCampaignID: "7e9a1b3d-5f7a-4c9e-b1d3-5f7a9c1e3b5d",
+				RemovedByID:    "0c2e4a6c-8e0a-4c4e-a6c8-e0a2c4e6a8c0",
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "7e9a1b3d-5f7a-4c9e-b1d3-5f7a9c1e3b5d",
+				OwnerID: "9e1b3d5f-7a9c-4e3b-85d7-f9a1c3e5b7d9",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				repo    = mock.NewTestCampaignRepository(c.TestCampaign)
+				handler = command.NewRemoveTestCampaignHandler(repo, repo)
+			)
+
+			err := handler.Handle(context.Background(), c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+				require.Equal(t, 1, repo.TestCampaignsNumber())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 0, repo.TestCampaignsNumber())
+			require.Equal(t, c.ExpectedArchived, repo.ArchivedTestCampaignsNumber())
+		})
+	}
+}
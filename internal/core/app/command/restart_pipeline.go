@@ -13,6 +13,7 @@ import (
 type RestartPipeline struct {
 	PipelineID  string
 	StartedByID string
+	Priority    service.Priority
 }
 
 type RestartPipelineHandler interface {
@@ -68,7 +69,7 @@ func (h restartPipelineHandler) Handle(
 		return err
 	}
 
-	_, err = h.maintainer.MaintainPipeline(ctx, pipe)
+	_, err = h.maintainer.MaintainPipeline(ctx, pipe, cmd.Priority)
 
 	return err
 }
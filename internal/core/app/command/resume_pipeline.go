@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type ResumePipeline struct {
+	PipelineID  string
+	ResumedByID string
+}
+
+type ResumePipelineHandler interface {
+	Handle(ctx context.Context, cmd ResumePipeline) error
+}
+
+type resumePipelineHandler struct {
+	pipeRepo  service.PipelineRepository
+	publisher service.PipelinePausePublisher
+}
+
+func NewResumePipelineHandler(
+	pipeRepo service.PipelineRepository,
+	pausePub service.PipelinePausePublisher,
+) ResumePipelineHandler {
+	if pipeRepo == nil {
+		panic("pipeline repository is nil")
+	}
+
+	if pausePub == nil {
+		panic("pipeline pause publisher is nil")
+	}
+
+	return resumePipelineHandler{
+		pipeRepo:  pipeRepo,
+		publisher: pausePub,
+	}
+}
+
+func (h resumePipelineHandler) Handle(ctx context.Context, cmd ResumePipeline) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "pipeline resuming")
+	}()
+
+	pipe, err := h.pipeRepo.GetPipeline(ctx, cmd.PipelineID, service.WithoutSpecification())
+	if err != nil {
+		return err
+	}
+
+	if err := user.CanAccessPipeline(cmd.ResumedByID, pipe, user.Read); err != nil {
+		return err
+	}
+
+	if err := pipe.ShouldBeStarted(); err != nil {
+		return err
+	}
+
+	return h.publisher.PublishPipelineResume(pipe.ID())
+}
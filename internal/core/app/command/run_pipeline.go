@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+// RunPipeline runs the existing pipeline on behalf
+// of the system, e.g. when it is requeued from another
+// instance, so access of the user is not checked.
+// If HandedOverBy is set, the pipeline is leased to that
+// instance and is released by it before running.
+type RunPipeline struct {
+	PipelineID   string
+	Priority     service.Priority
+	HandedOverBy string
+}
+
+type RunPipelineHandler interface {
+	Handle(ctx context.Context, cmd RunPipeline) error
+}
+
+type runPipelineHandler struct {
+	pipeRepo   service.PipelineRepository
+	specGetter service.SpecificationGetter
+	handover   service.PipelineHandover
+	maintainer service.PipelineMaintainer
+	registrars []pipeline.ExecutorRegistrar
+}
+
+func NewRunPipelineHandler(
+	pipeRepo service.PipelineRepository,
+	specGetter service.SpecificationGetter,
+	handover service.PipelineHandover,
+	maintainer service.PipelineMaintainer,
+	registrars ...pipeline.ExecutorRegistrar,
+) RunPipelineHandler {
+	if pipeRepo == nil {
+		panic("pipeline repository is nil")
+	}
+
+	if specGetter == nil {
+		panic("specification getter is nil")
+	}
+
+	if handover == nil {
+		panic("pipeline handover is nil")
+	}
+
+	if maintainer == nil {
+		panic("pipeline maintainer is nil")
+	}
+
+	return runPipelineHandler{
+		pipeRepo:   pipeRepo,
+		specGetter: specGetter,
+		handover:   handover,
+		maintainer: maintainer,
+		registrars: registrars,
+	}
+}
+
+func (h runPipelineHandler) Handle(ctx context.Context, cmd RunPipeline) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "pipeline running")
+	}()
+
+	pipe, err := h.pipeRepo.GetPipeline(ctx, cmd.PipelineID, h.specGetter, h.registrars...)
+	if err != nil {
+		return err
+	}
+
+	if cmd.HandedOverBy != "" {
+		if err := h.handover.HandOverPipeline(ctx, pipe.ID(), cmd.HandedOverBy); err != nil {
+			return err
+		}
+	}
+
+	_, err = h.maintainer.MaintainPipeline(ctx, pipe, cmd.Priority)
+
+	return err
+}
@@ -0,0 +1,204 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+func TestNewRunPipelineHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name            string
+		GivenPipeRepo   service.PipelineRepository
+		GivenSpecGetter service.SpecificationGetter
+		GivenHandover   service.PipelineHandover
+		GivenMaintainer service.PipelineMaintainer
+		ShouldPanic     bool
+		PanicMessage    string
+	}{
+		{
+			Name:            "all_dependencies_are_not_nil",
+			GivenPipeRepo:   mock.NewPipelineRepository(),
+			GivenSpecGetter: service.WithoutSpecification(),
+			GivenHandover:   mock.NewPipelineGuard(nil, nil),
+			GivenMaintainer: mock.NewPipelineMaintainer(false),
+			ShouldPanic:     false,
+		},
+		{
+			Name:            "pipeline_repository_is_nil",
+			GivenPipeRepo:   nil,
+			GivenSpecGetter: service.WithoutSpecification(),
+			GivenHandover:   mock.NewPipelineGuard(nil, nil),
+			GivenMaintainer: mock.NewPipelineMaintainer(false),
+			ShouldPanic:     true,
+			PanicMessage:    "pipeline repository is nil",
+		},
+		{
+			Name:            "specification_getter_is_nil",
+			GivenPipeRepo:   mock.NewPipelineRepository(),
+			GivenSpecGetter: nil,
+			GivenHandover:   mock.NewPipelineGuard(nil, nil),
+			GivenMaintainer: mock.NewPipelineMaintainer(false),
+			ShouldPanic:     true,
+			PanicMessage:    "specification getter is nil",
+		},
+		{
+			Name:            "pipeline_handover_is_nil",
+			GivenPipeRepo:   mock.NewPipelineRepository(),
+			GivenSpecGetter: service.WithoutSpecification(),
+			GivenHandover:   nil,
+			GivenMaintainer: mock.NewPipelineMaintainer(false),
+			ShouldPanic:     true,
+			PanicMessage:    "pipeline handover is nil",
+		},
+		{
+			Name:            "pipeline_maintainer_is_nil",
+			GivenPipeRepo:   mock.NewPipelineRepository(),
+			GivenSpecGetter: service.WithoutSpecification(),
+			GivenHandover:   mock.NewPipelineGuard(nil, nil),
+			GivenMaintainer: nil,
+			ShouldPanic:     true,
+			PanicMessage:    "pipeline maintainer is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewRunPipelineHandler(
+					c.GivenPipeRepo,
+					c.GivenSpecGetter,
+					c.GivenHandover,
+					c.GivenMaintainer,
+				)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleRunPipeline(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                   string
+		Command                command.RunPipeline
+		Pipeline               *pipeline.Pipeline
+		PipelineAlreadyStarted bool
+		ShouldBeErr            bool
+		IsErr                  func(err error) bool
+		ExpectedHandedOverBy   []string
+		ExpectedPriorities     []service.Priority
+	}{
+		{
+			Name: "pipeline_not_found",
+			Command: command.RunPipeline{
+				PipelineID: "3d3f4f8e-1b7a-4e8e-9c3a-2b8f1d6e7a90",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "a4b3c2d1-0e9f-4a8b-b7c6-d5e4f3a2b1c0",
+				OwnerID: "7177997a-b63d-4e1b-9288-0a581f7ff03a",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrPipelineNotFound)
+			},
+		},
+		{
+			Name: "pipeline_already_started",
+			Command: command.RunPipeline{
+				PipelineID: "6f112cf1-3dd5-4f14-a5ef-7ef18dfb8921",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "6f112cf1-3dd5-4f14-a5ef-7ef18dfb8921",
+				OwnerID: "960f7ba1-b16c-43eb-9f87-d367ec9e0ba9",
+			}),
+			PipelineAlreadyStarted: true,
+			ShouldBeErr:            true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, pipeline.ErrAlreadyStarted)
+			},
+		},
+		{
+			Name: "success_pipeline_running",
+			Command: command.RunPipeline{
+				PipelineID: "fc2f14b3-6125-47fa-a343-5fabcac9abd1",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "fc2f14b3-6125-47fa-a343-5fabcac9abd1",
+				OwnerID: "5da02570-a192-4a9a-9180-1a2704732b06",
+			}),
+			ShouldBeErr:          false,
+			ExpectedHandedOverBy: []string{},
+			ExpectedPriorities:   []service.Priority{service.ScheduledPriority},
+		},
+		{
+			Name: "success_handed_over_pipeline_running",
+			Command: command.RunPipeline{
+				PipelineID:   "0c8e4f1a-2b3d-4e5f-8a9b-1c2d3e4f5a6b",
+				Priority:     service.ManualPriority,
+				HandedOverBy: "api-1",
+			},
+			Pipeline: pipeline.Unmarshal(pipeline.Params{
+				ID:      "0c8e4f1a-2b3d-4e5f-8a9b-1c2d3e4f5a6b",
+				OwnerID: "5da02570-a192-4a9a-9180-1a2704732b06",
+			}),
+			ShouldBeErr:          false,
+			ExpectedHandedOverBy: []string{"api-1"},
+			ExpectedPriorities:   []service.Priority{service.ManualPriority},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				pipeRepo   = mock.NewPipelineRepository(c.Pipeline)
+				guard      = mock.NewPipelineGuard(nil, nil)
+				maintainer = mock.NewPipelineMaintainer(c.PipelineAlreadyStarted)
+				handler    = command.NewRunPipelineHandler(
+					pipeRepo,
+					service.WithoutSpecification(),
+					guard,
+					maintainer,
+					pipeline.WithHTTP(pipeline.PassingExecutor()),
+				)
+			)
+
+			err := handler.Handle(context.Background(), c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.ExpectedHandedOverBy, guard.HandedOverBy())
+			require.Equal(t, c.ExpectedPriorities, maintainer.Priorities())
+		})
+	}
+}
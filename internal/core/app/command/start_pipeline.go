@@ -7,6 +7,7 @@ import (
 
 	"github.com/harpyd/thestis/internal/core/app/service"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
 	"github.com/harpyd/thestis/internal/core/entity/user"
 )
 
@@ -14,6 +15,9 @@ type StartPipeline struct {
 	PipelineID     string
 	TestCampaignID string
 	StartedByID    string
+	Priority       service.Priority
+	Environment    string
+	ScenarioFilter string
 }
 
 type StartPipelineHandler interface {
@@ -67,13 +71,52 @@ func (h startPipelineHandler) Handle(ctx context.Context, cmd StartPipeline) (er
 		return err
 	}
 
-	pipe := pipeline.Trigger(cmd.PipelineID, spec, h.registrars...)
+	filter := specification.ScenarioFilter(cmd.ScenarioFilter)
+
+	if err := checkRunParams(spec, cmd.Environment, filter); err != nil {
+		return err
+	}
+
+	registrars := make([]pipeline.ExecutorRegistrar, 0, len(h.registrars)+2)
+	registrars = append(registrars, h.registrars...)
+	registrars = append(
+		registrars,
+		pipeline.WithEnvironment(cmd.Environment),
+		pipeline.WithScenarioFilter(filter),
+	)
+
+	pipe := pipeline.Trigger(cmd.PipelineID, spec, registrars...)
 
 	if err := h.pipeRepo.AddPipeline(ctx, pipe); err != nil {
 		return err
 	}
 
-	_, err = h.maintainer.MaintainPipeline(ctx, pipe)
+	_, err = h.maintainer.MaintainPipeline(ctx, pipe, cmd.Priority)
 
 	return err
 }
+
+// checkRunParams returns error if the specification has no
+// environment profile with given name or the filter selects
+// no scenarios of the specification.
+func checkRunParams(
+	spec *specification.Specification,
+	environment string,
+	filter specification.ScenarioFilter,
+) error {
+	if environment != "" {
+		if _, ok := spec.Environment(environment); !ok {
+			return errors.Wrap(specification.ErrUnknownEnvironment, environment)
+		}
+	}
+
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	if !filter.IsZero() && len(spec.FilteredScenarios(filter)) == 0 {
+		return errors.Wrap(specification.ErrNoFilteredScenarios, filter.String())
+	}
+
+	return nil
+}
@@ -141,6 +141,60 @@ func TestHandleStartPipeline(t *testing.T) {
 				ErrlessBuild(),
 			ShouldBeErr: false,
 		},
+		{
+			Name: "unknown_environment",
+			Command: command.StartPipeline{
+				PipelineID:     "2b1b36a7-f1a1-4c43-9c39-b0a3bb1a3e0e",
+				TestCampaignID: "70c8e87d-395d-4ae6-b53e-3b2f587039a3",
+				StartedByID:    "aa584d3d-c790-4ed3-8bfa-19e1b6fed88e",
+				Environment:    "prod",
+			},
+			Specification: specificationWithEnvironment(),
+			ShouldBeErr:   true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, specification.ErrUnknownEnvironment)
+			},
+		},
+		{
+			Name: "invalid_scenario_filter",
+			Command: command.StartPipeline{
+				PipelineID:     "1f0a3f51-3f86-4a5a-9d0b-0a3c9d7b8f34",
+				TestCampaignID: "70c8e87d-395d-4ae6-b53e-3b2f587039a3",
+				StartedByID:    "aa584d3d-c790-4ed3-8bfa-19e1b6fed88e",
+				ScenarioFilter: "foo.[bar",
+			},
+			Specification: specificationWithEnvironment(),
+			ShouldBeErr:   true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, specification.ErrInvalidScenarioFilter)
+			},
+		},
+		{
+			Name: "scenario_filter_matches_nothing",
+			Command: command.StartPipeline{
+				PipelineID:     "c4e2a0a5-4b8f-4a9b-8d8e-1c7f7b4a2d11",
+				TestCampaignID: "70c8e87d-395d-4ae6-b53e-3b2f587039a3",
+				StartedByID:    "aa584d3d-c790-4ed3-8bfa-19e1b6fed88e",
+				ScenarioFilter: "qux",
+			},
+			Specification: specificationWithEnvironment(),
+			ShouldBeErr:   true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, specification.ErrNoFilteredScenarios)
+			},
+		},
+		{
+			Name: "success_pipeline_starting_with_environment_and_filter",
+			Command: command.StartPipeline{
+				PipelineID:     "7d1c7a4b-6f0e-4f4c-9e55-3d7b2a9c8e20",
+				TestCampaignID: "70c8e87d-395d-4ae6-b53e-3b2f587039a3",
+				StartedByID:    "aa584d3d-c790-4ed3-8bfa-19e1b6fed88e",
+				Environment:    "staging",
+				ScenarioFilter: "foo.bar",
+			},
+			Specification: specificationWithEnvironment(),
+			ShouldBeErr:   false,
+		},
 	}
 
 	for _, c := range testCases {
@@ -179,3 +233,14 @@ func TestHandleStartPipeline(t *testing.T) {
 		})
 	}
 }
+
+func specificationWithEnvironment() *specification.Specification {
+	return (&specification.Builder{}).
+		WithTestCampaignID("70c8e87d-395d-4ae6-b53e-3b2f587039a3").
+		WithOwnerID("aa584d3d-c790-4ed3-8bfa-19e1b6fed88e").
+		WithEnvironment("staging", map[string]string{"baseUrl": "https://staging.com"}).
+		WithStory("foo", func(b *specification.StoryBuilder) {
+			b.WithScenario("bar", func(b *specification.ScenarioBuilder) {})
+		}).
+		ErrlessBuild()
+}
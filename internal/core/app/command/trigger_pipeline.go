@@ -0,0 +1,114 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+// TriggerPipeline starts new pipeline of the test campaign
+// by the webhook signed with the trigger token instead of
+// the user authentication. Each signed trigger is accepted
+// only once.
+type TriggerPipeline struct {
+	PipelineID     string
+	TestCampaignID string
+	Payload        []byte
+	Signature      string
+	Timestamp      time.Time
+	Priority       service.Priority
+}
+
+type TriggerPipelineHandler interface {
+	Handle(ctx context.Context, cmd TriggerPipeline) error
+}
+
+type triggerPipelineHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+	specRepo         service.SpecificationRepository
+	pipeRepo         service.PipelineRepository
+	replayGuard      service.TriggerReplayGuard
+	maintainer       service.PipelineMaintainer
+	registrars       []pipeline.ExecutorRegistrar
+}
+
+func NewTriggerPipelineHandler(
+	testCampaignRepo service.TestCampaignRepository,
+	specRepo service.SpecificationRepository,
+	pipeRepo service.PipelineRepository,
+	replayGuard service.TriggerReplayGuard,
+	maintainer service.PipelineMaintainer,
+	registrars ...pipeline.ExecutorRegistrar,
+) TriggerPipelineHandler {
+	if testCampaignRepo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	if specRepo == nil {
+		panic("specification repository is nil")
+	}
+
+	if pipeRepo == nil {
+		panic("pipeline repository is nil")
+	}
+
+	if replayGuard == nil {
+		panic("trigger replay guard is nil")
+	}
+
+	if maintainer == nil {
+		panic("pipeline maintainer is nil")
+	}
+
+	return triggerPipelineHandler{
+		testCampaignRepo: testCampaignRepo,
+		specRepo:         specRepo,
+		pipeRepo:         pipeRepo,
+		replayGuard:      replayGuard,
+		maintainer:       maintainer,
+		registrars:       registrars,
+	}
+}
+
+func (h triggerPipelineHandler) Handle(ctx context.Context, cmd TriggerPipeline) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "pipeline triggering")
+	}()
+
+	tc, err := h.testCampaignRepo.GetTestCampaign(ctx, cmd.TestCampaignID)
+	if err != nil {
+		return err
+	}
+
+	if err := tc.VerifyTrigger(cmd.Payload, cmd.Signature, cmd.Timestamp, time.Now()); err != nil {
+		return err
+	}
+
+	if err := h.replayGuard.RememberTrigger(
+		ctx,
+		tc.ID()+":"+cmd.Signature,
+		cmd.Timestamp.Add(testcampaign.TriggerTolerance),
+	); err != nil {
+		return err
+	}
+
+	spec, err := h.specRepo.GetActiveSpecificationByTestCampaignID(ctx, tc.ID())
+	if err != nil {
+		return err
+	}
+
+	pipe := pipeline.Trigger(cmd.PipelineID, spec, h.registrars...)
+
+	if err := h.pipeRepo.AddPipeline(ctx, pipe); err != nil {
+		return err
+	}
+
+	_, err = h.maintainer.MaintainPipeline(ctx, pipe, cmd.Priority)
+
+	return err
+}
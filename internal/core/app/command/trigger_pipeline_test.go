@@ -0,0 +1,286 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+func TestNewTriggerPipelineHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		GivenSpecRepo         service.SpecificationRepository
+		GivenPipeRepo         service.PipelineRepository
+		GivenReplayGuard      service.TriggerReplayGuard
+		GivenMaintainer       service.PipelineMaintainer
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			GivenSpecRepo:         mock.NewSpecificationRepository(),
+			GivenPipeRepo:         mock.NewPipelineRepository(),
+			GivenReplayGuard:      mock.NewTriggerReplayGuard(),
+			GivenMaintainer:       mock.NewPipelineMaintainer(false),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "test_campaign_repository_is_nil",
+			GivenTestCampaignRepo: nil,
+			GivenSpecRepo:         mock.NewSpecificationRepository(),
+			GivenPipeRepo:         mock.NewPipelineRepository(),
+			GivenReplayGuard:      mock.NewTriggerReplayGuard(),
+			GivenMaintainer:       mock.NewPipelineMaintainer(false),
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+		{
+			Name:                  "specification_repository_is_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			GivenSpecRepo:         nil,
+			GivenPipeRepo:         mock.NewPipelineRepository(),
+			GivenReplayGuard:      mock.NewTriggerReplayGuard(),
+			GivenMaintainer:       mock.NewPipelineMaintainer(false),
+			ShouldPanic:           true,
+			PanicMessage:          "specification repository is nil",
+		},
+		{
+			Name:                  "pipeline_repository_is_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			GivenSpecRepo:         mock.NewSpecificationRepository(),
+			GivenPipeRepo:         nil,
+			GivenReplayGuard:      mock.NewTriggerReplayGuard(),
+			GivenMaintainer:       mock.NewPipelineMaintainer(false),
+			ShouldPanic:           true,
+			PanicMessage:          "pipeline repository is nil",
+		},
+		{
+			Name:                  "trigger_replay_guard_is_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			GivenSpecRepo:         mock.NewSpecificationRepository(),
+			GivenPipeRepo:         mock.NewPipelineRepository(),
+			GivenReplayGuard:      nil,
+			GivenMaintainer:       mock.NewPipelineMaintainer(false),
+			ShouldPanic:           true,
+			PanicMessage:          "trigger replay guard is nil",
+		},
+		{
+			Name:                  "pipeline_maintainer_is_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			GivenSpecRepo:         mock.NewSpecificationRepository(),
+			GivenPipeRepo:         mock.NewPipelineRepository(),
+			GivenReplayGuard:      mock.NewTriggerReplayGuard(),
+			GivenMaintainer:       nil,
+			ShouldPanic:           true,
+			PanicMessage:          "pipeline maintainer is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewTriggerPipelineHandler(
+					c.GivenTestCampaignRepo,
+					c.GivenSpecRepo,
+					c.GivenPipeRepo,
+					c.GivenReplayGuard,
+					c.GivenMaintainer,
+				)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleTriggerPipeline(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tcID    = "3e7a1c2b-9f4d-4b6e-8a1c-5d2f7e9b0c3a"
+		ownerID = "6b2d8f1e-4a7c-4e9b-b3d5-1f8a2c6e9d7b"
+		token   = "8f1c3e5a7b9d2f4a6c8e0b1d3f5a7c9e"
+	)
+
+	var (
+		payload   = []byte(`{"priority":"MANUAL"}`)
+		timestamp = time.Now()
+		signature = testcampaign.SignTrigger(token, payload, timestamp)
+	)
+
+	testCases := []struct {
+		Name           string
+		Command        command.TriggerPipeline
+		TriggerToken   string
+		RememberedKeys []string
+		WithoutSpec    bool
+		ShouldBeErr    bool
+		IsErr          func(err error) bool
+	}{
+		{
+			Name: "success_pipeline_triggering",
+			Command: command.TriggerPipeline{
+				PipelineID:     "0f5e3b8a-2c1d-4a7f-9e6b-8d4c2a1f3e5b",
+				TestCampaignID: tcID,
+				Payload:        payload,
+				Signature:      signature,
+				Timestamp:      timestamp,
+			},
+			TriggerToken: token,
+			ShouldBeErr:  false,
+		},
+		{
+			Name: "test_campaign_not_found",
+			Command: command.TriggerPipeline{
+				PipelineID:     "1a6f4c9b-3d2e-4b8a-8f7c-9e5d3b2a4f6c",
+				TestCampaignID: "7c3e9a1d-5b8f-4d2a-a6e4-2b9f7d1c8e3a",
+				Payload:        payload,
+				Signature:      signature,
+				Timestamp:      timestamp,
+			},
+			TriggerToken: token,
+			ShouldBeErr:  true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTestCampaignNotFound)
+			},
+		},
+		{
+			Name: "trigger_token_not_issued",
+			Command: command.TriggerPipeline{
+				PipelineID:     "2b7a5d0c-4e3f-4c9b-9a8d-0f6e4c3b5a7d",
+				TestCampaignID: tcID,
+				Payload:        payload,
+				Signature:      signature,
+				Timestamp:      timestamp,
+			},
+			TriggerToken: "",
+			ShouldBeErr:  true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrTriggerTokenNotIssued)
+			},
+		},
+		{
+			Name: "invalid_signature",
+			Command: command.TriggerPipeline{
+				PipelineID:     "3c8b6e1d-5f4a-4d0c-8b9e-1a7f5d4c6b8e",
+				TestCampaignID: tcID,
+				Payload:        []byte(`{"priority":"SCHEDULED"}`),
+				Signature:      signature,
+				Timestamp:      timestamp,
+			},
+			TriggerToken: token,
+			ShouldBeErr:  true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrInvalidTriggerSignature)
+			},
+		},
+		{
+			Name: "replayed_trigger",
+			Command: command.TriggerPipeline{
+				PipelineID:     "4d9c7f2e-6a5b-4e1d-9c0f-2b8a6e5d7c9f",
+				TestCampaignID: tcID,
+				Payload:        payload,
+				Signature:      signature,
+				Timestamp:      timestamp,
+			},
+			TriggerToken:   token,
+			RememberedKeys: []string{tcID + ":" + signature},
+			ShouldBeErr:    true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTriggerReplayed)
+			},
+		},
+		{
+			Name: "active_specification_not_found",
+			Command: command.TriggerPipeline{
+				PipelineID:     "5e0d8a3f-7b6c-4f2e-8d1a-3c9b7f6e8d0a",
+				TestCampaignID: tcID,
+				Payload:        payload,
+				Signature:      signature,
+				Timestamp:      timestamp,
+			},
+			TriggerToken: token,
+			WithoutSpec:  true,
+			ShouldBeErr:  true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrSpecificationNotFound)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tc := testcampaign.MustNew(testcampaign.Params{
+				ID:           tcID,
+				OwnerID:      ownerID,
+				TriggerToken: c.TriggerToken,
+			})
+
+			specs := []*specification.Specification{
+				(&specification.Builder{}).
+					WithTestCampaignID(tcID).
+					WithOwnerID(ownerID).
+					ErrlessBuild(),
+			}
+
+			if c.WithoutSpec {
+				specs = nil
+			}
+
+			var (
+				specRepo = mock.NewSpecificationRepository(specs...)
+				pipeRepo = mock.NewPipelineRepository()
+				handler  = command.NewTriggerPipelineHandler(
+					mock.NewTestCampaignRepository(tc),
+					specRepo,
+					pipeRepo,
+					mock.NewTriggerReplayGuard(c.RememberedKeys...),
+					mock.NewPipelineMaintainer(false),
+					pipeline.WithHTTP(pipeline.PassingExecutor()),
+					pipeline.WithAssertion(pipeline.PassingExecutor()),
+				)
+			)
+
+			err := handler.Handle(context.Background(), c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+				require.Equal(t, 0, pipeRepo.PipelinesNumber())
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			require.Equal(t, 1, pipeRepo.PipelinesNumber())
+		})
+	}
+}
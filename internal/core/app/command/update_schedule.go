@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type UpdateSchedule struct {
+	ScheduleID     string
+	TestCampaignID string
+	UpdatedByID    string
+	Cron           *string
+	Timezone       *string
+	Environment    *string
+	Filter         *string
+}
+
+type UpdateScheduleHandler interface {
+	Handle(ctx context.Context, cmd UpdateSchedule) error
+}
+
+type updateScheduleHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+}
+
+func NewUpdateScheduleHandler(repo service.TestCampaignRepository) UpdateScheduleHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	return updateScheduleHandler{testCampaignRepo: repo}
+}
+
+func (h updateScheduleHandler) Handle(
+	ctx context.Context,
+	cmd UpdateSchedule,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "schedule updating")
+	}()
+
+	return h.testCampaignRepo.UpdateTestCampaign(
+		ctx,
+		cmd.TestCampaignID,
+		func(
+			_ context.Context,
+			tc *testcampaign.TestCampaign,
+		) (*testcampaign.TestCampaign, error) {
+			if err := user.CanAccessTestCampaign(cmd.UpdatedByID, tc, user.Write); err != nil {
+				return nil, err
+			}
+
+			schedule, err := tc.Schedule(cmd.ScheduleID)
+			if err != nil {
+				return nil, err
+			}
+
+			updated, err := testcampaign.NewSchedule(updatedScheduleParams(schedule, cmd))
+			if err != nil {
+				return nil, err
+			}
+
+			if err := tc.ReplaceSchedule(updated); err != nil {
+				return nil, err
+			}
+
+			return tc, nil
+		},
+	)
+}
+
+func updatedScheduleParams(
+	schedule testcampaign.Schedule,
+	cmd UpdateSchedule,
+) testcampaign.ScheduleParams {
+	params := testcampaign.ScheduleParams{
+		ID:          schedule.ID(),
+		Cron:        schedule.Cron(),
+		Timezone:    schedule.Timezone(),
+		Environment: schedule.Environment(),
+		Filter:      schedule.Filter(),
+	}
+
+	if cmd.Cron != nil {
+		params.Cron = *cmd.Cron
+	}
+
+	if cmd.Timezone != nil {
+		params.Timezone = *cmd.Timezone
+	}
+
+	if cmd.Environment != nil {
+		params.Environment = *cmd.Environment
+	}
+
+	if cmd.Filter != nil {
+		params.Filter = *cmd.Filter
+	}
+
+	return params
+}
@@ -0,0 +1,239 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewUpdateScheduleHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "all_dependencies_are_nil",
+			GivenTestCampaignRepo: nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewUpdateScheduleHandler(c.GivenTestCampaignRepo)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleUpdateSchedule(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cron        = "0 3 * * *"
+		timezone    = "Europe/Moscow"
+		environment = "staging"
+		filter      = "payments.["
+		invalidCron = "at night"
+	)
+
+	newTestCampaign := func(tcID, ownerID, scheduleID string) *testcampaign.TestCampaign {
+		return testcampaign.MustNew(testcampaign.Params{
+			ID:      tcID,
+			OwnerID: ownerID,
+			Schedules: []testcampaign.Schedule{
+				testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+					ID:       scheduleID,
+					Cron:     "@hourly",
+					Timezone: "UTC",
+				}),
+			},
+		})
+	}
+
+	testCases := []struct {
+		Name             string
+		Command          command.UpdateSchedule
+		TestCampaign     *testcampaign.TestCampaign
+		ExpectedCron     string
+		ExpectedTimezone string
+		ShouldBeErr      bool
+		IsErr            func(err error) bool
+	}{
+		{
+			Name: "update_cron",
+			Command: command.UpdateSchedule{
+				ScheduleID:     "d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a",
+				TestCampaignID: "e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b",
+				UpdatedByID:    "f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c",
+				Cron:           &cron,
+			},
+			TestCampaign: newTestCampaign(
+				"e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b",
+				"f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c",
+				"d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a",
+			),
+			ExpectedCron:     "0 3 * * *",
+			ExpectedTimezone: "UTC",
+			ShouldBeErr:      false,
+		},
+		{
+			Name: "update_timezone",
+			Command: command.UpdateSchedule{
+				ScheduleID:     "5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a8b",
+				TestCampaignID: "6f7a8b9c-0d1e-4f2a-9b3c-4d5e6f7a8b9c",
+				UpdatedByID:    "f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c",
+				Timezone:       &timezone,
+			},
+			TestCampaign: newTestCampaign(
+				"6f7a8b9c-0d1e-4f2a-9b3c-4d5e6f7a8b9c",
+				"f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c",
+				"5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a8b",
+			),
+			ExpectedCron:     "@hourly",
+			ExpectedTimezone: "Europe/Moscow",
+			ShouldBeErr:      false,
+		},
+		{
+			Name: "invalid_filter",
+			Command: command.UpdateSchedule{
+				ScheduleID:     "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+				TestCampaignID: "8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d1e",
+				UpdatedByID:    "f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c",
+				Environment:    &environment,
+				Filter:         &filter,
+			},
+			TestCampaign: newTestCampaign(
+				"8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d1e",
+				"f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c",
+				"7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d",
+			),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, specification.ErrInvalidScenarioFilter)
+			},
+		},
+		{
+			Name: "invalid_cron",
+			Command: command.UpdateSchedule{
+				ScheduleID:     "a4b5c6d7-e8f9-4a0b-9c2d-3e4f5a6b7c8d",
+				TestCampaignID: "b5c6d7e8-f9a0-4b1c-8d3e-4f5a6b7c8d9e",
+				UpdatedByID:    "c6d7e8f9-a0b1-4c2d-9e4f-5a6b7c8d9e0f",
+				Cron:           &invalidCron,
+			},
+			TestCampaign: newTestCampaign(
+				"b5c6d7e8-f9a0-4b1c-8d3e-4f5a6b7c8d9e",
+				"c6d7e8f9-a0b1-4c2d-9e4f-5a6b7c8d9e0f",
+				"a4b5c6d7-e8f9-4a0b-9c2d-3e4f5a6b7c8d",
+			),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrInvalidCron)
+			},
+		},
+		{
+			Name: "schedule_not_found",
+			Command: command.UpdateSchedule{
+				ScheduleID:     "d7e8f9a0-b1c2-4d3e-8f5a-6b7c8d9e0f1a",
+				TestCampaignID: "e8f9a0b1-c2d3-4e4f-9a6b-7c8d9e0f1a2b",
+				UpdatedByID:    "f9a0b1c2-d3e4-4f5a-8b7c-8d9e0f1a2b3c",
+				Cron:           &cron,
+			},
+			TestCampaign: newTestCampaign(
+				"e8f9a0b1-c2d3-4e4f-9a6b-7c8d9e0f1a2b",
+				"f9a0b1c2-d3e4-4f5a-8b7c-8d9e0f1a2b3c",
+				"0a1b2c3d-4e5f-4a6b-9c8d-9e0f1a2b3c4d",
+			),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, testcampaign.ErrScheduleNotFound)
+			},
+		},
+		{
+			Name: "user_cant_update_schedule",
+			Command: command.UpdateSchedule{
+				ScheduleID:     "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+				TestCampaignID: "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f",
+				UpdatedByID:    "3d4e5f6a-7b8c-4d9e-8f1a-2b3c4d5e6f7a",
+				Cron:           &cron,
+			},
+			TestCampaign: newTestCampaign(
+				"2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f",
+				"4e5f6a7b-8c9d-4e0f-9a2b-3c4d5e6f7a8b",
+				"1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+			),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				repo    = mock.NewTestCampaignRepository(c.TestCampaign)
+				handler = command.NewUpdateScheduleHandler(repo)
+			)
+
+			ctx := context.Background()
+
+			err := handler.Handle(ctx, c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tc, err := repo.GetTestCampaign(ctx, c.Command.TestCampaignID)
+			require.NoError(t, err)
+
+			schedule, err := tc.Schedule(c.Command.ScheduleID)
+			require.NoError(t, err)
+
+			require.Equal(t, c.ExpectedCron, schedule.Cron())
+			require.Equal(t, c.ExpectedTimezone, schedule.Timezone())
+		})
+	}
+}
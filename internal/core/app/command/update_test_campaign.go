@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type UpdateTestCampaign struct {
+	TestCampaignID string
+	UpdatedByID    string
+	ViewName       *string
+	Summary        *string
+}
+
+type UpdateTestCampaignHandler interface {
+	Handle(ctx context.Context, cmd UpdateTestCampaign) error
+}
+
+type updateTestCampaignHandler struct {
+	testCampaignRepo service.TestCampaignRepository
+}
+
+func NewUpdateTestCampaignHandler(repo service.TestCampaignRepository) UpdateTestCampaignHandler {
+	if repo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	return updateTestCampaignHandler{testCampaignRepo: repo}
+}
+
+func (h updateTestCampaignHandler) Handle(
+	ctx context.Context,
+	cmd UpdateTestCampaign,
+) (err error) {
+	defer func() {
+		err = errors.Wrap(err, "test campaign updating")
+	}()
+
+	return h.testCampaignRepo.UpdateTestCampaign(
+		ctx,
+		cmd.TestCampaignID,
+		func(
+			_ context.Context,
+			tc *testcampaign.TestCampaign,
+		) (*testcampaign.TestCampaign, error) {
+			if err := user.CanAccessTestCampaign(cmd.UpdatedByID, tc, user.Write); err != nil {
+				return nil, err
+			}
+
+			if cmd.ViewName != nil {
+				tc.SetViewName(*cmd.ViewName)
+			}
+
+			if cmd.Summary != nil {
+				tc.SetSummary(*cmd.Summary)
+			}
+
+			return tc, nil
+		},
+	)
+}
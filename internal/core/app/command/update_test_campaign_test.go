@@ -0,0 +1,180 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/command"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+func TestNewUpdateTestCampaignHandlerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		GivenTestCampaignRepo service.TestCampaignRepository
+		ShouldPanic           bool
+		PanicMessage          string
+	}{
+		{
+			Name:                  "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo: mock.NewTestCampaignRepository(),
+			ShouldPanic:           false,
+		},
+		{
+			Name:                  "all_dependencies_are_nil",
+			GivenTestCampaignRepo: nil,
+			ShouldPanic:           true,
+			PanicMessage:          "test campaign repository is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = command.NewUpdateTestCampaignHandler(c.GivenTestCampaignRepo)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestHandleUpdateTestCampaign(t *testing.T) {
+	t.Parallel()
+
+	var (
+		viewName = "new view name"
+		summary  = "new summary"
+	)
+
+	testCases := []struct {
+		Name             string
+		Command          command.UpdateTestCampaign
+		TestCampaign     *testcampaign.TestCampaign
+		ExpectedViewName string
+		ExpectedSummary  string
+		ShouldBeErr      bool
+		IsErr            func(err error) bool
+	}{
+		{
+			Name: "update_view_name_and_summary",
+			Command: command.UpdateTestCampaign{
+				TestCampaignID: "2bb0b0c2-c6a8-4f8e-9d0e-7a4e0f3a9e6b",
+				UpdatedByID:    "5e2a1b3c-0bdf-4a44-8f2a-3f0a34c7a9de",
+				ViewName:       &viewName,
+				Summary:        &summary,
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:       "2bb0b0c2-c6a8-4f8e-9d0e-7a4e0f3a9e6b",
+				ViewName: "view name",
+				Summary:  "summary",
+				OwnerID:  "5e2a1b3c-0bdf-4a44-8f2a-3f0a34c7a9de",
+			}),
+			ExpectedViewName: "new view name",
+			ExpectedSummary:  "new summary",
+			ShouldBeErr:      false,
+		},
+		{
+			Name: "update_only_summary",
+			Command: command.UpdateTestCampaign{
+				TestCampaignID: "d7f0e35b-52fa-4d61-b3c5-1d2f4b8b70c4",
+				UpdatedByID:    "a83f3d9f-35c4-44d2-a2cb-0f0c6e3f2f01",
+				Summary:        &summary,
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:       "d7f0e35b-52fa-4d61-b3c5-1d2f4b8b70c4",
+				ViewName: "view name",
+				Summary:  "summary",
+				OwnerID:  "a83f3d9f-35c4-44d2-a2cb-0f0c6e3f2f01",
+			}),
+			ExpectedViewName: "view name",
+			ExpectedSummary:  "new summary",
+			ShouldBeErr:      false,
+		},
+		{
+			Name: "test_campaign_not_found",
+			Command: command.UpdateTestCampaign{
+				TestCampaignID: "0b8e2f7c-8b5f-4c1a-b6ee-b4d6c3c6a3c1",
+				UpdatedByID:    "7a1fd3a4-0b76-4b8b-9c9f-0cf6a4a1b0c2",
+				ViewName:       &viewName,
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:      "c0e9b54d-4a6e-4cf6-9b33-6a1c8d0e2f47",
+				OwnerID: "7a1fd3a4-0b76-4b8b-9c9f-0cf6a4a1b0c2",
+			}),
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrTestCampaignNotFound)
+			},
+		},
+		{
+			Name: "user_cant_update_test_campaign",
+			Command: command.UpdateTestCampaign{
+				TestCampaignID: "9a0e7a3b-62c4-4bb0-8c0b-3f1a4f6d1e2a",
+				UpdatedByID:    "e8b3a6d4-1c2f-4d5e-8f9a-0b1c2d3e4f5a",
+				ViewName:       &viewName,
+			},
+			TestCampaign: testcampaign.MustNew(testcampaign.Params{
+				ID:       "9a0e7a3b-62c4-4bb0-8c0b-3f1a4f6d1e2a",
+				ViewName: "view name",
+				OwnerID:  "4b6c8d0e-2f3a-4b5c-9d7e-1f2a3b4c5d6e",
+			}),
+			ExpectedViewName: "view name",
+			ShouldBeErr:      true,
+			IsErr: func(err error) bool {
+				var target *user.AccessError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				repo    = mock.NewTestCampaignRepository(c.TestCampaign)
+				handler = command.NewUpdateTestCampaignHandler(repo)
+			)
+
+			ctx := context.Background()
+
+			err := handler.Handle(ctx, c.Command)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tc, err := repo.GetTestCampaign(ctx, c.Command.TestCampaignID)
+			require.NoError(t, err)
+
+			require.Equal(t, c.ExpectedViewName, tc.ViewName())
+			require.Equal(t, c.ExpectedSummary, tc.Summary())
+		})
+	}
+}
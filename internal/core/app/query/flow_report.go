@@ -0,0 +1,92 @@
+package query
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+type FlowReport struct {
+	FlowID string
+	UserID string
+	Format string
+}
+
+var ErrUnknownReportFormat = errors.New("unknown report format")
+
+type FlowReportHandler interface {
+	Handle(ctx context.Context, qry FlowReport) (FlowReportModel, error)
+}
+
+type FlowReportReadModel interface {
+	// FindFlow returns the flow together with
+	// the specification its pipeline was run by.
+	FindFlow(ctx context.Context, qry FlowReport) (*flow.Flow, *specification.Specification, error)
+}
+
+type flowReportHandler struct {
+	readModel FlowReportReadModel
+	reporters map[string]service.FlowReporter
+}
+
+func NewFlowReportHandler(
+	readModel FlowReportReadModel,
+	reporters ...service.FlowReporter,
+) FlowReportHandler {
+	if readModel == nil {
+		panic("flow report read model is nil")
+	}
+
+	h := flowReportHandler{
+		readModel: readModel,
+		reporters: make(map[string]service.FlowReporter, len(reporters)),
+	}
+
+	for _, r := range reporters {
+		if r == nil {
+			panic("flow reporter is nil")
+		}
+
+		h.reporters[r.Format()] = r
+	}
+
+	return h
+}
+
+// Handle renders the flow with reporter of the query format.
+// ErrUnknownReportFormat is returned if there is no such reporter.
+func (h flowReportHandler) Handle(
+	ctx context.Context,
+	qry FlowReport,
+) (_ FlowReportModel, err error) {
+	defer func() {
+		err = errors.Wrap(err, "getting flow report")
+	}()
+
+	reporter, ok := h.reporters[qry.Format]
+	if !ok {
+		return FlowReportModel{}, errors.Wrap(ErrUnknownReportFormat, qry.Format)
+	}
+
+	f, spec, err := h.readModel.FindFlow(ctx, qry)
+	if err != nil {
+		return FlowReportModel{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := reporter.WriteFlowReport(&buf, f, spec); err != nil {
+		return FlowReportModel{}, err
+	}
+
+	return FlowReportModel{
+		FlowID:      f.ID(),
+		Format:      reporter.Format(),
+		ContentType: reporter.ContentType(),
+		Content:     buf.Bytes(),
+	}, nil
+}
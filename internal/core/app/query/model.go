@@ -2,14 +2,62 @@ package query
 
 import "time"
 
-type TestCampaignModel struct {
-	ID        string
-	ViewName  string
-	Summary   string
-	CreatedAt time.Time
-}
+type (
+	TestCampaignModel struct {
+		ID        string
+		ViewName  string
+		Summary   string
+		CreatedAt time.Time
+	}
+
+	TestCampaignsModel struct {
+		TestCampaigns []TestCampaignModel
+		NextCursor    string
+	}
+
+	ScheduleModel struct {
+		ID          string
+		Cron        string
+		Timezone    string
+		Environment string
+		Filter      string
+		NextRunAt   time.Time
+	}
+
+	SubscriptionModel struct {
+		ID     string
+		URL    string
+		Filter string
+	}
+
+	NotificationModel struct {
+		ID             string
+		SubscriptionID string
+		PipelineID     string
+		FlowID         string
+		URL            string
+		Event          string
+		State          string
+		Attempts       int
+		LastError      string
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+	}
+)
 
 type (
+	SpecificationHistoryModel struct {
+		Specifications []GeneralSpecificationModel
+	}
+
+	GeneralSpecificationModel struct {
+		ID       string
+		LoadedAt time.Time
+		Author   string
+		Title    string
+		Active   bool
+	}
+
 	SpecificationModel struct {
 		ID             string
 		TestCampaignID string
@@ -76,6 +124,27 @@ type (
 	}
 )
 
+type (
+	SpecificationDiffModel struct {
+		SpecificationID string
+		AgainstID       string
+		Changes         []SpecificationChangeModel
+	}
+
+	SpecificationChangeModel struct {
+		Slug     string
+		SlugKind string
+		Kind     string
+		Fields   []FieldChangeModel
+	}
+
+	FieldChangeModel struct {
+		Field string
+		From  interface{}
+		To    interface{}
+	}
+)
+
 func (h HTTPModel) IsZero() bool {
 	return h.Request.IsZero() && h.Response.IsZero()
 }
@@ -99,11 +168,14 @@ type (
 	PipelineModel struct {
 		ID              string
 		SpecificationID string
+		Started         bool
+		Queued          bool
 		StartedAt       time.Time
 		Flows           []FlowModel
 	}
 
 	FlowModel struct {
+		ID           string
 		StartedAt    time.Time
 		OverallState string
 		Statuses     []StatusModel
@@ -126,3 +198,56 @@ type (
 		OccurredErrs []string
 	}
 )
+
+type LintProblemModel struct {
+	Rule     string
+	Severity string
+	Slug     string
+	SlugKind string
+	Message  string
+}
+
+type SpecificationExportModel struct {
+	SpecificationID string
+	ContentType     string
+	Content         []byte
+}
+
+type FlowReportModel struct {
+	FlowID      string
+	Format      string
+	ContentType string
+	Content     []byte
+}
+
+type StepModel struct {
+	Slug         string
+	SlugKind     string
+	ExecutorType string
+	Event        string
+	Err          string
+	OccurredAt   time.Time
+}
+
+type (
+	PipelineHistoryModel struct {
+		Pipelines  []GeneralPipelineModel
+		NextCursor string
+	}
+
+	GeneralPipelineModel struct {
+		ID              string
+		SpecificationID string
+		StartedAt       time.Time
+		LastState       string
+	}
+)
+
+type QueuedPipelineModel struct {
+	PipelineID string
+	Priority   string
+	Position   int
+	EnqueuedAt time.Time
+	// ETA is zero if there is no estimate yet.
+	ETA time.Duration
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Notifications is a query of the delivery log of the test
+// campaign notifications, newest first. Non empty State
+// selects notifications in such state, e.g. dead ones.
+type Notifications struct {
+	TestCampaignID string
+	UserID         string
+	State          string
+}
+
+type NotificationsHandler interface {
+	Handle(ctx context.Context, qry Notifications) ([]NotificationModel, error)
+}
+
+type NotificationsReadModel interface {
+	FindNotifications(ctx context.Context, qry Notifications) ([]NotificationModel, error)
+}
+
+type notificationsHandler struct {
+	readModel NotificationsReadModel
+}
+
+func NewNotificationsHandler(readModel NotificationsReadModel) NotificationsHandler {
+	if readModel == nil {
+		panic("notifications read model is nil")
+	}
+
+	return notificationsHandler{
+		readModel: readModel,
+	}
+}
+
+func (h notificationsHandler) Handle(
+	ctx context.Context,
+	qry Notifications,
+) ([]NotificationModel, error) {
+	notifications, err := h.readModel.FindNotifications(ctx, qry)
+
+	return notifications, errors.Wrap(err, "getting notifications")
+}
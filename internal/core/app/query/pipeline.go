@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
 )
 
 type Pipeline struct {
@@ -21,15 +23,21 @@ type PipelineReadModel interface {
 
 type pipelineHandler struct {
 	readModel PipelineReadModel
+	queue     service.PipelineQueue
 }
 
-func NewPipelineHandler(readModel PipelineReadModel) PipelineHandler {
+func NewPipelineHandler(readModel PipelineReadModel, queue service.PipelineQueue) PipelineHandler {
 	if readModel == nil {
 		panic("pipeline read model is nil")
 	}
 
+	if queue == nil {
+		panic("pipeline queue is nil")
+	}
+
 	return pipelineHandler{
 		readModel: readModel,
+		queue:     queue,
 	}
 }
 
@@ -38,6 +46,35 @@ func (h pipelineHandler) Handle(
 	qry Pipeline,
 ) (PipelineModel, error) {
 	pipe, err := h.readModel.FindPipeline(ctx, qry)
+	if err != nil {
+		return PipelineModel{}, errors.Wrap(err, "getting pipeline")
+	}
+
+	pipe.Queued, err = h.isQueued(pipe.ID)
+	if err != nil {
+		return PipelineModel{}, errors.Wrap(err, "getting pipeline")
+	}
+
+	return pipe, nil
+}
+
+// isQueued returns false if the queue is unavailable,
+// because the pipeline is queued by the worker then.
+func (h pipelineHandler) isQueued(pipeID string) (bool, error) {
+	queued, err := h.queue.QueuedPipelines()
+	if errors.Is(err, service.ErrPipelineQueueUnavailable) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range queued {
+		if p.PipelineID == pipeID {
+			return true, nil
+		}
+	}
 
-	return pipe, errors.Wrap(err, "getting pipeline")
+	return false, nil
 }
@@ -0,0 +1,71 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type PipelineHistory struct {
+	TestCampaignID  string
+	UserID          string
+	SpecificationID string
+	State           string
+	From            time.Time
+	To              time.Time
+	Cursor          string
+	Limit           int
+}
+
+const (
+	DefaultPipelineHistoryLimit = 20
+	MaxPipelineHistoryLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type PipelineHistoryHandler interface {
+	Handle(ctx context.Context, qry PipelineHistory) (PipelineHistoryModel, error)
+}
+
+type PipelineHistoryReadModel interface {
+	FindPipelineHistory(ctx context.Context, qry PipelineHistory) (PipelineHistoryModel, error)
+}
+
+type pipelineHistoryHandler struct {
+	readModel PipelineHistoryReadModel
+}
+
+func NewPipelineHistoryHandler(readModel PipelineHistoryReadModel) PipelineHistoryHandler {
+	if readModel == nil {
+		panic("pipeline history read model is nil")
+	}
+
+	return pipelineHistoryHandler{
+		readModel: readModel,
+	}
+}
+
+func (h pipelineHistoryHandler) Handle(
+	ctx context.Context,
+	qry PipelineHistory,
+) (PipelineHistoryModel, error) {
+	qry.Limit = normalizedLimit(qry.Limit, DefaultPipelineHistoryLimit, MaxPipelineHistoryLimit)
+
+	history, err := h.readModel.FindPipelineHistory(ctx, qry)
+
+	return history, errors.Wrap(err, "getting pipeline history")
+}
+
+func normalizedLimit(limit, defaultLimit, maxLimit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+
+	if limit > maxLimit {
+		return maxLimit
+	}
+
+	return limit
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type PipelineQueue struct {
+	UserID string
+}
+
+type PipelineQueueHandler interface {
+	Handle(ctx context.Context, qry PipelineQueue) ([]QueuedPipelineModel, error)
+}
+
+type pipelineQueueHandler struct {
+	queue service.PipelineQueue
+}
+
+func NewPipelineQueueHandler(queue service.PipelineQueue) PipelineQueueHandler {
+	if queue == nil {
+		panic("pipeline queue is nil")
+	}
+
+	return pipelineQueueHandler{queue: queue}
+}
+
+// Handle returns queued pipelines of the user, positions
+// are counted among pipelines of all users.
+func (h pipelineQueueHandler) Handle(
+	_ context.Context,
+	qry PipelineQueue,
+) ([]QueuedPipelineModel, error) {
+	queued, err := h.queue.QueuedPipelines()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pipeline queue")
+	}
+
+	pipes := make([]QueuedPipelineModel, 0, len(queued))
+
+	for _, p := range queued {
+		if p.OwnerID != qry.UserID {
+			continue
+		}
+
+		pipes = append(pipes, QueuedPipelineModel{
+			PipelineID: p.PipelineID,
+			Priority:   p.Priority.String(),
+			Position:   p.Position,
+			EnqueuedAt: p.EnqueuedAt,
+			ETA:        p.ETA,
+		})
+	}
+
+	return pipes, nil
+}
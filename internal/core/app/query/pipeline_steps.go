@@ -0,0 +1,122 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type PipelineSteps struct {
+	PipelineID string
+	UserID     string
+}
+
+type PipelineStepsHandler interface {
+	Handle(ctx context.Context, qry PipelineSteps) (<-chan StepModel, error)
+}
+
+type pipelineStepsHandler struct {
+	pipeRepo   service.PipelineRepository
+	subscriber service.PipelineStepSubscriber
+}
+
+func NewPipelineStepsHandler(
+	pipeRepo service.PipelineRepository,
+	stepSub service.PipelineStepSubscriber,
+) PipelineStepsHandler {
+	if pipeRepo == nil {
+		panic("pipeline repository is nil")
+	}
+
+	if stepSub == nil {
+		panic("pipeline step subscriber is nil")
+	}
+
+	return pipelineStepsHandler{
+		pipeRepo:   pipeRepo,
+		subscriber: stepSub,
+	}
+}
+
+// Handle returns steps of the started pipeline as they occur.
+// The returned channel is closed when the pipeline is completed
+// or the context is done.
+func (h pipelineStepsHandler) Handle(
+	ctx context.Context,
+	qry PipelineSteps,
+) (_ <-chan StepModel, err error) {
+	defer func() {
+		err = errors.Wrap(err, "getting pipeline steps")
+	}()
+
+	subCtx, cancel := context.WithCancel(ctx)
+
+	// Subscription goes before the started check,
+	// so steps occurred in between are not lost.
+	msgs, err := h.subscriber.SubscribePipelineSteps(subCtx, qry.PipelineID)
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	if err := h.checkPipeline(ctx, qry); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	steps := make(chan StepModel)
+
+	go func() {
+		defer cancel()
+		defer close(steps)
+
+		for msg := range msgs {
+			select {
+			case steps <- newStepModel(msg):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return steps, nil
+}
+
+func (h pipelineStepsHandler) checkPipeline(ctx context.Context, qry PipelineSteps) error {
+	pipe, err := h.pipeRepo.GetPipeline(ctx, qry.PipelineID, service.WithoutSpecification())
+	if err != nil {
+		return err
+	}
+
+	if err := user.CanAccessPipeline(qry.UserID, pipe, user.Read); err != nil {
+		return err
+	}
+
+	return pipe.ShouldBeStarted()
+}
+
+func newStepModel(msg service.StepMessage) StepModel {
+	var (
+		step = msg.Step
+		slug = step.Slug()
+	)
+
+	m := StepModel{
+		Slug:         slug.String(),
+		SlugKind:     string(slug.Kind()),
+		ExecutorType: string(step.ExecutorType()),
+		Event:        string(step.Event()),
+		OccurredAt:   msg.OccurredAt,
+	}
+
+	if err := step.Err(); err != nil {
+		m.Err = err.Error()
+	}
+
+	return m
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type Schedules struct {
+	TestCampaignID string
+	UserID         string
+}
+
+type SchedulesHandler interface {
+	Handle(ctx context.Context, qry Schedules) ([]ScheduleModel, error)
+}
+
+type SchedulesReadModel interface {
+	FindSchedules(ctx context.Context, qry Schedules) ([]ScheduleModel, error)
+}
+
+type schedulesHandler struct {
+	readModel SchedulesReadModel
+}
+
+func NewSchedulesHandler(readModel SchedulesReadModel) SchedulesHandler {
+	if readModel == nil {
+		panic("schedules read model is nil")
+	}
+
+	return schedulesHandler{
+		readModel: readModel,
+	}
+}
+
+func (h schedulesHandler) Handle(
+	ctx context.Context,
+	qry Schedules,
+) ([]ScheduleModel, error) {
+	schedules, err := h.readModel.FindSchedules(ctx, qry)
+
+	return schedules, errors.Wrap(err, "getting schedules")
+}
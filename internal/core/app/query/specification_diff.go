@@ -0,0 +1,136 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type SpecificationDiff struct {
+	SpecificationID string
+	AgainstID       string
+	UserID          string
+}
+
+type SpecificationDiffHandler interface {
+	Handle(ctx context.Context, qry SpecificationDiff) (SpecificationDiffModel, error)
+}
+
+type SpecificationDiffReadModel interface {
+	GetSpecification(ctx context.Context, specID string) (*specification.Specification, error)
+}
+
+type specificationDiffHandler struct {
+	readModel SpecificationDiffReadModel
+}
+
+func NewSpecificationDiffHandler(readModel SpecificationDiffReadModel) SpecificationDiffHandler {
+	if readModel == nil {
+		panic("specification diff read model is nil")
+	}
+
+	return specificationDiffHandler{
+		readModel: readModel,
+	}
+}
+
+// Handle returns changes that turn the specification
+// with AgainstID into the specification with SpecificationID.
+func (h specificationDiffHandler) Handle(
+	ctx context.Context,
+	qry SpecificationDiff,
+) (_ SpecificationDiffModel, err error) {
+	defer func() {
+		err = errors.Wrap(err, "getting specification diff")
+	}()
+
+	spec, err := h.getSpecification(ctx, qry.SpecificationID, qry.UserID)
+	if err != nil {
+		return SpecificationDiffModel{}, err
+	}
+
+	against, err := h.getSpecification(ctx, qry.AgainstID, qry.UserID)
+	if err != nil {
+		return SpecificationDiffModel{}, err
+	}
+
+	return newSpecificationDiffModel(qry, specification.Compare(against, spec)), nil
+}
+
+func (h specificationDiffHandler) getSpecification(
+	ctx context.Context,
+	specID, userID string,
+) (*specification.Specification, error) {
+	spec, err := h.readModel.GetSpecification(ctx, specID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.CanAccessSpecification(userID, spec, user.Read); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+func newSpecificationDiffModel(qry SpecificationDiff, diff specification.Diff) SpecificationDiffModel {
+	changes := diff.Changes()
+
+	model := SpecificationDiffModel{
+		SpecificationID: qry.SpecificationID,
+		AgainstID:       qry.AgainstID,
+		Changes:         make([]SpecificationChangeModel, 0, len(changes)),
+	}
+
+	for _, c := range changes {
+		fields := c.Fields()
+
+		change := SpecificationChangeModel{
+			Slug:     c.Slug().String(),
+			SlugKind: string(c.Slug().Kind()),
+			Kind:     c.Kind().String(),
+			Fields:   make([]FieldChangeModel, 0, len(fields)),
+		}
+
+		for _, f := range fields {
+			change.Fields = append(change.Fields, FieldChangeModel{
+				Field: f.Field(),
+				From:  newFieldValue(f.From()),
+				To:    newFieldValue(f.To()),
+			})
+		}
+
+		model.Changes = append(model.Changes, change)
+	}
+
+	return model
+}
+
+func newFieldValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case specification.Stage:
+		return value.String()
+	case specification.HTTPMethod:
+		return value.String()
+	case specification.ContentType:
+		return value.String()
+	case specification.AssertionMethod:
+		return string(value)
+	case []specification.Assert:
+		asserts := make([]AssertModel, 0, len(value))
+
+		for _, a := range value {
+			asserts = append(asserts, AssertModel{
+				Actual:   a.Actual(),
+				Expected: a.Expected(),
+			})
+		}
+
+		return asserts
+	}
+
+	return v
+}
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/user"
+)
+
+type SpecificationExport struct {
+	SpecificationID string
+	UserID          string
+}
+
+type SpecificationExportHandler interface {
+	Handle(ctx context.Context, qry SpecificationExport) (SpecificationExportModel, error)
+}
+
+type SpecificationExportReadModel interface {
+	GetSpecification(ctx context.Context, specID string) (*specification.Specification, error)
+}
+
+type specificationExportHandler struct {
+	readModel  SpecificationExportReadModel
+	serializer service.SpecificationSerializer
+}
+
+func NewSpecificationExportHandler(
+	readModel SpecificationExportReadModel,
+	serializer service.SpecificationSerializer,
+) SpecificationExportHandler {
+	if readModel == nil {
+		panic("specification export read model is nil")
+	}
+
+	if serializer == nil {
+		panic("specification serializer is nil")
+	}
+
+	return specificationExportHandler{
+		readModel:  readModel,
+		serializer: serializer,
+	}
+}
+
+// Handle returns source of the specification
+// written back by the specification serializer.
+func (h specificationExportHandler) Handle(
+	ctx context.Context,
+	qry SpecificationExport,
+) (_ SpecificationExportModel, err error) {
+	defer func() {
+		err = errors.Wrap(err, "exporting specification")
+	}()
+
+	spec, err := h.readModel.GetSpecification(ctx, qry.SpecificationID)
+	if err != nil {
+		return SpecificationExportModel{}, err
+	}
+
+	if err := user.CanAccessSpecification(qry.UserID, spec, user.Read); err != nil {
+		return SpecificationExportModel{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := h.serializer.SerializeSpecification(&buf, spec); err != nil {
+		return SpecificationExportModel{}, err
+	}
+
+	return SpecificationExportModel{
+		SpecificationID: spec.ID(),
+		ContentType:     h.serializer.ContentType(),
+		Content:         buf.Bytes(),
+	}, nil
+}
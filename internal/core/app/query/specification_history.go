@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type SpecificationHistory struct {
+	TestCampaignID string
+	UserID         string
+}
+
+type SpecificationHistoryHandler interface {
+	Handle(ctx context.Context, qry SpecificationHistory) (SpecificationHistoryModel, error)
+}
+
+type SpecificationHistoryReadModel interface {
+	FindSpecificationHistory(ctx context.Context, qry SpecificationHistory) (SpecificationHistoryModel, error)
+}
+
+type specificationHistoryHandler struct {
+	readModel SpecificationHistoryReadModel
+}
+
+func NewSpecificationHistoryHandler(readModel SpecificationHistoryReadModel) SpecificationHistoryHandler {
+	if readModel == nil {
+		panic("specification history read model is nil")
+	}
+
+	return specificationHistoryHandler{
+		readModel: readModel,
+	}
+}
+
+func (h specificationHistoryHandler) Handle(
+	ctx context.Context,
+	qry SpecificationHistory,
+) (SpecificationHistoryModel, error) {
+	history, err := h.readModel.FindSpecificationHistory(ctx, qry)
+
+	return history, errors.Wrap(err, "getting specification history")
+}
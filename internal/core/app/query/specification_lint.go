@@ -0,0 +1,72 @@
+package query
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+type SpecificationLint struct {
+	Content []byte
+	// Rules override default severities of lint rules by their IDs.
+	Rules map[string]string
+}
+
+type SpecificationLintHandler interface {
+	Handle(ctx context.Context, qry SpecificationLint) ([]LintProblemModel, error)
+}
+
+type specificationLintHandler struct {
+	specParser service.SpecificationParser
+}
+
+func NewSpecificationLintHandler(specParser service.SpecificationParser) SpecificationLintHandler {
+	if specParser == nil {
+		panic("specification parser is nil")
+	}
+
+	return specificationLintHandler{
+		specParser: specParser,
+	}
+}
+
+// Handle parses specification from the content without saving
+// and returns problems found by the lint rules.
+func (h specificationLintHandler) Handle(
+	_ context.Context,
+	qry SpecificationLint,
+) (_ []LintProblemModel, err error) {
+	defer func() {
+		err = errors.Wrap(err, "specification linting")
+	}()
+
+	cfg, err := specification.NewLintConfig(qry.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := h.specParser.ParseSpecification(bytes.NewReader(qry.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	problems := specification.Lint(spec, cfg)
+
+	models := make([]LintProblemModel, 0, len(problems))
+
+	for _, p := range problems {
+		models = append(models, LintProblemModel{
+			Rule:     string(p.Rule()),
+			Severity: string(p.Severity()),
+			Slug:     p.Slug().String(),
+			SlugKind: string(p.Slug().Kind()),
+			Message:  p.Message(),
+		})
+	}
+
+	return models, nil
+}
@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type SpecificationSchema struct{}
+
+type SpecificationSchemaHandler interface {
+	Handle(ctx context.Context, qry SpecificationSchema) ([]byte, error)
+}
+
+type specificationSchemaHandler struct {
+	provider service.SpecificationSchemaProvider
+}
+
+func NewSpecificationSchemaHandler(provider service.SpecificationSchemaProvider) SpecificationSchemaHandler {
+	if provider == nil {
+		panic("specification schema provider is nil")
+	}
+
+	return specificationSchemaHandler{
+		provider: provider,
+	}
+}
+
+// Handle returns JSON Schema of the specification source format.
+func (h specificationSchemaHandler) Handle(_ context.Context, _ SpecificationSchema) (_ []byte, err error) {
+	defer func() {
+		err = errors.Wrap(err, "getting specification schema")
+	}()
+
+	return h.provider.SpecificationSchema()
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type Subscriptions struct {
+	TestCampaignID string
+	UserID         string
+}
+
+type SubscriptionsHandler interface {
+	Handle(ctx context.Context, qry Subscriptions) ([]SubscriptionModel, error)
+}
+
+type SubscriptionsReadModel interface {
+	FindSubscriptions(ctx context.Context, qry Subscriptions) ([]SubscriptionModel, error)
+}
+
+type subscriptionsHandler struct {
+	readModel SubscriptionsReadModel
+}
+
+func NewSubscriptionsHandler(readModel SubscriptionsReadModel) SubscriptionsHandler {
+	if readModel == nil {
+		panic("subscriptions read model is nil")
+	}
+
+	return subscriptionsHandler{
+		readModel: readModel,
+	}
+}
+
+func (h subscriptionsHandler) Handle(
+	ctx context.Context,
+	qry Subscriptions,
+) ([]SubscriptionModel, error) {
+	subscriptions, err := h.readModel.FindSubscriptions(ctx, qry)
+
+	return subscriptions, errors.Wrap(err, "getting subscriptions")
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type TestCampaigns struct {
+	UserID string
+	Search string
+	Cursor string
+	Limit  int
+}
+
+const (
+	DefaultTestCampaignsLimit = 20
+	MaxTestCampaignsLimit     = 100
+)
+
+type TestCampaignsHandler interface {
+	Handle(ctx context.Context, qry TestCampaigns) (TestCampaignsModel, error)
+}
+
+type TestCampaignsReadModel interface {
+	FindTestCampaigns(ctx context.Context, qry TestCampaigns) (TestCampaignsModel, error)
+}
+
+type testCampaignsHandler struct {
+	readModel TestCampaignsReadModel
+}
+
+func NewTestCampaignsHandler(readModel TestCampaignsReadModel) TestCampaignsHandler {
+	if readModel == nil {
+		panic("test campaigns read model is nil")
+	}
+
+	return testCampaignsHandler{
+		readModel: readModel,
+	}
+}
+
+func (h testCampaignsHandler) Handle(
+	ctx context.Context,
+	qry TestCampaigns,
+) (TestCampaignsModel, error) {
+	qry.Limit = normalizedLimit(qry.Limit, DefaultTestCampaignsLimit, MaxTestCampaignsLimit)
+
+	tcs, err := h.readModel.FindTestCampaigns(ctx, qry)
+
+	return tcs, errors.Wrap(err, "getting test campaigns")
+}
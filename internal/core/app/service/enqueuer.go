@@ -1,11 +1,86 @@
 package service
 
-type Enqueuer interface {
-	Enqueue(fn func())
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	Enqueuer interface {
+		Enqueue(job Job)
+	}
+
+	// Job is the pipeline maintenance waiting for the free worker.
+	Job struct {
+		PipelineID string
+		OwnerID    string
+		Priority   Priority
+		Run        func()
+		// Discard is called instead of Run if the job
+		// is removed from the queue before it is run.
+		Discard func()
+	}
+
+	// Priority orders jobs in the queue, jobs with higher
+	// priority are run first, jobs with equal priority
+	// are run in order of their enqueuing.
+	Priority int
+)
+
+const (
+	ScheduledPriority Priority = iota
+	ManualPriority
+)
+
+func (p Priority) String() string {
+	switch p {
+	case ScheduledPriority:
+		return "scheduled"
+	case ManualPriority:
+		return "manual"
+	}
+
+	return "unknown"
 }
 
-type EnqueueFunc func(fn func())
+type (
+	// PipelineQueue shows pipelines waiting for the free worker.
+	PipelineQueue interface {
+		QueuedPipelines() ([]QueuedPipeline, error)
+		RemovePipeline(pipeID string) error
+	}
+
+	QueuedPipeline struct {
+		PipelineID string
+		OwnerID    string
+		Priority   Priority
+		Position   int
+		EnqueuedAt time.Time
+		// ETA is the estimated time left before the pipeline
+		// is run, zero if there is no estimate yet.
+		ETA time.Duration
+	}
+)
+
+var (
+	ErrPipelineNotQueued        = errors.New("pipeline not queued")
+	ErrPipelineQueueUnavailable = errors.New("pipeline queue unavailable")
+)
+
+// WithoutPipelineQueue returns PipelineQueue of the instance
+// that doesn't run pipelines itself, so queues of workers
+// can't be seen from it.
+func WithoutPipelineQueue() PipelineQueue {
+	return unavailablePipelineQueue{}
+}
+
+type unavailablePipelineQueue struct{}
+
+func (unavailablePipelineQueue) QueuedPipelines() ([]QueuedPipeline, error) {
+	return nil, ErrPipelineQueueUnavailable
+}
 
-func (e EnqueueFunc) Enqueue(fn func()) {
-	e(fn)
+func (unavailablePipelineQueue) RemovePipeline(_ string) error {
+	return ErrPipelineQueueUnavailable
 }
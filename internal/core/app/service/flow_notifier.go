@@ -0,0 +1,453 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+type (
+	// FlowNotifier notifies subscriptions of the test
+	// campaign about the completed flow of its pipeline.
+	FlowNotifier interface {
+		NotifyFlowCompleted(ctx context.Context, pipe *pipeline.Pipeline, f *flow.Flow)
+	}
+
+	// NotificationSender sends the notification
+	// payload signed with the subscription secret.
+	NotificationSender interface {
+		SendNotification(ctx context.Context, n Notification, secret string) error
+	}
+
+	// NotificationRepository keeps the delivery log of notifications.
+	NotificationRepository interface {
+		AddNotification(ctx context.Context, n Notification) error
+		UpdateNotification(ctx context.Context, n Notification) error
+		// ClaimStaleNotifications returns pending notifications not
+		// updated since updatedBefore and sets their update time to
+		// claimedAt, so each of them is claimed by one caller only.
+		ClaimStaleNotifications(
+			ctx context.Context,
+			updatedBefore, claimedAt time.Time,
+		) ([]Notification, error)
+	}
+
+	// Notification is a delivery of the completed
+	// flow summary to the subscription URL.
+	Notification struct {
+		ID             string
+		TestCampaignID string
+		SubscriptionID string
+		PipelineID     string
+		FlowID         string
+		URL            string
+		Event          testcampaign.Event
+		Payload        []byte
+		State          NotificationState
+		Attempts       int
+		LastError      string
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+	}
+
+	NotificationState string
+)
+
+const (
+	NotificationPending   NotificationState = "pending"
+	NotificationDelivered NotificationState = "delivered"
+	// NotificationDead is the state of the notification
+	// which is not delivered in all attempts.
+	NotificationDead NotificationState = "dead"
+)
+
+// FlowSummary is the payload of the notification.
+type FlowSummary struct {
+	Event          testcampaign.Event `json:"event"`
+	TestCampaignID string             `json:"testCampaignId"`
+	PipelineID     string             `json:"pipelineId"`
+	FlowID         string             `json:"flowId"`
+	State          string             `json:"state"`
+	Scenarios      []ScenarioSummary  `json:"scenarios"`
+	CompletedAt    time.Time          `json:"completedAt"`
+}
+
+type ScenarioSummary struct {
+	Slug  string `json:"slug"`
+	State string `json:"state"`
+}
+
+// WebhookNotifier delivers summaries of completed flows to
+// subscriptions matching the flow event. Failed deliveries
+// are retried with exponential backoff, notification that is
+// not delivered in maxAttempts becomes NotificationDead.
+type WebhookNotifier struct {
+	testCampaignRepo TestCampaignRepository
+	notificationRepo NotificationRepository
+	sender           NotificationSender
+	logger           Logger
+	maxAttempts      int
+	backoff          time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWebhookNotifier(
+	testCampaignRepo TestCampaignRepository,
+	notificationRepo NotificationRepository,
+	sender NotificationSender,
+	logger Logger,
+	maxAttempts int,
+	backoff time.Duration,
+) *WebhookNotifier {
+	if testCampaignRepo == nil {
+		panic("test campaign repository is nil")
+	}
+
+	if notificationRepo == nil {
+		panic("notification repository is nil")
+	}
+
+	if sender == nil {
+		panic("notification sender is nil")
+	}
+
+	if logger == nil {
+		panic("logger is nil")
+	}
+
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &WebhookNotifier{
+		testCampaignRepo: testCampaignRepo,
+		notificationRepo: notificationRepo,
+		sender:           sender,
+		logger:           logger,
+		maxAttempts:      maxAttempts,
+		backoff:          backoff,
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+}
+
+// NotifyFlowCompleted remembers the flow outcome in the test campaign
+// and starts deliveries to matching subscriptions in background.
+// Flow that is not completed yet is ignored.
+//
+// The event is found from the outcome replaced in the test campaign
+// by this flow, so concurrently completed flows of the same test
+// campaign don't lose each other outcomes.
+func (n *WebhookNotifier) NotifyFlowCompleted(
+	ctx context.Context,
+	pipe *pipeline.Pipeline,
+	f *flow.Flow,
+) {
+	outcome, ok := flowOutcome(f.OverallState())
+	if !ok {
+		return
+	}
+
+	l := n.logger.With(
+		"testCampaignId", pipe.TestCampaignID(),
+		"pipelineId", pipe.ID(),
+		"flowId", f.ID(),
+	)
+
+	tc, err := n.swapLastOutcome(ctx, pipe.TestCampaignID(), outcome)
+	if err != nil {
+		l.Error("Flow outcome is not saved", "error", err)
+
+		return
+	}
+
+	var (
+		event         = tc.CompleteFlow(outcome)
+		subscriptions = tc.Subscriptions()
+	)
+
+	completedAt := time.Now().UTC()
+
+	payload, err := json.Marshal(newFlowSummary(event, pipe, f, completedAt))
+	if err != nil {
+		l.Error("Flow summary is not encoded", "error", err)
+
+		return
+	}
+
+	for _, s := range subscriptions {
+		if !s.Matches(event) {
+			continue
+		}
+
+		notification := Notification{
+			ID:             uuid.New().String(),
+			TestCampaignID: pipe.TestCampaignID(),
+			SubscriptionID: s.ID(),
+			PipelineID:     pipe.ID(),
+			FlowID:         f.ID(),
+			URL:            s.URL(),
+			Event:          event,
+			Payload:        payload,
+			State:          NotificationPending,
+			CreatedAt:      completedAt,
+			UpdatedAt:      completedAt,
+		}
+
+		if err := n.notificationRepo.AddNotification(ctx, notification); err != nil {
+			l.Error("Notification is not added", "error", err, "subscriptionId", s.ID())
+
+			continue
+		}
+
+		n.wg.Add(1)
+
+		go func(notification Notification, secret string) {
+			defer n.wg.Done()
+
+			n.deliver(notification, secret)
+		}(notification, s.Secret())
+	}
+}
+
+// ResumeStaleNotifications continues deliveries of pending
+// notifications not updated for staleAfter, i.e. stopped by
+// Close or left by the crashed instance. Notification of the
+// removed test campaign or subscription becomes NotificationDead.
+// It returns the number of claimed notifications.
+func (n *WebhookNotifier) ResumeStaleNotifications(
+	ctx context.Context,
+	staleAfter time.Duration,
+) (int, error) {
+	now := time.Now().UTC()
+
+	notifications, err := n.notificationRepo.ClaimStaleNotifications(ctx, now.Add(-staleAfter), now)
+	if err != nil {
+		return 0, err
+	}
+
+	tcs := make(map[string]*testcampaign.TestCampaign)
+
+	for _, notification := range notifications {
+		l := n.logger.With(
+			"notificationId", notification.ID,
+			"subscriptionId", notification.SubscriptionID,
+		)
+
+		tc, ok := tcs[notification.TestCampaignID]
+		if !ok {
+			tc, err = n.testCampaignRepo.GetTestCampaign(ctx, notification.TestCampaignID)
+			if err != nil && !errors.Is(err, ErrTestCampaignNotFound) {
+				l.Error("Notification is not resumed", "error", err)
+
+				continue
+			}
+
+			tcs[notification.TestCampaignID] = tc
+		}
+
+		s, ok := subscription(tc, notification.SubscriptionID)
+		if !ok {
+			n.bury(notification, errSubscriptionRemoved)
+
+			continue
+		}
+
+		n.wg.Add(1)
+
+		go func(notification Notification, secret string) {
+			defer n.wg.Done()
+
+			n.deliver(notification, secret)
+		}(notification, s.Secret())
+	}
+
+	return len(notifications), nil
+}
+
+var errSubscriptionRemoved = errors.New("subscription is removed")
+
+// subscription returns the subscription of tc, tc is nil
+// if the test campaign is removed.
+func subscription(tc *testcampaign.TestCampaign, subscriptionID string) (testcampaign.Subscription, bool) {
+	if tc == nil {
+		return testcampaign.Subscription{}, false
+	}
+
+	for _, s := range tc.Subscriptions() {
+		if s.ID() == subscriptionID {
+			return s, true
+		}
+	}
+
+	return testcampaign.Subscription{}, false
+}
+
+func (n *WebhookNotifier) bury(notification Notification, reason error) {
+	notification.State = NotificationDead
+	notification.LastError = reason.Error()
+	notification.UpdatedAt = time.Now().UTC()
+
+	l := n.logger.With(
+		"notificationId", notification.ID,
+		"subscriptionId", notification.SubscriptionID,
+	)
+
+	if err := n.notificationRepo.UpdateNotification(context.Background(), notification); err != nil {
+		l.Warn("Attempt to update notification failed", "error", err)
+
+		return
+	}
+
+	l.Error("Notification is dead", "error", reason, "attempts", notification.Attempts)
+}
+
+// Close stops retries of pending notifications and waits for
+// running deliveries. Stopped notifications stay pending until
+// they are resumed with ResumeStaleNotifications.
+func (n *WebhookNotifier) Close() {
+	n.cancel()
+	n.wg.Wait()
+}
+
+// Wait waits until all started deliveries are
+// either delivered or dead.
+func (n *WebhookNotifier) Wait() {
+	n.wg.Wait()
+}
+
+func (n *WebhookNotifier) deliver(notification Notification, secret string) {
+	l := n.logger.With(
+		"notificationId", notification.ID,
+		"subscriptionId", notification.SubscriptionID,
+	)
+
+	for {
+		err := n.sender.SendNotification(n.ctx, notification, secret)
+
+		notification.Attempts++
+		notification.UpdatedAt = time.Now().UTC()
+
+		switch {
+		case err == nil:
+			notification.State = NotificationDelivered
+			notification.LastError = ""
+		case notification.Attempts >= n.maxAttempts:
+			notification.State = NotificationDead
+			notification.LastError = err.Error()
+		default:
+			notification.LastError = err.Error()
+		}
+
+		if err := n.notificationRepo.UpdateNotification(context.Background(), notification); err != nil {
+			l.Warn("Attempt to update notification failed", "error", err)
+		}
+
+		switch notification.State {
+		case NotificationDelivered:
+			l.Info("Notification delivered", "attempts", notification.Attempts)
+
+			return
+		case NotificationDead:
+			l.Error("Notification is dead", "error", err, "attempts", notification.Attempts)
+
+			return
+		}
+
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-time.After(NotificationBackoff(n.backoff, notification.Attempts)):
+		}
+	}
+}
+
+// MaxNotificationBackoff caps the exponential backoff
+// between delivery attempts of the notification.
+const MaxNotificationBackoff = time.Hour
+
+// NotificationBackoff returns delay after the attempt of delivery,
+// initial delay is doubled with each attempt up to MaxNotificationBackoff.
+func NotificationBackoff(initial time.Duration, attempt int) time.Duration {
+	backoff := initial
+
+	for i := 1; i < attempt && backoff < MaxNotificationBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > MaxNotificationBackoff {
+		return MaxNotificationBackoff
+	}
+
+	return backoff
+}
+
+// swapLastOutcome returns the test campaign as it was before the
+// outcome is remembered. Canceled flow is not remembered, see
+// testcampaign.TestCampaign CompleteFlow.
+func (n *WebhookNotifier) swapLastOutcome(
+	ctx context.Context,
+	tcID string,
+	outcome testcampaign.Event,
+) (*testcampaign.TestCampaign, error) {
+	if outcome == testcampaign.CanceledEvent {
+		return n.testCampaignRepo.GetTestCampaign(ctx, tcID)
+	}
+
+	return n.testCampaignRepo.SwapLastOutcome(ctx, tcID, outcome)
+}
+
+func flowOutcome(state flow.State) (testcampaign.Event, bool) {
+	switch state {
+	case flow.Passed:
+		return testcampaign.PassedEvent, true
+	case flow.Failed:
+		return testcampaign.FailedEvent, true
+	case flow.Crashed:
+		return testcampaign.CrashedEvent, true
+	case flow.Canceled:
+		return testcampaign.CanceledEvent, true
+	}
+
+	return "", false
+}
+
+func newFlowSummary(
+	event testcampaign.Event,
+	pipe *pipeline.Pipeline,
+	f *flow.Flow,
+	completedAt time.Time,
+) FlowSummary {
+	statuses := f.Statuses()
+
+	scenarios := make([]ScenarioSummary, 0, len(statuses))
+	for _, s := range statuses {
+		scenarios = append(scenarios, ScenarioSummary{
+			Slug:  s.Slug().String(),
+			State: s.State().String(),
+		})
+	}
+
+	return FlowSummary{
+		Event:          event,
+		TestCampaignID: pipe.TestCampaignID(),
+		PipelineID:     pipe.ID(),
+		FlowID:         f.ID(),
+		State:          f.OverallState().String(),
+		Scenarios:      scenarios,
+		CompletedAt:    completedAt,
+	}
+}
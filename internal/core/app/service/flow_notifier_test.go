@@ -0,0 +1,416 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+const notificationBackoff = time.Millisecond
+
+func TestNewWebhookNotifierPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                    string
+		GivenTestCampaignRepo   service.TestCampaignRepository
+		GivenNotificationRepo   service.NotificationRepository
+		GivenNotificationSender service.NotificationSender
+		GivenLogger             service.Logger
+		ShouldPanic             bool
+		PanicMessage            string
+	}{
+		{
+			Name:                    "all_dependencies_are_not_nil",
+			GivenTestCampaignRepo:   mock.NewTestCampaignRepository(),
+			GivenNotificationRepo:   mock.NewNotificationRepository(),
+			GivenNotificationSender: mock.NewNotificationSender(0),
+			GivenLogger:             mock.NewMemoryLogger(),
+			ShouldPanic:             false,
+		},
+		{
+			Name:                    "test_campaign_repository_is_nil",
+			GivenTestCampaignRepo:   nil,
+			GivenNotificationRepo:   mock.NewNotificationRepository(),
+			GivenNotificationSender: mock.NewNotificationSender(0),
+			GivenLogger:             mock.NewMemoryLogger(),
+			ShouldPanic:             true,
+			PanicMessage:            "test campaign repository is nil",
+		},
+		{
+			Name:                    "notification_repository_is_nil",
+			GivenTestCampaignRepo:   mock.NewTestCampaignRepository(),
+			GivenNotificationRepo:   nil,
+			GivenNotificationSender: mock.NewNotificationSender(0),
+			GivenLogger:             mock.NewMemoryLogger(),
+			ShouldPanic:             true,
+			PanicMessage:            "notification repository is nil",
+		},
+		{
+			Name:                    "notification_sender_is_nil",
+			GivenTestCampaignRepo:   mock.NewTestCampaignRepository(),
+			GivenNotificationRepo:   mock.NewNotificationRepository(),
+			GivenNotificationSender: nil,
+			GivenLogger:             mock.NewMemoryLogger(),
+			ShouldPanic:             true,
+			PanicMessage:            "notification sender is nil",
+		},
+		{
+			Name:                    "logger_is_nil",
+			GivenTestCampaignRepo:   mock.NewTestCampaignRepository(),
+			GivenNotificationRepo:   mock.NewNotificationRepository(),
+			GivenNotificationSender: mock.NewNotificationSender(0),
+			GivenLogger:             nil,
+			ShouldPanic:             true,
+			PanicMessage:            "logger is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = service.NewWebhookNotifier(
+					c.GivenTestCampaignRepo,
+					c.GivenNotificationRepo,
+					c.GivenNotificationSender,
+					c.GivenLogger,
+					3,
+					notificationBackoff,
+				)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestWebhookNotifierNotifyFlowCompleted(t *testing.T) {
+	t.Parallel()
+
+	const tcID = "tc"
+
+	var (
+		all = testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+			ID:     "all",
+			URL:    "https://ci.example.com/all",
+			Secret: "secret",
+		})
+		failed = testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+			ID:     "failed",
+			URL:    "https://ci.example.com/failed",
+			Secret: "secret",
+			Filter: testcampaign.FailedEvent,
+		})
+		recovered = testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+			ID:     "recovered",
+			URL:    "https://ci.example.com/recovered",
+			Secret: "secret",
+			Filter: testcampaign.RecoveredEvent,
+		})
+	)
+
+	testCases := []struct {
+		Name                  string
+		LastOutcome           testcampaign.Event
+		Executor              pipeline.Executor
+		SendFailures          int
+		ExpectedEvent         testcampaign.Event
+		ExpectedSubscriptions []string
+		ExpectedState         service.NotificationState
+		ExpectedAttempts      int
+	}{
+		{
+			Name:                  "passed_flow_notifies_all",
+			Executor:              pipeline.PassingExecutor(),
+			ExpectedEvent:         testcampaign.PassedEvent,
+			ExpectedSubscriptions: []string{"all"},
+			ExpectedState:         service.NotificationDelivered,
+			ExpectedAttempts:      1,
+		},
+		{
+			Name:                  "failed_flow_notifies_failed",
+			Executor:              pipeline.FailingExecutor(),
+			ExpectedEvent:         testcampaign.FailedEvent,
+			ExpectedSubscriptions: []string{"all", "failed"},
+			ExpectedState:         service.NotificationDelivered,
+			ExpectedAttempts:      1,
+		},
+		{
+			Name:                  "passed_after_failed_flow_notifies_recovered",
+			LastOutcome:           testcampaign.FailedEvent,
+			Executor:              pipeline.PassingExecutor(),
+			ExpectedEvent:         testcampaign.RecoveredEvent,
+			ExpectedSubscriptions: []string{"all", "recovered"},
+			ExpectedState:         service.NotificationDelivered,
+			ExpectedAttempts:      1,
+		},
+		{
+			Name:                  "delivered_after_retries",
+			Executor:              pipeline.CrashingExecutor(),
+			SendFailures:          2,
+			ExpectedEvent:         testcampaign.CrashedEvent,
+			ExpectedSubscriptions: []string{"all"},
+			ExpectedState:         service.NotificationDelivered,
+			ExpectedAttempts:      3,
+		},
+		{
+			Name:                  "dead_after_all_attempts",
+			Executor:              pipeline.FailingExecutor(),
+			SendFailures:          3,
+			ExpectedEvent:         testcampaign.FailedEvent,
+			ExpectedSubscriptions: []string{"all", "failed"},
+			ExpectedState:         service.NotificationDead,
+			ExpectedAttempts:      3,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				tcRepo = mock.NewTestCampaignRepository(testcampaign.MustNew(testcampaign.Params{
+					ID:            tcID,
+					OwnerID:       "owner",
+					Subscriptions: []testcampaign.Subscription{all, failed, recovered},
+					LastOutcome:   c.LastOutcome,
+				}))
+				notificationRepo = mock.NewNotificationRepository()
+				sender           = mock.NewNotificationSender(c.SendFailures)
+			)
+
+			notifier := service.NewWebhookNotifier(
+				tcRepo,
+				notificationRepo,
+				sender,
+				mock.NewMemoryLogger(),
+				3,
+				notificationBackoff,
+			)
+
+			pipe, f := completedFlow(tcID, c.Executor)
+
+			notifier.NotifyFlowCompleted(context.Background(), pipe, f)
+			notifier.Wait()
+
+			notifications := notificationRepo.Notifications()
+
+			subscriptions := make([]string, 0, len(notifications))
+
+			for _, n := range notifications {
+				subscriptions = append(subscriptions, n.SubscriptionID)
+
+				require.Equal(t, c.ExpectedEvent, n.Event)
+				require.Equal(t, c.ExpectedState, n.State)
+				require.Equal(t, c.ExpectedAttempts, n.Attempts)
+				require.Equal(t, c.ExpectedAttempts, sender.Attempts(n.ID))
+
+				var summary service.FlowSummary
+
+				require.NoError(t, json.Unmarshal(n.Payload, &summary))
+				require.Equal(t, c.ExpectedEvent, summary.Event)
+				require.Equal(t, f.ID(), summary.FlowID)
+				require.Len(t, summary.Scenarios, 1)
+			}
+
+			require.ElementsMatch(t, c.ExpectedSubscriptions, subscriptions)
+		})
+	}
+}
+
+func TestWebhookNotifierIgnoresUnknownTestCampaign(t *testing.T) {
+	t.Parallel()
+
+	var (
+		notificationRepo = mock.NewNotificationRepository()
+		logger           = mock.NewMemoryLogger()
+	)
+
+	notifier := service.NewWebhookNotifier(
+		mock.NewTestCampaignRepository(),
+		notificationRepo,
+		mock.NewNotificationSender(0),
+		logger,
+		3,
+		notificationBackoff,
+	)
+
+	pipe, f := completedFlow("unknown", pipeline.PassingExecutor())
+
+	notifier.NotifyFlowCompleted(context.Background(), pipe, f)
+	notifier.Close()
+
+	require.Empty(t, notificationRepo.Notifications())
+	require.NotEmpty(t, logger.FlushedLogs())
+}
+
+func TestWebhookNotifierResumeStaleNotifications(t *testing.T) {
+	t.Parallel()
+
+	const tcID = "tc"
+
+	var (
+		now   = time.Now().UTC()
+		stale = now.Add(-time.Hour)
+	)
+
+	tcRepo := mock.NewTestCampaignRepository(testcampaign.MustNew(testcampaign.Params{
+		ID:      tcID,
+		OwnerID: "owner",
+		Subscriptions: []testcampaign.Subscription{
+			testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+				ID:     "all",
+				URL:    "https://ci.example.com/all",
+				Secret: "secret",
+			}),
+		},
+	}))
+
+	notificationRepo := mock.NewNotificationRepository()
+
+	for _, n := range []service.Notification{
+		{ID: "stale", TestCampaignID: tcID, SubscriptionID: "all", Attempts: 1, UpdatedAt: stale},
+		{ID: "fresh", TestCampaignID: tcID, SubscriptionID: "all", Attempts: 1, UpdatedAt: now},
+		{ID: "removed_subscription", TestCampaignID: tcID, SubscriptionID: "failed", UpdatedAt: stale},
+		{ID: "removed_test_campaign", TestCampaignID: "unknown", SubscriptionID: "all", UpdatedAt: stale},
+	} {
+		n.State = service.NotificationPending
+		require.NoError(t, notificationRepo.AddNotification(context.Background(), n))
+	}
+
+	sender := mock.NewNotificationSender(0)
+
+	notifier := service.NewWebhookNotifier(
+		tcRepo,
+		notificationRepo,
+		sender,
+		mock.NewMemoryLogger(),
+		3,
+		notificationBackoff,
+	)
+
+	resumed, err := notifier.ResumeStaleNotifications(context.Background(), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 3, resumed)
+
+	notifier.Wait()
+
+	expected := map[string]struct {
+		State    service.NotificationState
+		Attempts int
+	}{
+		"stale":                 {State: service.NotificationDelivered, Attempts: 2},
+		"fresh":                 {State: service.NotificationPending, Attempts: 1},
+		"removed_subscription":  {State: service.NotificationDead, Attempts: 0},
+		"removed_test_campaign": {State: service.NotificationDead, Attempts: 0},
+	}
+
+	for _, n := range notificationRepo.Notifications() {
+		require.Equal(t, expected[n.ID].State, n.State, n.ID)
+		require.Equal(t, expected[n.ID].Attempts, n.Attempts, n.ID)
+	}
+
+	require.Equal(t, 1, sender.Attempts("stale"))
+	require.Zero(t, sender.Attempts("fresh"))
+}
+
+func completedFlow(tcID string, executor pipeline.Executor) (*pipeline.Pipeline, *flow.Flow) {
+	pipe := pipeline.Trigger(
+		"pipeline",
+		(&specification.Builder{}).
+			WithTestCampaignID(tcID).
+			WithStory("a", func(b *specification.StoryBuilder) {
+				b.WithScenario("b", func(b *specification.ScenarioBuilder) {
+					b.WithThesis("c", func(b *specification.ThesisBuilder) {
+						b.WithAssertion(func(b *specification.AssertionBuilder) {
+							b.WithMethod(specification.JSONPath)
+						})
+					})
+				})
+			}).
+			ErrlessBuild(),
+		pipeline.WithAssertion(executor),
+	)
+
+	f := flow.Fulfill("flow", pipe)
+
+	for s := range pipe.MustStart(context.Background()) {
+		f = f.ApplyStep(s)
+	}
+
+	return pipe, f
+}
+
+func TestNotificationBackoff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name            string
+		Initial         time.Duration
+		Attempt         int
+		ExpectedBackoff time.Duration
+	}{
+		{
+			Name:            "first_attempt",
+			Initial:         time.Second,
+			Attempt:         1,
+			ExpectedBackoff: time.Second,
+		},
+		{
+			Name:            "third_attempt",
+			Initial:         time.Second,
+			Attempt:         3,
+			ExpectedBackoff: 4 * time.Second,
+		},
+		{
+			Name:            "capped_backoff",
+			Initial:         time.Second,
+			Attempt:         20,
+			ExpectedBackoff: service.MaxNotificationBackoff,
+		},
+		{
+			Name:            "attempt_overflowing_shift",
+			Initial:         time.Second,
+			Attempt:         100,
+			ExpectedBackoff: service.MaxNotificationBackoff,
+		},
+		{
+			Name:            "initial_greater_than_cap",
+			Initial:         24 * time.Hour,
+			Attempt:         2,
+			ExpectedBackoff: service.MaxNotificationBackoff,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, c.ExpectedBackoff, service.NotificationBackoff(c.Initial, c.Attempt))
+		})
+	}
+}
@@ -1,19 +1,43 @@
 package mock
 
+import (
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
 type Enqueuer struct {
+	mu           sync.RWMutex
 	enqueueCalls int
+	jobs         []service.Job
 }
 
 func NewEnqueuer() *Enqueuer {
 	return &Enqueuer{}
 }
 
-func (e *Enqueuer) Enqueue(fn func()) {
+func (e *Enqueuer) Enqueue(job service.Job) {
+	e.mu.Lock()
 	e.enqueueCalls++
+	e.jobs = append(e.jobs, job)
+	e.mu.Unlock()
 
-	go fn()
+	go job.Run()
 }
 
 func (e *Enqueuer) EnqueueCalls() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	return e.enqueueCalls
 }
+
+func (e *Enqueuer) Jobs() []service.Job {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	jobs := make([]service.Job, len(e.jobs))
+	copy(jobs, e.jobs)
+
+	return jobs
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+type FlowNotifier struct {
+	mu    sync.RWMutex
+	flows []string
+}
+
+func NewFlowNotifier() *FlowNotifier {
+	return &FlowNotifier{}
+}
+
+func (n *FlowNotifier) NotifyFlowCompleted(_ context.Context, _ *pipeline.Pipeline, f *flow.Flow) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.flows = append(n.flows, f.ID())
+}
+
+func (n *FlowNotifier) NotifiedFlows() []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return append([]string(nil), n.flows...)
+}
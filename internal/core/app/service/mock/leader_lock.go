@@ -0,0 +1,63 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type LeaderLock struct {
+	mu sync.RWMutex
+
+	leader bool
+
+	acqCalls int
+	rlsCalls int
+}
+
+// NewLeaderLock returns lock that grants leadership if
+// leader is true, otherwise returns service.ErrLeadershipTaken.
+func NewLeaderLock(leader bool) *LeaderLock {
+	return &LeaderLock{leader: leader}
+}
+
+func (l *LeaderLock) AcquireLeadership(ctx context.Context, _ string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.acqCalls++
+
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	if !l.leader {
+		return service.ErrLeadershipTaken
+	}
+
+	return nil
+}
+
+func (l *LeaderLock) ReleaseLeadership(_ context.Context, _ string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rlsCalls++
+
+	return nil
+}
+
+func (l *LeaderLock) AcquireCalls() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.acqCalls
+}
+
+func (l *LeaderLock) ReleaseCalls() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.rlsCalls
+}
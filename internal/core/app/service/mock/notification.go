@@ -0,0 +1,134 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type NotificationRepository struct {
+	mu            sync.RWMutex
+	notifications map[string]service.Notification
+}
+
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		notifications: make(map[string]service.Notification),
+	}
+}
+
+func (m *NotificationRepository) AddNotification(ctx context.Context, n service.Notification) error {
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.notifications[n.ID]; ok {
+		return service.WrapWithDatabaseError(errDuplicateID)
+	}
+
+	m.notifications[n.ID] = n
+
+	return nil
+}
+
+func (m *NotificationRepository) UpdateNotification(ctx context.Context, n service.Notification) error {
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.notifications[n.ID]; !ok {
+		return service.WrapWithDatabaseError(errNotificationNotFound)
+	}
+
+	m.notifications[n.ID] = n
+
+	return nil
+}
+
+func (m *NotificationRepository) ClaimStaleNotifications(
+	ctx context.Context,
+	updatedBefore, claimedAt time.Time,
+) ([]service.Notification, error) {
+	if ctx.Err() != nil {
+		return nil, service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	claimed := make([]service.Notification, 0)
+
+	for id, n := range m.notifications {
+		if n.State != service.NotificationPending || !n.UpdatedAt.Before(updatedBefore) {
+			continue
+		}
+
+		n.UpdatedAt = claimedAt
+		m.notifications[id] = n
+
+		claimed = append(claimed, n)
+	}
+
+	return claimed, nil
+}
+
+func (m *NotificationRepository) Notifications() []service.Notification {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	notifications := make([]service.Notification, 0, len(m.notifications))
+	for _, n := range m.notifications {
+		notifications = append(notifications, n)
+	}
+
+	return notifications
+}
+
+var errNotificationNotFound = errors.New("notification not found")
+
+type NotificationSender struct {
+	mu       sync.Mutex
+	failures int
+	attempts map[string]int
+}
+
+var errNotificationNotSent = errors.New("notification is not sent")
+
+// NewNotificationSender returns sender failing
+// first failures attempts of each notification.
+func NewNotificationSender(failures int) *NotificationSender {
+	return &NotificationSender{
+		failures: failures,
+		attempts: make(map[string]int),
+	}
+}
+
+func (s *NotificationSender) SendNotification(_ context.Context, n service.Notification, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts[n.ID]++
+
+	if s.attempts[n.ID] <= s.failures {
+		return errNotificationNotSent
+	}
+
+	return nil
+}
+
+func (s *NotificationSender) Attempts(notificationID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attempts[notificationID]
+}
@@ -31,3 +31,20 @@ func (m SpecificationParserService) ParseSpecification(
 
 	return b.ErrlessBuild(), nil
 }
+
+type SpecificationFormatParserService struct {
+	SpecificationParserService
+
+	contentType string
+}
+
+func NewSpecificationFormatParserService(contentType string, withErr bool) SpecificationFormatParserService {
+	return SpecificationFormatParserService{
+		SpecificationParserService: NewSpecificationParserService(withErr),
+		contentType:                contentType,
+	}
+}
+
+func (m SpecificationFormatParserService) ContentType() string {
+	return m.contentType
+}
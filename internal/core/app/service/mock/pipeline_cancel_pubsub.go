@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"sync"
 
 	"github.com/harpyd/thestis/internal/core/app/service"
@@ -9,6 +10,7 @@ import (
 type PipelineCancelPubsub struct {
 	mu          sync.RWMutex
 	subscribers map[string][]chan service.CancelSignal
+	subErr      error
 
 	pubCalls int
 	subCalls int
@@ -20,9 +22,18 @@ func NewPipelineCancelPubsub() *PipelineCancelPubsub {
 	}
 }
 
+// NewFailingPipelineCancelPubsub returns pubsub
+// failing to subscribe with subscribeErr.
+func NewFailingPipelineCancelPubsub(subscribeErr error) *PipelineCancelPubsub {
+	ps := NewPipelineCancelPubsub()
+	ps.subErr = subscribeErr
+
+	return ps
+}
+
 func (ps *PipelineCancelPubsub) PublishPipelineCancel(pipeID string) error {
-	ps.mu.RLock()
-	defer ps.mu.RUnlock()
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
 
 	ps.pubCalls++
 
@@ -39,18 +50,56 @@ func (ps *PipelineCancelPubsub) PublishPipelineCancel(pipeID string) error {
 	return nil
 }
 
-func (ps *PipelineCancelPubsub) SubscribePipelineCancel(pipeID string) (<-chan service.CancelSignal, error) {
+// SubscribePipelineCancel returns channel closed on cancel
+// signal, the subscription is removed when ctx is done.
+func (ps *PipelineCancelPubsub) SubscribePipelineCancel(
+	ctx context.Context,
+	pipeID string,
+) (<-chan service.CancelSignal, error) {
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 
 	ps.subCalls++
 
+	if ps.subErr != nil {
+		return nil, ps.subErr
+	}
+
 	ch := make(chan service.CancelSignal, 1)
 	ps.subscribers[pipeID] = append(ps.subscribers[pipeID], ch)
 
+	go func() {
+		<-ctx.Done()
+
+		ps.unsubscribe(pipeID, ch)
+	}()
+
 	return ch, nil
 }
 
+func (ps *PipelineCancelPubsub) unsubscribe(pipeID string, ch chan service.CancelSignal) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	channels := ps.subscribers[pipeID]
+
+	for i, c := range channels {
+		if c == ch {
+			ps.subscribers[pipeID] = append(channels[:i:i], channels[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Subscriptions returns count of active subscriptions to the pipeline.
+func (ps *PipelineCancelPubsub) Subscriptions(pipeID string) int {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	return len(ps.subscribers[pipeID])
+}
+
 func (ps *PipelineCancelPubsub) PublishCalls() int {
 	ps.mu.RLock()
 	defer ps.mu.RUnlock()
@@ -2,16 +2,23 @@ package mock
 
 import (
 	"context"
+	"sync"
 
 	"github.com/harpyd/thestis/internal/core/app/service"
 )
 
 type PipelineGuard struct {
+	mu sync.RWMutex
+
 	acqErr error
+	extErr error
 	rlsErr error
 
 	acqCalls int
+	extCalls int
 	rlsCalls int
+
+	handedOver []string
 }
 
 func NewPipelineGuard(acquireErr error, releaseErr error) *PipelineGuard {
@@ -23,7 +30,18 @@ func NewPipelineGuard(acquireErr error, releaseErr error) *PipelineGuard {
 	}
 }
 
+// NewLeaseLosingPipelineGuard returns guard that acquires
+// pipelines, but fails to extend their leases with extendErr.
+func NewLeaseLosingPipelineGuard(extendErr error) *PipelineGuard {
+	return &PipelineGuard{
+		extErr: extendErr,
+	}
+}
+
 func (g *PipelineGuard) AcquirePipeline(ctx context.Context, _ string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	g.acqCalls++
 
 	if ctx.Err() != nil {
@@ -33,7 +51,23 @@ func (g *PipelineGuard) AcquirePipeline(ctx context.Context, _ string) error {
 	return g.acqErr
 }
 
+func (g *PipelineGuard) ExtendPipelineLease(ctx context.Context, _ string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.extCalls++
+
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	return g.extErr
+}
+
 func (g *PipelineGuard) ReleasePipeline(ctx context.Context, _ string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	g.rlsCalls++
 
 	if ctx.Err() != nil {
@@ -43,10 +77,47 @@ func (g *PipelineGuard) ReleasePipeline(ctx context.Context, _ string) error {
 	return g.rlsErr
 }
 
+func (g *PipelineGuard) HandOverPipeline(ctx context.Context, _, owner string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	g.handedOver = append(g.handedOver, owner)
+
+	return nil
+}
+
+// HandedOverBy returns owners pipelines are handed over by.
+func (g *PipelineGuard) HandedOverBy() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	owners := make([]string, len(g.handedOver))
+	copy(owners, g.handedOver)
+
+	return owners
+}
+
 func (g *PipelineGuard) AcquireCalls() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	return g.acqCalls
 }
 
+func (g *PipelineGuard) ExtendCalls() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.extCalls
+}
+
 func (g *PipelineGuard) ReleaseCalls() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	return g.rlsCalls
 }
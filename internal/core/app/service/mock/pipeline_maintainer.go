@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"sync"
 
 	"github.com/harpyd/thestis/internal/core/app/service"
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
@@ -9,22 +10,41 @@ import (
 
 type PipelineMaintainer struct {
 	withErr bool
+
+	mu         sync.Mutex
+	priorities []service.Priority
 }
 
-func NewPipelineMaintainer(withErr bool) PipelineMaintainer {
-	return PipelineMaintainer{withErr: withErr}
+func NewPipelineMaintainer(withErr bool) *PipelineMaintainer {
+	return &PipelineMaintainer{withErr: withErr}
 }
 
-func (m PipelineMaintainer) MaintainPipeline(
+func (m *PipelineMaintainer) MaintainPipeline(
 	_ context.Context,
 	_ *pipeline.Pipeline,
+	priority service.Priority,
 ) (<-chan service.DoneSignal, error) {
 	if m.withErr {
 		return nil, pipeline.ErrAlreadyStarted
 	}
 
+	m.mu.Lock()
+	m.priorities = append(m.priorities, priority)
+	m.mu.Unlock()
+
 	done := make(chan service.DoneSignal)
 	close(done)
 
 	return done, nil
 }
+
+// Priorities returns priorities pipelines are maintained with.
+func (m *PipelineMaintainer) Priorities() []service.Priority {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	priorities := make([]service.Priority, len(m.priorities))
+	copy(priorities, m.priorities)
+
+	return priorities
+}
@@ -0,0 +1,90 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type PipelinePausePubsub struct {
+	mu          sync.RWMutex
+	subscribers map[string][]chan service.PauseSignal
+	subErr      error
+
+	pubCalls int
+	subCalls int
+}
+
+const pauseSignalBufferSize = 10
+
+func NewPipelinePausePubsub() *PipelinePausePubsub {
+	return &PipelinePausePubsub{
+		subscribers: make(map[string][]chan service.PauseSignal),
+	}
+}
+
+// NewFailingPipelinePausePubsub returns pubsub
+// failing to subscribe with subscribeErr.
+func NewFailingPipelinePausePubsub(subscribeErr error) *PipelinePausePubsub {
+	ps := NewPipelinePausePubsub()
+	ps.subErr = subscribeErr
+
+	return ps
+}
+
+func (ps *PipelinePausePubsub) PublishPipelinePause(pipeID string) error {
+	return ps.publish(pipeID, service.Paused)
+}
+
+func (ps *PipelinePausePubsub) PublishPipelineResume(pipeID string) error {
+	return ps.publish(pipeID, service.Resumed)
+}
+
+func (ps *PipelinePausePubsub) publish(pipeID string, signal service.PauseSignal) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.pubCalls++
+
+	for _, ch := range ps.subscribers[pipeID] {
+		ch <- signal
+	}
+
+	return nil
+}
+
+// SubscribePipelinePause returns buffered channel of signals,
+// the channel is never closed.
+func (ps *PipelinePausePubsub) SubscribePipelinePause(
+	_ context.Context,
+	pipeID string,
+) (<-chan service.PauseSignal, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.subCalls++
+
+	if ps.subErr != nil {
+		return nil, ps.subErr
+	}
+
+	ch := make(chan service.PauseSignal, pauseSignalBufferSize)
+	ps.subscribers[pipeID] = append(ps.subscribers[pipeID], ch)
+
+	return ch, nil
+}
+
+func (ps *PipelinePausePubsub) PublishCalls() int {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	return ps.pubCalls
+}
+
+func (ps *PipelinePausePubsub) SubscribeCalls() int {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	return ps.subCalls
+}
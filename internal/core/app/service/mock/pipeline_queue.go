@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type PipelineQueue struct {
+	mu     sync.RWMutex
+	queued []service.QueuedPipeline
+
+	removeCalls int
+}
+
+func NewPipelineQueue(queued ...service.QueuedPipeline) *PipelineQueue {
+	return &PipelineQueue{queued: queued}
+}
+
+func (q *PipelineQueue) QueuedPipelines() ([]service.QueuedPipeline, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	queued := make([]service.QueuedPipeline, len(q.queued))
+	copy(queued, q.queued)
+
+	return queued, nil
+}
+
+func (q *PipelineQueue) RemovePipeline(pipeID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.removeCalls++
+
+	for i, p := range q.queued {
+		if p.PipelineID == pipeID {
+			q.queued = append(q.queued[:i], q.queued[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return service.ErrPipelineNotQueued
+}
+
+func (q *PipelineQueue) RemoveCalls() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.removeCalls
+}
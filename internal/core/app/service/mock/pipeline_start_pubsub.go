@@ -0,0 +1,80 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type PipelineStartPubsub struct {
+	mu          sync.RWMutex
+	subscribers []chan service.PipelineStartRequest
+
+	published []service.PipelineStartMessage
+}
+
+const startBufferSize = 10
+
+func NewPipelineStartPubsub() *PipelineStartPubsub {
+	return &PipelineStartPubsub{}
+}
+
+// PublishPipelineStart sends msg to the first subscriber only,
+// like the queue group does, and waits for its reply. Without
+// subscribers service.ErrNoPipelineWorkers is returned.
+func (ps *PipelineStartPubsub) PublishPipelineStart(ctx context.Context, msg service.PipelineStartMessage) error {
+	ps.mu.Lock()
+
+	ps.published = append(ps.published, msg)
+
+	if len(ps.subscribers) == 0 {
+		ps.mu.Unlock()
+
+		return service.WrapWithPublishStartError(service.ErrNoPipelineWorkers)
+	}
+
+	sub := ps.subscribers[0]
+
+	ps.mu.Unlock()
+
+	replies := make(chan error, 1)
+
+	sub <- service.PipelineStartRequest{
+		PipelineStartMessage: msg,
+		Reply: func(err error) error {
+			replies <- err
+
+			return nil
+		},
+	}
+
+	select {
+	case err := <-replies:
+		return err
+	case <-ctx.Done():
+		return service.WrapWithPublishStartError(ctx.Err())
+	}
+}
+
+// SubscribePipelineStart returns buffered channel of start requests,
+// the channel is never closed.
+func (ps *PipelineStartPubsub) SubscribePipelineStart(_ context.Context) (<-chan service.PipelineStartRequest, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ch := make(chan service.PipelineStartRequest, startBufferSize)
+	ps.subscribers = append(ps.subscribers, ch)
+
+	return ch, nil
+}
+
+func (ps *PipelineStartPubsub) Published() []service.PipelineStartMessage {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	published := make([]service.PipelineStartMessage, len(ps.published))
+	copy(published, ps.published)
+
+	return published
+}
@@ -0,0 +1,81 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type PipelineStepPubsub struct {
+	mu        sync.RWMutex
+	published map[string][]service.StepMessage
+	completed map[string]bool
+}
+
+func NewPipelineStepPubsub() *PipelineStepPubsub {
+	return &PipelineStepPubsub{
+		published: make(map[string][]service.StepMessage),
+		completed: make(map[string]bool),
+	}
+}
+
+func (ps *PipelineStepPubsub) PublishPipelineStep(pipeID string, msg service.StepMessage) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.published[pipeID] = append(ps.published[pipeID], msg)
+
+	return nil
+}
+
+func (ps *PipelineStepPubsub) PublishPipelineCompleted(pipeID string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.completed[pipeID] = true
+
+	return nil
+}
+
+// SubscribePipelineSteps returns channel with all
+// steps published before the subscription. The channel
+// is closed if the pipeline is completed.
+func (ps *PipelineStepPubsub) SubscribePipelineSteps(
+	_ context.Context,
+	pipeID string,
+) (<-chan service.StepMessage, error) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	published := ps.published[pipeID]
+
+	steps := make(chan service.StepMessage, len(published))
+
+	for _, msg := range published {
+		steps <- msg
+	}
+
+	if ps.completed[pipeID] {
+		close(steps)
+	}
+
+	return steps, nil
+}
+
+func (ps *PipelineStepPubsub) PublishedSteps(pipeID string) []service.StepMessage {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	published := make([]service.StepMessage, len(ps.published[pipeID]))
+	copy(published, ps.published[pipeID])
+
+	return published
+}
+
+func (ps *PipelineStepPubsub) Completed(pipeID string) bool {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	return ps.completed[pipeID]
+}
@@ -18,11 +18,13 @@ var errDuplicateID = errors.New("duplicate id in mock map")
 type TestCampaignRepository struct {
 	mu        sync.RWMutex
 	campaigns map[string]testcampaign.TestCampaign
+	archived  map[string]testcampaign.TestCampaign
 }
 
 func NewTestCampaignRepository(tcs ...*testcampaign.TestCampaign) *TestCampaignRepository {
 	tcm := &TestCampaignRepository{
 		campaigns: make(map[string]testcampaign.TestCampaign, len(tcs)),
+		archived:  make(map[string]testcampaign.TestCampaign),
 	}
 
 	for _, tc := range tcs {
@@ -92,6 +94,84 @@ func (m *TestCampaignRepository) UpdateTestCampaign(
 	return nil
 }
 
+func (m *TestCampaignRepository) SwapLastOutcome(
+	ctx context.Context,
+	tcID string,
+	outcome testcampaign.Event,
+) (*testcampaign.TestCampaign, error) {
+	if ctx.Err() != nil {
+		return nil, service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tc, ok := m.campaigns[tcID]
+	if !ok {
+		return nil, service.ErrTestCampaignNotFound
+	}
+
+	previous := tc
+	tc.CompleteFlow(outcome)
+
+	m.campaigns[tcID] = tc
+
+	return &previous, nil
+}
+
+func (m *TestCampaignRepository) FindScheduledTestCampaigns(
+	ctx context.Context,
+) ([]*testcampaign.TestCampaign, error) {
+	if ctx.Err() != nil {
+		return nil, service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tcs := make([]*testcampaign.TestCampaign, 0, len(m.campaigns))
+
+	for _, tc := range m.campaigns {
+		tc := tc
+
+		if len(tc.Schedules()) > 0 {
+			tcs = append(tcs, &tc)
+		}
+	}
+
+	return tcs, nil
+}
+
+func (m *TestCampaignRepository) RemoveTestCampaign(ctx context.Context, tcID string) error {
+	return m.removeTestCampaign(ctx, tcID, false)
+}
+
+func (m *TestCampaignRepository) ArchiveTestCampaign(ctx context.Context, tcID string) error {
+	return m.removeTestCampaign(ctx, tcID, true)
+}
+
+func (m *TestCampaignRepository) removeTestCampaign(ctx context.Context, tcID string, archive bool) error {
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tc, ok := m.campaigns[tcID]
+	if !ok {
+		return service.ErrTestCampaignNotFound
+	}
+
+	if archive {
+		m.archived[tcID] = tc
+	}
+
+	delete(m.campaigns, tcID)
+
+	return nil
+}
+
 func (m *TestCampaignRepository) TestCampaignsNumber() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -99,14 +179,23 @@ func (m *TestCampaignRepository) TestCampaignsNumber() int {
 	return len(m.campaigns)
 }
 
+func (m *TestCampaignRepository) ArchivedTestCampaignsNumber() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.archived)
+}
+
 type SpecificationRepository struct {
 	mu             sync.RWMutex
 	specifications map[string]specification.Specification
+	active         map[string]string
 }
 
 func NewSpecificationRepository(specs ...*specification.Specification) *SpecificationRepository {
 	m := &SpecificationRepository{
 		specifications: make(map[string]specification.Specification, len(specs)),
+		active:         make(map[string]string),
 	}
 
 	for _, spec := range specs {
@@ -146,6 +235,12 @@ func (m *SpecificationRepository) GetActiveSpecificationByTestCampaignID(
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	if specID, ok := m.active[tcID]; ok {
+		spec := m.specifications[specID]
+
+		return &spec, nil
+	}
+
 	for _, spec := range m.specifications {
 		if spec.TestCampaignID() == tcID {
 			return &spec, nil
@@ -171,6 +266,25 @@ func (m *SpecificationRepository) AddSpecification(
 	}
 
 	m.specifications[spec.ID()] = *spec
+	m.active[spec.TestCampaignID()] = spec.ID()
+
+	return nil
+}
+
+func (m *SpecificationRepository) ActivateSpecification(ctx context.Context, specID string) error {
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	spec, ok := m.specifications[specID]
+	if !ok {
+		return service.ErrSpecificationNotFound
+	}
+
+	m.active[spec.TestCampaignID()] = specID
 
 	return nil
 }
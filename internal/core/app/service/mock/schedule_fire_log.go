@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type ScheduleFireLog struct {
+	mu    sync.RWMutex
+	fires map[string]time.Time
+}
+
+func NewScheduleFireLog() *ScheduleFireLog {
+	return &ScheduleFireLog{
+		fires: make(map[string]time.Time),
+	}
+}
+
+func (l *ScheduleFireLog) LastScheduleFire(ctx context.Context, tcID, scheduleID string) (time.Time, error) {
+	if ctx.Err() != nil {
+		return time.Time{}, service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	return l.LastFire(tcID, scheduleID), nil
+}
+
+func (l *ScheduleFireLog) MarkScheduleFired(
+	ctx context.Context,
+	tcID, scheduleID string,
+	last, firedAt time.Time,
+) error {
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := scheduleFireKey(tcID, scheduleID)
+
+	if !l.fires[key].Equal(last) {
+		return service.ErrScheduleAlreadyFired
+	}
+
+	l.fires[key] = firedAt
+
+	return nil
+}
+
+// SetLastFire sets the last fire time of the schedule.
+func (l *ScheduleFireLog) SetLastFire(tcID, scheduleID string, firedAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.fires[scheduleFireKey(tcID, scheduleID)] = firedAt
+}
+
+func (l *ScheduleFireLog) LastFire(tcID, scheduleID string) time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.fires[scheduleFireKey(tcID, scheduleID)]
+}
+
+func scheduleFireKey(tcID, scheduleID string) string {
+	return tcID + "/" + scheduleID
+}
@@ -0,0 +1,45 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+type ScheduledPipelineStarter struct {
+	mu  sync.RWMutex
+	err error
+
+	started []string
+}
+
+func NewScheduledPipelineStarter(err error) *ScheduledPipelineStarter {
+	return &ScheduledPipelineStarter{err: err}
+}
+
+func (s *ScheduledPipelineStarter) StartScheduledPipeline(
+	_ context.Context,
+	_ *testcampaign.TestCampaign,
+	schedule testcampaign.Schedule,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.started = append(s.started, schedule.ID())
+
+	return nil
+}
+
+// StartedSchedules returns IDs of schedules
+// which pipelines are started.
+func (s *ScheduledPipelineStarter) StartedSchedules() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]string(nil), s.started...)
+}
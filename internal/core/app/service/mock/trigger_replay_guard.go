@@ -0,0 +1,50 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+type TriggerReplayGuard struct {
+	mu       sync.Mutex
+	triggers map[string]time.Time
+}
+
+func NewTriggerReplayGuard(keys ...string) *TriggerReplayGuard {
+	g := &TriggerReplayGuard{
+		triggers: make(map[string]time.Time, len(keys)),
+	}
+
+	for _, key := range keys {
+		g.triggers[key] = time.Time{}
+	}
+
+	return g
+}
+
+func (g *TriggerReplayGuard) RememberTrigger(ctx context.Context, key string, expiresAt time.Time) error {
+	if ctx.Err() != nil {
+		return service.WrapWithDatabaseError(ctx.Err())
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.triggers[key]; ok {
+		return service.ErrTriggerReplayed
+	}
+
+	g.triggers[key] = expiresAt
+
+	return nil
+}
+
+func (g *TriggerReplayGuard) TriggersNumber() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return len(g.triggers)
+}
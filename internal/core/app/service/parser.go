@@ -15,6 +15,13 @@ type (
 		ParseSpecification(reader io.Reader, opts ...ParserOption) (*specification.Specification, error)
 	}
 
+	// SpecificationFormatParser is the parser of the specification
+	// source format with the content type, e.g. text/x-gherkin.
+	SpecificationFormatParser interface {
+		SpecificationParser
+		ContentType() string
+	}
+
 	ParserOption func(b *specification.Builder)
 )
 
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+type queuedPipelineMaintainer struct {
+	guard      PipelineGuard
+	publisher  PipelineStartPublisher
+	instanceID string
+}
+
+// NewQueuedPipelineMaintainer returns PipelineMaintainer that
+// doesn't run pipelines itself, but requests workers to start them.
+// The pipeline is acquired before the request, so started pipeline
+// is rejected at once, and the lease is handed over to the worker
+// accepting the pipeline. The returned done channel is closed once
+// the pipeline is accepted.
+func NewQueuedPipelineMaintainer(
+	guard PipelineGuard,
+	startPub PipelineStartPublisher,
+	instanceID string,
+) PipelineMaintainer {
+	if guard == nil {
+		panic("pipeline guard is nil")
+	}
+
+	if startPub == nil {
+		panic("pipeline start publisher is nil")
+	}
+
+	return queuedPipelineMaintainer{
+		guard:      guard,
+		publisher:  startPub,
+		instanceID: instanceID,
+	}
+}
+
+func (m queuedPipelineMaintainer) MaintainPipeline(
+	ctx context.Context,
+	pipe *pipeline.Pipeline,
+	priority Priority,
+) (<-chan DoneSignal, error) {
+	if err := m.guard.AcquirePipeline(ctx, pipe.ID()); err != nil {
+		return nil, err
+	}
+
+	err := m.publisher.PublishPipelineStart(ctx, PipelineStartMessage{
+		PipelineID: pipe.ID(),
+		Priority:   priority,
+		LeaseOwner: m.instanceID,
+	})
+	if err != nil {
+		// Lease is released only if it is not handed over yet.
+		_ = m.guard.ReleasePipeline(context.Background(), pipe.ID())
+
+		return nil, err
+	}
+
+	done := make(chan DoneSignal)
+	close(done)
+
+	return done, nil
+}
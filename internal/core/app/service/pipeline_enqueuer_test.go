@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+func TestNewQueuedPipelineMaintainerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name           string
+		GivenGuard     service.PipelineGuard
+		GivenPublisher service.PipelineStartPublisher
+		ShouldPanic    bool
+		PanicMessage   string
+	}{
+		{
+			Name:           "all_dependencies_are_not_nil",
+			GivenGuard:     mock.NewPipelineGuard(nil, nil),
+			GivenPublisher: mock.NewPipelineStartPubsub(),
+			ShouldPanic:    false,
+		},
+		{
+			Name:           "pipeline_guard_is_nil",
+			GivenGuard:     nil,
+			GivenPublisher: mock.NewPipelineStartPubsub(),
+			ShouldPanic:    true,
+			PanicMessage:   "pipeline guard is nil",
+		},
+		{
+			Name:           "pipeline_start_publisher_is_nil",
+			GivenGuard:     mock.NewPipelineGuard(nil, nil),
+			GivenPublisher: nil,
+			ShouldPanic:    true,
+			PanicMessage:   "pipeline start publisher is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = service.NewQueuedPipelineMaintainer(c.GivenGuard, c.GivenPublisher, "api")
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestQueuedMaintainPipeline(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                 string
+		AcquireErr           error
+		WithWorker           bool
+		WorkerErr            error
+		ExpectedPublished    []service.PipelineStartMessage
+		ExpectedReleaseCalls int
+		ShouldBeErr          bool
+		IsErr                func(err error) bool
+	}{
+		{
+			Name:                 "pipeline_already_started",
+			AcquireErr:           pipeline.ErrAlreadyStarted,
+			WithWorker:           true,
+			ExpectedPublished:    []service.PipelineStartMessage{},
+			ExpectedReleaseCalls: 0,
+			ShouldBeErr:          true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, pipeline.ErrAlreadyStarted)
+			},
+		},
+		{
+			Name:       "no_pipeline_workers",
+			WithWorker: false,
+			ExpectedPublished: []service.PipelineStartMessage{
+				{PipelineID: "bar", Priority: service.ManualPriority, LeaseOwner: "api"},
+			},
+			ExpectedReleaseCalls: 1,
+			ShouldBeErr:          true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, service.ErrNoPipelineWorkers)
+			},
+		},
+		{
+			Name:       "pipeline_rejected_by_worker",
+			WithWorker: true,
+			WorkerErr:  pipeline.ErrAlreadyStarted,
+			ExpectedPublished: []service.PipelineStartMessage{
+				{PipelineID: "bar", Priority: service.ManualPriority, LeaseOwner: "api"},
+			},
+			ExpectedReleaseCalls: 1,
+			ShouldBeErr:          true,
+			IsErr: func(err error) bool {
+				return errors.Is(err, pipeline.ErrAlreadyStarted)
+			},
+		},
+		{
+			Name:       "pipeline_accepted_by_worker",
+			WithWorker: true,
+			ExpectedPublished: []service.PipelineStartMessage{
+				{PipelineID: "bar", Priority: service.ManualPriority, LeaseOwner: "api"},
+			},
+			ExpectedReleaseCalls: 0,
+			ShouldBeErr:          false,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var (
+				guard      = mock.NewPipelineGuard(c.AcquireErr, nil)
+				pubsub     = mock.NewPipelineStartPubsub()
+				maintainer = service.NewQueuedPipelineMaintainer(guard, pubsub, "api")
+			)
+
+			if c.WithWorker {
+				reqs, err := pubsub.SubscribePipelineStart(ctx)
+				require.NoError(t, err)
+
+				go func() {
+					select {
+					case req := <-reqs:
+						_ = req.Reply(c.WorkerErr)
+					case <-ctx.Done():
+					}
+				}()
+			}
+
+			pipe := pipeline.Unmarshal(pipeline.Params{ID: "bar"})
+
+			done, err := maintainer.MaintainPipeline(ctx, pipe, service.ManualPriority)
+
+			require.Equal(t, c.ExpectedPublished, pubsub.Published())
+			require.Equal(t, c.ExpectedReleaseCalls, guard.ReleaseCalls())
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			_, ok := <-done
+			require.False(t, ok)
+		})
+	}
+}
@@ -2,45 +2,100 @@ package service
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/harpyd/thestis/internal/core/entity/pipeline"
 	"github.com/harpyd/thestis/pkg/correlationid"
 )
 
-type PipelineGuard interface {
-	AcquirePipeline(ctx context.Context, pipeID string) error
-	ReleasePipeline(ctx context.Context, pipeID string) error
-}
+type (
+	// PipelineGuard leases the pipeline to the only maintainer
+	// at a time. The lease expires if it is not extended, so
+	// the pipeline of the died process can be taken over.
+	PipelineGuard interface {
+		AcquirePipeline(ctx context.Context, pipeID string) error
+		ExtendPipelineLease(ctx context.Context, pipeID string) error
+		ReleasePipeline(ctx context.Context, pipeID string) error
+	}
+
+	// PipelineHandover releases the pipeline leased to the owner
+	// instance, so the worker the pipeline is handed over to can
+	// acquire it. Pipeline leased to another instance is kept.
+	PipelineHandover interface {
+		HandOverPipeline(ctx context.Context, pipeID, owner string) error
+	}
+
+	// PipelineRecoverer releases pipelines with expired leases
+	// and crashes their unfinished flows, IDs of crashed flows
+	// are returned to notify about them.
+	PipelineRecoverer interface {
+		RecoverOrphanedPipelines(ctx context.Context) (crashedFlowIDs []string, err error)
+	}
+)
+
+var ErrPipelineLeaseLost = errors.New("pipeline lease lost")
 
 type (
 	PipelineMaintainer interface {
 		MaintainPipeline(
 			ctx context.Context,
 			pipe *pipeline.Pipeline,
+			priority Priority,
 		) (<-chan DoneSignal, error)
 	}
 
+	// PipelineDrainer cancels all maintaining pipelines on shutdown.
+	// Canceled pipelines are persisted and released as usual,
+	// after that DrainedPipelines returns their IDs, so they
+	// can be requeued to another instance. Pipelines still
+	// running or not released are not returned, they are left
+	// to PipelineRecoverer after their leases expire.
+	PipelineDrainer interface {
+		DrainPipelines()
+		DrainedPipelines() []string
+	}
+
+	DrainingPipelineMaintainer interface {
+		PipelineMaintainer
+		PipelineDrainer
+	}
+
 	DoneSignal struct{}
 )
 
+var ErrPipelineMaintainerDrained = errors.New("pipeline maintainer is drained")
+
 type pipelineMaintainer struct {
-	guard      PipelineGuard
-	subscriber PipelineCancelSubscriber
-	policy     PipelinePolicy
-	enqueuer   Enqueuer
-	logger     Logger
-	timeout    time.Duration
+	guard           PipelineGuard
+	subscriber      PipelineCancelSubscriber
+	pauseSubscriber PipelinePauseSubscriber
+	policy          PipelinePolicy
+	enqueuer        Enqueuer
+	logger          Logger
+	timeout         time.Duration
+	heartbeat       time.Duration
+
+	drainOnce sync.Once
+	drain     chan struct{}
+
+	mu       sync.Mutex
+	draining map[string]bool
+	drained  []string
 }
 
 func NewPipelineMaintainer(
 	guard PipelineGuard,
 	cancelSub PipelineCancelSubscriber,
+	pauseSub PipelinePauseSubscriber,
 	policy PipelinePolicy,
 	enqueuer Enqueuer,
 	logger Logger,
 	flowTimeout time.Duration,
-) PipelineMaintainer {
+	leaseHeartbeat time.Duration,
+) DrainingPipelineMaintainer {
 	if guard == nil {
 		panic("pipeline guard is nil")
 	}
@@ -49,6 +104,10 @@ func NewPipelineMaintainer(
 		panic("pipeline cancel subscriber is nil")
 	}
 
+	if pauseSub == nil {
+		panic("pipeline pause subscriber is nil")
+	}
+
 	if policy == nil {
 		panic("pipeline policy is nil")
 	}
@@ -61,65 +120,255 @@ func NewPipelineMaintainer(
 		panic("logger is nil")
 	}
 
+	if leaseHeartbeat <= 0 {
+		panic("lease heartbeat is not positive")
+	}
+
 	return &pipelineMaintainer{
-		guard:      guard,
-		subscriber: cancelSub,
-		policy:     policy,
-		enqueuer:   enqueuer,
-		logger:     logger,
-		timeout:    flowTimeout,
+		guard:           guard,
+		subscriber:      cancelSub,
+		pauseSubscriber: pauseSub,
+		policy:          policy,
+		enqueuer:        enqueuer,
+		logger:          logger,
+		timeout:         flowTimeout,
+		heartbeat:       leaseHeartbeat,
+		drain:           make(chan struct{}),
+		draining:        make(map[string]bool),
 	}
 }
 
+// MaintainPipeline acquires the pipeline and enqueues it.
+// Signals are handled and the lease is extended from the
+// moment of enqueuing, so the waiting pipeline can be
+// canceled and is not taken over by another instance.
+// If the pipeline can't be subscribed to signals,
+// it is released right away.
 func (m *pipelineMaintainer) MaintainPipeline(
 	ctx context.Context,
 	pipe *pipeline.Pipeline,
+	priority Priority,
 ) (<-chan DoneSignal, error) {
+	select {
+	case <-m.drain:
+		return nil, ErrPipelineMaintainerDrained
+	default:
+	}
+
 	if err := m.guard.AcquirePipeline(ctx, pipe.ID()); err != nil {
 		return nil, err
 	}
 
-	canceled, err := m.subscriber.SubscribePipelineCancel(pipe.ID())
+	correlationID := correlationid.FromCtx(ctx)
+
+	runCtx, cancelRun := context.WithCancel(
+		correlationid.AssignToCtx(context.Background(), correlationID),
+	)
+
+	canceled, err := m.subscriber.SubscribePipelineCancel(runCtx, pipe.ID())
+	if err != nil {
+		cancelRun()
+		m.releasePipeline(pipe, correlationID)
+
+		return nil, err
+	}
+
+	paused, err := m.pauseSubscriber.SubscribePipelinePause(runCtx, pipe.ID())
 	if err != nil {
+		// Canceling of the run context also
+		// unsubscribes from cancel signals.
+		cancelRun()
+		m.releasePipeline(pipe, correlationID)
+
 		return nil, err
 	}
 
+	go m.handleCancelSignals(runCtx, cancelRun, pipe, canceled, correlationID)
+	go m.handlePauseSignals(runCtx, pipe, paused, correlationID)
+	go m.extendLease(runCtx, cancelRun, pipe, correlationID)
+
 	done := make(chan DoneSignal)
 
-	m.enqueuer.Enqueue(m.maintainFn(pipe, canceled, done, correlationid.FromCtx(ctx)))
+	m.enqueuer.Enqueue(Job{
+		PipelineID: pipe.ID(),
+		OwnerID:    pipe.OwnerID(),
+		Priority:   priority,
+		Run:        m.maintainFn(runCtx, cancelRun, pipe, done, correlationID),
+		Discard:    m.discardFn(cancelRun, pipe, done, correlationID),
+	})
 
 	return done, nil
 }
 
 func (m *pipelineMaintainer) maintainFn(
+	runCtx context.Context,
+	cancelRun context.CancelFunc,
 	pipe *pipeline.Pipeline,
-	canceled <-chan CancelSignal,
 	done chan<- DoneSignal,
 	correlationID string,
 ) func() {
 	return func() {
 		defer close(done)
 		defer m.releasePipeline(pipe, correlationID)
+		defer cancelRun()
 
-		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		ctx, cancel := context.WithTimeout(runCtx, m.timeout)
 		defer cancel()
 
-		ctx = correlationid.AssignToCtx(ctx, correlationID)
+		m.policy.ConsumePipeline(ctx, pipe)
+	}
+}
+
+func (m *pipelineMaintainer) discardFn(
+	cancelRun context.CancelFunc,
+	pipe *pipeline.Pipeline,
+	done chan<- DoneSignal,
+	correlationID string,
+) func() {
+	return func() {
+		defer close(done)
+		defer m.releasePipeline(pipe, correlationID)
+
+		cancelRun()
+
+		m.enrichedLogger(pipe, correlationID).Info("Pipeline is removed from the queue")
+	}
+}
+
+func (m *pipelineMaintainer) handleCancelSignals(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	pipe *pipeline.Pipeline,
+	canceled <-chan CancelSignal,
+	correlationID string,
+) {
+	l := m.enrichedLogger(pipe, correlationID)
+
+	select {
+	case <-ctx.Done():
+	case <-canceled:
+		l.Info("Cancel signal received")
+
+		cancel()
+	case <-m.drain:
+		l.Info("Pipeline is canceled due to shutdown")
+
+		m.markDrained(pipe.ID())
+		cancel()
+	}
+}
+
+func (m *pipelineMaintainer) DrainPipelines() {
+	m.drainOnce.Do(func() {
+		close(m.drain)
+	})
+}
+
+func (m *pipelineMaintainer) DrainedPipelines() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	drained := make([]string, len(m.drained))
+	copy(drained, m.drained)
+
+	return drained
+}
+
+func (m *pipelineMaintainer) markDrained(pipeID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.draining[pipeID] = true
+}
+
+// markReleased moves the draining pipeline to drained ones,
+// since only released pipeline can be acquired by another
+// instance it is requeued to.
+func (m *pipelineMaintainer) markReleased(pipeID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.draining[pipeID] {
+		return
+	}
+
+	delete(m.draining, pipeID)
+	m.drained = append(m.drained, pipeID)
+}
+
+func (m *pipelineMaintainer) handlePauseSignals(
+	ctx context.Context,
+	pipe *pipeline.Pipeline,
+	paused <-chan PauseSignal,
+	correlationID string,
+) {
+	l := m.enrichedLogger(pipe, correlationID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case signal, ok := <-paused:
+			if !ok {
+				return
+			}
 
-		go func() {
-			select {
-			case <-ctx.Done():
-			case <-canceled:
-				m.enrichedLogger(pipe, correlationID).Info("Cancel signal received")
+			l.Info("Pause signal received", "signal", signal)
 
-				cancel()
+			if err := applyPauseSignal(pipe, signal); err != nil {
+				l.Warn("Pause signal is not applied", "error", err)
 			}
-		}()
+		}
+	}
+}
 
-		m.policy.ConsumePipeline(ctx, pipe)
+// extendLease periodically extends the pipeline lease while
+// the pipeline is maintaining. If the lease is lost, the
+// pipeline is canceled, because it is taken over by another
+// maintainer.
+func (m *pipelineMaintainer) extendLease(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	pipe *pipeline.Pipeline,
+	correlationID string,
+) {
+	ticker := time.NewTicker(m.heartbeat)
+	defer ticker.Stop()
+
+	l := m.enrichedLogger(pipe, correlationID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := m.guard.ExtendPipelineLease(ctx, pipe.ID())
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, ErrPipelineLeaseLost) {
+			l.Error("Pipeline lease lost, pipeline is canceled", "error", err)
+
+			cancel()
+
+			return
+		}
+
+		l.Warn("Attempt to extend pipeline lease failed", "error", err)
 	}
 }
 
+func applyPauseSignal(pipe *pipeline.Pipeline, signal PauseSignal) error {
+	if signal == Paused {
+		return pipe.Pause()
+	}
+
+	return pipe.Resume()
+}
+
 func (m *pipelineMaintainer) enrichedLogger(
 	pipe *pipeline.Pipeline,
 	correlationID string,
@@ -143,7 +392,11 @@ func (m *pipelineMaintainer) releasePipeline(
 		pipe.ID(),
 	); err != nil {
 		l.Error("Attempt to release pipeline failed", "error", err)
+
+		return
 	}
 
+	m.markReleased(pipe.ID())
+
 	l.Info("Pipeline released")
 }
@@ -20,6 +20,8 @@ var (
 	errPipelineRelease = errors.New("pipeline release")
 )
 
+const leaseHeartbeat = 1 * time.Minute
+
 func TestNewPipelineMaintainerPanics(t *testing.T) {
 	t.Parallel()
 
@@ -29,6 +31,7 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 		Name            string
 		GivenGuard      service.PipelineGuard
 		GivenSubscriber service.PipelineCancelSubscriber
+		GivenPauseSub   service.PipelinePauseSubscriber
 		GivenPipePolicy service.PipelinePolicy
 		GivenEnqueuer   service.Enqueuer
 		GivenLogger     service.Logger
@@ -39,6 +42,7 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 			Name:            "all_dependencies_are_not_nil",
 			GivenGuard:      mock.NewPipelineGuard(nil, nil),
 			GivenSubscriber: mock.NewPipelineCancelPubsub(),
+			GivenPauseSub:   mock.NewPipelinePausePubsub(),
 			GivenPipePolicy: mock.NewPipelinePolicy(),
 			GivenEnqueuer:   mock.NewEnqueuer(),
 			GivenLogger:     mock.NewMemoryLogger(),
@@ -48,6 +52,7 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 			Name:            "pipeline_guard_is_nil",
 			GivenGuard:      nil,
 			GivenSubscriber: mock.NewPipelineCancelPubsub(),
+			GivenPauseSub:   mock.NewPipelinePausePubsub(),
 			GivenPipePolicy: mock.NewPipelinePolicy(),
 			GivenEnqueuer:   mock.NewEnqueuer(),
 			GivenLogger:     mock.NewMemoryLogger(),
@@ -58,16 +63,29 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 			Name:            "pipeline_cancel_subscriber_is_nil",
 			GivenGuard:      mock.NewPipelineGuard(nil, nil),
 			GivenSubscriber: nil,
+			GivenPauseSub:   mock.NewPipelinePausePubsub(),
 			GivenPipePolicy: mock.NewPipelinePolicy(),
 			GivenEnqueuer:   mock.NewEnqueuer(),
 			GivenLogger:     mock.NewMemoryLogger(),
 			ShouldPanic:     true,
 			PanicMessage:    "pipeline cancel subscriber is nil",
 		},
+		{
+			Name:            "pipeline_pause_subscriber_is_nil",
+			GivenGuard:      mock.NewPipelineGuard(nil, nil),
+			GivenSubscriber: mock.NewPipelineCancelPubsub(),
+			GivenPauseSub:   nil,
+			GivenPipePolicy: mock.NewPipelinePolicy(),
+			GivenEnqueuer:   mock.NewEnqueuer(),
+			GivenLogger:     mock.NewMemoryLogger(),
+			ShouldPanic:     true,
+			PanicMessage:    "pipeline pause subscriber is nil",
+		},
 		{
 			Name:            "steps_policy_is_nil",
 			GivenGuard:      mock.NewPipelineGuard(nil, nil),
 			GivenSubscriber: mock.NewPipelineCancelPubsub(),
+			GivenPauseSub:   mock.NewPipelinePausePubsub(),
 			GivenPipePolicy: nil,
 			GivenEnqueuer:   mock.NewEnqueuer(),
 			GivenLogger:     mock.NewMemoryLogger(),
@@ -78,6 +96,7 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 			Name:            "enqueuer_is_nil",
 			GivenGuard:      mock.NewPipelineGuard(nil, nil),
 			GivenSubscriber: mock.NewPipelineCancelPubsub(),
+			GivenPauseSub:   mock.NewPipelinePausePubsub(),
 			GivenPipePolicy: mock.NewPipelinePolicy(),
 			GivenEnqueuer:   nil,
 			GivenLogger:     mock.NewMemoryLogger(),
@@ -88,6 +107,7 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 			Name:            "logger_is_nil",
 			GivenGuard:      mock.NewPipelineGuard(nil, nil),
 			GivenSubscriber: mock.NewPipelineCancelPubsub(),
+			GivenPauseSub:   mock.NewPipelinePausePubsub(),
 			GivenPipePolicy: mock.NewPipelinePolicy(),
 			GivenEnqueuer:   mock.NewEnqueuer(),
 			GivenLogger:     nil,
@@ -115,10 +135,12 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 				_ = service.NewPipelineMaintainer(
 					c.GivenGuard,
 					c.GivenSubscriber,
+					c.GivenPauseSub,
 					c.GivenPipePolicy,
 					c.GivenEnqueuer,
 					c.GivenLogger,
 					flowTimeout,
+					leaseHeartbeat,
 				)
 			}
 
@@ -133,6 +155,27 @@ func TestNewPipelineMaintainerPanics(t *testing.T) {
 	}
 }
 
+func TestNewPipelineMaintainerPanicsOnNotPositiveHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	for _, heartbeat := range []time.Duration{0, -time.Second} {
+		heartbeat := heartbeat
+
+		require.PanicsWithValue(t, "lease heartbeat is not positive", func() {
+			_ = service.NewPipelineMaintainer(
+				mock.NewPipelineGuard(nil, nil),
+				mock.NewPipelineCancelPubsub(),
+				mock.NewPipelinePausePubsub(),
+				mock.NewPipelinePolicy(),
+				mock.NewEnqueuer(),
+				mock.NewMemoryLogger(),
+				time.Second,
+				heartbeat,
+			)
+		})
+	}
+}
+
 func TestMaintainPipeline(t *testing.T) {
 	t.Parallel()
 
@@ -257,22 +300,24 @@ func TestMaintainPipeline(t *testing.T) {
 
 			var (
 				pubsub   = mock.NewPipelineCancelPubsub()
+				pausePS  = mock.NewPipelinePausePubsub()
 				policy   = mock.NewPipelinePolicy()
 				enqueuer = mock.NewEnqueuer()
 				logger   = mock.NewMemoryLogger()
 			)
 
 			maintainer := service.NewPipelineMaintainer(
-				c.Guard, pubsub,
+				c.Guard, pubsub, pausePS,
 				policy, enqueuer,
 				logger, flowTimeout,
+				leaseHeartbeat,
 			)
 
 			pipe := c.PipelineFactory()
 
 			ctx := correlationid.AssignToCtx(context.Background(), correlationID)
 
-			done, err := maintainer.MaintainPipeline(ctx, pipe)
+			done, err := maintainer.MaintainPipeline(ctx, pipe, service.ManualPriority)
 
 			t.Run("pipeline_acquired", func(t *testing.T) {
 				require.Equal(t, c.ExpectedAcquireCalls, c.Guard.AcquireCalls())
@@ -282,6 +327,10 @@ func TestMaintainPipeline(t *testing.T) {
 				require.Equal(t, c.ExpectedSubscribeCalls, pubsub.SubscribeCalls())
 			})
 
+			t.Run("pipeline_pause_subscribed", func(t *testing.T) {
+				require.Equal(t, c.ExpectedSubscribeCalls, pausePS.SubscribeCalls())
+			})
+
 			if c.ShouldBeErr {
 				t.Run("err", func(t *testing.T) {
 					require.True(t, c.IsErr(err))
@@ -296,6 +345,7 @@ func TestMaintainPipeline(t *testing.T) {
 
 			t.Run("pipeline_enqueued", func(t *testing.T) {
 				require.Equal(t, 1, enqueuer.EnqueueCalls())
+				require.Equal(t, service.ManualPriority, enqueuer.Jobs()[0].Priority)
 			})
 
 			t.Run("pipeline_released", func(t *testing.T) {
@@ -363,15 +413,17 @@ func TestCancelWhilePipelineIsMaintaining(t *testing.T) {
 			var (
 				guard    = errlessPipelineGuard(t)
 				pubsub   = mock.NewPipelineCancelPubsub()
+				pausePS  = mock.NewPipelinePausePubsub()
 				policy   = mock.NewPipelinePolicy()
 				enqueuer = mock.NewEnqueuer()
 				logger   = mock.NewMemoryLogger()
 			)
 
 			maintainer := service.NewPipelineMaintainer(
-				guard, pubsub,
+				guard, pubsub, pausePS,
 				policy, enqueuer,
 				logger, c.FlowTimeout,
+				leaseHeartbeat,
 			)
 
 			pass := make(chan struct{})
@@ -388,7 +440,7 @@ func TestCancelWhilePipelineIsMaintaining(t *testing.T) {
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
 
-			done, err := maintainer.MaintainPipeline(ctx, pipe)
+			done, err := maintainer.MaintainPipeline(ctx, pipe, service.ManualPriority)
 			require.NoError(t, err)
 
 			if c.PublishCancel {
@@ -405,6 +457,348 @@ func TestCancelWhilePipelineIsMaintaining(t *testing.T) {
 	}
 }
 
+func TestPauseWhilePipelineIsMaintaining(t *testing.T) {
+	t.Parallel()
+
+	const pipelineID = "pipe"
+
+	spec := (&specification.Builder{}).
+		WithID("spec").
+		WithStory("foo", func(b *specification.StoryBuilder) {
+			b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+				b.WithThesis("baz", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Given, "baz")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.POST)
+						})
+					})
+				})
+			})
+		}).
+		ErrlessBuild()
+
+	var (
+		guard    = errlessPipelineGuard(t)
+		cancelPS = mock.NewPipelineCancelPubsub()
+		pausePS  = mock.NewPipelinePausePubsub()
+		policy   = mock.NewPipelinePolicy()
+		enqueuer = mock.NewEnqueuer()
+		logger   = mock.NewMemoryLogger()
+	)
+
+	maintainer := service.NewPipelineMaintainer(
+		guard, cancelPS, pausePS,
+		policy, enqueuer,
+		logger, 1*time.Second,
+		leaseHeartbeat,
+	)
+
+	pass := make(chan struct{})
+
+	pipe := pipeline.Trigger(
+		pipelineID,
+		spec,
+		pipeline.WithHTTP(pendingPassExecutor(t, pass)),
+	)
+
+	done, err := maintainer.MaintainPipeline(context.Background(), pipe, service.ManualPriority)
+	require.NoError(t, err)
+
+	require.Eventually(t, pipe.Started, time.Second, time.Millisecond)
+
+	require.NoError(t, pausePS.PublishPipelinePause(pipelineID))
+	require.Eventually(t, pipe.Paused, time.Second, time.Millisecond)
+
+	require.NoError(t, pausePS.PublishPipelineResume(pipelineID))
+	require.Eventually(t, func() bool {
+		return !pipe.Paused()
+	}, time.Second, time.Millisecond)
+
+	close(pass)
+
+	<-done
+
+	require.Equal(t, 1, guard.ReleaseCalls())
+}
+
+func TestLoseLeaseWhilePipelineIsMaintaining(t *testing.T) {
+	t.Parallel()
+
+	spec := (&specification.Builder{}).
+		WithID("spec").
+		WithStory("foo", func(b *specification.StoryBuilder) {
+			b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+				b.WithThesis("baz", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Given, "baz")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.POST)
+						})
+					})
+				})
+			})
+		}).
+		ErrlessBuild()
+
+	var (
+		guard    = mock.NewLeaseLosingPipelineGuard(service.ErrPipelineLeaseLost)
+		cancelPS = mock.NewPipelineCancelPubsub()
+		pausePS  = mock.NewPipelinePausePubsub()
+		policy   = mock.NewPipelinePolicy()
+		enqueuer = mock.NewEnqueuer()
+		logger   = mock.NewMemoryLogger()
+	)
+
+	maintainer := service.NewPipelineMaintainer(
+		guard, cancelPS, pausePS,
+		policy, enqueuer,
+		logger, 5*time.Second,
+		time.Millisecond,
+	)
+
+	pass := make(chan struct{})
+	defer close(pass)
+
+	pipe := pipeline.Trigger(
+		"pipe",
+		spec,
+		pipeline.WithHTTP(pendingPassExecutor(t, pass)),
+	)
+
+	done, err := maintainer.MaintainPipeline(context.Background(), pipe, service.ManualPriority)
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pipeline with lost lease is not canceled")
+	}
+
+	require.Equal(t, 1, guard.ExtendCalls())
+	require.Equal(t, 1, guard.ReleaseCalls())
+}
+
+func TestMaintainPipelineWithSubscribeError(t *testing.T) {
+	t.Parallel()
+
+	errSubscribe := errors.New("subscribe")
+
+	testCases := []struct {
+		Name     string
+		CancelPS *mock.PipelineCancelPubsub
+		PausePS  *mock.PipelinePausePubsub
+	}{
+		{
+			Name:     "cancel_subscribe_error",
+			CancelPS: mock.NewFailingPipelineCancelPubsub(errSubscribe),
+			PausePS:  mock.NewPipelinePausePubsub(),
+		},
+		{
+			Name:     "pause_subscribe_error",
+			CancelPS: mock.NewPipelineCancelPubsub(),
+			PausePS:  mock.NewFailingPipelinePausePubsub(errSubscribe),
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				guard    = errlessPipelineGuard(t)
+				enqueuer = mock.NewEnqueuer()
+			)
+
+			maintainer := service.NewPipelineMaintainer(
+				guard, c.CancelPS, c.PausePS,
+				mock.NewPipelinePolicy(), enqueuer,
+				mock.NewMemoryLogger(), 5*time.Second,
+				leaseHeartbeat,
+			)
+
+			pipe := pipeline.Trigger("pipe", (&specification.Builder{}).WithID("spec").ErrlessBuild())
+
+			_, err := maintainer.MaintainPipeline(context.Background(), pipe, service.ManualPriority)
+			require.ErrorIs(t, err, errSubscribe)
+
+			require.Equal(t, 1, guard.AcquireCalls())
+			require.Equal(t, 1, guard.ReleaseCalls())
+			require.Eventually(t, func() bool {
+				return c.CancelPS.Subscriptions(pipe.ID()) == 0
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestDrainWhilePipelineIsMaintaining(t *testing.T) {
+	t.Parallel()
+
+	const pipelineID = "pipe"
+
+	spec := (&specification.Builder{}).
+		WithID("spec").
+		WithStory("foo", func(b *specification.StoryBuilder) {
+			b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+				b.WithThesis("baz", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Given, "baz")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.POST)
+						})
+					})
+				})
+			})
+		}).
+		ErrlessBuild()
+
+	var (
+		guard    = errlessPipelineGuard(t)
+		cancelPS = mock.NewPipelineCancelPubsub()
+		pausePS  = mock.NewPipelinePausePubsub()
+		policy   = mock.NewPipelinePolicy()
+		enqueuer = mock.NewEnqueuer()
+		logger   = mock.NewMemoryLogger()
+	)
+
+	maintainer := service.NewPipelineMaintainer(
+		guard, cancelPS, pausePS,
+		policy, enqueuer,
+		logger, 5*time.Second,
+		leaseHeartbeat,
+	)
+
+	pass := make(chan struct{})
+	defer close(pass)
+
+	newPipeline := func() *pipeline.Pipeline {
+		return pipeline.Trigger(
+			pipelineID,
+			spec,
+			pipeline.WithHTTP(pendingPassExecutor(t, pass)),
+		)
+	}
+
+	done, err := maintainer.MaintainPipeline(context.Background(), newPipeline(), service.ManualPriority)
+	require.NoError(t, err)
+
+	maintainer.DrainPipelines()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("drained pipeline is not canceled")
+	}
+
+	require.Equal(t, []string{pipelineID}, maintainer.DrainedPipelines())
+	require.Equal(t, 1, guard.ReleaseCalls())
+
+	_, err = maintainer.MaintainPipeline(context.Background(), newPipeline(), service.ManualPriority)
+	require.ErrorIs(t, err, service.ErrPipelineMaintainerDrained)
+}
+
+func TestDrainedPipelinesAreReleased(t *testing.T) {
+	t.Parallel()
+
+	const pipelineID = "pipe"
+
+	spec := (&specification.Builder{}).
+		WithID("spec").
+		WithStory("foo", func(b *specification.StoryBuilder) {
+			b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+				b.WithThesis("baz", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Given, "baz")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.POST)
+						})
+					})
+				})
+			})
+		}).
+		ErrlessBuild()
+
+	t.Run("release_failed", func(t *testing.T) {
+		t.Parallel()
+
+		maintainer := service.NewPipelineMaintainer(
+			mock.NewPipelineGuard(nil, errPipelineRelease),
+			mock.NewPipelineCancelPubsub(), mock.NewPipelinePausePubsub(),
+			mock.NewPipelinePolicy(), mock.NewEnqueuer(),
+			mock.NewMemoryLogger(), 5*time.Second,
+			leaseHeartbeat,
+		)
+
+		pass := make(chan struct{})
+		defer close(pass)
+
+		pipe := pipeline.Trigger(pipelineID, spec, pipeline.WithHTTP(pendingPassExecutor(t, pass)))
+
+		done, err := maintainer.MaintainPipeline(context.Background(), pipe, service.ManualPriority)
+		require.NoError(t, err)
+
+		maintainer.DrainPipelines()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("drained pipeline is not canceled")
+		}
+
+		require.Empty(t, maintainer.DrainedPipelines())
+	})
+
+	t.Run("still_running", func(t *testing.T) {
+		t.Parallel()
+
+		maintainer := service.NewPipelineMaintainer(
+			errlessPipelineGuard(t),
+			mock.NewPipelineCancelPubsub(), mock.NewPipelinePausePubsub(),
+			mock.NewPipelinePolicy(), mock.NewEnqueuer(),
+			mock.NewMemoryLogger(), 5*time.Second,
+			leaseHeartbeat,
+		)
+
+		var (
+			pass    = make(chan struct{})
+			stopped = make(chan struct{})
+		)
+
+		// Executor ignores cancellation like the
+		// thesis stuck longer than the drain timeout.
+		stuck := pipeline.ExecutorFunc(func(
+			_ context.Context,
+			_ *pipeline.Environment,
+			_ specification.Thesis,
+		) pipeline.Result {
+			close(stopped)
+			<-pass
+
+			return pipeline.Pass()
+		})
+
+		pipe := pipeline.Trigger(pipelineID, spec, pipeline.WithHTTP(stuck))
+
+		done, err := maintainer.MaintainPipeline(context.Background(), pipe, service.ManualPriority)
+		require.NoError(t, err)
+
+		<-stopped
+
+		maintainer.DrainPipelines()
+
+		require.Never(t, func() bool {
+			return len(maintainer.DrainedPipelines()) > 0
+		}, 50*time.Millisecond, 10*time.Millisecond)
+
+		close(pass)
+		<-done
+
+		require.Equal(t, []string{pipelineID}, maintainer.DrainedPipelines())
+	})
+}
+
 func pendingPassExecutor(t *testing.T, pass <-chan struct{}) pipeline.Executor {
 	t.Helper()
 
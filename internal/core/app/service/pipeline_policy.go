@@ -20,12 +20,20 @@ type PipelinePolicy interface {
 
 type savePerStepPolicy struct {
 	flowRepo FlowRepository
+	stepPub  PipelineStepPublisher
+	notifier FlowNotifier
 	logger   Logger
 	timeout  time.Duration
 }
 
+// NewSavePerStepPolicy returns PipelinePolicy that saves
+// the flow after each step of the pipeline and publishes
+// the step to PipelineStepPublisher. Completed flow is
+// passed to FlowNotifier.
 func NewSavePerStepPolicy(
 	flowRepo FlowRepository,
+	stepPub PipelineStepPublisher,
+	notifier FlowNotifier,
 	logger Logger,
 	saveTimeout time.Duration,
 ) PipelinePolicy {
@@ -33,12 +41,22 @@ func NewSavePerStepPolicy(
 		panic("flow repository is nil")
 	}
 
+	if stepPub == nil {
+		panic("pipeline step publisher is nil")
+	}
+
+	if notifier == nil {
+		panic("flow notifier is nil")
+	}
+
 	if logger == nil {
 		panic("logger is nil")
 	}
 
 	return &savePerStepPolicy{
 		flowRepo: flowRepo,
+		stepPub:  stepPub,
+		notifier: notifier,
 		logger:   logger,
 		timeout:  saveTimeout,
 	}
@@ -59,9 +77,22 @@ func (p *savePerStepPolicy) ConsumePipeline(
 		if err := p.flowRepo.UpsertFlow(context.Background(), f); err != nil {
 			l.Error("Last attempt to upsert flow failed", "error", err)
 		}
+
+		if err := p.stepPub.PublishPipelineCompleted(pipeline.ID()); err != nil {
+			l.Warn("Attempt to publish pipeline completion failed", "error", err)
+		}
+
+		p.notifier.NotifyFlowCompleted(context.Background(), pipeline, f)
 	}()
 
 	for s := range steps {
+		if err := p.stepPub.PublishPipelineStep(pipeline.ID(), StepMessage{
+			Step:       s,
+			OccurredAt: time.Now().UTC(),
+		}); err != nil {
+			l.Warn("Attempt to publish step failed", "error", err)
+		}
+
 		if err := p.upsertFlowWithTimeout(ctx, f.ApplyStep(s)); err != nil {
 			l.Warn("Attempt to upsert flow failed", "error", err)
 		}
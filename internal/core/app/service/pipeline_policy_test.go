@@ -21,6 +21,8 @@ func TestNewSavePerStepPolicyPanics(t *testing.T) {
 	testCases := []struct {
 		Name                string
 		GivenFlowRepository service.FlowRepository
+		GivenStepPublisher  service.PipelineStepPublisher
+		GivenFlowNotifier   service.FlowNotifier
 		GivenLogger         service.Logger
 		ShouldPanic         bool
 		PanicMessage        string
@@ -28,19 +30,43 @@ func TestNewSavePerStepPolicyPanics(t *testing.T) {
 		{
 			Name:                "all_dependencies_are_not_nil",
 			GivenFlowRepository: mock.NewFlowRepository(),
+			GivenStepPublisher:  mock.NewPipelineStepPubsub(),
+			GivenFlowNotifier:   mock.NewFlowNotifier(),
 			GivenLogger:         mock.NewMemoryLogger(),
 			ShouldPanic:         false,
 		},
 		{
 			Name:                "flow_repository_is_nil",
 			GivenFlowRepository: nil,
+			GivenStepPublisher:  mock.NewPipelineStepPubsub(),
+			GivenFlowNotifier:   mock.NewFlowNotifier(),
 			GivenLogger:         mock.NewMemoryLogger(),
 			ShouldPanic:         true,
 			PanicMessage:        "flow repository is nil",
 		},
+		{
+			Name:                "step_publisher_is_nil",
+			GivenFlowRepository: mock.NewFlowRepository(),
+			GivenStepPublisher:  nil,
+			GivenFlowNotifier:   mock.NewFlowNotifier(),
+			GivenLogger:         mock.NewMemoryLogger(),
+			ShouldPanic:         true,
+			PanicMessage:        "pipeline step publisher is nil",
+		},
+		{
+			Name:                "flow_notifier_is_nil",
+			GivenFlowRepository: mock.NewFlowRepository(),
+			GivenStepPublisher:  mock.NewPipelineStepPubsub(),
+			GivenFlowNotifier:   nil,
+			GivenLogger:         mock.NewMemoryLogger(),
+			ShouldPanic:         true,
+			PanicMessage:        "flow notifier is nil",
+		},
 		{
 			Name:                "logger_is_nil",
 			GivenFlowRepository: mock.NewFlowRepository(),
+			GivenStepPublisher:  mock.NewPipelineStepPubsub(),
+			GivenFlowNotifier:   mock.NewFlowNotifier(),
 			GivenLogger:         nil,
 			ShouldPanic:         true,
 			PanicMessage:        "logger is nil",
@@ -48,6 +74,8 @@ func TestNewSavePerStepPolicyPanics(t *testing.T) {
 		{
 			Name:                "all_dependencies_are_nil",
 			GivenFlowRepository: nil,
+			GivenStepPublisher:  nil,
+			GivenFlowNotifier:   nil,
 			GivenLogger:         nil,
 			ShouldPanic:         true,
 			PanicMessage:        "flow repository is nil",
@@ -63,6 +91,8 @@ func TestNewSavePerStepPolicyPanics(t *testing.T) {
 			init := func() {
 				_ = service.NewSavePerStepPolicy(
 					c.GivenFlowRepository,
+					c.GivenStepPublisher,
+					c.GivenFlowNotifier,
 					c.GivenLogger,
 					saveTimeout,
 				)
@@ -160,9 +190,13 @@ func TestConsumePipelineSavePerStep(t *testing.T) {
 
 			var (
 				flowRepo = mock.NewFlowRepository()
+				stepPub  = mock.NewPipelineStepPubsub()
+				notifier = mock.NewFlowNotifier()
 				logger   = mock.NewMemoryLogger()
 				policy   = service.NewSavePerStepPolicy(
 					flowRepo,
+					stepPub,
+					notifier,
 					logger,
 					c.InitSaveTimeout,
 				)
@@ -176,6 +210,10 @@ func TestConsumePipelineSavePerStep(t *testing.T) {
 			}
 
 			policy.ConsumePipeline(ctx, c.GivenPipeline)
+
+			require.NotEmpty(t, stepPub.PublishedSteps(c.GivenPipeline.ID()))
+			require.True(t, stepPub.Completed(c.GivenPipeline.ID()))
+			require.Len(t, notifier.NotifiedFlows(), 1)
 		})
 	}
 }
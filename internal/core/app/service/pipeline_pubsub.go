@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -11,13 +12,87 @@ type (
 		PublishPipelineCancel(pipeID string) error
 	}
 
+	// PipelineCancelSubscriber returns channel closed on the cancel
+	// signal of the pipeline, subscription is removed when ctx is done.
 	PipelineCancelSubscriber interface {
-		SubscribePipelineCancel(pipeID string) (<-chan CancelSignal, error)
+		SubscribePipelineCancel(ctx context.Context, pipeID string) (<-chan CancelSignal, error)
 	}
 
 	CancelSignal = struct{}
 )
 
+type (
+	PipelinePausePublisher interface {
+		PublishPipelinePause(pipeID string) error
+		PublishPipelineResume(pipeID string) error
+	}
+
+	// PipelinePauseSubscriber receives pause and resume signals
+	// of the pipeline. The returned channel is closed when
+	// the context is done.
+	PipelinePauseSubscriber interface {
+		SubscribePipelinePause(ctx context.Context, pipeID string) (<-chan PauseSignal, error)
+	}
+
+	PauseSignal string
+)
+
+const (
+	Paused  PauseSignal = "paused"
+	Resumed PauseSignal = "resumed"
+)
+
+type (
+	// PipelineRequeuePublisher hands the pipeline over to
+	// another instance, e.g. when it is drained on shutdown.
+	PipelineRequeuePublisher interface {
+		PublishPipelineRequeue(pipeID string) error
+	}
+
+	// PipelineRequeueSubscriber receives IDs of requeued pipelines,
+	// each ID is received by the only subscribed instance.
+	// The returned channel is closed when the context is done.
+	PipelineRequeueSubscriber interface {
+		SubscribePipelineRequeue(ctx context.Context) (<-chan string, error)
+	}
+)
+
+type (
+	// PipelineStartPublisher requests workers to start pipelines.
+	// PublishPipelineStart returns once any of workers accepts the
+	// pipeline, or the error the worker rejected the pipeline with.
+	PipelineStartPublisher interface {
+		PublishPipelineStart(ctx context.Context, msg PipelineStartMessage) error
+	}
+
+	// PipelineStartSubscriber receives requests to start pipelines,
+	// each request is received by the only subscribed worker, which
+	// must reply to it. The returned channel is closed when the
+	// context is done.
+	PipelineStartSubscriber interface {
+		SubscribePipelineStart(ctx context.Context) (<-chan PipelineStartRequest, error)
+	}
+
+	// PipelineStartMessage requests to start the pipeline with
+	// the priority. The pipeline is leased to the LeaseOwner
+	// instance, which hands it over to the accepting worker.
+	PipelineStartMessage struct {
+		PipelineID string
+		Priority   Priority
+		LeaseOwner string
+	}
+
+	PipelineStartRequest struct {
+		PipelineStartMessage
+
+		// Reply accepts the request if err is nil
+		// and rejects it with the err otherwise.
+		Reply func(err error) error
+	}
+)
+
+var ErrNoPipelineWorkers = errors.New("no pipeline workers")
+
 type PublishCancelError struct {
 	err error
 }
@@ -65,3 +140,147 @@ func (e *SubscribeCancelError) Error() string {
 
 	return fmt.Sprintf("subscribe cancel: %s", e.err)
 }
+
+type PublishPauseError struct {
+	err error
+}
+
+func WrapWithPublishPauseError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&PublishPauseError{err: err})
+}
+
+func (e *PublishPauseError) Unwrap() error {
+	return e.err
+}
+
+func (e *PublishPauseError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("publish pause: %s", e.err)
+}
+
+type SubscribePauseError struct {
+	err error
+}
+
+func WrapWithSubscribePauseError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&SubscribePauseError{err: err})
+}
+
+func (e *SubscribePauseError) Unwrap() error {
+	return e.err
+}
+
+func (e *SubscribePauseError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("subscribe pause: %s", e.err)
+}
+
+type PublishRequeueError struct {
+	err error
+}
+
+func WrapWithPublishRequeueError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&PublishRequeueError{err: err})
+}
+
+func (e *PublishRequeueError) Unwrap() error {
+	return e.err
+}
+
+func (e *PublishRequeueError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("publish requeue: %s", e.err)
+}
+
+type SubscribeRequeueError struct {
+	err error
+}
+
+func WrapWithSubscribeRequeueError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&SubscribeRequeueError{err: err})
+}
+
+func (e *SubscribeRequeueError) Unwrap() error {
+	return e.err
+}
+
+func (e *SubscribeRequeueError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("subscribe requeue: %s", e.err)
+}
+
+type PublishStartError struct {
+	err error
+}
+
+func WrapWithPublishStartError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&PublishStartError{err: err})
+}
+
+func (e *PublishStartError) Unwrap() error {
+	return e.err
+}
+
+func (e *PublishStartError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("publish start: %s", e.err)
+}
+
+type SubscribeStartError struct {
+	err error
+}
+
+func WrapWithSubscribeStartError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&SubscribeStartError{err: err})
+}
+
+func (e *SubscribeStartError) Unwrap() error {
+	return e.err
+}
+
+func (e *SubscribeStartError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("subscribe start: %s", e.err)
+}
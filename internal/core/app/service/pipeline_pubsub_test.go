@@ -187,3 +187,537 @@ func TestFormatSubscribeCancelError(t *testing.T) {
 		})
 	}
 }
+
+func TestAsPublishPauseError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithPublishPauseError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.PublishPauseError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithPublishPauseError(errors.New("foo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("foo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.PublishPauseError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatPublishPauseError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.PublishPauseError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithPublishPauseError(errors.New("failed")),
+			ExpectedErrorString: "publish pause: failed",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
+
+func TestAsSubscribePauseError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithSubscribePauseError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.SubscribePauseError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithSubscribePauseError(errors.New("qoo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("qoo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.SubscribePauseError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatSubscribePauseError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.SubscribePauseError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithSubscribePauseError(errors.New("wrong")),
+			ExpectedErrorString: "subscribe pause: wrong",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
+
+func TestAsPublishRequeueError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithPublishRequeueError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.PublishRequeueError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithPublishRequeueError(errors.New("foo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("foo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.PublishRequeueError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatPublishRequeueError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.PublishRequeueError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithPublishRequeueError(errors.New("failed")),
+			ExpectedErrorString: "publish requeue: failed",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
+
+func TestAsSubscribeRequeueError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithSubscribeRequeueError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.SubscribeRequeueError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithSubscribeRequeueError(errors.New("qoo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("qoo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.SubscribeRequeueError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatSubscribeRequeueError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.SubscribeRequeueError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithSubscribeRequeueError(errors.New("wrong")),
+			ExpectedErrorString: "subscribe requeue: wrong",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
+
+func TestAsPublishStartError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithPublishStartError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.PublishStartError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithPublishStartError(errors.New("foo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("foo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.PublishStartError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatPublishStartError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.PublishStartError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithPublishStartError(errors.New("failed")),
+			ExpectedErrorString: "publish start: failed",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
+
+func TestAsSubscribeStartError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithSubscribeStartError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.SubscribeStartError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithSubscribeStartError(errors.New("qoo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("qoo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.SubscribeStartError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatSubscribeStartError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.SubscribeStartError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithSubscribeStartError(errors.New("wrong")),
+			ExpectedErrorString: "subscribe start: wrong",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+type (
+	// LeaderLock elects the only instance doing the work
+	// with such name, e.g. firing scheduled pipelines.
+	LeaderLock interface {
+		// AcquireLeadership acquires or extends the leadership
+		// of the instance. ErrLeadershipTaken is returned while
+		// another instance leads.
+		AcquireLeadership(ctx context.Context, name string) error
+		ReleaseLeadership(ctx context.Context, name string) error
+	}
+
+	// ScheduleFireLog keeps the last fire time of each schedule, so
+	// fire times are neither missed nor repeated when the leadership
+	// moves to another instance.
+	ScheduleFireLog interface {
+		// LastScheduleFire returns the last fire time of the
+		// schedule or zero time if the schedule never fired.
+		LastScheduleFire(ctx context.Context, tcID, scheduleID string) (time.Time, error)
+		// MarkScheduleFired replaces the last fire time of the schedule
+		// with firedAt. ErrScheduleAlreadyFired is returned if the last
+		// fire time is not last anymore, i.e. another instance has fired
+		// the schedule meanwhile.
+		MarkScheduleFired(ctx context.Context, tcID, scheduleID string, last, firedAt time.Time) error
+	}
+
+	// ScheduledPipelineStarter starts new pipeline of the
+	// test campaign when its schedule fires.
+	ScheduledPipelineStarter interface {
+		StartScheduledPipeline(
+			ctx context.Context,
+			tc *testcampaign.TestCampaign,
+			schedule testcampaign.Schedule,
+		) error
+	}
+)
+
+var (
+	ErrLeadershipTaken      = errors.New("leadership is taken by another instance")
+	ErrScheduleAlreadyFired = errors.New("schedule is already fired")
+)
+
+const pipelineSchedulerLeadership = "pipeline-scheduler"
+
+// PipelineScheduler fires schedules of test campaigns every tick.
+// Only the leading instance fires schedules and each fire is
+// recorded in the ScheduleFireLog, so each fire time starts one
+// pipeline however many instances are running.
+type PipelineScheduler struct {
+	finder  ScheduledTestCampaignFinder
+	lock    LeaderLock
+	fireLog ScheduleFireLog
+	starter ScheduledPipelineStarter
+	logger  Logger
+	tick    time.Duration
+}
+
+func NewPipelineScheduler(
+	finder ScheduledTestCampaignFinder,
+	lock LeaderLock,
+	fireLog ScheduleFireLog,
+	starter ScheduledPipelineStarter,
+	logger Logger,
+	tick time.Duration,
+) *PipelineScheduler {
+	if finder == nil {
+		panic("scheduled test campaign finder is nil")
+	}
+
+	if lock == nil {
+		panic("leader lock is nil")
+	}
+
+	if fireLog == nil {
+		panic("schedule fire log is nil")
+	}
+
+	if starter == nil {
+		panic("scheduled pipeline starter is nil")
+	}
+
+	if logger == nil {
+		panic("logger is nil")
+	}
+
+	return &PipelineScheduler{
+		finder:  finder,
+		lock:    lock,
+		fireLog: fireLog,
+		starter: starter,
+		logger:  logger,
+		tick:    tick,
+	}
+}
+
+// Run fires schedules until ctx is done,
+// then the leadership is released.
+func (s *PipelineScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.releaseLeadership()
+
+			return
+		case now := <-ticker.C:
+			s.FireSchedules(ctx, now)
+		}
+	}
+}
+
+// FireSchedules starts pipelines of schedules firing after
+// their last fire time up to now, if the instance leads.
+// Schedule missed several times fires once. Schedule that
+// never fired is only due within the last tick.
+func (s *PipelineScheduler) FireSchedules(ctx context.Context, now time.Time) int {
+	if err := s.lock.AcquireLeadership(ctx, pipelineSchedulerLeadership); err != nil {
+		if !errors.Is(err, ErrLeadershipTaken) {
+			s.logger.Error("Scheduler leadership is not acquired", "error", err)
+		}
+
+		return 0
+	}
+
+	tcs, err := s.finder.FindScheduledTestCampaigns(ctx)
+	if err != nil {
+		s.logger.Error("Scheduled test campaigns are not found", "error", err)
+
+		return 0
+	}
+
+	fired := 0
+
+	for _, tc := range tcs {
+		for _, schedule := range tc.Schedules() {
+			if !s.markDueScheduleFired(ctx, tc, schedule, now) {
+				continue
+			}
+
+			if err := s.starter.StartScheduledPipeline(ctx, tc, schedule); err != nil {
+				s.logger.Error(
+					"Scheduled pipeline is not started",
+					"error", err,
+					"testCampaignId", tc.ID(),
+					"scheduleId", schedule.ID(),
+				)
+
+				continue
+			}
+
+			fired++
+
+			s.logger.Info(
+				"Scheduled pipeline started",
+				"testCampaignId", tc.ID(),
+				"scheduleId", schedule.ID(),
+			)
+		}
+	}
+
+	return fired
+}
+
+// markDueScheduleFired records the fire of the schedule if it
+// is due, false is returned if the schedule must not be fired.
+func (s *PipelineScheduler) markDueScheduleFired(
+	ctx context.Context,
+	tc *testcampaign.TestCampaign,
+	schedule testcampaign.Schedule,
+	now time.Time,
+) bool {
+	last, err := s.fireLog.LastScheduleFire(ctx, tc.ID(), schedule.ID())
+	if err != nil {
+		s.logger.Error(
+			"Last schedule fire is not found",
+			"error", err,
+			"testCampaignId", tc.ID(),
+			"scheduleId", schedule.ID(),
+		)
+
+		return false
+	}
+
+	from := last
+	if from.IsZero() {
+		from = now.Add(-s.tick)
+	}
+
+	if !schedule.Due(from, now) {
+		return false
+	}
+
+	err = s.fireLog.MarkScheduleFired(ctx, tc.ID(), schedule.ID(), last, now)
+	if errors.Is(err, ErrScheduleAlreadyFired) {
+		return false
+	}
+
+	if err != nil {
+		s.logger.Error(
+			"Schedule fire is not marked",
+			"error", err,
+			"testCampaignId", tc.ID(),
+			"scheduleId", schedule.ID(),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+func (s *PipelineScheduler) releaseLeadership() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.tick)
+	defer cancel()
+
+	if err := s.lock.ReleaseLeadership(ctx, pipelineSchedulerLeadership); err != nil {
+		s.logger.Error("Scheduler leadership is not released", "error", err)
+	}
+}
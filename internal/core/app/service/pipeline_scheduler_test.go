@@ -0,0 +1,339 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+const schedulerTick = 1 * time.Minute
+
+func TestNewPipelineSchedulerPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		GivenFinder  service.ScheduledTestCampaignFinder
+		GivenLock    service.LeaderLock
+		GivenFireLog service.ScheduleFireLog
+		GivenStarter service.ScheduledPipelineStarter
+		GivenLogger  service.Logger
+		ShouldPanic  bool
+		PanicMessage string
+	}{
+		{
+			Name:         "all_dependencies_are_not_nil",
+			GivenFinder:  mock.NewTestCampaignRepository(),
+			GivenLock:    mock.NewLeaderLock(true),
+			GivenFireLog: mock.NewScheduleFireLog(),
+			GivenStarter: mock.NewScheduledPipelineStarter(nil),
+			GivenLogger:  mock.NewMemoryLogger(),
+			ShouldPanic:  false,
+		},
+		{
+			Name:         "scheduled_test_campaign_finder_is_nil",
+			GivenFinder:  nil,
+			GivenLock:    mock.NewLeaderLock(true),
+			GivenFireLog: mock.NewScheduleFireLog(),
+			GivenStarter: mock.NewScheduledPipelineStarter(nil),
+			GivenLogger:  mock.NewMemoryLogger(),
+			ShouldPanic:  true,
+			PanicMessage: "scheduled test campaign finder is nil",
+		},
+		{
+			Name:         "leader_lock_is_nil",
+			GivenFinder:  mock.NewTestCampaignRepository(),
+			GivenLock:    nil,
+			GivenFireLog: mock.NewScheduleFireLog(),
+			GivenStarter: mock.NewScheduledPipelineStarter(nil),
+			GivenLogger:  mock.NewMemoryLogger(),
+			ShouldPanic:  true,
+			PanicMessage: "leader lock is nil",
+		},
+		{
+			Name:         "schedule_fire_log_is_nil",
+			GivenFinder:  mock.NewTestCampaignRepository(),
+			GivenLock:    mock.NewLeaderLock(true),
+			GivenFireLog: nil,
+			GivenStarter: mock.NewScheduledPipelineStarter(nil),
+			GivenLogger:  mock.NewMemoryLogger(),
+			ShouldPanic:  true,
+			PanicMessage: "schedule fire log is nil",
+		},
+		{
+			Name:         "scheduled_pipeline_starter_is_nil",
+			GivenFinder:  mock.NewTestCampaignRepository(),
+			GivenLock:    mock.NewLeaderLock(true),
+			GivenFireLog: mock.NewScheduleFireLog(),
+			GivenStarter: nil,
+			GivenLogger:  mock.NewMemoryLogger(),
+			ShouldPanic:  true,
+			PanicMessage: "scheduled pipeline starter is nil",
+		},
+		{
+			Name:         "logger_is_nil",
+			GivenFinder:  mock.NewTestCampaignRepository(),
+			GivenLock:    mock.NewLeaderLock(true),
+			GivenFireLog: mock.NewScheduleFireLog(),
+			GivenStarter: mock.NewScheduledPipelineStarter(nil),
+			GivenLogger:  nil,
+			ShouldPanic:  true,
+			PanicMessage: "logger is nil",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			init := func() {
+				_ = service.NewPipelineScheduler(
+					c.GivenFinder,
+					c.GivenLock,
+					c.GivenFireLog,
+					c.GivenStarter,
+					c.GivenLogger,
+					schedulerTick,
+				)
+			}
+
+			if !c.ShouldPanic {
+				require.NotPanics(t, init)
+
+				return
+			}
+
+			require.PanicsWithValue(t, c.PanicMessage, init)
+		})
+	}
+}
+
+func TestPipelineSchedulerFireSchedules(t *testing.T) {
+	t.Parallel()
+
+	var (
+		quarter = testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+			ID:   "quarter",
+			Cron: "*/15 * * * *",
+		})
+		nightly = testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+			ID:       "nightly",
+			Cron:     "0 3 * * *",
+			Timezone: "Europe/Moscow",
+		})
+	)
+
+	var (
+		scheduled = testcampaign.MustNew(testcampaign.Params{
+			ID:        "scheduled",
+			OwnerID:   "owner",
+			Schedules: []testcampaign.Schedule{quarter, nightly},
+		})
+		unscheduled = testcampaign.MustNew(testcampaign.Params{
+			ID:      "unscheduled",
+			OwnerID: "owner",
+		})
+	)
+
+	errStart := errors.New("start")
+
+	testCases := []struct {
+		Name            string
+		Leader          bool
+		StartErr        error
+		LastFires       map[string]time.Time
+		Now             time.Time
+		ExpectedFired   int
+		ExpectedStarted []string
+		ShouldLogErrors bool
+	}{
+		{
+			Name:            "quarter_schedule_fires",
+			Leader:          true,
+			Now:             time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC),
+			ExpectedFired:   1,
+			ExpectedStarted: []string{"quarter"},
+		},
+		{
+			Name:            "both_schedules_fire_at_midnight_utc",
+			Leader:          true,
+			Now:             time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC),
+			ExpectedFired:   2,
+			ExpectedStarted: []string{"quarter", "nightly"},
+		},
+		{
+			Name:   "missed_fire_times_fire_once",
+			Leader: true,
+			LastFires: map[string]time.Time{
+				"quarter": time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+				"nightly": time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+			},
+			Now:             time.Date(2022, 3, 1, 13, 0, 0, 0, time.UTC),
+			ExpectedFired:   1,
+			ExpectedStarted: []string{"quarter"},
+		},
+		{
+			Name:   "already_fired_schedule_does_not_fire_again",
+			Leader: true,
+			LastFires: map[string]time.Time{
+				"quarter": time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC),
+			},
+			Now:             time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC),
+			ExpectedFired:   0,
+			ExpectedStarted: nil,
+		},
+		{
+			Name:            "nothing_fires",
+			Leader:          true,
+			Now:             time.Date(2022, 3, 1, 12, 2, 0, 0, time.UTC),
+			ExpectedFired:   0,
+			ExpectedStarted: nil,
+		},
+		{
+			Name:            "not_leader_does_not_fire",
+			Leader:          false,
+			Now:             time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC),
+			ExpectedFired:   0,
+			ExpectedStarted: nil,
+		},
+		{
+			Name:            "failed_start_is_logged",
+			Leader:          true,
+			StartErr:        errStart,
+			Now:             time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC),
+			ExpectedFired:   0,
+			ExpectedStarted: nil,
+			ShouldLogErrors: true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock    = mock.NewLeaderLock(c.Leader)
+				fireLog = mock.NewScheduleFireLog()
+				starter = mock.NewScheduledPipelineStarter(c.StartErr)
+				logger  = mock.NewMemoryLogger()
+			)
+
+			for scheduleID, firedAt := range c.LastFires {
+				fireLog.SetLastFire(scheduled.ID(), scheduleID, firedAt)
+			}
+
+			scheduler := service.NewPipelineScheduler(
+				mock.NewTestCampaignRepository(scheduled, unscheduled),
+				lock,
+				fireLog,
+				starter,
+				logger,
+				schedulerTick,
+			)
+
+			fired := scheduler.FireSchedules(context.Background(), c.Now)
+
+			require.Equal(t, c.ExpectedFired, fired)
+			require.ElementsMatch(t, c.ExpectedStarted, starter.StartedSchedules())
+			require.Equal(t, 1, lock.AcquireCalls())
+
+			for _, scheduleID := range c.ExpectedStarted {
+				require.Equal(t, c.Now, fireLog.LastFire(scheduled.ID(), scheduleID))
+			}
+
+			var errorLogs int
+
+			for _, l := range logger.FlushedLogs() {
+				if l.Level == "ERROR" {
+					errorLogs++
+				}
+			}
+
+			require.Equal(t, c.ShouldLogErrors, errorLogs > 0)
+		})
+	}
+}
+
+func TestPipelineSchedulerReleasesLeadershipOnStop(t *testing.T) {
+	t.Parallel()
+
+	lock := mock.NewLeaderLock(true)
+
+	scheduler := service.NewPipelineScheduler(
+		mock.NewTestCampaignRepository(),
+		lock,
+		mock.NewScheduleFireLog(),
+		mock.NewScheduledPipelineStarter(nil),
+		mock.NewMemoryLogger(),
+		schedulerTick,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		scheduler.Run(ctx)
+	}()
+
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		require.Fail(t, "scheduler is not stopped")
+	}
+
+	require.Equal(t, 1, lock.ReleaseCalls())
+}
+
+func TestPipelineSchedulerFiresScheduleOnce(t *testing.T) {
+	t.Parallel()
+
+	tc := testcampaign.MustNew(testcampaign.Params{
+		ID:      "scheduled",
+		OwnerID: "owner",
+		Schedules: []testcampaign.Schedule{
+			testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+				ID:   "quarter",
+				Cron: "*/15 * * * *",
+			}),
+		},
+	})
+
+	var (
+		fireLog = mock.NewScheduleFireLog()
+		starter = mock.NewScheduledPipelineStarter(nil)
+		now     = time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC)
+	)
+
+	newScheduler := func() *service.PipelineScheduler {
+		return service.NewPipelineScheduler(
+			mock.NewTestCampaignRepository(tc),
+			mock.NewLeaderLock(true),
+			fireLog,
+			starter,
+			mock.NewMemoryLogger(),
+			schedulerTick,
+		)
+	}
+
+	// The leadership moved to another instance
+	// which ticks a bit later than the previous one.
+	require.Equal(t, 1, newScheduler().FireSchedules(context.Background(), now))
+	require.Equal(t, 0, newScheduler().FireSchedules(context.Background(), now.Add(30*time.Second)))
+	require.Equal(t, []string{"quarter"}, starter.StartedSchedules())
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+)
+
+type (
+	// PipelineStepPublisher fans out steps of the running
+	// pipeline, so they can be observed from any replica.
+	PipelineStepPublisher interface {
+		PublishPipelineStep(pipeID string, msg StepMessage) error
+		// PublishPipelineCompleted notifies subscribers that
+		// the pipeline will not publish steps anymore.
+		PublishPipelineCompleted(pipeID string) error
+	}
+
+	// PipelineStepSubscriber receives steps of the running pipeline.
+	// The returned channel is closed when the pipeline is completed
+	// or the context is done.
+	PipelineStepSubscriber interface {
+		SubscribePipelineSteps(ctx context.Context, pipeID string) (<-chan StepMessage, error)
+	}
+
+	StepMessage struct {
+		Step       pipeline.Step
+		OccurredAt time.Time
+	}
+)
+
+type PublishStepError struct {
+	err error
+}
+
+func WrapWithPublishStepError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&PublishStepError{err: err})
+}
+
+func (e *PublishStepError) Unwrap() error {
+	return e.err
+}
+
+func (e *PublishStepError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("publish step: %s", e.err)
+}
+
+type SubscribeStepError struct {
+	err error
+}
+
+func WrapWithSubscribeStepError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(&SubscribeStepError{err: err})
+}
+
+func (e *SubscribeStepError) Unwrap() error {
+	return e.err
+}
+
+func (e *SubscribeStepError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("subscribe step: %s", e.err)
+}
@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/app/service"
+)
+
+func TestAsPublishStepError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithPublishStepError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.PublishStepError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithPublishStepError(errors.New("foo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("foo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.PublishStepError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatPublishStepError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.PublishStepError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithPublishStepError(errors.New("failed")),
+			ExpectedErrorString: "publish step: failed",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
+
+func TestAsSubscribeStepError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError        error
+		ShouldBeWrapped   bool
+		ExpectedUnwrapped error
+	}{
+		{
+			GivenError:      nil,
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:      service.WrapWithSubscribeStepError(nil),
+			ShouldBeWrapped: false,
+		},
+		{
+			GivenError:        &service.SubscribeStepError{},
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: nil,
+		},
+		{
+			GivenError:        service.WrapWithSubscribeStepError(errors.New("foo")),
+			ShouldBeWrapped:   true,
+			ExpectedUnwrapped: errors.New("foo"),
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var target *service.SubscribeStepError
+
+			if !c.ShouldBeWrapped {
+				t.Run("not", func(t *testing.T) {
+					require.False(t, errors.As(c.GivenError, &target))
+				})
+
+				return
+			}
+
+			t.Run("as", func(t *testing.T) {
+				require.ErrorAs(t, c.GivenError, &target)
+
+				t.Run("unwrap", func(t *testing.T) {
+					if c.ExpectedUnwrapped != nil {
+						require.EqualError(t, target.Unwrap(), c.ExpectedUnwrapped.Error())
+
+						return
+					}
+
+					require.NoError(t, target.Unwrap())
+				})
+			})
+		})
+	}
+}
+
+func TestFormatSubscribeStepError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		GivenError          error
+		ExpectedErrorString string
+	}{
+		{
+			GivenError:          &service.SubscribeStepError{},
+			ExpectedErrorString: "",
+		},
+		{
+			GivenError:          service.WrapWithSubscribeStepError(errors.New("failed")),
+			ExpectedErrorString: "subscribe step: failed",
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualError(t, c.GivenError, c.ExpectedErrorString)
+		})
+	}
+}
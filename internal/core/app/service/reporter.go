@@ -0,0 +1,20 @@
+package service
+
+import (
+	"io"
+
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+// FlowReporter renders completed flow to the report
+// of some format understandable by external tools.
+// The specification the flow was run by is passed
+// to reporters describing stories and scenarios.
+type FlowReporter interface {
+	// Format returns short name of the report format
+	// used to select the reporter, e.g. junit.
+	Format() string
+	ContentType() string
+	WriteFlowReport(w io.Writer, f *flow.Flow, spec *specification.Specification) error
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -17,6 +18,9 @@ var (
 	ErrSpecificationNotFound = errors.New("specification not found")
 	ErrPipelineNotFound      = errors.New("pipeline not found")
 	ErrFlowNotFound          = errors.New("flow not found")
+
+	ErrTestCampaignHasStartedPipelines = errors.New("test campaign has started pipelines")
+	ErrTriggerReplayed                 = errors.New("trigger is replayed")
 )
 
 type (
@@ -24,12 +28,47 @@ type (
 		GetTestCampaign(ctx context.Context, tcID string) (*testcampaign.TestCampaign, error)
 		AddTestCampaign(ctx context.Context, tc *testcampaign.TestCampaign) error
 		UpdateTestCampaign(ctx context.Context, tcID string, updater TestCampaignUpdater) error
+		// SwapLastOutcome atomically replaces the last flow outcome of
+		// the test campaign and returns the test campaign as it was
+		// before the replacement.
+		SwapLastOutcome(
+			ctx context.Context,
+			tcID string,
+			outcome testcampaign.Event,
+		) (*testcampaign.TestCampaign, error)
 	}
 
 	TestCampaignUpdater func(
 		ctx context.Context,
 		tc *testcampaign.TestCampaign,
 	) (*testcampaign.TestCampaign, error)
+
+	// ScheduledTestCampaignFinder finds test campaigns
+	// of all users having at least one schedule.
+	ScheduledTestCampaignFinder interface {
+		FindScheduledTestCampaigns(ctx context.Context) ([]*testcampaign.TestCampaign, error)
+	}
+
+	// TriggerReplayGuard remembers accepted pipeline triggers
+	// until they expire, so the same trigger can't be accepted
+	// twice.
+	TriggerReplayGuard interface {
+		// RememberTrigger returns ErrTriggerReplayed if
+		// the trigger with such key is already remembered.
+		RememberTrigger(ctx context.Context, key string, expiresAt time.Time) error
+	}
+
+	// TestCampaignRemover removes test campaign together with
+	// its specifications, pipelines and flows. While any pipeline
+	// of the test campaign is started, removing is refused with
+	// ErrTestCampaignHasStartedPipelines.
+	TestCampaignRemover interface {
+		// RemoveTestCampaign permanently deletes test campaign.
+		RemoveTestCampaign(ctx context.Context, tcID string) error
+		// ArchiveTestCampaign moves test campaign to the archive,
+		// where it is no longer available to the application.
+		ArchiveTestCampaign(ctx context.Context, tcID string) error
+	}
 )
 
 type (
@@ -40,6 +79,7 @@ type (
 			testCampaignID string,
 		) (*specification.Specification, error)
 		AddSpecification(ctx context.Context, spec *specification.Specification) error
+		ActivateSpecification(ctx context.Context, specID string) error
 	}
 )
 
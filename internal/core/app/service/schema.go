@@ -0,0 +1,7 @@
+package service
+
+// SpecificationSchemaProvider returns JSON Schema of the specification
+// source format to validate and autocomplete specification files in editors.
+type SpecificationSchemaProvider interface {
+	SpecificationSchema() ([]byte, error)
+}
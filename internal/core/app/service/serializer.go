@@ -0,0 +1,14 @@
+package service
+
+import (
+	"io"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+// SpecificationSerializer writes the specification back
+// to the source format, e.g. to export the specification.
+type SpecificationSerializer interface {
+	ContentType() string
+	SerializeSpecification(w io.Writer, spec *specification.Specification) error
+}
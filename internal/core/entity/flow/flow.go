@@ -30,6 +30,7 @@ type (
 		thesisSlug   string
 		state        State
 		occurredErrs []string
+		exchange     pipeline.HTTPExchange
 	}
 )
 
@@ -99,6 +100,12 @@ func (f *Flow) ApplyStep(step pipeline.Step) *Flow {
 
 		thesisStatus.state = thesisStatus.state.Next(step.Event())
 
+		status.state = nextScenarioState(status.state, step.Event())
+
+		if x := step.Exchange(); !x.IsZero() {
+			thesisStatus.exchange = x
+		}
+
 		if step.Err() != nil {
 			thesisStatus.occurredErrs = append(
 				thesisStatus.occurredErrs,
@@ -110,6 +117,19 @@ func (f *Flow) ApplyStep(step pipeline.Step) *Flow {
 	return f
 }
 
+// nextScenarioState reflects pause and resume of the thesis
+// in the state of the scenario to which the thesis belongs.
+func nextScenarioState(state State, thesisEvent pipeline.Event) State {
+	switch {
+	case state == Executing && thesisEvent == pipeline.FiredPause:
+		return Paused
+	case state == Paused && thesisEvent == pipeline.FiredExecute:
+		return Executing
+	}
+
+	return state
+}
+
 // NewStatus creates a progress representation of specification.Scenario.
 //
 // If the slug is not specification.ScenarioSlug, it panics with
@@ -176,6 +196,20 @@ func NewThesisStatus(slug string, state State, occurredErrs ...string) *ThesisSt
 	}
 }
 
+// NewThesisStatusWithExchange is similar to NewThesisStatus,
+// only it gets the HTTP exchange captured at the thesis.
+func NewThesisStatusWithExchange(
+	slug string,
+	state State,
+	exchange pipeline.HTTPExchange,
+	occurredErrs ...string,
+) *ThesisStatus {
+	status := NewThesisStatus(slug, state, occurredErrs...)
+	status.exchange = exchange
+
+	return status
+}
+
 func errsOrNil(errs []string) []string {
 	if len(errs) == 0 {
 		return nil
@@ -207,6 +241,13 @@ func (s *ThesisStatus) OccurredErrs() []string {
 	return occurredErrs
 }
 
+// Exchange returns the HTTP exchange of the last
+// executing of the thesis, it's zero if the thesis
+// isn't HTTP or nothing was sent.
+func (s *ThesisStatus) Exchange() pipeline.HTTPExchange {
+	return s.exchange
+}
+
 // Fulfill starts a new flow from pipeline.Pipeline.
 // The result of the function is a Flow, with which you can
 // collect the steps during pipeline execution.
@@ -292,6 +292,114 @@ func TestFulfilledFlow(t *testing.T) {
 			},
 			ExpectedOverallState: flow.NotExecuted,
 		},
+		{
+			FlowFactory: func() *flow.Flow {
+				spec := (&specification.Builder{}).
+					WithStory("foo", func(b *specification.StoryBuilder) {
+						b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+							b.WithThesis("baz", func(b *specification.ThesisBuilder) {})
+						})
+					}).
+					ErrlessBuild()
+
+				f := flow.Fulfill("gar", pipeline.Trigger("gla", spec))
+
+				return f.ApplyStep(pipeline.NewThesisStep(
+					specification.NewThesisSlug("foo", "bar", "baz"),
+					pipeline.HTTPExecutor,
+					pipeline.FiredPass,
+				).WithExchange(pipeline.HTTPExchange{
+					Method:       "GET",
+					URL:          "https://api.example.com/orders/1",
+					ResponseCode: 200,
+					ResponseBody: `{"id":1}`,
+				}))
+			},
+			ExpectedFlowID:     "gar",
+			ExpectedPipelineID: "gla",
+			ExpectedStatuses: []*flow.Status{
+				flow.NewStatus(
+					specification.NewScenarioSlug("foo", "bar"),
+					flow.NotExecuted,
+					flow.NewThesisStatusWithExchange("baz", flow.Passed, pipeline.HTTPExchange{
+						Method:       "GET",
+						URL:          "https://api.example.com/orders/1",
+						ResponseCode: 200,
+						ResponseBody: `{"id":1}`,
+					}),
+				),
+			},
+			ExpectedOverallState: flow.NotExecuted,
+		},
+		{
+			FlowFactory: func() *flow.Flow {
+				spec := (&specification.Builder{}).
+					WithStory("foo", func(b *specification.StoryBuilder) {
+						b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+							b.WithThesis("baz", func(b *specification.ThesisBuilder) {})
+						})
+					}).
+					ErrlessBuild()
+
+				return flow.Fulfill("par", pipeline.Trigger("pip", spec)).
+					ApplyStep(pipeline.NewScenarioStep(
+						specification.NewScenarioSlug("foo", "bar"),
+						pipeline.FiredExecute,
+					)).
+					ApplyStep(pipeline.NewThesisStep(
+						specification.NewThesisSlug("foo", "bar", "baz"),
+						pipeline.HTTPExecutor,
+						pipeline.FiredPause,
+					))
+			},
+			ExpectedFlowID:     "par",
+			ExpectedPipelineID: "pip",
+			ExpectedStatuses: []*flow.Status{
+				flow.NewStatus(
+					specification.NewScenarioSlug("foo", "bar"),
+					flow.Paused,
+					flow.NewThesisStatus("baz", flow.Paused),
+				),
+			},
+			ExpectedOverallState: flow.Paused,
+		},
+		{
+			FlowFactory: func() *flow.Flow {
+				spec := (&specification.Builder{}).
+					WithStory("foo", func(b *specification.StoryBuilder) {
+						b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+							b.WithThesis("baz", func(b *specification.ThesisBuilder) {})
+						})
+					}).
+					ErrlessBuild()
+
+				return flow.Fulfill("rep", pipeline.Trigger("pip", spec)).
+					ApplyStep(pipeline.NewScenarioStep(
+						specification.NewScenarioSlug("foo", "bar"),
+						pipeline.FiredExecute,
+					)).
+					ApplyStep(pipeline.NewThesisStep(
+						specification.NewThesisSlug("foo", "bar", "baz"),
+						pipeline.HTTPExecutor,
+						pipeline.FiredPause,
+					)).
+					ApplyStep(pipeline.NewThesisStep(
+						specification.NewThesisSlug("foo", "bar", "baz"),
+						pipeline.HTTPExecutor,
+						pipeline.FiredExecute,
+					))
+			},
+			ExpectedFlowID:     "rep",
+			ExpectedPipelineID: "pip",
+			ExpectedStatuses: []*flow.Status{
+				flow.NewStatus(
+					specification.NewScenarioSlug("foo", "bar"),
+					flow.Executing,
+					flow.NewThesisStatus("baz", flow.Executing),
+				),
+			},
+			ExpectedOverallState: flow.Executing,
+		},
 		{
 			FlowFactory: func() *flow.Flow {
 				spec := (&specification.Builder{}).
@@ -14,6 +14,7 @@ const (
 	Failed      State = "failed"
 	Crashed     State = "crashed"
 	Canceled    State = "canceled"
+	Paused      State = "paused"
 )
 
 type stateTransitionRules map[State]map[pipeline.Event]State
@@ -26,6 +27,14 @@ func rules() stateTransitionRules {
 			pipeline.FiredFail:    Failed,
 			pipeline.FiredCrash:   Crashed,
 			pipeline.FiredCancel:  Canceled,
+			pipeline.FiredPause:   Paused,
+		},
+		Paused: {
+			pipeline.FiredExecute: Executing,
+			pipeline.FiredPass:    Passed,
+			pipeline.FiredFail:    Failed,
+			pipeline.FiredCrash:   Crashed,
+			pipeline.FiredCancel:  Canceled,
 		},
 		Executing: {
 			pipeline.FiredPass:   Passed,
@@ -88,6 +97,8 @@ func (s State) Precedence() int {
 		return 5
 	case Executing:
 		return 6
+	case Paused:
+		return 7
 	default:
 		return 0
 	}
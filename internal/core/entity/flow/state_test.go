@@ -37,6 +37,36 @@ func TestNextState(t *testing.T) {
 			GivenEvent:    pipeline.FiredExecute,
 			ExpectedState: flow.Executing,
 		},
+		{
+			Name:          "not_executed-(pause)->paused",
+			GivenState:    flow.NotExecuted,
+			GivenEvent:    pipeline.FiredPause,
+			ExpectedState: flow.Paused,
+		},
+		{
+			Name:          "paused-(execute)->executing",
+			GivenState:    flow.Paused,
+			GivenEvent:    pipeline.FiredExecute,
+			ExpectedState: flow.Executing,
+		},
+		{
+			Name:          "paused-(cancel)->canceled",
+			GivenState:    flow.Paused,
+			GivenEvent:    pipeline.FiredCancel,
+			ExpectedState: flow.Canceled,
+		},
+		{
+			Name:          "paused-(pause)->paused",
+			GivenState:    flow.Paused,
+			GivenEvent:    pipeline.FiredPause,
+			ExpectedState: flow.Paused,
+		},
+		{
+			Name:          "executing-(pause)->executing",
+			GivenState:    flow.Executing,
+			GivenEvent:    pipeline.FiredPause,
+			ExpectedState: flow.Executing,
+		},
 		{
 			Name:          "not_executed-(pass)->passed",
 			GivenState:    flow.NotExecuted,
@@ -249,6 +279,7 @@ func TestStatePrecedenceOrder(t *testing.T) {
 		flow.Failed,
 		flow.Crashed,
 		flow.Canceled,
+		flow.Paused,
 		flow.NotExecuted,
 	}
 
@@ -262,6 +293,7 @@ func TestStatePrecedenceOrder(t *testing.T) {
 		flow.Failed,
 		flow.Crashed,
 		flow.Executing,
+		flow.Paused,
 	}
 
 	require.Equal(t, expectedStates, sortedStates)
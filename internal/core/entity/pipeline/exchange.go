@@ -0,0 +1,22 @@
+package pipeline
+
+// HTTPExchange is the HTTP request sent by the Executor
+// while executing the thesis and the response received
+// to it. Response fields are empty if the request failed.
+// It's kept to show what has actually happened, unlike
+// the request template and the expected response of
+// the specification.
+type HTTPExchange struct {
+	Method              string
+	URL                 string
+	RequestContentType  string
+	RequestBody         string
+	ResponseCode        int
+	ResponseContentType string
+	ResponseBody        string
+}
+
+// IsZero returns true if nothing was sent.
+func (x HTTPExchange) IsZero() bool {
+	return x == HTTPExchange{}
+}
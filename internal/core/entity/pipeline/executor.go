@@ -19,6 +19,7 @@ const (
 	FiredFail    Event = "fail"
 	FiredCrash   Event = "crash"
 	FiredCancel  Event = "cancel"
+	FiredPause   Event = "pause"
 )
 
 func (e Event) String() string {
@@ -50,8 +51,9 @@ type Executor interface {
 }
 
 type Result struct {
-	event Event
-	err   error
+	event    Event
+	err      error
+	exchange HTTPExchange
 }
 
 // Pass returns the passed Result.
@@ -139,6 +141,20 @@ func (r Result) Err() error {
 	return r.err
 }
 
+// WithExchange returns the Result with the HTTPExchange
+// captured while executing the thesis.
+func (r Result) WithExchange(x HTTPExchange) Result {
+	r.exchange = x
+
+	return r
+}
+
+// Exchange returns the captured HTTPExchange
+// of the Result if any.
+func (r Result) Exchange() HTTPExchange {
+	return r.exchange
+}
+
 // ExecutorFunc is an adapter
 // to allow the use of ordinary
 // functions as Executor.
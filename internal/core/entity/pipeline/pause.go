@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// pauseGate blocks theses before their executing
+// while the Pipeline is paused.
+type pauseGate struct {
+	mu      sync.Mutex
+	paused  bool
+	resumed chan struct{}
+}
+
+func newPauseGate() *pauseGate {
+	resumed := make(chan struct{})
+	close(resumed)
+
+	return &pauseGate{resumed: resumed}
+}
+
+func (g *pauseGate) pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused {
+		return
+	}
+
+	g.paused = true
+	g.resumed = make(chan struct{})
+}
+
+func (g *pauseGate) resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.paused {
+		return
+	}
+
+	g.paused = false
+	close(g.resumed)
+}
+
+func (g *pauseGate) isPaused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.paused
+}
+
+// wait blocks until the gate is resumed or the context is done.
+func (g *pauseGate) wait(ctx context.Context) error {
+	g.mu.Lock()
+	resumed := g.resumed
+	g.mu.Unlock()
+
+	select {
+	case <-resumed:
+		return nil
+	default:
+	}
+
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return WrapWithTerminatedError(ctx.Err(), FiredCancel)
+	}
+}
@@ -19,13 +19,17 @@ type (
 	// many times, but if the pipeline is running, it
 	// cannot be started until executing is over.
 	Pipeline struct {
-		id      string
-		ownerID string
-		spec    *specification.Specification
+		id             string
+		ownerID        string
+		testCampaignID string
+		spec           *specification.Specification
+		environment    string
+		scenarioFilter specification.ScenarioFilter
 
 		executors map[ExecutorType]Executor
 
 		state lockState
+		gate  *pauseGate
 	}
 
 	ExecutorRegistrar func(p *Pipeline)
@@ -61,12 +65,31 @@ func WithAssertion(executor Executor) ExecutorRegistrar {
 	}
 }
 
+// WithEnvironment makes the Pipeline run theses with variables
+// of the specification environment profile with given name.
+func WithEnvironment(name string) ExecutorRegistrar {
+	return func(p *Pipeline) {
+		p.environment = name
+	}
+}
+
+// WithScenarioFilter makes the Pipeline run
+// only scenarios selected by the filter.
+func WithScenarioFilter(filter specification.ScenarioFilter) ExecutorRegistrar {
+	return func(p *Pipeline) {
+		p.scenarioFilter = filter
+	}
+}
+
 type (
 	Params struct {
-		ID            string
-		Specification *specification.Specification
-		OwnerID       string
-		Started       bool
+		ID             string
+		Specification  *specification.Specification
+		OwnerID        string
+		TestCampaignID string
+		Environment    string
+		ScenarioFilter specification.ScenarioFilter
+		Started        bool
 	}
 )
 
@@ -82,11 +105,15 @@ const defaultExecutorsSize = 2
 // business code of domain and app layers.
 func Unmarshal(params Params, registrars ...ExecutorRegistrar) *Pipeline {
 	p := &Pipeline{
-		id:        params.ID,
-		ownerID:   params.OwnerID,
-		spec:      params.Specification,
-		executors: make(map[ExecutorType]Executor, defaultExecutorsSize),
-		state:     newLockState(params.Started),
+		id:             params.ID,
+		ownerID:        params.OwnerID,
+		testCampaignID: params.TestCampaignID,
+		spec:           params.Specification,
+		environment:    params.Environment,
+		scenarioFilter: params.ScenarioFilter,
+		executors:      make(map[ExecutorType]Executor, defaultExecutorsSize),
+		state:          newLockState(params.Started),
+		gate:           newPauseGate(),
 	}
 
 	p.applyOpts(registrars)
@@ -107,22 +134,26 @@ func newLockState(started bool) lockState {
 //
 // Trigger receives options that you're
 // free to pass or not. You can pass:
-// WithHTTP, WithAssertion.
+// WithHTTP, WithAssertion, WithEnvironment,
+// WithScenarioFilter.
 func Trigger(
 	id string,
 	spec *specification.Specification,
 	registrars ...ExecutorRegistrar,
 ) *Pipeline {
 	p := &Pipeline{
-		id:        id,
-		ownerID:   "",
-		spec:      spec,
-		executors: make(map[ExecutorType]Executor, defaultExecutorsSize),
-		state:     unlocked,
+		id:             id,
+		ownerID:        "",
+		testCampaignID: "",
+		spec:           spec,
+		executors:      make(map[ExecutorType]Executor, defaultExecutorsSize),
+		state:          unlocked,
+		gate:           newPauseGate(),
 	}
 
 	if spec != nil {
 		p.ownerID = spec.OwnerID()
+		p.testCampaignID = spec.TestCampaignID()
 	}
 
 	p.applyOpts(registrars)
@@ -146,6 +177,12 @@ func (p *Pipeline) OwnerID() string {
 	return p.ownerID
 }
 
+// TestCampaignID returns the identifier of the test campaign
+// to which the Pipeline belongs.
+func (p *Pipeline) TestCampaignID() string {
+	return p.testCampaignID
+}
+
 // SpecificationID returns the identifier of the Specification,
 // if it isn't nil, else returns empty string.
 func (p *Pipeline) SpecificationID() string {
@@ -156,19 +193,31 @@ func (p *Pipeline) SpecificationID() string {
 	return p.spec.ID()
 }
 
+// Environment returns the name of the specification environment
+// profile the Pipeline runs with, empty if there is no profile.
+func (p *Pipeline) Environment() string {
+	return p.environment
+}
+
+// ScenarioFilter returns the filter of scenarios
+// the Pipeline runs, empty filter selects all.
+func (p *Pipeline) ScenarioFilter() specification.ScenarioFilter {
+	return p.scenarioFilter
+}
+
 // Started indicates whether the Pipeline is running.
 func (p *Pipeline) Started() bool {
 	return atomic.LoadUint32(&p.state) == locked
 }
 
 // WorkingScenarios returns the specification scenarios
-// that the Pipeline will run.
+// selected by the scenario filter that the Pipeline will run.
 func (p *Pipeline) WorkingScenarios() []specification.Scenario {
 	if p.spec == nil {
 		return nil
 	}
 
-	return p.spec.Scenarios()
+	return p.spec.FilteredScenarios(p.scenarioFilter)
 }
 
 // ShouldBeStarted returns ErrNotStarted if
@@ -181,6 +230,39 @@ func (p *Pipeline) ShouldBeStarted() error {
 	return ErrNotStarted
 }
 
+// Paused indicates whether the Pipeline is paused.
+func (p *Pipeline) Paused() bool {
+	return p.gate.isPaused()
+}
+
+// Pause makes the started Pipeline wait before executing
+// of each next thesis until Resume is called. Already
+// executing theses are not interrupted.
+//
+// Pause returns ErrNotStarted if the Pipeline is not started.
+func (p *Pipeline) Pause() error {
+	if err := p.ShouldBeStarted(); err != nil {
+		return err
+	}
+
+	p.gate.pause()
+
+	return nil
+}
+
+// Resume continues executing of the paused Pipeline.
+//
+// Resume returns ErrNotStarted if the Pipeline is not started.
+func (p *Pipeline) Resume() error {
+	if err := p.ShouldBeStarted(); err != nil {
+		return err
+	}
+
+	p.gate.resume()
+
+	return nil
+}
+
 // Start asynchronously starts executing of the Pipeline.
 // Start returns non buffered chan of flow Step's. With Step's you can
 // build Flow using flow.Reducer.
@@ -224,6 +306,7 @@ func (p *Pipeline) unlock() {
 func (p *Pipeline) run(ctx context.Context, steps chan<- Step) {
 	defer close(steps)
 	defer p.unlock()
+	defer p.gate.resume()
 
 	p.runScenarios(ctx, steps)
 }
@@ -246,6 +329,10 @@ func (p *Pipeline) runScenarios(ctx context.Context, steps chan<- Step) {
 
 const defaultEnvStoreInitialSize = 10
 
+// EnvironmentKey is the key of the environment store under which
+// variables of the Pipeline environment profile are stored.
+const EnvironmentKey = "env"
+
 func (p *Pipeline) runScenario(
 	ctx context.Context,
 	steps chan<- Step,
@@ -258,6 +345,8 @@ func (p *Pipeline) runScenario(
 		sg  = SyncDependencies(scenario)
 	)
 
+	p.storeEnvironmentVariables(env)
+
 	steps <- NewScenarioStep(scenario.Slug(), FiredExecute)
 
 	for _, thesis := range scenario.Theses() {
@@ -281,6 +370,26 @@ func (p *Pipeline) runScenario(
 	steps <- NewScenarioStep(scenario.Slug(), FiredPass)
 }
 
+func (p *Pipeline) storeEnvironmentVariables(env *Environment) {
+	if p.spec == nil || p.environment == "" {
+		return
+	}
+
+	profile, ok := p.spec.Environment(p.environment)
+	if !ok {
+		return
+	}
+
+	variables := profile.Variables()
+	values := make(map[string]interface{}, len(variables))
+
+	for k, v := range variables {
+		values[k] = v
+	}
+
+	env.Store(EnvironmentKey, values)
+}
+
 func (p *Pipeline) runThesisFn(
 	ctx context.Context,
 	steps chan<- Step,
@@ -307,15 +416,43 @@ func (p *Pipeline) runThesis(
 
 	pt := executorType(thesis)
 
+	if err := p.waitResume(ctx, steps, thesis.Slug(), pt); err != nil {
+		return err
+	}
+
 	steps <- NewThesisStep(thesis.Slug(), pt, FiredExecute)
 
 	result := p.executeThesis(ctx, env, thesis)
 
-	steps <- NewThesisStepWithErr(result.err, thesis.Slug(), pt, result.event)
+	steps <- NewThesisStepWithErr(result.err, thesis.Slug(), pt, result.event).
+		WithExchange(result.exchange)
 
 	return result.err
 }
 
+// waitResume blocks the thesis while the Pipeline is paused.
+// The thesis paused before executing is canceled with the context.
+func (p *Pipeline) waitResume(
+	ctx context.Context,
+	steps chan<- Step,
+	slug specification.Slug,
+	pt ExecutorType,
+) error {
+	if !p.gate.isPaused() {
+		return nil
+	}
+
+	steps <- NewThesisStep(slug, pt, FiredPause)
+
+	if err := p.gate.wait(ctx); err != nil {
+		steps <- NewThesisStepWithErr(err, slug, pt, FiredCancel)
+
+		return err
+	}
+
+	return nil
+}
+
 func (p *Pipeline) executeThesis(
 	ctx context.Context,
 	env *Environment,
@@ -20,6 +20,7 @@ func TestPipeline(t *testing.T) {
 		Pipeline                 *pipeline.Pipeline
 		ExpectedID               string
 		ExpectedSpecificationID  string
+		ExpectedTestCampaignID   string
 		ExpectedOwnerID          string
 		ExpectedStarted          bool
 		ExpectedWorkingScenarios []specification.Scenario
@@ -61,6 +62,7 @@ func TestPipeline(t *testing.T) {
 				(&specification.Builder{}).
 					WithID("bar").
 					WithOwnerID("baz").
+					WithTestCampaignID("tcc").
 					WithStory("moo", func(b *specification.StoryBuilder) {
 						b.WithScenario("koo", func(b *specification.ScenarioBuilder) {
 							b.WithThesis("too", func(b *specification.ThesisBuilder) {})
@@ -70,6 +72,7 @@ func TestPipeline(t *testing.T) {
 			),
 			ExpectedID:              "foo",
 			ExpectedSpecificationID: "bar",
+			ExpectedTestCampaignID:  "tcc",
 			ExpectedOwnerID:         "baz",
 			ExpectedStarted:         false,
 			ExpectedWorkingScenarios: []specification.Scenario{
@@ -92,11 +95,13 @@ func TestPipeline(t *testing.T) {
 						})
 					}).
 					ErrlessBuild(),
-				OwnerID: "djr",
-				Started: true,
+				OwnerID:        "djr",
+				TestCampaignID: "cmp",
+				Started:        true,
 			}),
 			ExpectedID:              "foo",
 			ExpectedSpecificationID: "spc",
+			ExpectedTestCampaignID:  "cmp",
 			ExpectedOwnerID:         "djr",
 			ExpectedStarted:         true,
 			ExpectedWorkingScenarios: []specification.Scenario{
@@ -124,6 +129,10 @@ func TestPipeline(t *testing.T) {
 				require.Equal(t, c.ExpectedSpecificationID, c.Pipeline.SpecificationID())
 			})
 
+			t.Run("test_campaign_id", func(t *testing.T) {
+				require.Equal(t, c.ExpectedTestCampaignID, c.Pipeline.TestCampaignID())
+			})
+
 			t.Run("owner_id", func(t *testing.T) {
 				require.Equal(t, c.ExpectedOwnerID, c.Pipeline.OwnerID())
 			})
@@ -500,6 +509,106 @@ func TestStartPipeline(t *testing.T) {
 	}
 }
 
+func TestStartPipelineWithExchange(t *testing.T) {
+	t.Parallel()
+
+	exchange := pipeline.HTTPExchange{
+		Method:       "GET",
+		URL:          "https://some-url.com",
+		ResponseCode: 200,
+	}
+
+	pipe := pipeline.Trigger(
+		"exc",
+		(&specification.Builder{}).
+			WithStory("foo", func(b *specification.StoryBuilder) {
+				b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+					b.WithThesis("baz", func(b *specification.ThesisBuilder) {
+						b.WithHTTP(func(b *specification.HTTPBuilder) {
+							b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+								b.WithMethod(specification.GET)
+								b.WithURL("https://some-url.com")
+							})
+						})
+					})
+				})
+			}).
+			ErrlessBuild(),
+		pipeline.WithHTTP(pipeline.ExecutorFunc(func(
+			_ context.Context,
+			_ *pipeline.Environment,
+			_ specification.Thesis,
+		) pipeline.Result {
+			return pipeline.Pass().WithExchange(exchange)
+		})),
+	)
+
+	var exchanges []pipeline.HTTPExchange
+
+	for step := range pipe.MustStart(context.Background()) {
+		if step.Event() == pipeline.FiredPass && step.Slug().Kind() == specification.ThesisSlug {
+			exchanges = append(exchanges, step.Exchange())
+		}
+	}
+
+	require.Equal(t, []pipeline.HTTPExchange{exchange}, exchanges)
+}
+
+func TestStartPipelineWithEnvironmentAndScenarioFilter(t *testing.T) {
+	t.Parallel()
+
+	httpThesis := func(b *specification.ThesisBuilder) {
+		b.WithHTTP(func(b *specification.HTTPBuilder) {
+			b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+				b.WithMethod(specification.GET)
+				b.WithURL("{{env.baseUrl}}/products")
+			})
+		})
+	}
+
+	loaded := make(chan interface{}, 1)
+
+	pipe := pipeline.Trigger(
+		"env",
+		(&specification.Builder{}).
+			WithEnvironment("staging", map[string]string{"baseUrl": "https://staging.com"}).
+			WithStory("foo", func(b *specification.StoryBuilder) {
+				b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+					b.WithThesis("baz", httpThesis)
+				})
+				b.WithScenario("qux", func(b *specification.ScenarioBuilder) {
+					b.WithThesis("baz", httpThesis)
+				})
+			}).
+			ErrlessBuild(),
+		pipeline.WithEnvironment("staging"),
+		pipeline.WithScenarioFilter("foo.bar"),
+		pipeline.WithHTTP(pipeline.ExecutorFunc(func(
+			_ context.Context,
+			env *pipeline.Environment,
+			_ specification.Thesis,
+		) pipeline.Result {
+			variables, _ := env.Load(pipeline.EnvironmentKey)
+			loaded <- variables
+
+			return pipeline.Pass()
+		})),
+	)
+
+	require.Equal(t, "staging", pipe.Environment())
+	require.Equal(t, specification.ScenarioFilter("foo.bar"), pipe.ScenarioFilter())
+	require.Len(t, pipe.WorkingScenarios(), 1)
+
+	requireStepsMatch(t, []pipeline.Step{
+		pipeline.NewScenarioStep(specification.NewScenarioSlug("foo", "bar"), pipeline.FiredExecute),
+		pipeline.NewThesisStep(specification.NewThesisSlug("foo", "bar", "baz"), pipeline.HTTPExecutor, pipeline.FiredExecute),
+		pipeline.NewThesisStep(specification.NewThesisSlug("foo", "bar", "baz"), pipeline.HTTPExecutor, pipeline.FiredPass),
+		pipeline.NewScenarioStep(specification.NewScenarioSlug("foo", "bar"), pipeline.FiredPass),
+	}, pipe.MustStart(context.Background()))
+
+	require.Equal(t, map[string]interface{}{"baseUrl": "https://staging.com"}, <-loaded)
+}
+
 func TestOneExecutingAtATime(t *testing.T) {
 	t.Parallel()
 
@@ -657,6 +766,113 @@ func TestCancelPipelineContext(t *testing.T) {
 	}
 }
 
+func TestPausePipelineNotStarted(t *testing.T) {
+	t.Parallel()
+
+	pipe := pipeline.Trigger("foo", validSpecification(t))
+
+	require.ErrorIs(t, pipe.Pause(), pipeline.ErrNotStarted)
+	require.ErrorIs(t, pipe.Resume(), pipeline.ErrNotStarted)
+	require.False(t, pipe.Paused())
+}
+
+func TestPauseAndResumePipeline(t *testing.T) {
+	t.Parallel()
+
+	spec := (&specification.Builder{}).
+		WithStory("foo", func(b *specification.StoryBuilder) {
+			b.WithScenario("bar", func(b *specification.ScenarioBuilder) {
+				b.WithThesis("saz", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Given, "saz")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.POST)
+							b.WithURL("https://preparing.net")
+						})
+					})
+				})
+				b.WithThesis("faz", func(b *specification.ThesisBuilder) {
+					b.WithDependency("saz")
+					b.WithStatement(specification.When, "faz")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.GET)
+							b.WithURL("https://testing.net")
+						})
+					})
+				})
+			})
+		}).
+		ErrlessBuild()
+
+	var (
+		executed = make(chan specification.Slug, 2)
+		release  = make(chan struct{})
+	)
+
+	pipe := pipeline.Trigger(
+		"foo",
+		spec,
+		pipeline.WithHTTP(pipeline.ExecutorFunc(func(
+			ctx context.Context,
+			_ *pipeline.Environment,
+			thesis specification.Thesis,
+		) pipeline.Result {
+			executed <- thesis.Slug()
+
+			if thesis.Slug().Thesis() == "saz" {
+				<-release
+			}
+
+			return pipeline.Pass()
+		})),
+	)
+
+	steps, err := pipe.Start(context.Background())
+	require.NoError(t, err)
+
+	collected := make(chan []pipeline.Step)
+
+	go func() {
+		var all []pipeline.Step
+
+		for s := range steps {
+			all = append(all, s)
+		}
+
+		collected <- all
+	}()
+
+	require.Equal(t, specification.NewThesisSlug("foo", "bar", "saz"), <-executed)
+
+	require.NoError(t, pipe.Pause())
+	require.True(t, pipe.Paused())
+
+	close(release)
+
+	select {
+	case slug := <-executed:
+		require.Failf(t, "Thesis executed while pipeline is paused", "%s", slug)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, pipe.Resume())
+	require.False(t, pipe.Paused())
+
+	select {
+	case slug := <-executed:
+		require.Equal(t, specification.NewThesisSlug("foo", "bar", "faz"), slug)
+	case <-time.After(time.Second):
+		require.Fail(t, "Thesis is not executed after resume")
+	}
+
+	require.Contains(t, <-collected, pipeline.NewThesisStep(
+		specification.NewThesisSlug("foo", "bar", "faz"),
+		pipeline.HTTPExecutor,
+		pipeline.FiredPause,
+	))
+}
+
 var errTest = errors.New("test")
 
 func TestIsWrappedInTerminatedError(t *testing.T) {
@@ -15,6 +15,7 @@ type Step struct {
 	executorType ExecutorType
 	event        Event
 	err          error
+	exchange     HTTPExchange
 }
 
 // NewScenarioStep returns a Step for the scenario,
@@ -98,6 +99,20 @@ func (s Step) Err() error {
 	return s.err
 }
 
+// WithExchange returns the Step with the HTTPExchange
+// captured while executing the thesis.
+func (s Step) WithExchange(x HTTPExchange) Step {
+	s.exchange = x
+
+	return s
+}
+
+// Exchange returns the HTTPExchange captured
+// at the Step if any.
+func (s Step) Exchange() HTTPExchange {
+	return s.exchange
+}
+
 func (s Step) String() string {
 	var b strings.Builder
 
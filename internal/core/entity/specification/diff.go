@@ -0,0 +1,244 @@
+package specification
+
+import (
+	"reflect"
+	"sort"
+)
+
+type (
+	// Diff is a structural difference between two specifications.
+	// Stories, scenarios and theses are matched by their slugs.
+	Diff struct {
+		changes []Change
+	}
+
+	// Change describes added, removed or modified story,
+	// scenario or thesis. Added and removed elements are
+	// reported without their nested elements.
+	Change struct {
+		slug   Slug
+		kind   ChangeKind
+		fields []FieldChange
+	}
+
+	// FieldChange describes modified field of the story,
+	// scenario or thesis with its values before and after.
+	FieldChange struct {
+		field string
+		from  interface{}
+		to    interface{}
+	}
+
+	ChangeKind string
+)
+
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "modified"
+)
+
+// Compare returns structural difference that turns
+// the from specification into the to specification.
+func Compare(from, to *Specification) Diff {
+	var d Diff
+
+	d.compareStories(from.stories, to.stories)
+
+	sort.Slice(d.changes, func(i, j int) bool {
+		return d.changes[i].slug.String() < d.changes[j].slug.String()
+	})
+
+	return d
+}
+
+func (d Diff) Changes() []Change {
+	changes := make([]Change, len(d.changes))
+	copy(changes, d.changes)
+
+	return changes
+}
+
+func (d Diff) IsZero() bool {
+	return len(d.changes) == 0
+}
+
+func (c Change) Slug() Slug {
+	return c.slug
+}
+
+func (c Change) Kind() ChangeKind {
+	return c.kind
+}
+
+func (c Change) Fields() []FieldChange {
+	fields := make([]FieldChange, len(c.fields))
+	copy(fields, c.fields)
+
+	return fields
+}
+
+func (f FieldChange) Field() string {
+	return f.field
+}
+
+func (f FieldChange) From() interface{} {
+	return f.from
+}
+
+func (f FieldChange) To() interface{} {
+	return f.to
+}
+
+func (k ChangeKind) String() string {
+	return string(k)
+}
+
+func (d *Diff) compareStories(from, to map[string]Story) {
+	for slug, fromStory := range from {
+		toStory, ok := to[slug]
+		if !ok {
+			d.add(fromStory.slug, Removed, nil)
+
+			continue
+		}
+
+		d.add(fromStory.slug, Modified, compareStoryFields(fromStory, toStory))
+		d.compareScenarios(fromStory.scenarios, toStory.scenarios)
+	}
+
+	for slug, toStory := range to {
+		if _, ok := from[slug]; !ok {
+			d.add(toStory.slug, Added, nil)
+		}
+	}
+}
+
+func (d *Diff) compareScenarios(from, to map[string]Scenario) {
+	for slug, fromScenario := range from {
+		toScenario, ok := to[slug]
+		if !ok {
+			d.add(fromScenario.slug, Removed, nil)
+
+			continue
+		}
+
+		d.add(fromScenario.slug, Modified, compareScenarioFields(fromScenario, toScenario))
+		d.compareTheses(fromScenario.theses, toScenario.theses)
+	}
+
+	for slug, toScenario := range to {
+		if _, ok := from[slug]; !ok {
+			d.add(toScenario.slug, Added, nil)
+		}
+	}
+}
+
+func (d *Diff) compareTheses(from, to map[string]Thesis) {
+	for slug, fromThesis := range from {
+		toThesis, ok := to[slug]
+		if !ok {
+			d.add(fromThesis.slug, Removed, nil)
+
+			continue
+		}
+
+		d.add(fromThesis.slug, Modified, compareThesisFields(fromThesis, toThesis))
+	}
+
+	for slug, toThesis := range to {
+		if _, ok := from[slug]; !ok {
+			d.add(toThesis.slug, Added, nil)
+		}
+	}
+}
+
+// add appends change to the diff. Modified change
+// without modified fields is skipped.
+func (d *Diff) add(slug Slug, kind ChangeKind, fields []FieldChange) {
+	if kind == Modified && len(fields) == 0 {
+		return
+	}
+
+	d.changes = append(d.changes, Change{
+		slug:   slug,
+		kind:   kind,
+		fields: fields,
+	})
+}
+
+type fieldComparator []FieldChange
+
+func (c *fieldComparator) compare(field string, from, to interface{}) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+
+	*c = append(*c, FieldChange{
+		field: field,
+		from:  from,
+		to:    to,
+	})
+}
+
+func compareStoryFields(from, to Story) []FieldChange {
+	var c fieldComparator
+
+	c.compare("description", from.description, to.description)
+	c.compare("asA", from.asA, to.asA)
+	c.compare("inOrderTo", from.inOrderTo, to.inOrderTo)
+	c.compare("wantTo", from.wantTo, to.wantTo)
+
+	return c
+}
+
+func compareScenarioFields(from, to Scenario) []FieldChange {
+	var c fieldComparator
+
+	c.compare("description", from.description, to.description)
+
+	return c
+}
+
+func compareThesisFields(from, to Thesis) []FieldChange {
+	var c fieldComparator
+
+	c.compare("after", dependencyStrings(from.dependencies), dependencyStrings(to.dependencies))
+	c.compare("stage", from.stage, to.stage)
+	c.compare("behavior", from.behavior, to.behavior)
+
+	var (
+		fromReq = from.http.request
+		toReq   = to.http.request
+	)
+
+	c.compare("http.request.method", fromReq.method, toReq.method)
+	c.compare("http.request.url", fromReq.url, toReq.url)
+	c.compare("http.request.contentType", fromReq.contentType, toReq.contentType)
+	c.compare("http.request.body", fromReq.body, toReq.body)
+
+	var (
+		fromResp = from.http.response
+		toResp   = to.http.response
+	)
+
+	c.compare("http.response.allowedCodes", fromResp.allowedCodes, toResp.allowedCodes)
+	c.compare("http.response.allowedContentType", fromResp.allowedContentType, toResp.allowedContentType)
+
+	c.compare("assertion.method", from.assertion.method, to.assertion.method)
+	c.compare("assertion.asserts", from.assertion.asserts, to.assertion.asserts)
+
+	return c
+}
+
+func dependencyStrings(deps map[Slug]bool) []string {
+	strs := make([]string, 0, len(deps))
+
+	for dep := range deps {
+		strs = append(strs, dep.Partial())
+	}
+
+	sort.Strings(strs)
+
+	return strs
+}
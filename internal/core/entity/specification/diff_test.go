@@ -0,0 +1,221 @@
+package specification_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+type expectedChange struct {
+	Slug   specification.Slug
+	Kind   specification.ChangeKind
+	Fields map[string][2]interface{}
+}
+
+func TestCompareSpecifications(t *testing.T) {
+	t.Parallel()
+
+	baseThesis := func(b *specification.ThesisBuilder) {
+		b.WithStatement(specification.When, "create order")
+		b.WithHTTP(func(b *specification.HTTPBuilder) {
+			b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+				b.WithMethod(specification.POST)
+				b.WithURL("https://api.shop/v1/orders")
+				b.WithContentType(specification.ApplicationJSON)
+				b.WithBody(map[string]interface{}{"count": 1})
+			})
+			b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+				b.WithAllowedCodes([]int{201})
+				b.WithAllowedContentType(specification.ApplicationJSON)
+			})
+		})
+	}
+
+	baseSpec := func(b *specification.Builder) {
+		b.WithStory("order", func(b *specification.StoryBuilder) {
+			b.WithDescription("ordering")
+			b.WithAsA("customer")
+			b.WithScenario("create", func(b *specification.ScenarioBuilder) {
+				b.WithDescription("create order")
+				b.WithThesis("createOrder", baseThesis)
+				b.WithThesis("checkOrder", func(b *specification.ThesisBuilder) {
+					b.WithDependency("createOrder")
+					b.WithStatement(specification.Then, "order is created")
+					b.WithAssertion(func(b *specification.AssertionBuilder) {
+						b.WithMethod(specification.JSONPath)
+						b.WithAssert("getOrder.response.body.count", 1)
+					})
+				})
+			})
+		})
+	}
+
+	testCases := []struct {
+		Name            string
+		From            func(b *specification.Builder)
+		To              func(b *specification.Builder)
+		ExpectedChanges []expectedChange
+	}{
+		{
+			Name:            "equal_specifications",
+			From:            baseSpec,
+			To:              baseSpec,
+			ExpectedChanges: nil,
+		},
+		{
+			Name: "added_and_removed_stories",
+			From: baseSpec,
+			To: func(b *specification.Builder) {
+				b.WithStory("payment", func(b *specification.StoryBuilder) {
+					b.WithScenario("pay", func(b *specification.ScenarioBuilder) {
+						b.WithThesis("payOrder", baseThesis)
+					})
+				})
+			},
+			ExpectedChanges: []expectedChange{
+				{
+					Slug: specification.NewStorySlug("order"),
+					Kind: specification.Removed,
+				},
+				{
+					Slug: specification.NewStorySlug("payment"),
+					Kind: specification.Added,
+				},
+			},
+		},
+		{
+			Name: "modified_story_and_added_scenario",
+			From: baseSpec,
+			To: func(b *specification.Builder) {
+				b.WithStory("order", func(b *specification.StoryBuilder) {
+					b.WithDescription("ordering goods")
+					b.WithAsA("customer")
+					b.WithScenario("create", func(b *specification.ScenarioBuilder) {
+						b.WithDescription("create order")
+						b.WithThesis("createOrder", baseThesis)
+						b.WithThesis("checkOrder", func(b *specification.ThesisBuilder) {
+							b.WithDependency("createOrder")
+							b.WithStatement(specification.Then, "order is created")
+							b.WithAssertion(func(b *specification.AssertionBuilder) {
+								b.WithMethod(specification.JSONPath)
+								b.WithAssert("getOrder.response.body.count", 1)
+							})
+						})
+					})
+					b.WithScenario("cancel", func(b *specification.ScenarioBuilder) {
+						b.WithThesis("cancelOrder", baseThesis)
+					})
+				})
+			},
+			ExpectedChanges: []expectedChange{
+				{
+					Slug: specification.NewStorySlug("order"),
+					Kind: specification.Modified,
+					Fields: map[string][2]interface{}{
+						"description": {"ordering", "ordering goods"},
+					},
+				},
+				{
+					Slug: specification.NewScenarioSlug("order", "cancel"),
+					Kind: specification.Added,
+				},
+			},
+		},
+		{
+			Name: "modified_thesis_http_and_assertion",
+			From: baseSpec,
+			To: func(b *specification.Builder) {
+				b.WithStory("order", func(b *specification.StoryBuilder) {
+					b.WithDescription("ordering")
+					b.WithAsA("customer")
+					b.WithScenario("create", func(b *specification.ScenarioBuilder) {
+						b.WithDescription("create order")
+						b.WithThesis("createOrder", func(b *specification.ThesisBuilder) {
+							b.WithStatement(specification.When, "create order")
+							b.WithHTTP(func(b *specification.HTTPBuilder) {
+								b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+									b.WithMethod(specification.PUT)
+									b.WithURL("https://api.shop/v2/orders")
+									b.WithContentType(specification.ApplicationJSON)
+									b.WithBody(map[string]interface{}{"count": 1})
+								})
+								b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+									b.WithAllowedCodes([]int{200, 201})
+									b.WithAllowedContentType(specification.ApplicationJSON)
+								})
+							})
+						})
+						b.WithThesis("checkOrder", func(b *specification.ThesisBuilder) {
+							b.WithDependency("createOrder")
+							b.WithStatement(specification.Then, "order is created")
+							b.WithAssertion(func(b *specification.AssertionBuilder) {
+								b.WithMethod(specification.JSONPath)
+								b.WithAssert("getOrder.response.body.count", 2)
+							})
+						})
+					})
+				})
+			},
+			ExpectedChanges: []expectedChange{
+				{
+					Slug: specification.NewThesisSlug("order", "create", "checkOrder"),
+					Kind: specification.Modified,
+					Fields: map[string][2]interface{}{
+						"assertion.asserts": {
+							[]specification.Assert{specification.NewAssert("getOrder.response.body.count", 1)},
+							[]specification.Assert{specification.NewAssert("getOrder.response.body.count", 2)},
+						},
+					},
+				},
+				{
+					Slug: specification.NewThesisSlug("order", "create", "createOrder"),
+					Kind: specification.Modified,
+					Fields: map[string][2]interface{}{
+						"http.request.method":        {specification.POST, specification.PUT},
+						"http.request.url":           {"https://api.shop/v1/orders", "https://api.shop/v2/orders"},
+						"http.response.allowedCodes": {[]int{201}, []int{200, 201}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				from = errlessBuildSpec(t, c.From)
+				to   = errlessBuildSpec(t, c.To)
+			)
+
+			diff := specification.Compare(from, to)
+
+			require.Equal(t, len(c.ExpectedChanges) == 0, diff.IsZero())
+
+			changes := diff.Changes()
+			require.Len(t, changes, len(c.ExpectedChanges))
+
+			for i, expected := range c.ExpectedChanges {
+				actual := changes[i]
+
+				require.Equal(t, expected.Slug, actual.Slug())
+				require.Equal(t, expected.Kind, actual.Kind())
+
+				fields := actual.Fields()
+				require.Len(t, fields, len(expected.Fields))
+
+				for _, f := range fields {
+					values, ok := expected.Fields[f.Field()]
+					require.Truef(t, ok, "unexpected field %s", f.Field())
+					require.Equal(t, values[0], f.From())
+					require.Equal(t, values[1], f.To())
+				}
+			}
+		})
+	}
+}
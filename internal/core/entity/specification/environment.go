@@ -0,0 +1,97 @@
+package specification
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Environment is a named profile of variables the specification
+// can be run with, e.g. base URL of the staging server. Variables
+// of the profile the pipeline is started with are available to
+// theses as {{env.<variable>}} templates.
+type Environment struct {
+	name      string
+	variables map[string]string
+}
+
+var (
+	ErrUnknownEnvironment   = errors.New("unknown environment")
+	ErrEmptyEnvironmentName = errors.New("empty environment name")
+)
+
+func (e Environment) Name() string {
+	return e.name
+}
+
+// Variables returns copy of the environment variables.
+func (e Environment) Variables() map[string]string {
+	variables := make(map[string]string, len(e.variables))
+
+	for k, v := range e.variables {
+		variables[k] = v
+	}
+
+	return variables
+}
+
+func (e Environment) IsZero() bool {
+	return e.name == "" && len(e.variables) == 0
+}
+
+// Environment returns the environment profile by its name.
+func (s *Specification) Environment(name string) (env Environment, ok bool) {
+	env, ok = s.environments[name]
+
+	return
+}
+
+// Environments returns the environment profiles sorted by name.
+func (s *Specification) Environments() []Environment {
+	envs := make([]Environment, 0, len(s.environments))
+
+	for _, env := range s.environments {
+		envs = append(envs, env)
+	}
+
+	sort.Slice(envs, func(i, j int) bool {
+		return envs[i].name < envs[j].name
+	})
+
+	return envs
+}
+
+// WithEnvironment adds the environment profile
+// with the given variables to the specification.
+func (b *Builder) WithEnvironment(name string, variables map[string]string) *Builder {
+	if b.environments == nil {
+		b.environments = make(map[string]Environment)
+	}
+
+	env := Environment{
+		name:      name,
+		variables: make(map[string]string, len(variables)),
+	}
+
+	for k, v := range variables {
+		env.variables[k] = v
+	}
+
+	b.environments[name] = env
+
+	return b
+}
+
+func environmentsOrNil(envs map[string]Environment) map[string]Environment {
+	if len(envs) == 0 {
+		return nil
+	}
+
+	copied := make(map[string]Environment, len(envs))
+
+	for name, env := range envs {
+		copied[name] = env
+	}
+
+	return copied
+}
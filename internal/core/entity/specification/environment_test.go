@@ -0,0 +1,48 @@
+package specification_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestBuildSpecificationWithEnvironments(t *testing.T) {
+	t.Parallel()
+
+	variables := map[string]string{"baseUrl": "https://staging.example.com"}
+
+	spec := errlessBuildSpec(t, func(b *specification.Builder) {
+		b.WithEnvironment("staging", variables)
+		b.WithEnvironment("prod", map[string]string{"baseUrl": "https://example.com"})
+	})
+
+	variables["baseUrl"] = "changed"
+
+	staging, ok := spec.Environment("staging")
+	require.True(t, ok)
+	require.Equal(t, "staging", staging.Name())
+	require.Equal(t, map[string]string{"baseUrl": "https://staging.example.com"}, staging.Variables())
+
+	_, ok = spec.Environment("dev")
+	require.False(t, ok)
+
+	envs := spec.Environments()
+	require.Len(t, envs, 2)
+	require.Equal(t, "prod", envs[0].Name())
+	require.Equal(t, "staging", envs[1].Name())
+}
+
+func TestValidateSpecificationEnvironments(t *testing.T) {
+	t.Parallel()
+
+	var b specification.Builder
+
+	b.WithEnvironment("", map[string]string{"baseUrl": "https://example.com"})
+
+	_, err := b.Build()
+
+	require.True(t, errors.Is(err, specification.ErrEmptyEnvironmentName))
+}
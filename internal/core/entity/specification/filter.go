@@ -0,0 +1,99 @@
+package specification
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ScenarioFilter selects scenarios of the specification by
+// comma-separated patterns. Pattern without a dot matches the
+// whole story, e.g. "checkout", pattern with a dot matches the
+// scenarios of the story, e.g. "checkout.pay*". Patterns use
+// path.Match syntax. Empty filter matches every scenario.
+type ScenarioFilter string
+
+const (
+	filterPatternSeparator = ","
+	filterSlugSeparator    = "."
+)
+
+var (
+	ErrInvalidScenarioFilter = errors.New("invalid scenario filter")
+	ErrNoFilteredScenarios   = errors.New("no scenarios match the filter")
+)
+
+func (f ScenarioFilter) String() string {
+	return string(f)
+}
+
+func (f ScenarioFilter) IsZero() bool {
+	return strings.TrimSpace(string(f)) == ""
+}
+
+// Validate returns ErrInvalidScenarioFilter
+// if some pattern of the filter is malformed.
+func (f ScenarioFilter) Validate() error {
+	if f.IsZero() {
+		return nil
+	}
+
+	for _, pattern := range f.patterns() {
+		if pattern == "" {
+			return errors.Wrap(ErrInvalidScenarioFilter, "empty pattern")
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(ErrInvalidScenarioFilter, "%s: %v", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether the scenario with
+// the given slug is selected by the filter.
+func (f ScenarioFilter) Matches(slug Slug) bool {
+	if f.IsZero() {
+		return true
+	}
+
+	scenario := slug.Story() + filterSlugSeparator + slug.Scenario()
+
+	for _, pattern := range f.patterns() {
+		target := scenario
+		if !strings.Contains(pattern, filterSlugSeparator) {
+			target = slug.Story()
+		}
+
+		if ok, err := path.Match(pattern, target); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f ScenarioFilter) patterns() []string {
+	patterns := strings.Split(string(f), filterPatternSeparator)
+
+	for i := range patterns {
+		patterns[i] = strings.TrimSpace(patterns[i])
+	}
+
+	return patterns
+}
+
+// FilteredScenarios returns scenarios selected by the filter.
+func (s *Specification) FilteredScenarios(filter ScenarioFilter) []Scenario {
+	scenarios := make([]Scenario, 0, s.ScenariosCount())
+
+	for _, scenario := range s.Scenarios() {
+		if filter.Matches(scenario.Slug()) {
+			scenarios = append(scenarios, scenario)
+		}
+	}
+
+	return scenarios
+}
@@ -0,0 +1,137 @@
+package specification_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestValidateScenarioFilter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Filter      specification.ScenarioFilter
+		ShouldBeErr bool
+	}{
+		{
+			Name:        "empty",
+			Filter:      "",
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "story_and_scenario_patterns",
+			Filter:      "checkout, cart.add*",
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "empty_pattern",
+			Filter:      "checkout,,cart",
+			ShouldBeErr: true,
+		},
+		{
+			Name:        "malformed_pattern",
+			Filter:      "checkout.[pay",
+			ShouldBeErr: true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Filter.Validate()
+
+			if c.ShouldBeErr {
+				require.True(t, errors.Is(err, specification.ErrInvalidScenarioFilter))
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestScenarioFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		Filter        specification.ScenarioFilter
+		Slug          specification.Slug
+		ShouldMatches bool
+	}{
+		{
+			Name:          "empty_filter",
+			Filter:        "",
+			Slug:          specification.NewScenarioSlug("checkout", "pay"),
+			ShouldMatches: true,
+		},
+		{
+			Name:          "story_pattern",
+			Filter:        "checkout",
+			Slug:          specification.NewScenarioSlug("checkout", "pay"),
+			ShouldMatches: true,
+		},
+		{
+			Name:          "other_story_pattern",
+			Filter:        "cart",
+			Slug:          specification.NewScenarioSlug("checkout", "pay"),
+			ShouldMatches: false,
+		},
+		{
+			Name:          "scenario_wildcard_pattern",
+			Filter:        "cart, checkout.pa*",
+			Slug:          specification.NewScenarioSlug("checkout", "pay"),
+			ShouldMatches: true,
+		},
+		{
+			Name:          "other_scenario_pattern",
+			Filter:        "checkout.refund",
+			Slug:          specification.NewScenarioSlug("checkout", "pay"),
+			ShouldMatches: false,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, c.ShouldMatches, c.Filter.Matches(c.Slug))
+		})
+	}
+}
+
+func TestFilteredScenarios(t *testing.T) {
+	t.Parallel()
+
+	spec := errlessBuildSpec(t, func(b *specification.Builder) {
+		b.WithStory("checkout", func(b *specification.StoryBuilder) {
+			b.WithScenario("pay", func(b *specification.ScenarioBuilder) {})
+			b.WithScenario("refund", func(b *specification.ScenarioBuilder) {})
+		})
+		b.WithStory("cart", func(b *specification.StoryBuilder) {
+			b.WithScenario("add", func(b *specification.ScenarioBuilder) {})
+		})
+	})
+
+	scenarios := spec.FilteredScenarios("checkout.pay, cart")
+
+	slugs := make([]specification.Slug, 0, len(scenarios))
+	for _, s := range scenarios {
+		slugs = append(slugs, s.Slug())
+	}
+
+	require.ElementsMatch(t, []specification.Slug{
+		specification.NewScenarioSlug("checkout", "pay"),
+		specification.NewScenarioSlug("cart", "add"),
+	}, slugs)
+}
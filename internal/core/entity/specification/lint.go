@@ -0,0 +1,334 @@
+package specification
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// LintRule is an ID of the style or quality check
+	// of the valid specification.
+	LintRule string
+
+	// Severity is a level of the lint problem. Rule
+	// with the Off severity is not checked at all.
+	Severity string
+
+	// LintConfig sets severities of lint rules. Rules
+	// missing in the config have default severities.
+	LintConfig struct {
+		severities map[LintRule]Severity
+	}
+
+	// LintProblem is a violation of the lint rule by
+	// the story, scenario or thesis with the slug.
+	LintProblem struct {
+		rule     LintRule
+		severity Severity
+		slug     Slug
+		message  string
+	}
+)
+
+const (
+	ThenWithoutAssertionRule LintRule = "then-without-assertion"
+	NoAllowedCodesRule       LintRule = "no-allowed-codes"
+	UnusedResponseRule       LintRule = "unused-response"
+	HardcodedHostRule        LintRule = "hardcoded-host"
+	DuplicateRequestBodyRule LintRule = "duplicate-request-body"
+	MissingNarrativeRule     LintRule = "missing-narrative"
+)
+
+const (
+	ErrorSeverity   Severity = "error"
+	WarningSeverity Severity = "warning"
+	InfoSeverity    Severity = "info"
+	OffSeverity     Severity = "off"
+)
+
+var defaultSeverities = map[LintRule]Severity{
+	ThenWithoutAssertionRule: WarningSeverity,
+	NoAllowedCodesRule:       WarningSeverity,
+	UnusedResponseRule:       InfoSeverity,
+	HardcodedHostRule:        InfoSeverity,
+	DuplicateRequestBodyRule: InfoSeverity,
+	MissingNarrativeRule:     WarningSeverity,
+}
+
+// LintRules returns IDs of all lint rules with their default severities.
+func LintRules() map[LintRule]Severity {
+	rules := make(map[LintRule]Severity, len(defaultSeverities))
+
+	for rule, severity := range defaultSeverities {
+		rules[rule] = severity
+	}
+
+	return rules
+}
+
+func (s Severity) IsValid() bool {
+	switch s {
+	case ErrorSeverity, WarningSeverity, InfoSeverity, OffSeverity:
+		return true
+	}
+
+	return false
+}
+
+// NewLintConfig returns config with severities of rules overridden
+// by the rules map of rule IDs to severities. Unknown rule
+// or severity is an error.
+func NewLintConfig(rules map[string]string) (LintConfig, error) {
+	cfg := LintConfig{
+		severities: LintRules(),
+	}
+
+	for rule, severity := range rules {
+		if _, ok := defaultSeverities[LintRule(rule)]; !ok {
+			return LintConfig{}, NewUnknownLintRuleError(rule)
+		}
+
+		if !Severity(severity).IsValid() {
+			return LintConfig{}, NewNotAllowedSeverityError(severity)
+		}
+
+		cfg.severities[LintRule(rule)] = Severity(severity)
+	}
+
+	return cfg, nil
+}
+
+// Severity returns severity of the rule in the config.
+func (c LintConfig) Severity(rule LintRule) Severity {
+	if severity, ok := c.severities[rule]; ok {
+		return severity
+	}
+
+	return defaultSeverities[rule]
+}
+
+func (p LintProblem) Rule() LintRule {
+	return p.rule
+}
+
+func (p LintProblem) Severity() Severity {
+	return p.severity
+}
+
+func (p LintProblem) Slug() Slug {
+	return p.slug
+}
+
+func (p LintProblem) Message() string {
+	return p.message
+}
+
+// Lint checks the specification with the rules of the
+// config and returns problems sorted by slug and rule.
+func Lint(spec *Specification, cfg LintConfig) []LintProblem {
+	l := linter{cfg: cfg}
+
+	for _, story := range spec.Stories() {
+		l.lintStory(story)
+
+		for _, scenario := range story.Scenarios() {
+			l.lintScenario(scenario)
+		}
+	}
+
+	l.lintDuplicateBodies(spec.Theses())
+
+	sort.SliceStable(l.problems, func(i, j int) bool {
+		if l.problems[i].slug != l.problems[j].slug {
+			return l.problems[i].slug.String() < l.problems[j].slug.String()
+		}
+
+		return l.problems[i].rule < l.problems[j].rule
+	})
+
+	return l.problems
+}
+
+type linter struct {
+	cfg      LintConfig
+	problems []LintProblem
+}
+
+func (l *linter) report(rule LintRule, slug Slug, format string, args ...interface{}) {
+	severity := l.cfg.Severity(rule)
+	if severity == OffSeverity {
+		return
+	}
+
+	l.problems = append(l.problems, LintProblem{
+		rule:     rule,
+		severity: severity,
+		slug:     slug,
+		message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) lintStory(story Story) {
+	var missing []string
+
+	if story.AsA() == "" {
+		missing = append(missing, "asA")
+	}
+
+	if story.InOrderTo() == "" {
+		missing = append(missing, "inOrderTo")
+	}
+
+	if story.WantTo() == "" {
+		missing = append(missing, "wantTo")
+	}
+
+	if len(missing) > 0 {
+		l.report(MissingNarrativeRule, story.Slug(), "story narrative misses %s", strings.Join(missing, ", "))
+	}
+}
+
+func (l *linter) lintScenario(scenario Scenario) {
+	theses := scenario.Theses()
+	used := referencedTheses(theses)
+
+	for _, thesis := range theses {
+		if thesis.Stage() == Then && thesis.Assertion().IsZero() {
+			l.report(ThenWithoutAssertionRule, thesis.Slug(), "then thesis has no assertion")
+		}
+
+		if thesis.HTTP().IsZero() {
+			continue
+		}
+
+		if len(thesis.HTTP().Response().AllowedCodes()) == 0 {
+			l.report(NoAllowedCodesRule, thesis.Slug(), "HTTP thesis has no allowed codes of the response")
+		}
+
+		if !used[thesis.Slug().Thesis()] {
+			l.report(UnusedResponseRule, thesis.Slug(), "response of the thesis is never used")
+		}
+
+		if host, ok := hardcodedHost(thesis.HTTP().Request().URL()); ok {
+			l.report(HardcodedHostRule, thesis.Slug(), "URL has hard-coded host %q", host)
+		}
+	}
+}
+
+func (l *linter) lintDuplicateBodies(theses []Thesis) {
+	sort.Slice(theses, func(i, j int) bool {
+		return theses[i].Slug().String() < theses[j].Slug().String()
+	})
+
+	var withBodies []Thesis
+
+	for _, thesis := range theses {
+		body := thesis.HTTP().Request().Body()
+		if len(body) == 0 {
+			continue
+		}
+
+		for _, other := range withBodies {
+			if reflect.DeepEqual(body, other.HTTP().Request().Body()) {
+				l.report(DuplicateRequestBodyRule, thesis.Slug(), "request body duplicates body of %s", other.Slug())
+
+				break
+			}
+		}
+
+		withBodies = append(withBodies, thesis)
+	}
+}
+
+var templateRegexp = regexp.MustCompile(`{{\s*([^}]+?)\s*}}`)
+
+// referencedTheses returns slugs of theses, which responses are
+// referenced in URL templates and assertions of the theses.
+func referencedTheses(theses []Thesis) map[string]bool {
+	used := make(map[string]bool)
+
+	for _, thesis := range theses {
+		for _, match := range templateRegexp.FindAllStringSubmatch(thesis.HTTP().Request().URL(), -1) {
+			used[rootSegment(match[1])] = true
+		}
+
+		for _, assert := range thesis.Assertion().Asserts() {
+			used[rootSegment(assert.Actual())] = true
+		}
+	}
+
+	return used
+}
+
+func rootSegment(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+
+	return path
+}
+
+// hardcodedHost returns host of the URL if it is
+// written literally instead of the template.
+func hardcodedHost(rawURL string) (string, bool) {
+	if rawURL == "" || strings.HasPrefix(rawURL, "{{") {
+		return "", false
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || strings.Contains(u.Host, "{{") {
+		return "", false
+	}
+
+	return u.Host, true
+}
+
+type UnknownLintRuleError struct {
+	rule string
+}
+
+func NewUnknownLintRuleError(rule string) error {
+	return errors.WithStack(&UnknownLintRuleError{
+		rule: rule,
+	})
+}
+
+func (e *UnknownLintRuleError) Rule() string {
+	return e.rule
+}
+
+func (e *UnknownLintRuleError) Error() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("unknown lint rule %q", e.rule)
+}
+
+type NotAllowedSeverityError struct {
+	severity string
+}
+
+func NewNotAllowedSeverityError(severity string) error {
+	return errors.WithStack(&NotAllowedSeverityError{
+		severity: severity,
+	})
+}
+
+func (e *NotAllowedSeverityError) Severity() string {
+	return e.severity
+}
+
+func (e *NotAllowedSeverityError) Error() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("severity %q not allowed", e.severity)
+}
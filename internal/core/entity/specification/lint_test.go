@@ -0,0 +1,200 @@
+package specification_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+type lintProblem struct {
+	Rule     specification.LintRule
+	Severity specification.Severity
+	Slug     string
+}
+
+func lintProblems(problems []specification.LintProblem) []lintProblem {
+	res := make([]lintProblem, 0, len(problems))
+
+	for _, p := range problems {
+		res = append(res, lintProblem{
+			Rule:     p.Rule(),
+			Severity: p.Severity(),
+			Slug:     p.Slug().String(),
+		})
+	}
+
+	return res
+}
+
+func lintedSpecification(t *testing.T) *specification.Specification {
+	t.Helper()
+
+	return errlessBuildSpec(t, func(b *specification.Builder) {
+		b.WithStory("shop", func(b *specification.StoryBuilder) {
+			b.WithAsA("customer")
+
+			b.WithScenario("buy", func(b *specification.ScenarioBuilder) {
+				b.WithThesis("create", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.When, "create order")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.POST)
+							b.WithURL("https://shop.com/orders")
+							b.WithBody(map[string]interface{}{"item": "horn"})
+						})
+						b.WithResponse(func(b *specification.HTTPResponseBuilder) {
+							b.WithAllowedCodes([]int{201})
+						})
+					})
+				})
+
+				b.WithThesis("check", func(b *specification.ThesisBuilder) {
+					b.WithStatement(specification.Then, "order is created")
+					b.WithDependency("create")
+					b.WithHTTP(func(b *specification.HTTPBuilder) {
+						b.WithRequest(func(b *specification.HTTPRequestBuilder) {
+							b.WithMethod(specification.POST)
+							b.WithURL("{{create.response.headers.Location}}")
+							b.WithBody(map[string]interface{}{"item": "horn"})
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestLintSpecification(t *testing.T) {
+	t.Parallel()
+
+	spec := lintedSpecification(t)
+
+	problems := specification.Lint(spec, specification.LintConfig{})
+
+	require.Equal(t, []lintProblem{
+		{
+			Rule:     specification.MissingNarrativeRule,
+			Severity: specification.WarningSeverity,
+			Slug:     "shop",
+		},
+		{
+			Rule:     specification.NoAllowedCodesRule,
+			Severity: specification.WarningSeverity,
+			Slug:     "shop.buy.check",
+		},
+		{
+			Rule:     specification.ThenWithoutAssertionRule,
+			Severity: specification.WarningSeverity,
+			Slug:     "shop.buy.check",
+		},
+		{
+			Rule:     specification.UnusedResponseRule,
+			Severity: specification.InfoSeverity,
+			Slug:     "shop.buy.check",
+		},
+		{
+			Rule:     specification.DuplicateRequestBodyRule,
+			Severity: specification.InfoSeverity,
+			Slug:     "shop.buy.create",
+		},
+		{
+			Rule:     specification.HardcodedHostRule,
+			Severity: specification.InfoSeverity,
+			Slug:     "shop.buy.create",
+		},
+	}, lintProblems(problems))
+}
+
+func TestLintSpecificationWithConfig(t *testing.T) {
+	t.Parallel()
+
+	spec := lintedSpecification(t)
+
+	cfg, err := specification.NewLintConfig(map[string]string{
+		"missing-narrative":      "error",
+		"hardcoded-host":         "off",
+		"duplicate-request-body": "off",
+		"unused-response":        "off",
+		"no-allowed-codes":       "off",
+	})
+	require.NoError(t, err)
+
+	problems := specification.Lint(spec, cfg)
+
+	require.Equal(t, []lintProblem{
+		{
+			Rule:     specification.MissingNarrativeRule,
+			Severity: specification.ErrorSeverity,
+			Slug:     "shop",
+		},
+		{
+			Rule:     specification.ThenWithoutAssertionRule,
+			Severity: specification.WarningSeverity,
+			Slug:     "shop.buy.check",
+		},
+	}, lintProblems(problems))
+	require.Equal(t, "story narrative misses inOrderTo, wantTo", problems[0].Message())
+}
+
+func TestNewLintConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		GivenRules  map[string]string
+		ShouldBeErr bool
+		IsErr       func(err error) bool
+	}{
+		{
+			Name:        "empty",
+			GivenRules:  nil,
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "known_rule",
+			GivenRules:  map[string]string{"unused-response": "warning"},
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "unknown_rule",
+			GivenRules:  map[string]string{"foo": "warning"},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *specification.UnknownLintRuleError
+
+				return errors.As(err, &target) && target.Rule() == "foo"
+			},
+		},
+		{
+			Name:        "not_allowed_severity",
+			GivenRules:  map[string]string{"unused-response": "fatal"},
+			ShouldBeErr: true,
+			IsErr: func(err error) bool {
+				var target *specification.NotAllowedSeverityError
+
+				return errors.As(err, &target) && target.Severity() == "fatal"
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := specification.NewLintConfig(c.GivenRules)
+
+			if c.ShouldBeErr {
+				require.True(t, c.IsErr(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
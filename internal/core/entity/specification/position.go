@@ -0,0 +1,174 @@
+package specification
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Position is a place in the specification source.
+// Line and Column are 1-based.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) IsZero() bool {
+	return p == Position{}
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// PositionedError is the error occurred
+// at the position of the specification source.
+type PositionedError struct {
+	err      error
+	position Position
+}
+
+func NewPositionedError(err error, pos Position) error {
+	if err == nil {
+		return nil
+	}
+
+	return &PositionedError{
+		err:      err,
+		position: pos,
+	}
+}
+
+func (e *PositionedError) Position() Position {
+	return e.position
+}
+
+func (e *PositionedError) Unwrap() error {
+	return e.err
+}
+
+func (e *PositionedError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+
+	return e.err.Error()
+}
+
+// Locator returns position of the object in the specification source.
+// Path is the chain of build errors from the root to the object.
+// Err is the nested error of the last build error of the path or
+// nil if the position of the last build error object is requested.
+type Locator func(path []*BuildError, err error) (Position, bool)
+
+// LocateBuildError returns a copy of the BuildError tree of err,
+// where build errors and their nested errors have positions
+// returned by locate. Err without BuildError is returned as is.
+func LocateBuildError(err error, locate Locator) error {
+	var target *BuildError
+
+	if !errors.As(err, &target) {
+		return err
+	}
+
+	return errors.WithStack(locateBuildError(target, nil, locate))
+}
+
+func locateBuildError(err *BuildError, path []*BuildError, locate Locator) *BuildError {
+	path = append(path[:len(path):len(path)], err)
+
+	located := &BuildError{
+		context:  err.context,
+		position: err.position,
+		errs:     make([]error, 0, len(err.errs)),
+	}
+
+	if pos, ok := locate(path, nil); ok {
+		located.position = pos
+	}
+
+	for _, nested := range err.errs {
+		var target *BuildError
+
+		if errors.As(nested, &target) {
+			located.errs = append(located.errs, locateBuildError(target, path, locate))
+
+			continue
+		}
+
+		if pos, ok := locate(path, nested); ok {
+			nested = NewPositionedError(nested, pos)
+		}
+
+		located.errs = append(located.errs, nested)
+	}
+
+	return located
+}
+
+// ErrorDetail is the leaf error of the BuildError tree.
+//
+// Contexts are contexts of build errors from the root to the
+// leaf error. Position is the position of the leaf error or of
+// the closest build error with known position.
+type ErrorDetail struct {
+	Contexts []string
+	Position Position
+	Err      error
+}
+
+// FlattenBuildError returns leaf errors of the BuildError tree
+// of err. Err without BuildError is returned as a single detail.
+func FlattenBuildError(err error) []ErrorDetail {
+	if err == nil {
+		return nil
+	}
+
+	var details []ErrorDetail
+
+	flattenBuildError(&details, err, nil, Position{})
+
+	return details
+}
+
+func flattenBuildError(details *[]ErrorDetail, err error, contexts []string, pos Position) {
+	var target *BuildError
+
+	if !errors.As(err, &target) {
+		var perr *PositionedError
+		if errors.As(err, &perr) {
+			pos = perr.Position()
+		}
+
+		*details = append(*details, ErrorDetail{
+			Contexts: contexts,
+			Position: pos,
+			Err:      err,
+		})
+
+		return
+	}
+
+	if p, ok := target.Position(); ok {
+		pos = p
+	}
+
+	nested := contexts[:len(contexts):len(contexts)]
+	if c := target.contextString(); c != "" {
+		nested = append(nested, c)
+	}
+
+	for _, err := range target.errs {
+		flattenBuildError(details, err, nested, pos)
+	}
+}
+
+func (e *BuildError) contextString() string {
+	if slug, ok := e.SlugContext(); ok {
+		return slug.Partial()
+	}
+
+	msg, _ := e.StringContext()
+
+	return msg
+}
@@ -0,0 +1,148 @@
+package specification_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+func TestLocateBuildError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errLeaf   = errors.New("leaf")
+		errNested = errors.New("nested")
+	)
+
+	nested := (&specification.BuildErrorWrapper{}).
+		WithError(errNested).
+		SluggedWrap(specification.NewStorySlug("story"))
+
+	given := (&specification.BuildErrorWrapper{}).
+		WithError(errLeaf).
+		WithError(nested).
+		Wrap("specification")
+
+	located := specification.LocateBuildError(given, func(
+		path []*specification.BuildError,
+		err error,
+	) (specification.Position, bool) {
+		switch {
+		case err == nil:
+			return specification.Position{Line: len(path), Column: 1}, true
+		case errors.Is(err, errNested):
+			return specification.Position{Line: 10, Column: 5}, true
+		}
+
+		return specification.Position{}, false
+	})
+
+	require.ErrorIs(t, located, errLeaf)
+	require.ErrorIs(t, located, errNested)
+
+	var berr *specification.BuildError
+
+	require.ErrorAs(t, located, &berr)
+
+	pos, ok := berr.Position()
+	require.True(t, ok)
+	require.Equal(t, specification.Position{Line: 1, Column: 1}, pos)
+
+	details := specification.FlattenBuildError(located)
+	require.Len(t, details, 2)
+
+	require.Equal(t, []string{"specification"}, details[0].Contexts)
+	require.Equal(t, specification.Position{Line: 1, Column: 1}, details[0].Position)
+	require.ErrorIs(t, details[0].Err, errLeaf)
+
+	require.Equal(t, []string{"specification", "story"}, details[1].Contexts)
+	require.Equal(t, specification.Position{Line: 10, Column: 5}, details[1].Position)
+	require.ErrorIs(t, details[1].Err, errNested)
+}
+
+func TestLocateNotBuildError(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("foo")
+
+	located := specification.LocateBuildError(err, func(
+		[]*specification.BuildError,
+		error,
+	) (specification.Position, bool) {
+		return specification.Position{Line: 1, Column: 1}, true
+	})
+
+	require.Same(t, err, located)
+}
+
+func TestFlattenBuildError(t *testing.T) {
+	t.Parallel()
+
+	errFoo := errors.New("foo")
+
+	testCases := []struct {
+		GivenError      error
+		ExpectedDetails []specification.ErrorDetail
+	}{
+		{
+			GivenError:      nil,
+			ExpectedDetails: nil,
+		},
+		{
+			GivenError: errFoo,
+			ExpectedDetails: []specification.ErrorDetail{
+				{Err: errFoo},
+			},
+		},
+		{
+			GivenError: specification.NewPositionedError(errFoo, specification.Position{Line: 3}),
+			ExpectedDetails: []specification.ErrorDetail{
+				{
+					Position: specification.Position{Line: 3},
+					Err:      specification.NewPositionedError(errFoo, specification.Position{Line: 3}),
+				},
+			},
+		},
+		{
+			GivenError: (&specification.BuildErrorWrapper{}).
+				WithError(errFoo).
+				SluggedWrap(specification.NewScenarioSlug("story", "scenario")),
+			ExpectedDetails: []specification.ErrorDetail{
+				{
+					Contexts: []string{"scenario"},
+					Err:      errFoo,
+				},
+			},
+		},
+	}
+
+	for i := range testCases {
+		c := testCases[i]
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, c.ExpectedDetails, specification.FlattenBuildError(c.GivenError))
+		})
+	}
+}
+
+func TestPositionedError(t *testing.T) {
+	t.Parallel()
+
+	errFoo := errors.New("foo")
+
+	err := specification.NewPositionedError(errFoo, specification.Position{Line: 2, Column: 4})
+
+	var perr *specification.PositionedError
+
+	require.ErrorAs(t, err, &perr)
+	require.ErrorIs(t, err, errFoo)
+	require.Equal(t, specification.Position{Line: 2, Column: 4}, perr.Position())
+	require.Equal(t, "foo", err.Error())
+	require.NoError(t, specification.NewPositionedError(nil, specification.Position{}))
+}
@@ -15,10 +15,11 @@ type (
 		testCampaignID string
 		loadedAt       time.Time
 
-		author      string
-		title       string
-		description string
-		stories     map[string]Story
+		author       string
+		title        string
+		description  string
+		environments map[string]Environment
+		stories      map[string]Story
 	}
 
 	Builder struct {
@@ -29,6 +30,7 @@ type (
 		author         string
 		title          string
 		description    string
+		environments   map[string]Environment
 		storyFns       []storyFunc
 	}
 
@@ -140,6 +142,10 @@ func (s *Specification) validate() error {
 		w.WithError(ErrNoSpecificationStories)
 	}
 
+	if _, ok := s.environments[""]; ok {
+		w.WithError(ErrEmptyEnvironmentName)
+	}
+
 	for _, story := range s.stories {
 		w.WithError(story.validate())
 	}
@@ -170,6 +176,7 @@ func (b *Builder) build() *Specification {
 		author:         b.author,
 		title:          b.title,
 		description:    b.description,
+		environments:   environmentsOrNil(b.environments),
 		stories:        storiesOrNil(b.storyFns),
 	}
 }
@@ -194,6 +201,7 @@ func (b *Builder) Reset() {
 	b.author = ""
 	b.title = ""
 	b.description = ""
+	b.environments = nil
 	b.storyFns = nil
 }
 
@@ -288,8 +296,9 @@ func (w *BuildErrorWrapper) SluggedWrap(slug Slug) error {
 }
 
 type BuildError struct {
-	context interface{}
-	errs    []error
+	context  interface{}
+	position Position
+	errs     []error
 }
 
 func (e *BuildError) StringContext() (string, bool) {
@@ -304,6 +313,12 @@ func (e *BuildError) SlugContext() (Slug, bool) {
 	return v, ok
 }
 
+// Position returns position of the object of the
+// build error in the specification source if it is known.
+func (e *BuildError) Position() (Position, bool) {
+	return e.position, !e.position.IsZero()
+}
+
 func (e *BuildError) Errors() []error {
 	errs := make([]error, len(e.errs))
 	copy(errs, e.errs)
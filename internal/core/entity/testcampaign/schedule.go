@@ -0,0 +1,124 @@
+package testcampaign
+
+import (
+	"time"
+	// Timezones are loaded from the embedded database
+	// if the system one is missing, e.g. in scratch images.
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+// Schedule fires pipelines of the test campaign
+// according to the cron expression in the timezone.
+// Scheduled pipelines run the active specification with
+// the environment profile and only scenarios selected by
+// the filter, see specification.ScenarioFilter.
+type Schedule struct {
+	id          string
+	cron        string
+	timezone    string
+	environment string
+	filter      string
+
+	schedule cron.Schedule
+	location *time.Location
+}
+
+type ScheduleParams struct {
+	ID          string
+	Cron        string
+	Timezone    string
+	Environment string
+	Filter      string
+}
+
+var (
+	ErrEmptyScheduleID    = errors.New("empty schedule ID")
+	ErrInvalidCron        = errors.New("invalid cron expression")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+	ErrScheduleNotFound   = errors.New("schedule not found")
+	ErrScheduleDuplicated = errors.New("schedule already exists")
+)
+
+func MustNewSchedule(params ScheduleParams) Schedule {
+	s, err := NewSchedule(params)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// NewSchedule creates Schedule with the standard
+// cron expression, e.g. "*/15 * * * *" or "@daily".
+// Empty timezone is treated as UTC.
+func NewSchedule(params ScheduleParams) (Schedule, error) {
+	if params.ID == "" {
+		return Schedule{}, ErrEmptyScheduleID
+	}
+
+	schedule, err := cron.ParseStandard(params.Cron)
+	if err != nil {
+		return Schedule{}, errors.Wrapf(ErrInvalidCron, "%q", params.Cron)
+	}
+
+	location, err := time.LoadLocation(params.Timezone)
+	if err != nil {
+		return Schedule{}, errors.Wrapf(ErrInvalidTimezone, "%q", params.Timezone)
+	}
+
+	if err := specification.ScenarioFilter(params.Filter).Validate(); err != nil {
+		return Schedule{}, err
+	}
+
+	return Schedule{
+		id:          params.ID,
+		cron:        params.Cron,
+		timezone:    params.Timezone,
+		environment: params.Environment,
+		filter:      params.Filter,
+		schedule:    schedule,
+		location:    location,
+	}, nil
+}
+
+func (s Schedule) ID() string {
+	return s.id
+}
+
+func (s Schedule) Cron() string {
+	return s.cron
+}
+
+func (s Schedule) Timezone() string {
+	return s.timezone
+}
+
+func (s Schedule) Environment() string {
+	return s.environment
+}
+
+func (s Schedule) Filter() string {
+	return s.filter
+}
+
+// Next returns the first fire time of the schedule after t.
+func (s Schedule) Next(t time.Time) time.Time {
+	if s.schedule == nil {
+		return time.Time{}
+	}
+
+	return s.schedule.Next(t.In(s.location))
+}
+
+// Due returns true if the schedule fires
+// in the period (from, to].
+func (s Schedule) Due(from, to time.Time) bool {
+	next := s.Next(from)
+
+	return !next.IsZero() && !next.After(to)
+}
@@ -0,0 +1,195 @@
+package testcampaign_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+func TestNewSchedule(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Params      testcampaign.ScheduleParams
+		ShouldBeErr bool
+		ExpectedErr error
+	}{
+		{
+			Name: "without_error",
+			Params: testcampaign.ScheduleParams{
+				ID:       "schedule-id",
+				Cron:     "*/15 * * * *",
+				Timezone: "Europe/Moscow",
+			},
+			ShouldBeErr: false,
+		},
+		{
+			Name: "descriptor_without_timezone",
+			Params: testcampaign.ScheduleParams{
+				ID:   "schedule-id",
+				Cron: "@daily",
+			},
+			ShouldBeErr: false,
+		},
+		{
+			Name: "empty_schedule_id",
+			Params: testcampaign.ScheduleParams{
+				Cron: "@daily",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrEmptyScheduleID,
+		},
+		{
+			Name: "invalid_cron",
+			Params: testcampaign.ScheduleParams{
+				ID:   "schedule-id",
+				Cron: "* * *",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidCron,
+		},
+		{
+			Name: "invalid_timezone",
+			Params: testcampaign.ScheduleParams{
+				ID:       "schedule-id",
+				Cron:     "@hourly",
+				Timezone: "Mars/Olympus",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidTimezone,
+		},
+		{
+			Name: "environment_and_filter",
+			Params: testcampaign.ScheduleParams{
+				ID:          "schedule-id",
+				Cron:        "@hourly",
+				Environment: "staging",
+				Filter:      "payments, checkout.pay*",
+			},
+			ShouldBeErr: false,
+		},
+		{
+			Name: "invalid_filter",
+			Params: testcampaign.ScheduleParams{
+				ID:     "schedule-id",
+				Cron:   "@hourly",
+				Filter: "payments.[",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: specification.ErrInvalidScenarioFilter,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := testcampaign.NewSchedule(c.Params)
+
+			if c.ShouldBeErr {
+				require.ErrorIs(t, err, c.ExpectedErr)
+				require.Panics(t, func() {
+					_ = testcampaign.MustNewSchedule(c.Params)
+				})
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.Params.ID, s.ID())
+			require.Equal(t, c.Params.Cron, s.Cron())
+			require.Equal(t, c.Params.Timezone, s.Timezone())
+			require.Equal(t, c.Params.Environment, s.Environment())
+			require.Equal(t, c.Params.Filter, s.Filter())
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	s := testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+		ID:       "nightly",
+		Cron:     "0 3 * * *",
+		Timezone: "Europe/Moscow",
+	})
+
+	from := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	require.True(
+		t,
+		time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC).Equal(s.Next(from)),
+		"next is %s", s.Next(from),
+	)
+}
+
+func TestScheduleDue(t *testing.T) {
+	t.Parallel()
+
+	s := testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+		ID:   "quarter",
+		Cron: "*/15 * * * *",
+	})
+
+	var (
+		from = time.Date(2022, 3, 1, 12, 14, 0, 0, time.UTC)
+		tick = time.Date(2022, 3, 1, 12, 15, 0, 0, time.UTC)
+	)
+
+	require.True(t, s.Due(from, tick))
+	require.True(t, s.Due(from, tick.Add(time.Second)))
+	require.False(t, s.Due(from, tick.Add(-time.Second)))
+	require.False(t, s.Due(tick, tick.Add(time.Minute)))
+}
+
+func TestTestCampaignSchedules(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nightly = testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+			ID:   "nightly",
+			Cron: "@daily",
+		})
+		quarter = testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+			ID:   "quarter",
+			Cron: "*/15 * * * *",
+		})
+	)
+
+	tc := testcampaign.MustNew(testcampaign.Params{
+		ID:        "id",
+		OwnerID:   "owner-id",
+		Schedules: []testcampaign.Schedule{nightly},
+	})
+
+	require.ErrorIs(t, tc.AddSchedule(nightly), testcampaign.ErrScheduleDuplicated)
+	require.NoError(t, tc.AddSchedule(quarter))
+	require.Len(t, tc.Schedules(), 2)
+
+	updated := testcampaign.MustNewSchedule(testcampaign.ScheduleParams{
+		ID:   "quarter",
+		Cron: "*/30 * * * *",
+	})
+
+	require.NoError(t, tc.ReplaceSchedule(updated))
+
+	s, err := tc.Schedule("quarter")
+	require.NoError(t, err)
+	require.Equal(t, "*/30 * * * *", s.Cron())
+
+	require.NoError(t, tc.RemoveSchedule("nightly"))
+	require.ErrorIs(t, tc.RemoveSchedule("nightly"), testcampaign.ErrScheduleNotFound)
+
+	_, err = tc.Schedule("nightly")
+	require.ErrorIs(t, err, testcampaign.ErrScheduleNotFound)
+
+	require.ErrorIs(t, tc.ReplaceSchedule(nightly), testcampaign.ErrScheduleNotFound)
+	require.Len(t, tc.Schedules(), 1)
+}
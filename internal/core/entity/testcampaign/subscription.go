@@ -0,0 +1,207 @@
+package testcampaign
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/pkg/httphost"
+)
+
+// Event is a kind of the completed flow
+// that the test campaign notifies about.
+type Event string
+
+const (
+	PassedEvent    Event = "passed"
+	FailedEvent    Event = "failed"
+	CrashedEvent   Event = "crashed"
+	CanceledEvent  Event = "canceled"
+	RecoveredEvent Event = "recovered"
+
+	// AllEvents is the subscription filter
+	// matching every completed flow.
+	AllEvents Event = "all"
+)
+
+// Subscription delivers notifications about completed flows
+// matching the filter to the URL. Notifications are signed
+// with the secret.
+type Subscription struct {
+	id     string
+	url    string
+	filter Event
+	secret string
+}
+
+type SubscriptionParams struct {
+	ID     string
+	URL    string
+	Filter Event
+	Secret string
+}
+
+var (
+	ErrEmptySubscriptionID        = errors.New("empty subscription ID")
+	ErrInvalidSubscriptionURL     = errors.New("invalid subscription URL")
+	ErrInvalidSubscriptionEvent   = errors.New("invalid subscription event")
+	ErrEmptySubscriptionSecret    = errors.New("empty subscription secret")
+	ErrSubscriptionHostNotAllowed = errors.New("subscription host is not allowed")
+	ErrSubscriptionNotFound       = errors.New("subscription not found")
+	ErrSubscriptionDuplicated     = errors.New("subscription already exists")
+)
+
+func MustNewSubscription(params SubscriptionParams) Subscription {
+	s, err := NewSubscription(params)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// NewSubscription creates Subscription to the absolute HTTP(S) URL.
+// The filter is one of FailedEvent, CrashedEvent, RecoveredEvent
+// or AllEvents, empty filter is treated as AllEvents. The secret
+// is required, since receivers can't trust unsigned notifications.
+func NewSubscription(params SubscriptionParams) (Subscription, error) {
+	if params.ID == "" {
+		return Subscription{}, ErrEmptySubscriptionID
+	}
+
+	u, err := url.Parse(params.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Subscription{}, errors.Wrapf(ErrInvalidSubscriptionURL, "%q", params.URL)
+	}
+
+	if params.Secret == "" {
+		return Subscription{}, ErrEmptySubscriptionSecret
+	}
+
+	filter := params.Filter
+	if filter == "" {
+		filter = AllEvents
+	}
+
+	switch filter {
+	case FailedEvent, CrashedEvent, RecoveredEvent, AllEvents:
+	default:
+		return Subscription{}, errors.Wrapf(ErrInvalidSubscriptionEvent, "%q", params.Filter)
+	}
+
+	return Subscription{
+		id:     params.ID,
+		url:    params.URL,
+		filter: filter,
+		secret: params.Secret,
+	}, nil
+}
+
+func (s Subscription) ID() string {
+	return s.id
+}
+
+func (s Subscription) URL() string {
+	return s.url
+}
+
+func (s Subscription) Filter() Event {
+	return s.filter
+}
+
+func (s Subscription) Secret() string {
+	return s.secret
+}
+
+// CheckHost returns ErrSubscriptionHostNotAllowed if the host
+// of the URL is not one of allowedHosts, so notifications
+// can't be sent to internal addresses of the server network.
+func (s Subscription) CheckHost(allowedHosts []string) error {
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidSubscriptionURL, "%q", s.url)
+	}
+
+	if !httphost.Allowed(u.Hostname(), allowedHosts) {
+		return errors.Wrap(ErrSubscriptionHostNotAllowed, u.Hostname())
+	}
+
+	return nil
+}
+
+// Matches returns true if the subscription
+// should be notified about the event.
+func (s Subscription) Matches(event Event) bool {
+	return s.filter == AllEvents || s.filter == event
+}
+
+// SignNotification returns signature of the notification payload
+// sent at timestamp. The signature is computed the same way as
+// the trigger signature, with the subscription secret as key.
+func SignNotification(secret string, payload []byte, timestamp time.Time) string {
+	return sign(secret, payload, timestamp)
+}
+
+// Subscriptions returns copy of the test campaign subscriptions.
+func (tc *TestCampaign) Subscriptions() []Subscription {
+	return append([]Subscription(nil), tc.subscriptions...)
+}
+
+// AddSubscription returns ErrSubscriptionDuplicated
+// if subscription with such ID is already added.
+func (tc *TestCampaign) AddSubscription(s Subscription) error {
+	if tc.subscriptionIndex(s.ID()) >= 0 {
+		return ErrSubscriptionDuplicated
+	}
+
+	tc.subscriptions = append(tc.subscriptions, s)
+
+	return nil
+}
+
+func (tc *TestCampaign) RemoveSubscription(subscriptionID string) error {
+	i := tc.subscriptionIndex(subscriptionID)
+	if i < 0 {
+		return ErrSubscriptionNotFound
+	}
+
+	tc.subscriptions = append(tc.subscriptions[:i], tc.subscriptions[i+1:]...)
+
+	return nil
+}
+
+func (tc *TestCampaign) subscriptionIndex(subscriptionID string) int {
+	for i, s := range tc.subscriptions {
+		if s.ID() == subscriptionID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// LastOutcome returns event of the last completed flow.
+func (tc *TestCampaign) LastOutcome() Event {
+	return tc.lastOutcome
+}
+
+// CompleteFlow remembers the outcome of the completed flow
+// and returns event to notify about. Passed flow following
+// the failed or crashed one is RecoveredEvent. Canceled flow
+// is not remembered, since it says nothing about the system
+// under test.
+func (tc *TestCampaign) CompleteFlow(outcome Event) Event {
+	if outcome == CanceledEvent {
+		return CanceledEvent
+	}
+
+	previous := tc.lastOutcome
+	tc.lastOutcome = outcome
+
+	if outcome == PassedEvent && (previous == FailedEvent || previous == CrashedEvent) {
+		return RecoveredEvent
+	}
+
+	return outcome
+}
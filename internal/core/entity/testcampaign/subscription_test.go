@@ -0,0 +1,293 @@
+package testcampaign_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+func TestNewSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name           string
+		Params         testcampaign.SubscriptionParams
+		ShouldBeErr    bool
+		ExpectedErr    error
+		ExpectedFilter testcampaign.Event
+	}{
+		{
+			Name: "without_error",
+			Params: testcampaign.SubscriptionParams{
+				ID:     "subscription-id",
+				URL:    "https://ci.example.com/hooks/thestis",
+				Filter: testcampaign.FailedEvent,
+				Secret: "secret",
+			},
+			ShouldBeErr:    false,
+			ExpectedFilter: testcampaign.FailedEvent,
+		},
+		{
+			Name: "empty_filter_is_all_events",
+			Params: testcampaign.SubscriptionParams{
+				ID:     "subscription-id",
+				URL:    "http://localhost:8080",
+				Secret: "secret",
+			},
+			ShouldBeErr:    false,
+			ExpectedFilter: testcampaign.AllEvents,
+		},
+		{
+			Name: "empty_subscription_id",
+			Params: testcampaign.SubscriptionParams{
+				URL: "https://ci.example.com",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrEmptySubscriptionID,
+		},
+		{
+			Name: "relative_url",
+			Params: testcampaign.SubscriptionParams{
+				ID:  "subscription-id",
+				URL: "/hooks/thestis",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidSubscriptionURL,
+		},
+		{
+			Name: "not_http_url",
+			Params: testcampaign.SubscriptionParams{
+				ID:  "subscription-id",
+				URL: "ftp://ci.example.com",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidSubscriptionURL,
+		},
+		{
+			Name: "empty_secret",
+			Params: testcampaign.SubscriptionParams{
+				ID:  "subscription-id",
+				URL: "https://ci.example.com",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrEmptySubscriptionSecret,
+		},
+		{
+			Name: "passed_filter",
+			Params: testcampaign.SubscriptionParams{
+				ID:     "subscription-id",
+				URL:    "https://ci.example.com",
+				Filter: testcampaign.PassedEvent,
+				Secret: "secret",
+			},
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidSubscriptionEvent,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := testcampaign.NewSubscription(c.Params)
+
+			if c.ShouldBeErr {
+				require.ErrorIs(t, err, c.ExpectedErr)
+				require.Panics(t, func() {
+					_ = testcampaign.MustNewSubscription(c.Params)
+				})
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.Params.ID, s.ID())
+			require.Equal(t, c.Params.URL, s.URL())
+			require.Equal(t, c.ExpectedFilter, s.Filter())
+			require.Equal(t, c.Params.Secret, s.Secret())
+		})
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	t.Parallel()
+
+	var (
+		all = testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+			ID:     "all",
+			URL:    "https://ci.example.com",
+			Secret: "secret",
+		})
+		failed = testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+			ID:     "failed",
+			URL:    "https://ci.example.com",
+			Filter: testcampaign.FailedEvent,
+			Secret: "secret",
+		})
+	)
+
+	require.True(t, all.Matches(testcampaign.PassedEvent))
+	require.True(t, all.Matches(testcampaign.RecoveredEvent))
+	require.True(t, failed.Matches(testcampaign.FailedEvent))
+	require.False(t, failed.Matches(testcampaign.CrashedEvent))
+	require.False(t, failed.Matches(testcampaign.PassedEvent))
+}
+
+func TestSubscriptionCheckHost(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		URL          string
+		AllowedHosts []string
+		ShouldBeErr  bool
+	}{
+		{
+			Name:         "exact_host",
+			URL:          "https://ci.example.com/hooks/thestis",
+			AllowedHosts: []string{"ci.example.com"},
+			ShouldBeErr:  false,
+		},
+		{
+			Name:         "wildcard_host",
+			URL:          "https://CI.Example.com:8443/hooks/thestis",
+			AllowedHosts: []string{"*.example.com"},
+			ShouldBeErr:  false,
+		},
+		{
+			Name:         "internal_host",
+			URL:          "http://169.254.169.254/latest/meta-data",
+			AllowedHosts: []string{"*.example.com"},
+			ShouldBeErr:  true,
+		},
+		{
+			Name:         "no_allowed_hosts",
+			URL:          "https://ci.example.com",
+			AllowedHosts: nil,
+			ShouldBeErr:  true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			s := testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+				ID:     "subscription-id",
+				URL:    c.URL,
+				Secret: "secret",
+			})
+
+			err := s.CheckHost(c.AllowedHosts)
+
+			if c.ShouldBeErr {
+				require.ErrorIs(t, err, testcampaign.ErrSubscriptionHostNotAllowed)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTestCampaignSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	s := testcampaign.MustNewSubscription(testcampaign.SubscriptionParams{
+		ID:     "subscription-id",
+		URL:    "https://ci.example.com",
+		Secret: "secret",
+	})
+
+	tc := testcampaign.MustNew(testcampaign.Params{
+		ID:      "id",
+		OwnerID: "owner-id",
+	})
+
+	require.NoError(t, tc.AddSubscription(s))
+	require.ErrorIs(t, tc.AddSubscription(s), testcampaign.ErrSubscriptionDuplicated)
+	require.Len(t, tc.Subscriptions(), 1)
+
+	require.NoError(t, tc.RemoveSubscription("subscription-id"))
+	require.ErrorIs(t, tc.RemoveSubscription("subscription-id"), testcampaign.ErrSubscriptionNotFound)
+	require.Empty(t, tc.Subscriptions())
+}
+
+func TestCompleteFlow(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                string
+		LastOutcome         testcampaign.Event
+		Outcome             testcampaign.Event
+		ExpectedEvent       testcampaign.Event
+		ExpectedLastOutcome testcampaign.Event
+	}{
+		{
+			Name:                "first_passed_flow",
+			LastOutcome:         "",
+			Outcome:             testcampaign.PassedEvent,
+			ExpectedEvent:       testcampaign.PassedEvent,
+			ExpectedLastOutcome: testcampaign.PassedEvent,
+		},
+		{
+			Name:                "passed_after_passed",
+			LastOutcome:         testcampaign.PassedEvent,
+			Outcome:             testcampaign.PassedEvent,
+			ExpectedEvent:       testcampaign.PassedEvent,
+			ExpectedLastOutcome: testcampaign.PassedEvent,
+		},
+		{
+			Name:                "passed_after_failed",
+			LastOutcome:         testcampaign.FailedEvent,
+			Outcome:             testcampaign.PassedEvent,
+			ExpectedEvent:       testcampaign.RecoveredEvent,
+			ExpectedLastOutcome: testcampaign.PassedEvent,
+		},
+		{
+			Name:                "passed_after_crashed",
+			LastOutcome:         testcampaign.CrashedEvent,
+			Outcome:             testcampaign.PassedEvent,
+			ExpectedEvent:       testcampaign.RecoveredEvent,
+			ExpectedLastOutcome: testcampaign.PassedEvent,
+		},
+		{
+			Name:                "failed_after_failed",
+			LastOutcome:         testcampaign.FailedEvent,
+			Outcome:             testcampaign.FailedEvent,
+			ExpectedEvent:       testcampaign.FailedEvent,
+			ExpectedLastOutcome: testcampaign.FailedEvent,
+		},
+		{
+			Name:                "canceled_after_failed",
+			LastOutcome:         testcampaign.FailedEvent,
+			Outcome:             testcampaign.CanceledEvent,
+			ExpectedEvent:       testcampaign.CanceledEvent,
+			ExpectedLastOutcome: testcampaign.FailedEvent,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tc := testcampaign.MustNew(testcampaign.Params{
+				ID:          "id",
+				OwnerID:     "owner-id",
+				LastOutcome: c.LastOutcome,
+			})
+
+			require.Equal(t, c.ExpectedEvent, tc.CompleteFlow(c.Outcome))
+			require.Equal(t, c.ExpectedLastOutcome, tc.LastOutcome())
+		})
+	}
+}
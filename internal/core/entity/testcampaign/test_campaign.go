@@ -13,14 +13,23 @@ type TestCampaign struct {
 
 	ownerID   string
 	createdAt time.Time
+
+	schedules     []Schedule
+	triggerToken  string
+	subscriptions []Subscription
+	lastOutcome   Event
 }
 
 type Params struct {
-	ID        string
-	ViewName  string
-	Summary   string
-	OwnerID   string
-	CreatedAt time.Time
+	ID            string
+	ViewName      string
+	Summary       string
+	OwnerID       string
+	CreatedAt     time.Time
+	Schedules     []Schedule
+	TriggerToken  string
+	Subscriptions []Subscription
+	LastOutcome   Event
 }
 
 func MustNew(params Params) *TestCampaign {
@@ -47,11 +56,15 @@ func New(params Params) (*TestCampaign, error) {
 	}
 
 	return &TestCampaign{
-		id:        params.ID,
-		viewName:  params.ViewName,
-		summary:   params.Summary,
-		ownerID:   params.OwnerID,
-		createdAt: params.CreatedAt,
+		id:            params.ID,
+		viewName:      params.ViewName,
+		summary:       params.Summary,
+		ownerID:       params.OwnerID,
+		createdAt:     params.CreatedAt,
+		schedules:     append([]Schedule(nil), params.Schedules...),
+		triggerToken:  params.TriggerToken,
+		subscriptions: append([]Subscription(nil), params.Subscriptions...),
+		lastOutcome:   params.LastOutcome,
 	}, nil
 }
 
@@ -82,3 +95,62 @@ func (tc *TestCampaign) OwnerID() string {
 func (tc *TestCampaign) CreatedAt() time.Time {
 	return tc.createdAt
 }
+
+// Schedules returns copy of the test campaign schedules.
+func (tc *TestCampaign) Schedules() []Schedule {
+	return append([]Schedule(nil), tc.schedules...)
+}
+
+func (tc *TestCampaign) Schedule(scheduleID string) (Schedule, error) {
+	i := tc.scheduleIndex(scheduleID)
+	if i < 0 {
+		return Schedule{}, ErrScheduleNotFound
+	}
+
+	return tc.schedules[i], nil
+}
+
+// AddSchedule returns ErrScheduleDuplicated
+// if schedule with such ID is already added.
+func (tc *TestCampaign) AddSchedule(s Schedule) error {
+	if tc.scheduleIndex(s.ID()) >= 0 {
+		return ErrScheduleDuplicated
+	}
+
+	tc.schedules = append(tc.schedules, s)
+
+	return nil
+}
+
+// ReplaceSchedule replaces schedule with the same ID.
+func (tc *TestCampaign) ReplaceSchedule(s Schedule) error {
+	i := tc.scheduleIndex(s.ID())
+	if i < 0 {
+		return ErrScheduleNotFound
+	}
+
+	tc.schedules[i] = s
+
+	return nil
+}
+
+func (tc *TestCampaign) RemoveSchedule(scheduleID string) error {
+	i := tc.scheduleIndex(scheduleID)
+	if i < 0 {
+		return ErrScheduleNotFound
+	}
+
+	tc.schedules = append(tc.schedules[:i], tc.schedules[i+1:]...)
+
+	return nil
+}
+
+func (tc *TestCampaign) scheduleIndex(scheduleID string) int {
+	for i, s := range tc.schedules {
+		if s.ID() == scheduleID {
+			return i
+		}
+	}
+
+	return -1
+}
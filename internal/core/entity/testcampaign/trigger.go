@@ -0,0 +1,81 @@
+package testcampaign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TriggerTolerance is the maximum age of the trigger
+// timestamp, older triggers are rejected as replayed.
+const TriggerTolerance = 5 * time.Minute
+
+const triggerSignaturePrefix = "sha256="
+
+var (
+	ErrTriggerTokenNotIssued     = errors.New("trigger token is not issued")
+	ErrInvalidTriggerSignature   = errors.New("invalid trigger signature")
+	ErrTriggerTimestampOutOfDate = errors.New("trigger timestamp is out of date")
+)
+
+// SignTrigger returns signature of the payload sent at timestamp
+// in form of "sha256=<hex>". The signature is HMAC-SHA256 of
+// the "<unix timestamp>.<payload>" with the trigger token as key.
+func SignTrigger(token string, payload []byte, timestamp time.Time) string {
+	return sign(token, payload, timestamp)
+}
+
+func sign(key string, payload []byte, timestamp time.Time) string {
+	mac := hmac.New(sha256.New, []byte(key))
+
+	_, _ = mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(payload)
+
+	return triggerSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (tc *TestCampaign) TriggerToken() string {
+	return tc.triggerToken
+}
+
+// SetTriggerToken issues new trigger token
+// or revokes it if the token is empty.
+func (tc *TestCampaign) SetTriggerToken(token string) {
+	tc.triggerToken = token
+}
+
+// VerifyTrigger checks that the payload is signed with the trigger token
+// of the test campaign and the timestamp differs from now no more than
+// TriggerTolerance.
+func (tc *TestCampaign) VerifyTrigger(
+	payload []byte,
+	signature string,
+	timestamp time.Time,
+	now time.Time,
+) error {
+	if tc.triggerToken == "" {
+		return ErrTriggerTokenNotIssued
+	}
+
+	if age := now.Sub(timestamp); age > TriggerTolerance || age < -TriggerTolerance {
+		return ErrTriggerTimestampOutOfDate
+	}
+
+	if !strings.HasPrefix(signature, triggerSignaturePrefix) {
+		return ErrInvalidTriggerSignature
+	}
+
+	expected := SignTrigger(tc.triggerToken, payload, timestamp)
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidTriggerSignature
+	}
+
+	return nil
+}
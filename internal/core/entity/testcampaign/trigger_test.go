@@ -0,0 +1,145 @@
+package testcampaign_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
+)
+
+func TestVerifyTrigger(t *testing.T) {
+	t.Parallel()
+
+	const token = "c2VjcmV0LXRva2Vu"
+
+	var (
+		payload = []byte(`{"priority":"MANUAL"}`)
+		now     = time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	testCases := []struct {
+		Name        string
+		Token       string
+		Payload     []byte
+		Signature   string
+		Timestamp   time.Time
+		ShouldBeErr bool
+		ExpectedErr error
+	}{
+		{
+			Name:        "valid_signature",
+			Token:       token,
+			Payload:     payload,
+			Signature:   testcampaign.SignTrigger(token, payload, now),
+			Timestamp:   now,
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "timestamp_within_tolerance",
+			Token:       token,
+			Payload:     payload,
+			Signature:   testcampaign.SignTrigger(token, payload, now.Add(-4*time.Minute)),
+			Timestamp:   now.Add(-4 * time.Minute),
+			ShouldBeErr: false,
+		},
+		{
+			Name:        "token_not_issued",
+			Token:       "",
+			Payload:     payload,
+			Signature:   testcampaign.SignTrigger(token, payload, now),
+			Timestamp:   now,
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrTriggerTokenNotIssued,
+		},
+		{
+			Name:        "signed_with_another_token",
+			Token:       token,
+			Payload:     payload,
+			Signature:   testcampaign.SignTrigger("another", payload, now),
+			Timestamp:   now,
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidTriggerSignature,
+		},
+		{
+			Name:        "modified_payload",
+			Token:       token,
+			Payload:     []byte(`{"priority":"SCHEDULED"}`),
+			Signature:   testcampaign.SignTrigger(token, payload, now),
+			Timestamp:   now,
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidTriggerSignature,
+		},
+		{
+			Name:        "signature_without_prefix",
+			Token:       token,
+			Payload:     payload,
+			Signature:   testcampaign.SignTrigger(token, payload, now)[len("sha256="):],
+			Timestamp:   now,
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrInvalidTriggerSignature,
+		},
+		{
+			Name:        "out_of_date_timestamp",
+			Token:       token,
+			Payload:     payload,
+			Signature:   testcampaign.SignTrigger(token, payload, now.Add(-6*time.Minute)),
+			Timestamp:   now.Add(-6 * time.Minute),
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrTriggerTimestampOutOfDate,
+		},
+		{
+			Name:        "timestamp_from_future",
+			Token:       token,
+			Payload:     payload,
+			Signature:   testcampaign.SignTrigger(token, payload, now.Add(6*time.Minute)),
+			Timestamp:   now.Add(6 * time.Minute),
+			ShouldBeErr: true,
+			ExpectedErr: testcampaign.ErrTriggerTimestampOutOfDate,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tc := testcampaign.MustNew(testcampaign.Params{
+				ID:           "tc-id",
+				OwnerID:      "owner-id",
+				TriggerToken: c.Token,
+			})
+
+			err := tc.VerifyTrigger(c.Payload, c.Signature, c.Timestamp, now)
+
+			if c.ShouldBeErr {
+				require.ErrorIs(t, err, c.ExpectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetTriggerToken(t *testing.T) {
+	t.Parallel()
+
+	tc := testcampaign.MustNew(testcampaign.Params{
+		ID:      "id",
+		OwnerID: "owner-id",
+	})
+
+	require.Empty(t, tc.TriggerToken())
+
+	tc.SetTriggerToken("token")
+
+	require.Equal(t, "token", tc.TriggerToken())
+
+	tc.SetTriggerToken("")
+
+	require.Empty(t, tc.TriggerToken())
+}
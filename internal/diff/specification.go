@@ -0,0 +1,97 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+// Specifications writes to w changes that turn specification
+// from the fromPath file into specification from the toPath file.
+// Error is returned if some file can't be read or parsed.
+func Specifications(w io.Writer, fromPath, toPath string) error {
+	from, err := parseSpecification(fromPath)
+	if err != nil {
+		return err
+	}
+
+	to, err := parseSpecification(toPath)
+	if err != nil {
+		return err
+	}
+
+	return printDiff(w, specification.Compare(from, to))
+}
+
+func parseSpecification(specPath string) (spec *specification.Specification, err error) {
+	defer func() {
+		err = errors.Wrap(err, specPath)
+	}()
+
+	specFile, err := os.Open(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer specFile.Close()
+
+	specParser := parser.ForFile(specPath)
+
+	return specParser.ParseSpecification(specFile)
+}
+
+const fieldIndent = "    "
+
+var changeMarks = map[specification.ChangeKind]struct {
+	mark  string
+	color color.Color
+}{
+	specification.Added:    {mark: "+", color: color.FgGreen},
+	specification.Removed:  {mark: "-", color: color.FgRed},
+	specification.Modified: {mark: "~", color: color.FgYellow},
+}
+
+func printDiff(w io.Writer, d specification.Diff) error {
+	if d.IsZero() {
+		_, err := fmt.Fprintln(w, "No changes")
+
+		return err
+	}
+
+	for _, c := range d.Changes() {
+		m := changeMarks[c.Kind()]
+
+		if _, err := fmt.Fprintln(w, m.color.Sprintf("%s %s %s", m.mark, c.Slug().Kind(), c.Slug())); err != nil {
+			return err
+		}
+
+		for _, f := range c.Fields() {
+			_, err := fmt.Fprintf(w, "%s%s: %v -> %v\n", fieldIndent, f.Field(), formatValue(f.From()), formatValue(f.To()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatValue(v interface{}) string {
+	if asserts, ok := v.([]specification.Assert); ok {
+		values := make([]string, 0, len(asserts))
+
+		for _, a := range asserts {
+			values = append(values, fmt.Sprintf("%s=%v", a.Actual(), a.Expected()))
+		}
+
+		return fmt.Sprintf("%q", values)
+	}
+
+	return fmt.Sprintf("%q", fmt.Sprint(v))
+}
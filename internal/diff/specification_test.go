@@ -0,0 +1,151 @@
+package diff_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/color"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/diff"
+)
+
+const baseSpec = `
+stories:
+  cart:
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: https://api.example.com/cart
+              response:
+                allowedCodes: [201]
+`
+
+func TestSpecifications(t *testing.T) {
+	color.Disable()
+
+	testCases := []struct {
+		Name        string
+		From        string
+		To          string
+		ShouldBeErr bool
+		Expected    string
+	}{
+		{
+			Name:     "no_changes",
+			From:     baseSpec,
+			To:       baseSpec,
+			Expected: "No changes\n",
+		},
+		{
+			Name: "modified_http_method",
+			From: baseSpec,
+			To: `
+stories:
+  cart:
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: PUT
+                url: https://api.example.com/cart
+              response:
+                allowedCodes: [201]
+`,
+			Expected: "~ thesis cart.add.addProduct\n" +
+				"    http.request.method: \"POST\" -> \"PUT\"\n",
+		},
+		{
+			Name: "added_scenario",
+			From: baseSpec,
+			To: baseSpec + `      remove:
+        theses:
+          removeProduct:
+            when: product is removed
+            http:
+              request:
+                method: DELETE
+                url: https://api.example.com/cart
+`,
+			Expected: "+ scenario cart.remove\n",
+		},
+		{
+			Name: "removed_story",
+			From: baseSpec + `  checkout:
+    scenarios:
+      pay:
+        theses:
+          payOrder:
+            when: order is paid
+            http:
+              request:
+                method: POST
+                url: https://api.example.com/checkout
+`,
+			To:       baseSpec,
+			Expected: "- story checkout\n",
+		},
+		{
+			Name:        "invalid_specification",
+			From:        baseSpec,
+			To:          "stories: {}\n",
+			ShouldBeErr: true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				fromPath = writeSpec(t, c.From)
+				toPath   = writeSpec(t, c.To)
+				out      bytes.Buffer
+			)
+
+			err := diff.Specifications(&out, fromPath, toPath)
+
+			if c.ShouldBeErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), toPath)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.Expected, out.String())
+		})
+	}
+}
+
+func TestSpecificationsWithMissingFile(t *testing.T) {
+	t.Parallel()
+
+	missingPath := filepath.Join(t.TempDir(), "missing.yml")
+
+	err := diff.Specifications(&bytes.Buffer{}, writeSpec(t, baseSpec), missingPath)
+
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.Contains(t, err.Error(), missingPath)
+}
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+
+	specPath := filepath.Join(t.TempDir(), "spec.yml")
+	require.NoError(t, os.WriteFile(specPath, []byte(content), 0o600))
+
+	return specPath
+}
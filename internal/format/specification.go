@@ -0,0 +1,98 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/validate"
+)
+
+type Options struct {
+	// Check reports unformatted files
+	// without rewriting them.
+	Check bool
+}
+
+// Specifications rewrites specification files matched by patterns
+// in canonical form and writes paths of the changed files to w.
+// Invalid specifications are left as is and reported with
+// diagnostics to errW.
+//
+// In check mode files are not rewritten and Specifications returns
+// non-zero exit code if any file is not formatted, so it can be used
+// in CI. Invalid specifications always lead to non-zero exit code.
+// Gherkin feature files have no canonical form and are skipped.
+func Specifications(w, errW io.Writer, patterns []string, opts Options) (int, error) {
+	files, err := validate.Files(patterns...)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		invalid     validate.Report
+		unformatted int
+	)
+
+	for _, file := range files {
+		if parser.IsFeature(file) {
+			continue
+		}
+
+		changed, err := formatFile(file, opts.Check)
+		if err != nil {
+			invalid.Files = append(invalid.Files, file)
+			invalid.Diagnostics = append(invalid.Diagnostics, validate.Diagnostics(file, err)...)
+
+			continue
+		}
+
+		if changed {
+			unformatted++
+
+			if _, err := fmt.Fprintln(w, file); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if len(invalid.Diagnostics) > 0 {
+		return 1, validate.WriteReport(errW, invalid, validate.TextFormat)
+	}
+
+	if opts.Check && unformatted > 0 {
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+func formatFile(file string, check bool) (changed bool, err error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+
+	formatted, err := yaml.FormatSpecification(content)
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(content, formatted) {
+		return false, nil
+	}
+
+	if check {
+		return true, nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, err
+	}
+
+	return true, os.WriteFile(file, formatted, info.Mode())
+}
@@ -0,0 +1,161 @@
+package format_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/color"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/format"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite golden specifications in testdata")
+
+// TestFormatSpecifications compares formatted specifications of
+// testdata with golden files. Run with -update-golden flag to
+// rewrite them.
+func TestFormatSpecifications(t *testing.T) {
+	testCases := []string{
+		"key-order",
+		"comments",
+	}
+
+	for _, name := range testCases {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			specPath := copySpecification(t, filepath.Join("testdata", name+".yml"))
+
+			var out bytes.Buffer
+
+			code, err := format.Specifications(&out, &bytes.Buffer{}, []string{specPath}, format.Options{})
+			require.NoError(t, err)
+
+			require.Equal(t, 0, code)
+			require.Equal(t, specPath+"\n", out.String())
+
+			formatted, err := os.ReadFile(specPath)
+			require.NoError(t, err)
+
+			goldenPath := filepath.Join("testdata", name+".golden")
+
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(goldenPath, formatted, 0o600))
+			}
+
+			golden, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+
+			require.Equal(t, string(golden), string(formatted))
+
+			out.Reset()
+
+			code, err = format.Specifications(&out, &bytes.Buffer{}, []string{specPath}, format.Options{Check: true})
+			require.NoError(t, err)
+
+			require.Equal(t, 0, code, "formatted specification should pass check")
+			require.Empty(t, out.String())
+		})
+	}
+}
+
+func TestFormatSpecificationsCheck(t *testing.T) {
+	color.Disable()
+
+	testCases := []struct {
+		Name          string
+		Specification string
+		WantCode      int
+		WantChanged   bool
+		WantInvalid   bool
+	}{
+		{
+			Name:          "formatted",
+			Specification: filepath.Join("testdata", "comments.golden"),
+			WantCode:      0,
+		},
+		{
+			Name:          "unformatted",
+			Specification: filepath.Join("testdata", "key-order.yml"),
+			WantCode:      1,
+			WantChanged:   true,
+		},
+		{
+			Name:          "invalid",
+			Specification: filepath.Join("testdata", "invalid.yml"),
+			WantCode:      1,
+			WantInvalid:   true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			specPath := copySpecification(t, c.Specification)
+
+			before, err := os.ReadFile(specPath)
+			require.NoError(t, err)
+
+			var out, errOut bytes.Buffer
+
+			code, err := format.Specifications(&out, &errOut, []string{specPath}, format.Options{Check: true})
+			require.NoError(t, err)
+
+			require.Equal(t, c.WantCode, code)
+
+			if c.WantChanged {
+				require.Equal(t, specPath+"\n", out.String())
+			} else {
+				require.Empty(t, out.String())
+			}
+
+			if c.WantInvalid {
+				require.Contains(t, errOut.String(), specPath)
+			} else {
+				require.Empty(t, errOut.String())
+			}
+
+			after, err := os.ReadFile(specPath)
+			require.NoError(t, err)
+
+			require.Equal(t, string(before), string(after), "check should not rewrite specification")
+		})
+	}
+}
+
+func TestFormatMissingSpecification(t *testing.T) {
+	color.Disable()
+
+	specPath := filepath.Join(t.TempDir(), "missing.yml")
+
+	var errOut bytes.Buffer
+
+	code, err := format.Specifications(&bytes.Buffer{}, &errOut, []string{specPath}, format.Options{})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, code)
+	require.Contains(t, errOut.String(), specPath)
+}
+
+func TestFormatSpecificationsWithMalformedPattern(t *testing.T) {
+	_, err := format.Specifications(&bytes.Buffer{}, &bytes.Buffer{}, []string{"[spec"}, format.Options{})
+	require.Error(t, err)
+}
+
+func copySpecification(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	specPath := filepath.Join(t.TempDir(), "spec.yml")
+
+	require.NoError(t, os.WriteFile(specPath, content, 0o600))
+
+	return specPath
+}
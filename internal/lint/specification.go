@@ -0,0 +1,250 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser"
+	yamlParser "github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/validate"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// DefaultConfigPath is the lint config used
+// if it exists and no other config is set.
+const DefaultConfigPath = ".thestis-lint.yml"
+
+type Options struct {
+	// ConfigPath is the path to YAML file with
+	// severities of rules by their IDs, for example:
+	//
+	//	rules:
+	//	  hardcoded-host: off
+	//	  missing-narrative: error
+	ConfigPath string
+	Format     string
+}
+
+// Problem is a lint problem of the specification file.
+type Problem struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Slug     string `json:"slug,omitempty"`
+	Message  string `json:"message"`
+}
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Specifications lints specification files matched by patterns
+// and writes found problems to w. Problems of YAML specifications
+// are positioned at their stories, scenarios and theses. Invalid
+// specifications are reported as problems of the error severity
+// with the invalid rule.
+//
+// Specifications returns non-zero exit code if there are problems
+// of the error severity. Error is returned if the config or files
+// can't be read.
+func Specifications(w io.Writer, patterns []string, opts Options) (int, error) {
+	if opts.Format != TextFormat && opts.Format != JSONFormat {
+		return 0, errors.Wrapf(ErrUnknownFormat, "%q", opts.Format)
+	}
+
+	cfg, err := loadConfig(opts.ConfigPath)
+	if err != nil {
+		return 0, err
+	}
+
+	files, err := validate.Files(patterns...)
+	if err != nil {
+		return 0, err
+	}
+
+	var problems []Problem
+
+	for _, file := range files {
+		problems = append(problems, lintFile(file, cfg)...)
+	}
+
+	if opts.Format == JSONFormat {
+		err = printJSON(w, problems)
+	} else {
+		err = printProblems(w, problems)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range problems {
+		if p.Severity == string(specification.ErrorSeverity) {
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+type config struct {
+	Rules map[string]string `yaml:"rules"`
+}
+
+func loadConfig(configPath string) (cfg specification.LintConfig, err error) {
+	explicit := configPath != ""
+	if !explicit {
+		configPath = DefaultConfigPath
+	}
+
+	defer func() {
+		err = errors.Wrap(err, configPath)
+	}()
+
+	content, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) && !explicit {
+		return specification.LintConfig{}, nil
+	}
+
+	if err != nil {
+		return specification.LintConfig{}, err
+	}
+
+	var c config
+	if err := yaml.Unmarshal(content, &c); err != nil {
+		return specification.LintConfig{}, err
+	}
+
+	return specification.NewLintConfig(c.Rules)
+}
+
+const invalidRule = "invalid"
+
+func lintFile(file string, cfg specification.LintConfig) []Problem {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return invalidProblems(file, err)
+	}
+
+	spec, err := parser.ForFile(file).ParseSpecification(bytes.NewReader(content))
+	if err != nil {
+		return invalidProblems(file, err)
+	}
+
+	positions := slugPositions(file, content)
+
+	lintProblems := specification.Lint(spec, cfg)
+	problems := make([]Problem, 0, len(lintProblems))
+
+	for _, p := range lintProblems {
+		pos := positions[p.Slug()]
+
+		problems = append(problems, Problem{
+			File:     file,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     string(p.Rule()),
+			Severity: string(p.Severity()),
+			Slug:     p.Slug().String(),
+			Message:  p.Message(),
+		})
+	}
+
+	return problems
+}
+
+func invalidProblems(file string, err error) []Problem {
+	diagnostics := validate.Diagnostics(file, err)
+	problems := make([]Problem, 0, len(diagnostics))
+
+	for _, d := range diagnostics {
+		problems = append(problems, Problem{
+			File:     file,
+			Line:     d.Line,
+			Column:   d.Column,
+			Rule:     invalidRule,
+			Severity: string(specification.ErrorSeverity),
+			Message:  d.Title(),
+		})
+	}
+
+	return problems
+}
+
+// slugPositions returns positions of the objects of the YAML
+// specification, Gherkin feature files have no such positions.
+func slugPositions(file string, content []byte) map[specification.Slug]specification.Position {
+	if parser.IsFeature(file) {
+		return nil
+	}
+
+	positions, err := yamlParser.SlugPositions(content)
+	if err != nil {
+		return nil
+	}
+
+	return positions
+}
+
+var severityColors = map[string]color.Color{
+	string(specification.ErrorSeverity):   color.FgRed,
+	string(specification.WarningSeverity): color.FgYellow,
+	string(specification.InfoSeverity):    color.FgCyan,
+}
+
+func printProblems(w io.Writer, problems []Problem) error {
+	for _, p := range problems {
+		location := p.File
+
+		if p.Line > 0 {
+			location += fmt.Sprintf(":%d:%d", p.Line, p.Column)
+		}
+
+		if p.Slug != "" {
+			location += ": " + p.Slug
+		}
+
+		_, err := fmt.Fprintf(
+			w,
+			"%s: %s %s (%s)\n",
+			location,
+			severityColors[p.Severity].Sprint(p.Severity),
+			p.Message,
+			p.Rule,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(problems) == 0 {
+		_, err := fmt.Fprintln(w, "No problems")
+
+		return err
+	}
+
+	return nil
+}
+
+func printJSON(w io.Writer, problems []Problem) error {
+	if problems == nil {
+		problems = []Problem{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(problems)
+}
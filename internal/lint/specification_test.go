@@ -0,0 +1,520 @@
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/color"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/lint"
+)
+
+const cleanSpecification = `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    inOrderTo: buy products
+    wantTo: add products to cart
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+              response:
+                allowedCodes: [201]
+          checkProduct:
+            then: product is in cart
+            after: [addProduct]
+            assertion:
+              with: jsonpath
+              assert:
+                - actual: addProduct.response.body.id
+                  expected: 1
+`
+
+func TestLintSpecificationsRules(t *testing.T) {
+	color.Disable()
+
+	testCases := []struct {
+		Name          string
+		Specification string
+		Config        string
+		Rule          specification.LintRule
+		Slug          string
+		Line          int
+		Column        int
+	}{
+		{
+			Name: "then_without_assertion",
+			Specification: `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    inOrderTo: buy products
+    wantTo: add products to cart
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+              response:
+                allowedCodes: [201]
+          checkProduct:
+            then: product is in cart
+            after: [addProduct]
+            http:
+              request:
+                method: GET
+                url: "{{ host }}/products/{{ addProduct.response.body.id }}"
+              response:
+                allowedCodes: [200]
+`,
+			Config: "rules:\n  unused-response: \"off\"\n",
+			Rule:   specification.ThenWithoutAssertionRule,
+			Slug:   "cart.add.checkProduct",
+			Line:   19,
+			Column: 11,
+		},
+		{
+			Name: "no_allowed_codes",
+			Specification: `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    inOrderTo: buy products
+    wantTo: add products to cart
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+          checkProduct:
+            then: product is in cart
+            after: [addProduct]
+            assertion:
+              with: jsonpath
+              assert:
+                - actual: addProduct.response.body.id
+                  expected: 1
+`,
+			Rule:   specification.NoAllowedCodesRule,
+			Slug:   "cart.add.addProduct",
+			Line:   11,
+			Column: 11,
+		},
+		{
+			Name: "unused_response",
+			Specification: `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    inOrderTo: buy products
+    wantTo: add products to cart
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+              response:
+                allowedCodes: [201]
+`,
+			Rule:   specification.UnusedResponseRule,
+			Slug:   "cart.add.addProduct",
+			Line:   11,
+			Column: 11,
+		},
+		{
+			Name: "hardcoded_host",
+			Specification: `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    inOrderTo: buy products
+    wantTo: add products to cart
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: https://shop.example.com/products
+              response:
+                allowedCodes: [201]
+          checkProduct:
+            then: product is in cart
+            after: [addProduct]
+            assertion:
+              with: jsonpath
+              assert:
+                - actual: addProduct.response.body.id
+                  expected: 1
+`,
+			Rule:   specification.HardcodedHostRule,
+			Slug:   "cart.add.addProduct",
+			Line:   11,
+			Column: 11,
+		},
+		{
+			Name: "duplicate_request_body",
+			Specification: `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    inOrderTo: buy products
+    wantTo: add products to cart
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+                body:
+                  name: apple
+              response:
+                allowedCodes: [201]
+          addSameProduct:
+            when: same product is added
+            after: [addProduct]
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products/{{ addProduct.response.body.id }}"
+                body:
+                  name: apple
+              response:
+                allowedCodes: [201]
+          checkProduct:
+            then: product is in cart
+            after: [addSameProduct]
+            assertion:
+              with: jsonpath
+              assert:
+                - actual: addSameProduct.response.body.id
+                  expected: 1
+`,
+			Rule:   specification.DuplicateRequestBodyRule,
+			Slug:   "cart.add.addSameProduct",
+			Line:   21,
+			Column: 11,
+		},
+		{
+			Name: "missing_narrative",
+			Specification: `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+              response:
+                allowedCodes: [201]
+          checkProduct:
+            then: product is in cart
+            after: [addProduct]
+            assertion:
+              with: jsonpath
+              assert:
+                - actual: addProduct.response.body.id
+                  expected: 1
+`,
+			Rule:   specification.MissingNarrativeRule,
+			Slug:   "cart",
+			Line:   4,
+			Column: 3,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			specPath := writeFile(t, "spec.yml", c.Specification)
+
+			var opts lint.Options
+			if c.Config != "" {
+				opts.ConfigPath = writeFile(t, "lint.yml", c.Config)
+			}
+
+			problems, code := lintSpecifications(t, specPath, opts)
+
+			require.Equal(t, 0, code)
+			require.NotEmpty(t, problems)
+			require.Equal(t, []lint.Problem{
+				{
+					File:     specPath,
+					Line:     c.Line,
+					Column:   c.Column,
+					Rule:     string(c.Rule),
+					Severity: string(specification.LintRules()[c.Rule]),
+					Slug:     c.Slug,
+					Message:  problems[0].Message,
+				},
+			}, problems)
+		})
+	}
+}
+
+func TestLintSpecificationsConfig(t *testing.T) {
+	color.Disable()
+
+	const withoutNarrative = `author: Djerys
+title: cart
+stories:
+  cart:
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+              response:
+                allowedCodes: [201]
+          checkProduct:
+            then: product is in cart
+            after: [addProduct]
+            assertion:
+              with: jsonpath
+              assert:
+                - actual: addProduct.response.body.id
+                  expected: 1
+`
+
+	testCases := []struct {
+		Name         string
+		Config       string
+		WantSeverity specification.Severity
+		WantCode     int
+	}{
+		{
+			Name:         "default_severity",
+			Config:       "rules: {}\n",
+			WantSeverity: specification.WarningSeverity,
+			WantCode:     0,
+		},
+		{
+			Name:     "disabled_rule",
+			Config:   "rules:\n  missing-narrative: \"off\"\n",
+			WantCode: 0,
+		},
+		{
+			Name:         "rule_as_error",
+			Config:       "rules:\n  missing-narrative: error\n",
+			WantSeverity: specification.ErrorSeverity,
+			WantCode:     1,
+		},
+		{
+			Name:         "other_rule_disabled",
+			Config:       "rules:\n  hardcoded-host: \"off\"\n",
+			WantSeverity: specification.WarningSeverity,
+			WantCode:     0,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			specPath := writeFile(t, "spec.yml", withoutNarrative)
+			configPath := writeFile(t, "lint.yml", c.Config)
+
+			problems, code := lintSpecifications(t, specPath, lint.Options{ConfigPath: configPath})
+
+			require.Equal(t, c.WantCode, code)
+
+			if c.WantSeverity == "" {
+				require.Empty(t, problems)
+
+				return
+			}
+
+			require.Len(t, problems, 1)
+			require.Equal(t, string(specification.MissingNarrativeRule), problems[0].Rule)
+			require.Equal(t, string(c.WantSeverity), problems[0].Severity)
+		})
+	}
+}
+
+func TestLintSpecificationsWithInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Config string
+	}{
+		{
+			Name:   "unknown_rule",
+			Config: "rules:\n  unknown-rule: error\n",
+		},
+		{
+			Name:   "not_allowed_severity",
+			Config: "rules:\n  missing-narrative: fatal\n",
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			specPath := writeFile(t, "spec.yml", cleanSpecification)
+			configPath := writeFile(t, "lint.yml", c.Config)
+
+			_, err := lint.Specifications(&bytes.Buffer{}, []string{specPath}, lint.Options{
+				ConfigPath: configPath,
+				Format:     lint.JSONFormat,
+			})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLintSpecificationsWithoutProblems(t *testing.T) {
+	color.Disable()
+
+	specPath := writeFile(t, "spec.yml", cleanSpecification)
+
+	var out bytes.Buffer
+
+	code, err := lint.Specifications(&out, []string{specPath}, lint.Options{
+		Format: lint.TextFormat,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 0, code)
+	require.Equal(t, "No problems\n", out.String())
+}
+
+func TestLintSpecificationsPrintsPositions(t *testing.T) {
+	color.Disable()
+
+	specPath := writeFile(t, "spec.yml", `author: Djerys
+title: cart
+stories:
+  cart:
+    asA: customer
+    inOrderTo: buy products
+    scenarios:
+      add:
+        theses:
+          addProduct:
+            when: product is added
+            http:
+              request:
+                method: POST
+                url: "{{ host }}/products"
+              response:
+                allowedCodes: [201]
+          checkProduct:
+            then: product is in cart
+            after: [addProduct]
+            assertion:
+              with: jsonpath
+              assert:
+                - actual: addProduct.response.body.id
+                  expected: 1
+`)
+
+	var out bytes.Buffer
+
+	code, err := lint.Specifications(&out, []string{specPath}, lint.Options{
+		Format: lint.TextFormat,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 0, code)
+	require.Contains(t, out.String(), specPath+":4:3: cart: warning")
+	require.Contains(t, out.String(), "(missing-narrative)")
+}
+
+func TestLintInvalidSpecification(t *testing.T) {
+	specPath := writeFile(t, "spec.yml", "author: Djerys\nstories: {}\n")
+
+	problems, code := lintSpecifications(t, specPath, lint.Options{})
+
+	require.Equal(t, 1, code)
+	require.NotEmpty(t, problems)
+
+	for _, p := range problems {
+		require.Equal(t, "invalid", p.Rule)
+		require.Equal(t, string(specification.ErrorSeverity), p.Severity)
+	}
+}
+
+func TestLintSpecificationsWithUnknownFormat(t *testing.T) {
+	specPath := writeFile(t, "spec.yml", cleanSpecification)
+
+	_, err := lint.Specifications(&bytes.Buffer{}, []string{specPath}, lint.Options{
+		Format: "xml",
+	})
+	require.ErrorIs(t, err, lint.ErrUnknownFormat)
+}
+
+func lintSpecifications(t *testing.T, specPath string, opts lint.Options) ([]lint.Problem, int) {
+	t.Helper()
+
+	if opts.ConfigPath == "" {
+		opts.ConfigPath = writeFile(t, "lint.yml", "rules: {}\n")
+	}
+
+	opts.Format = lint.JSONFormat
+
+	var out bytes.Buffer
+
+	code, err := lint.Specifications(&out, []string{specPath}, opts)
+	require.NoError(t, err)
+
+	var problems []lint.Problem
+
+	require.NoError(t, json.Unmarshal(out.Bytes(), &problems))
+
+	return problems, code
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
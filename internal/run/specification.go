@@ -0,0 +1,222 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gookit/color"
+
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/httpexec"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/jsonpath"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser"
+	htmlReport "github.com/harpyd/thestis/internal/core/adapter/driven/report/html"
+	jsonReport "github.com/harpyd/thestis/internal/core/adapter/driven/report/json"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/report/junit"
+	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/flow"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+	"github.com/harpyd/thestis/internal/validate"
+)
+
+type Options struct {
+	// HTTPTimeout limits each HTTP request of theses.
+	HTTPTimeout time.Duration
+	// FlowTimeout limits the whole run, zero means no limit.
+	FlowTimeout time.Duration
+	JUnitPath   string
+	JSONPath    string
+	HTMLPath    string
+}
+
+// Specification runs the specification from the specPath file
+// in memory with real executors, prints steps as they occur and
+// the tree of scenario states at the end. Reports are written to
+// the paths of opts if they are set.
+//
+// Specification returns non-zero exit code if the flow is not passed.
+func Specification(specPath string, opts Options) int {
+	spec := parseSpecification(specPath)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if opts.FlowTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.FlowTimeout)
+		defer cancel()
+	}
+
+	f := runSpecification(ctx, os.Stdout, spec, opts)
+
+	printTree(os.Stdout, f)
+
+	writeReport(opts.JUnitPath, junit.NewReporter(), f, spec)
+	writeReport(opts.JSONPath, jsonReport.NewReporter(), f, spec)
+	writeReport(opts.HTMLPath, htmlReport.NewReporter(), f, spec)
+
+	if f.OverallState() != flow.Passed {
+		return 1
+	}
+
+	return 0
+}
+
+func parseSpecification(specPath string) *specification.Specification {
+	specFile, err := os.Open(specPath)
+	if err != nil {
+		log.Fatalf("%s: %s", specPath, err)
+	}
+
+	defer specFile.Close()
+
+	specParser := parser.ForFile(specPath)
+
+	spec, err := specParser.ParseSpecification(specFile)
+	if err != nil {
+		log.Fatalf("%s:\n%s", specPath, validate.FormatError(err))
+	}
+
+	return spec
+}
+
+func runSpecification(
+	ctx context.Context,
+	w io.Writer,
+	spec *specification.Specification,
+	opts Options,
+) *flow.Flow {
+	pipe := pipeline.Trigger(
+		uuid.New().String(),
+		spec,
+		pipeline.WithHTTP(httpexec.NewExecutor(opts.HTTPTimeout)),
+		pipeline.WithAssertion(jsonpath.NewExecutor()),
+	)
+
+	f := flow.Fulfill(uuid.New().String(), pipe)
+
+	for step := range pipe.MustStart(ctx) {
+		f = f.ApplyStep(step)
+
+		printStep(w, step)
+	}
+
+	return f
+}
+
+type mark struct {
+	sign  string
+	color color.Color
+}
+
+var stateMarks = map[flow.State]mark{
+	flow.Passed:      {sign: "✓", color: color.FgGreen},
+	flow.Failed:      {sign: "✗", color: color.FgRed},
+	flow.Crashed:     {sign: "!", color: color.FgMagenta},
+	flow.Canceled:    {sign: "-", color: color.FgGray},
+	flow.NotExecuted: {sign: "·", color: color.FgGray},
+	flow.Paused:      {sign: "‖", color: color.FgYellow},
+	flow.Executing:   {sign: "…", color: color.FgYellow},
+}
+
+var eventStates = map[pipeline.Event]flow.State{
+	pipeline.NoEvent:     flow.Crashed,
+	pipeline.FiredPass:   flow.Passed,
+	pipeline.FiredFail:   flow.Failed,
+	pipeline.FiredCrash:  flow.Crashed,
+	pipeline.FiredCancel: flow.Canceled,
+	pipeline.FiredPause:  flow.Paused,
+}
+
+const indent = "  "
+
+func printStep(w io.Writer, step pipeline.Step) {
+	if step.Event() == pipeline.FiredExecute {
+		return
+	}
+
+	state := eventStates[step.Event()]
+	m := stateMarks[state]
+
+	line := m.color.Sprintf("%s %s %s %s", m.sign, step.Slug().Kind(), step.Slug(), state)
+
+	if step.Slug().Kind() == specification.ThesisSlug {
+		line = indent + line
+	}
+
+	if step.Err() != nil && step.Slug().Kind() == specification.ThesisSlug {
+		line += ": " + step.Err().Error()
+	}
+
+	_, _ = fmt.Fprintln(w, line)
+}
+
+func printTree(w io.Writer, f *flow.Flow) {
+	statuses := f.Statuses()
+	sort.Slice(statuses, func(i, j int) bool {
+		a, b := statuses[i].Slug(), statuses[j].Slug()
+		if a.Story() != b.Story() {
+			return a.Story() < b.Story()
+		}
+
+		return a.Scenario() < b.Scenario()
+	})
+
+	_, _ = fmt.Fprintln(w)
+
+	for i, s := range statuses {
+		if i == 0 || statuses[i-1].Slug().Story() != s.Slug().Story() {
+			_, _ = fmt.Fprintln(w, s.Slug().Story())
+		}
+
+		m := stateMarks[s.State()]
+		_, _ = fmt.Fprintln(w, indent+m.color.Sprintf("%s %s", m.sign, s.Slug().Scenario()))
+
+		theses := s.ThesisStatuses()
+		sort.Slice(theses, func(i, j int) bool {
+			return theses[i].ThesisSlug() < theses[j].ThesisSlug()
+		})
+
+		for _, t := range theses {
+			tm := stateMarks[t.State()]
+			_, _ = fmt.Fprintln(w, indent+indent+tm.color.Sprintf("%s %s", tm.sign, t.ThesisSlug()))
+
+			for _, err := range t.OccurredErrs() {
+				_, _ = fmt.Fprintln(w, indent+indent+indent+color.FgRed.Render(err))
+			}
+		}
+	}
+
+	m := stateMarks[f.OverallState()]
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, m.color.Sprintf("%s %s", m.sign, f.OverallState()))
+}
+
+func writeReport(
+	path string,
+	reporter service.FlowReporter,
+	f *flow.Flow,
+	spec *specification.Specification,
+) {
+	if path == "" {
+		return
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("%s: %s", path, err)
+	}
+
+	defer file.Close()
+
+	if err := reporter.WriteFlowReport(file, f, spec); err != nil {
+		log.Fatalf("%s: %s", path, err)
+	}
+}
@@ -0,0 +1,146 @@
+package run_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/run"
+)
+
+const specTemplate = `---
+author: Djerys
+title: run test
+description: run test
+
+stories:
+  buyHorns:
+    asA: customer
+    inOrderTo: sell horns
+    wantTo: buy them first
+    scenarios:
+      buyOne:
+        description: buy one pair of horns
+        theses:
+          addToCart:
+            when: add horns to the cart
+            http:
+              request:
+                method: POST
+                url: %s/cart
+                contentType: application/json
+                body:
+                  count: 1
+              response:
+                allowedCodes:
+                  - %d
+`
+
+type junitReport struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Errors   int `xml:"errors,attr"`
+}
+
+func TestSpecification(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	testCases := []struct {
+		Name             string
+		URL              string
+		AllowedCode      int
+		ExpectedExitCode int
+		ExpectedReport   junitReport
+	}{
+		{
+			Name:             "passed",
+			URL:              srv.URL,
+			AllowedCode:      http.StatusCreated,
+			ExpectedExitCode: 0,
+			ExpectedReport:   junitReport{Tests: 1},
+		},
+		{
+			Name:             "failed",
+			URL:              srv.URL,
+			AllowedCode:      http.StatusOK,
+			ExpectedExitCode: 1,
+			ExpectedReport:   junitReport{Tests: 1, Failures: 1},
+		},
+		{
+			Name:             "crashed",
+			URL:              closed.URL,
+			AllowedCode:      http.StatusCreated,
+			ExpectedExitCode: 1,
+			ExpectedReport:   junitReport{Tests: 1, Errors: 1},
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			specPath := filepath.Join(dir, "spec.yml")
+			spec := fmt.Sprintf(specTemplate, c.URL, c.AllowedCode)
+			require.NoError(t, os.WriteFile(specPath, []byte(spec), 0o600))
+
+			junitPath := filepath.Join(dir, "report.xml")
+
+			code := run.Specification(specPath, run.Options{
+				HTTPTimeout: time.Second,
+				JUnitPath:   junitPath,
+			})
+			require.Equal(t, c.ExpectedExitCode, code)
+
+			raw, err := os.ReadFile(junitPath)
+			require.NoError(t, err)
+
+			var report junitReport
+
+			require.NoError(t, xml.Unmarshal(raw, &report))
+			require.Equal(t, c.ExpectedReport, report)
+		})
+	}
+}
+
+func TestSpecificationWithoutReports(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+
+	specPath := filepath.Join(dir, "spec.yml")
+	spec := fmt.Sprintf(specTemplate, srv.URL, http.StatusCreated)
+	require.NoError(t, os.WriteFile(specPath, []byte(spec), 0o600))
+
+	code := run.Specification(specPath, run.Options{HTTPTimeout: time.Second})
+	require.Equal(t, 0, code)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "only specification should be in the directory")
+}
@@ -7,10 +7,11 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	fireauth "firebase.google.com/go/auth"
-	"github.com/gammazero/workerpool"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -22,17 +23,28 @@ import (
 	"github.com/harpyd/thestis/internal/config"
 	fakeAdapter "github.com/harpyd/thestis/internal/core/adapter/driven/auth/fake"
 	firebaseAdapter "github.com/harpyd/thestis/internal/core/adapter/driven/auth/firebase"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/httpexec"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/executor/jsonpath"
 	zapAdapter "github.com/harpyd/thestis/internal/core/adapter/driven/logger/zap"
 	"github.com/harpyd/thestis/internal/core/adapter/driven/metrics/prometheus"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/notification/webhook"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/gherkin"
 	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
 	mongoAdapter "github.com/harpyd/thestis/internal/core/adapter/driven/persistence/mongodb"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/pubsub/inmemory"
 	"github.com/harpyd/thestis/internal/core/adapter/driven/pubsub/natsio"
+	queueAdapter "github.com/harpyd/thestis/internal/core/adapter/driven/queue/inmemory"
+	htmlReport "github.com/harpyd/thestis/internal/core/adapter/driven/report/html"
+	jsonReport "github.com/harpyd/thestis/internal/core/adapter/driven/report/json"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/report/junit"
 	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
 	v1 "github.com/harpyd/thestis/internal/core/adapter/driver/rest/v1"
 	"github.com/harpyd/thestis/internal/core/app"
 	"github.com/harpyd/thestis/internal/core/app/command"
 	"github.com/harpyd/thestis/internal/core/app/query"
 	"github.com/harpyd/thestis/internal/core/app/service"
+	"github.com/harpyd/thestis/internal/core/entity/pipeline"
+	"github.com/harpyd/thestis/internal/core/entity/testcampaign"
 	"github.com/harpyd/thestis/internal/server"
 	"github.com/harpyd/thestis/pkg/auth/firebase"
 	"github.com/harpyd/thestis/pkg/correlationid"
@@ -44,7 +56,7 @@ type Manager struct {
 	mongoSingleton
 	natsSingleton
 	firebaseSingleton
-	pipelineWPSingleton
+	pipelineQueueSingleton
 
 	logger       service.Logger
 	config       *config.Config
@@ -53,9 +65,15 @@ type Manager struct {
 	metrics      metricsContext
 	pipeline     pipelineContext
 	signalBus    signalBusContext
+	stepBus      stepBusContext
 	app          *app.Application
 	authProvider rest.AuthProvider
 	server       *server.Server
+	scheduler    *service.PipelineScheduler
+
+	worker        bool
+	stopConsumer  context.CancelFunc
+	stopScheduler context.CancelFunc
 }
 
 type mongoSingleton struct {
@@ -78,30 +96,59 @@ type zapSingleton struct {
 	logger *zap.Logger
 }
 
-type pipelineWPSingleton struct {
-	once sync.Once
-	wp   *workerpool.WorkerPool
+type pipelineQueueSingleton struct {
+	once  sync.Once
+	queue *queueAdapter.PipelineQueue
 }
 
 type pipelineContext struct {
 	guard      service.PipelineGuard
+	handover   service.PipelineHandover
+	recoverer  service.PipelineRecoverer
 	policy     service.PipelinePolicy
 	maintainer service.PipelineMaintainer
+	drainer    service.PipelineDrainer
 	enqueuer   service.Enqueuer
+	notifier   *service.WebhookNotifier
 }
 
 type persistentContext struct {
 	testCampaignRepo service.TestCampaignRepository
+	testCampaignRmv  service.TestCampaignRemover
 	specRepo         service.SpecificationRepository
 	pipeRepo         service.PipelineRepository
 	flowRepo         service.FlowRepository
 	testCampaignRM   query.TestCampaignReadModel
+	testCampaignsRM  query.TestCampaignsReadModel
+	schedulesRM      query.SchedulesReadModel
+	subscriptionsRM  query.SubscriptionsReadModel
+	notificationRepo service.NotificationRepository
+	notificationsRM  query.NotificationsReadModel
+	scheduledFinder  service.ScheduledTestCampaignFinder
+	triggerGuard     service.TriggerReplayGuard
 	specificationRM  query.SpecificationReadModel
+	specHistoryRM    query.SpecificationHistoryReadModel
+	specDiffRM       query.SpecificationDiffReadModel
+	specExportRM     query.SpecificationExportReadModel
+	pipelineRM       query.PipelineReadModel
+	pipeHistoryRM    query.PipelineHistoryReadModel
+	flowReportRM     query.FlowReportReadModel
 }
 
 type signalBusContext struct {
-	publisher  service.PipelineCancelPublisher
-	subscriber service.PipelineCancelSubscriber
+	publisher         service.PipelineCancelPublisher
+	subscriber        service.PipelineCancelSubscriber
+	pausePublisher    service.PipelinePausePublisher
+	pauseSubscriber   service.PipelinePauseSubscriber
+	requeuePublisher  service.PipelineRequeuePublisher
+	requeueSubscriber service.PipelineRequeueSubscriber
+	startPublisher    service.PipelineStartPublisher
+	startSubscriber   service.PipelineStartSubscriber
+}
+
+type stepBusContext struct {
+	publisher  service.PipelineStepPublisher
+	subscriber service.PipelineStepSubscriber
 }
 
 type metricsContext struct {
@@ -117,17 +164,49 @@ func New(configsPath string) *Manager {
 	c.initSpecificationParser()
 	c.initMetrics()
 	c.initSignalBus()
+	c.initStepBus()
 	c.initPipeline()
 	c.initApplication()
+	c.initPipelineConsumer()
+	c.initScheduler()
 	c.initAuthenticationProvider()
 	c.initServer()
 
 	return c
 }
 
+// NewWorker returns the runner that doesn't serve HTTP API,
+// but only runs pipelines published to the queue of workers.
+func NewWorker(configsPath string) *Manager {
+	c := &Manager{worker: true}
+
+	c.initConfig(configsPath)
+	c.initLogger()
+	c.initPersistent()
+	c.initSpecificationParser()
+	c.initSignalBus()
+	c.initStepBus()
+	c.initPipeline()
+	c.initApplication()
+	c.initPipelineConsumer()
+
+	return c
+}
+
 func (c *Manager) Start() {
 	c.logger.Info("Runner started")
 
+	c.recoverOrphanedPipelines()
+	c.resumeStaleNotifications()
+
+	if c.worker {
+		c.logger.Info("Worker started", "workers", c.config.Pipeline.Workers)
+
+		return
+	}
+
+	c.startScheduler()
+
 	c.logger.Info(
 		"HTTP server started",
 		"port", fmt.Sprintf(":%s", c.config.HTTP.Port),
@@ -141,15 +220,32 @@ func (c *Manager) Start() {
 func (c *Manager) Stop() {
 	defer c.syncZap()
 
-	c.stopPipelineWorkerPool()
-	c.logger.Info("Pipeline worker pool stopped")
+	c.stopConsumer()
+	c.logger.Info("Pipeline consumer stopped")
 
-	err := multierr.Append(
-		c.shutdownServer(),
-		c.disconnectMongo(),
-	)
+	if c.stopScheduler != nil {
+		c.stopScheduler()
+		c.logger.Info("Pipeline scheduler stopped")
+	}
+
+	var err error
+
+	if !c.worker {
+		err = c.shutdownServer()
+		c.logger.Info("Server shutdown succeeded")
+	}
+
+	if c.pipeline.drainer != nil {
+		c.drainPipelines()
+		c.requeueDrainedPipelines()
+	}
 
-	c.logger.Info("Server shutdown succeeded")
+	if c.pipeline.notifier != nil {
+		c.pipeline.notifier.Close()
+		c.logger.Info("Flow notifier stopped")
+	}
+
+	err = multierr.Append(err, c.disconnectMongo())
 	c.logger.Info("Mongo disconnected")
 
 	c.disconnectNATS()
@@ -162,6 +258,107 @@ func (c *Manager) Stop() {
 	c.logger.Info("Runner stopped")
 }
 
+// drainPipelines cancels running pipelines and waits
+// for them to be persisted and released no longer
+// than the drain timeout.
+func (c *Manager) drainPipelines() {
+	c.pipeline.drainer.DrainPipelines()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		c.stopPipelineQueue()
+	}()
+
+	select {
+	case <-stopped:
+		c.logger.Info("Pipeline queue stopped")
+	case <-time.After(c.config.Pipeline.DrainTimeout):
+		c.logger.Warn(
+			"Pipeline drain timeout exceeded",
+			"drainTimeout", c.config.Pipeline.DrainTimeout,
+		)
+	}
+}
+
+// requeueDrainedPipelines republishes drained pipelines already
+// released, so another instance can acquire them. Pipelines
+// still running after the drain timeout keep their leases
+// and are recovered as orphaned ones.
+func (c *Manager) requeueDrainedPipelines() {
+	if !c.config.Pipeline.RequeueOnShutdown {
+		return
+	}
+
+	for _, pipeID := range c.pipeline.drainer.DrainedPipelines() {
+		if err := c.signalBus.requeuePublisher.PublishPipelineRequeue(pipeID); err != nil {
+			c.logger.Error("Attempt to requeue pipeline failed", "error", err, "pipelineId", pipeID)
+
+			continue
+		}
+
+		c.logger.Info("Pipeline requeued", "pipelineId", pipeID)
+	}
+}
+
+// recoverOrphanedPipelines crashes flows of pipelines
+// left started by died instances and notifies about them.
+func (c *Manager) recoverOrphanedPipelines() {
+	if c.pipeline.recoverer == nil {
+		return
+	}
+
+	ctx := context.Background()
+
+	crashedFlowIDs, err := c.pipeline.recoverer.RecoverOrphanedPipelines(ctx)
+	if err != nil {
+		c.logger.Error("Orphaned pipelines recovery failed", "error", err)
+
+		return
+	}
+
+	c.logger.Info("Orphaned pipelines recovered", "crashedFlows", len(crashedFlowIDs))
+
+	for _, flowID := range crashedFlowIDs {
+		f, err := c.persistent.flowRepo.GetFlow(ctx, flowID)
+		if err != nil {
+			c.logger.Error("Crashed flow is not notified", "error", err, "flowId", flowID)
+
+			continue
+		}
+
+		pipe, err := c.persistent.pipeRepo.GetPipeline(ctx, f.PipelineID(), c.persistent.specRepo)
+		if err != nil {
+			c.logger.Error("Crashed flow is not notified", "error", err, "flowId", flowID)
+
+			continue
+		}
+
+		c.pipeline.notifier.NotifyFlowCompleted(ctx, pipe, f)
+	}
+}
+
+// resumeStaleNotifications continues deliveries left pending by
+// stopped or died instances. Notification is stale if it isn't
+// updated longer than the longest delivery attempt with backoff.
+func (c *Manager) resumeStaleNotifications() {
+	staleAfter := c.config.Notifier.Timeout + service.NotificationBackoff(
+		c.config.Notifier.InitialBackoff,
+		c.config.Notifier.MaxAttempts,
+	)
+
+	resumed, err := c.pipeline.notifier.ResumeStaleNotifications(context.Background(), staleAfter)
+	if err != nil {
+		c.logger.Error("Stale notifications resuming failed", "error", err)
+
+		return
+	}
+
+	c.logger.Info("Stale notifications resumed", "count", resumed)
+}
+
 func (c *Manager) shutdownServer() error {
 	ctx, cancel := context.WithTimeout(
 		context.Background(),
@@ -230,6 +427,20 @@ func (c *Manager) mongo() *mongo.Database {
 	return c.mongoSingleton.db
 }
 
+func (c *Manager) migrateMongo(db *mongo.Database) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		c.config.Mongo.MigrationTimeout,
+	)
+	defer cancel()
+
+	if err := mongoAdapter.MigratePipelineTestCampaignIDs(ctx, db); err != nil {
+		c.logger.Fatal("Failed to migrate pipeline test campaign IDs", err)
+	}
+
+	c.logger.Info("MongoDB migrations completed")
+}
+
 func (c *Manager) disconnectMongo() error {
 	ctx, cancel := context.WithTimeout(
 		context.Background(),
@@ -259,21 +470,31 @@ func (c *Manager) disconnectNATS() {
 	c.nats().Close()
 }
 
-func (c *Manager) pipelineWorkerPool() *workerpool.WorkerPool {
-	c.pipelineWPSingleton.once.Do(func() {
-		c.pipelineWPSingleton.wp = workerpool.New(10)
+func (c *Manager) pipelineQueue() *queueAdapter.PipelineQueue {
+	c.pipelineQueueSingleton.once.Do(func() {
+		c.pipelineQueueSingleton.queue = queueAdapter.NewPipelineQueue(c.config.Pipeline.Workers)
 
 		c.logger.Info(
-			"Pipeline worker pool initialization completed",
+			"Pipeline queue initialization completed",
 			"workers", c.config.Pipeline.Workers,
 		)
 	})
 
-	return c.pipelineWPSingleton.wp
+	return c.pipelineQueueSingleton.queue
 }
 
-func (c *Manager) stopPipelineWorkerPool() {
-	c.pipelineWorkerPool().StopWait()
+// pipelineQueueView returns the queue shown by HTTP API. The API
+// server doesn't queue pipelines in distributed mode, workers do.
+func (c *Manager) pipelineQueueView() service.PipelineQueue {
+	if c.config.Pipeline.Distributed && !c.worker {
+		return service.WithoutPipelineQueue()
+	}
+
+	return c.pipelineQueue()
+}
+
+func (c *Manager) stopPipelineQueue() {
+	c.pipelineQueue().StopWait()
 }
 
 func (c *Manager) firebaseAuth() *fireauth.Client {
@@ -297,6 +518,10 @@ func (c *Manager) initConfig(configsPath string) {
 		log.Fatal("Failed to parse config", err)
 	}
 
+	if cfg.Pipeline.InstanceID == "" {
+		cfg.Pipeline.InstanceID = uuid.New().String()
+	}
+
 	c.config = cfg
 }
 
@@ -316,6 +541,8 @@ func (c *Manager) initPersistent() {
 	db := c.mongo()
 	args := []interface{}{"db", "mongo"}
 
+	c.migrateMongo(db)
+
 	var (
 		testCampaignRepo = mongoAdapter.NewTestCampaignRepository(db)
 		specRepo         = mongoAdapter.NewSpecificationRepository(db)
@@ -326,6 +553,9 @@ func (c *Manager) initPersistent() {
 	c.persistent.testCampaignRepo = testCampaignRepo
 	c.logger.Info("Test campaign repository initialization completed", args...)
 
+	c.persistent.testCampaignRmv = testCampaignRepo
+	c.logger.Info("Test campaign remover initialization completed", args...)
+
 	c.persistent.specRepo = specRepo
 	c.logger.Info("Specification repository initialization completed", args...)
 
@@ -338,8 +568,49 @@ func (c *Manager) initPersistent() {
 	c.persistent.testCampaignRM = testCampaignRepo
 	c.logger.Info("Test campaign read model initialization completed", args...)
 
+	c.persistent.testCampaignsRM = testCampaignRepo
+	c.logger.Info("Test campaigns read model initialization completed", args...)
+
+	c.persistent.schedulesRM = testCampaignRepo
+	c.logger.Info("Schedules read model initialization completed", args...)
+
+	c.persistent.scheduledFinder = testCampaignRepo
+	c.logger.Info("Scheduled test campaign finder initialization completed", args...)
+
+	c.persistent.subscriptionsRM = testCampaignRepo
+	c.logger.Info("Subscriptions read model initialization completed", args...)
+
+	notificationRepo := mongoAdapter.NewNotificationRepository(db)
+
+	c.persistent.notificationRepo = notificationRepo
+	c.logger.Info("Notification repository initialization completed", args...)
+
+	c.persistent.notificationsRM = notificationRepo
+	c.logger.Info("Notifications read model initialization completed", args...)
+
+	c.persistent.triggerGuard = mongoAdapter.NewTriggerReplayGuard(db)
+	c.logger.Info("Trigger replay guard initialization completed", args...)
+
 	c.persistent.specificationRM = specRepo
 	c.logger.Info("Specification read model initialization completed", args...)
+
+	c.persistent.specHistoryRM = specRepo
+	c.logger.Info("Specification history read model initialization completed", args...)
+
+	c.persistent.specDiffRM = specRepo
+	c.logger.Info("Specification diff read model initialization completed", args...)
+
+	c.persistent.specExportRM = specRepo
+	c.logger.Info("Specification export read model initialization completed", args...)
+
+	c.persistent.pipelineRM = pipeRepo
+	c.logger.Info("Pipeline read model initialization completed", args...)
+
+	c.persistent.pipeHistoryRM = pipeRepo
+	c.logger.Info("Pipeline history read model initialization completed", args...)
+
+	c.persistent.flowReportRM = pipeRepo
+	c.logger.Info("Flow report read model initialization completed", args...)
 }
 
 func (c *Manager) initSpecificationParser() {
@@ -351,32 +622,233 @@ func (c *Manager) initApplication() {
 	c.app = &app.Application{
 		Commands: app.Commands{
 			CreateTestCampaign: command.NewCreateTestCampaignHandler(c.persistent.testCampaignRepo),
+			UpdateTestCampaign: command.NewUpdateTestCampaignHandler(c.persistent.testCampaignRepo),
+			RemoveTestCampaign: command.NewRemoveTestCampaignHandler(
+				c.persistent.testCampaignRepo,
+				c.persistent.testCampaignRmv,
+			),
+			CreateSchedule:    command.NewCreateScheduleHandler(c.persistent.testCampaignRepo),
+			UpdateSchedule:    command.NewUpdateScheduleHandler(c.persistent.testCampaignRepo),
+			RemoveSchedule:    command.NewRemoveScheduleHandler(c.persistent.testCampaignRepo),
+			IssueTriggerToken: command.NewIssueTriggerTokenHandler(c.persistent.testCampaignRepo),
+			CreateSubscription: command.NewCreateSubscriptionHandler(
+				c.persistent.testCampaignRepo,
+				c.config.Notifier.AllowedHosts,
+			),
+			RemoveSubscription: command.NewRemoveSubscriptionHandler(c.persistent.testCampaignRepo),
 			LoadSpecification: command.NewLoadSpecificationHandler(
 				c.persistent.specRepo,
 				c.persistent.testCampaignRepo,
 				c.specParser,
+				gherkin.NewSpecificationParser(),
 			),
+			ActivateSpecification: command.NewActivateSpecificationHandler(c.persistent.specRepo),
 			StartPipeline: command.NewStartPipelineHandler(
 				c.persistent.specRepo,
 				c.persistent.pipeRepo,
 				c.pipeline.maintainer,
+				c.executors()...,
+			),
+			TriggerPipeline: command.NewTriggerPipelineHandler(
+				c.persistent.testCampaignRepo,
+				c.persistent.specRepo,
+				c.persistent.pipeRepo,
+				c.persistent.triggerGuard,
+				c.pipeline.maintainer,
+				c.executors()...,
 			),
 			RestartPipeline: command.NewRestartPipelineHandler(
 				c.persistent.pipeRepo,
 				c.persistent.specRepo,
 				c.pipeline.maintainer,
+				c.executors()...,
+			),
+			RunPipeline: command.NewRunPipelineHandler(
+				c.persistent.pipeRepo,
+				c.persistent.specRepo,
+				c.pipeline.handover,
+				c.pipeline.maintainer,
+				c.executors()...,
 			),
-			CancelPipeline: command.NewCancelPipelineHandler(c.persistent.pipeRepo, c.signalBus.publisher),
+			CancelPipeline:  command.NewCancelPipelineHandler(c.persistent.pipeRepo, c.signalBus.publisher),
+			PausePipeline:   command.NewPausePipelineHandler(c.persistent.pipeRepo, c.signalBus.pausePublisher),
+			ResumePipeline:  command.NewResumePipelineHandler(c.persistent.pipeRepo, c.signalBus.pausePublisher),
+			DequeuePipeline: command.NewDequeuePipelineHandler(c.persistent.pipeRepo, c.pipelineQueueView()),
 		},
 		Queries: app.Queries{
-			TestCampaign:  query.NewTestCampaignHandler(c.persistent.testCampaignRM),
-			Specification: query.NewSpecificationHandler(c.persistent.specificationRM),
+			TestCampaign:         query.NewTestCampaignHandler(c.persistent.testCampaignRM),
+			TestCampaigns:        query.NewTestCampaignsHandler(c.persistent.testCampaignsRM),
+			Schedules:            query.NewSchedulesHandler(c.persistent.schedulesRM),
+			Subscriptions:        query.NewSubscriptionsHandler(c.persistent.subscriptionsRM),
+			Notifications:        query.NewNotificationsHandler(c.persistent.notificationsRM),
+			Specification:        query.NewSpecificationHandler(c.persistent.specificationRM),
+			SpecificationHistory: query.NewSpecificationHistoryHandler(c.persistent.specHistoryRM),
+			SpecificationDiff:    query.NewSpecificationDiffHandler(c.persistent.specDiffRM),
+			SpecificationLint:    query.NewSpecificationLintHandler(c.specParser),
+			SpecificationSchema:  query.NewSpecificationSchemaHandler(yaml.NewSpecificationParser()),
+			Pipeline:             query.NewPipelineHandler(c.persistent.pipelineRM, c.pipelineQueueView()),
+			PipelineHistory:      query.NewPipelineHistoryHandler(c.persistent.pipeHistoryRM),
+			PipelineSteps:        query.NewPipelineStepsHandler(c.persistent.pipeRepo, c.stepBus.subscriber),
+			PipelineQueue:        query.NewPipelineQueueHandler(c.pipelineQueueView()),
+			FlowReport: query.NewFlowReportHandler(
+				c.persistent.flowReportRM,
+				junit.NewReporter(),
+				htmlReport.NewReporter(),
+				jsonReport.NewReporter(),
+			),
+			SpecificationExport: query.NewSpecificationExportHandler(
+				c.persistent.specExportRM,
+				yaml.NewSpecificationSerializer(),
+			),
 		},
 	}
 
+	if hosts := c.config.Pipeline.HTTPAllowedHosts; len(hosts) > 0 {
+		c.logger.Warn("HTTP theses of pipelines are sent by the server", "allowedHosts", hosts)
+	} else {
+		c.logger.Info("HTTP theses of pipelines are disabled, no allowed hosts")
+	}
+
 	c.logger.Info("Application context initialization completed")
 }
 
+// initPipelineConsumer runs pipelines published by other
+// instances. Pipelines requeued on shutdown are consumed by
+// any instance running pipelines on its own, start requests
+// are consumed by workers only.
+func (c *Manager) initPipelineConsumer() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.stopConsumer = cancel
+
+	if c.pipeline.drainer == nil {
+		c.logger.Info("Pipeline consumer is not required, pipelines are run by workers")
+
+		return
+	}
+
+	requeued, err := c.signalBus.requeueSubscriber.SubscribePipelineRequeue(ctx)
+	if err != nil {
+		c.logger.Fatal("Requeued pipelines subscription failed", err)
+	}
+
+	go c.runRequeuedPipelines(ctx, requeued)
+
+	if c.worker {
+		started, err := c.signalBus.startSubscriber.SubscribePipelineStart(ctx)
+		if err != nil {
+			c.logger.Fatal("Pipeline start requests subscription failed", err)
+		}
+
+		go c.runStartedPipelines(ctx, started)
+	}
+
+	c.logger.Info("Pipeline consumer initialization completed")
+}
+
+func (c *Manager) runRequeuedPipelines(ctx context.Context, pipeIDs <-chan string) {
+	for pipeID := range pipeIDs {
+		c.runPipeline(ctx, command.RunPipeline{
+			PipelineID: pipeID,
+			Priority:   service.ManualPriority,
+		})
+	}
+}
+
+// runStartedPipelines replies to each start request, so the
+// instance published the request knows the pipeline is accepted.
+func (c *Manager) runStartedPipelines(ctx context.Context, reqs <-chan service.PipelineStartRequest) {
+	for req := range reqs {
+		err := c.runPipeline(ctx, command.RunPipeline{
+			PipelineID:   req.PipelineID,
+			Priority:     req.Priority,
+			HandedOverBy: req.LeaseOwner,
+		})
+
+		if err := req.Reply(err); err != nil {
+			c.logger.Warn("Pipeline start request is not replied", "error", err, "pipelineId", req.PipelineID)
+		}
+	}
+}
+
+func (c *Manager) runPipeline(ctx context.Context, cmd command.RunPipeline) error {
+	if err := c.app.Commands.RunPipeline.Handle(ctx, cmd); err != nil {
+		c.logger.Warn("Pipeline is not run", "error", err, "pipelineId", cmd.PipelineID)
+
+		return err
+	}
+
+	c.logger.Info("Pipeline run", "pipelineId", cmd.PipelineID)
+
+	return nil
+}
+
+// initScheduler fires scheduled pipelines. Every instance serving
+// HTTP API runs the scheduler, but only the leader fires schedules.
+func (c *Manager) initScheduler() {
+	if c.config.Scheduler.Disabled {
+		c.logger.Info("Pipeline scheduler is disabled")
+
+		return
+	}
+
+	lock := mongoAdapter.NewLeaderLock(
+		c.mongo(),
+		c.config.Pipeline.InstanceID,
+		c.config.Scheduler.LeaderTTL,
+	)
+
+	c.scheduler = service.NewPipelineScheduler(
+		c.persistent.scheduledFinder,
+		lock,
+		mongoAdapter.NewScheduleFireLog(c.mongo()),
+		scheduledPipelineStarter{handler: c.app.Commands.StartPipeline},
+		c.logger.Named("PipelineScheduler"),
+		c.config.Scheduler.TickInterval,
+	)
+
+	c.logger.Info(
+		"Pipeline scheduler initialization completed",
+		"tickInterval", c.config.Scheduler.TickInterval,
+		"leaderTTL", c.config.Scheduler.LeaderTTL,
+	)
+}
+
+func (c *Manager) startScheduler() {
+	if c.scheduler == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.stopScheduler = cancel
+
+	go c.scheduler.Run(ctx)
+
+	c.logger.Info("Pipeline scheduler started")
+}
+
+// scheduledPipelineStarter starts scheduled pipelines on behalf of
+// the test campaign owner with the schedule environment and filter.
+type scheduledPipelineStarter struct {
+	handler command.StartPipelineHandler
+}
+
+func (s scheduledPipelineStarter) StartScheduledPipeline(
+	ctx context.Context,
+	tc *testcampaign.TestCampaign,
+	schedule testcampaign.Schedule,
+) error {
+	return s.handler.Handle(ctx, command.StartPipeline{
+		PipelineID:     uuid.New().String(),
+		TestCampaignID: tc.ID(),
+		StartedByID:    tc.OwnerID(),
+		Priority:       service.ScheduledPriority,
+		Environment:    schedule.Environment(),
+		ScenarioFilter: schedule.Filter(),
+	})
+}
+
 func (c *Manager) initMetrics() {
 	mrs, err := prometheus.NewMetricCollector()
 	if err != nil {
@@ -394,6 +866,21 @@ func (c *Manager) initSignalBus() {
 
 		c.signalBus.publisher = bus
 		c.signalBus.subscriber = bus
+
+		pauseBus := natsio.NewPipelinePauseSignalBus(c.nats())
+
+		c.signalBus.pausePublisher = pauseBus
+		c.signalBus.pauseSubscriber = pauseBus
+
+		requeueBus := natsio.NewPipelineRequeueBus(c.nats())
+
+		c.signalBus.requeuePublisher = requeueBus
+		c.signalBus.requeueSubscriber = requeueBus
+
+		startBus := natsio.NewPipelineStartBus(c.nats(), c.config.Pipeline.StartTimeout)
+
+		c.signalBus.startPublisher = startBus
+		c.signalBus.startSubscriber = startBus
 	} else {
 		c.logger.Fatal(
 			"Invalid pipeline signal bus",
@@ -408,20 +895,69 @@ func (c *Manager) initSignalBus() {
 	)
 }
 
+func (c *Manager) initStepBus() {
+	switch stepBus := c.config.Pipeline.StepBus; stepBus {
+	case config.NatsStepBus:
+		bus := natsio.NewPipelineStepBus(c.nats())
+
+		c.stepBus.publisher = bus
+		c.stepBus.subscriber = bus
+	case config.InMemoryStepBus:
+		bus := inmemory.NewPipelineStepBus()
+
+		c.stepBus.publisher = bus
+		c.stepBus.subscriber = bus
+	default:
+		c.logger.Fatal(
+			"Invalid pipeline step bus",
+			errors.Errorf("%s is not valid step bus", stepBus),
+			"allowed", strings.Join([]string{config.NatsStepBus, config.InMemoryStepBus}, ", "),
+		)
+	}
+
+	c.logger.Info(
+		"Step bus initialization completed",
+		"stepBus", c.config.Pipeline.StepBus,
+	)
+}
+
 func (c *Manager) initPipeline() {
+	// Notifier is needed by every instance, since any of them
+	// recovers orphaned pipelines and resumes stale notifications.
+	c.initFlowNotifier()
+
+	if c.config.Pipeline.Distributed && !c.worker {
+		c.initPipelineGuard()
+
+		c.pipeline.maintainer = service.NewQueuedPipelineMaintainer(
+			c.pipeline.guard,
+			c.signalBus.startPublisher,
+			c.config.Pipeline.InstanceID,
+		)
+
+		c.logger.Info("Pipeline maintainer initialized, pipelines are queued to workers")
+
+		return
+	}
+
 	c.initPipelineGuard()
 	c.initPipelinePolicy()
 	c.initEnqueuer()
 
-	c.pipeline.maintainer = service.NewPipelineMaintainer(
+	maintainer := service.NewPipelineMaintainer(
 		c.pipeline.guard,
 		c.signalBus.subscriber,
+		c.signalBus.pauseSubscriber,
 		c.pipeline.policy,
 		c.pipeline.enqueuer,
 		c.logger.Named("PipelineMaintainer"),
 		c.config.Pipeline.FlowTimeout,
+		c.config.Pipeline.HeartbeatInterval,
 	)
 
+	c.pipeline.maintainer = maintainer
+	c.pipeline.drainer = maintainer
+
 	c.logger.Info(
 		"Pipeline maintainer initialized",
 		"policy", c.config.Pipeline.Policy,
@@ -429,13 +965,69 @@ func (c *Manager) initPipeline() {
 }
 
 func (c *Manager) initPipelineGuard() {
-	c.pipeline.guard = mongoAdapter.NewPipelineGuard(c.mongo())
+	instanceID := c.config.Pipeline.InstanceID
+
+	guard := mongoAdapter.NewPipelineGuard(
+		c.mongo(),
+		instanceID,
+		c.config.Pipeline.LeaseTTL,
+	)
+
+	c.pipeline.guard = guard
+	c.pipeline.handover = guard
+	c.pipeline.recoverer = guard
+
+	c.logger.Info(
+		"Pipeline guard initialized",
+		"instanceId", instanceID,
+		"leaseTTL", c.config.Pipeline.LeaseTTL,
+	)
+}
+
+// executors returns registrars of executors running theses
+// of started pipelines. The server registers the real HTTP
+// executor only if pipeline.httpAllowedHosts is set, it is
+// off by default. Specifications are written by users, so
+// HTTP theses are sent only to the configured hosts, not to
+// the network of the server. Without allowed hosts HTTP
+// theses crash as having no executor.
+func (c *Manager) executors() []pipeline.ExecutorRegistrar {
+	registrars := []pipeline.ExecutorRegistrar{
+		pipeline.WithAssertion(jsonpath.NewExecutor()),
+	}
+
+	if hosts := c.config.Pipeline.HTTPAllowedHosts; len(hosts) > 0 {
+		registrars = append(registrars, pipeline.WithHTTP(
+			httpexec.NewRestrictedExecutor(c.config.Pipeline.HTTPTimeout, hosts),
+		))
+	}
+
+	return registrars
+}
+
+func (c *Manager) initFlowNotifier() {
+	c.pipeline.notifier = service.NewWebhookNotifier(
+		c.persistent.testCampaignRepo,
+		c.persistent.notificationRepo,
+		webhook.NewSender(c.config.Notifier.Timeout, c.config.Notifier.AllowedHosts),
+		c.logger.Named("WebhookNotifier"),
+		c.config.Notifier.MaxAttempts,
+		c.config.Notifier.InitialBackoff,
+	)
+
+	c.logger.Info(
+		"Flow notifier initialized",
+		"maxAttempts", c.config.Notifier.MaxAttempts,
+		"initialBackoff", c.config.Notifier.InitialBackoff,
+	)
 }
 
 func (c *Manager) initPipelinePolicy() {
 	if c.config.Pipeline.Policy == config.SavePerStepPolicy {
 		c.pipeline.policy = service.NewSavePerStepPolicy(
 			c.persistent.flowRepo,
+			c.stepBus.publisher,
+			c.pipeline.notifier,
 			c.logger.Named("SavePerStepPolicy"),
 			c.config.SavePerStep.SaveTimeout,
 		)
@@ -451,7 +1043,7 @@ func (c *Manager) initPipelinePolicy() {
 }
 
 func (c *Manager) initEnqueuer() {
-	c.pipeline.enqueuer = service.EnqueueFunc(c.pipelineWorkerPool().Submit)
+	c.pipeline.enqueuer = c.pipelineQueue()
 }
 
 func (c *Manager) initAuthenticationProvider() {
@@ -487,7 +1079,20 @@ func (c *Manager) initServer() {
 		Routes: []rest.Route{
 			{
 				Pattern: "/v1",
-				Handler: v1.NewHandler(c.app, c.logger, rest.AuthMiddleware(c.authProvider)),
+				Handler: v1.NewHandler(
+					c.app,
+					c.logger,
+					c.config.HTTP.AllowedOrigins,
+					rest.AuthMiddleware(c.authProvider),
+				),
+			},
+			{
+				Pattern: "/v1/hooks",
+				Handler: v1.NewWebhookHandler(c.app, c.logger),
+			},
+			{
+				Pattern: "/v1/schemas",
+				Handler: v1.NewSchemaHandler(c.app, c.logger),
 			},
 			{
 				Pattern: "/swagger",
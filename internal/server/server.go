@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/harpyd/thestis/internal/config"
+	"github.com/harpyd/thestis/pkg/httpconn"
 )
 
 type Server struct {
@@ -18,6 +19,7 @@ func New(cfg config.HTTP, handler http.Handler) *Server {
 			Handler:      handler,
 			ReadTimeout:  cfg.ReadTimeout,
 			WriteTimeout: cfg.WriteTimeout,
+			ConnContext:  httpconn.AssignToCtx,
 		},
 	}
 }
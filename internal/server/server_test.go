@@ -0,0 +1,198 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/config"
+	"github.com/harpyd/thestis/internal/core/adapter/driver/rest"
+	v1 "github.com/harpyd/thestis/internal/core/adapter/driver/rest/v1"
+	"github.com/harpyd/thestis/internal/core/app"
+	"github.com/harpyd/thestis/internal/core/app/query"
+	"github.com/harpyd/thestis/internal/core/app/service/mock"
+)
+
+type authProvider struct{}
+
+func (authProvider) AuthenticateUser(context.Context, *http.Request) (rest.User, error) {
+	return rest.User{UUID: "b5bbd4b4-8f4a-4e0c-9b55-6f5e58e1b7a3"}, nil
+}
+
+type slowPipelineSteps struct {
+	count    int
+	interval time.Duration
+}
+
+func (h slowPipelineSteps) Handle(ctx context.Context, _ query.PipelineSteps) (<-chan query.StepModel, error) {
+	steps := make(chan query.StepModel)
+
+	go func() {
+		defer close(steps)
+
+		for i := 0; i < h.count; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(h.interval):
+			}
+
+			steps <- query.StepModel{
+				Slug:       "story.scenario.thesis",
+				SlugKind:   "thesis",
+				Event:      "execute",
+				OccurredAt: time.Now(),
+			}
+		}
+	}()
+
+	return steps, nil
+}
+
+func TestServerStreamsPipelineEventsLongerThanWriteTimeout(t *testing.T) {
+	t.Parallel()
+
+	const (
+		writeTimeout = 100 * time.Millisecond
+		stepsCount   = 10
+	)
+
+	application := &app.Application{
+		Queries: app.Queries{
+			PipelineSteps: slowPipelineSteps{
+				count:    stepsCount,
+				interval: writeTimeout / 2,
+			},
+		},
+	}
+
+	handler := v1.NewHandler(application, mock.NewMemoryLogger(), nil, rest.AuthMiddleware(authProvider{}))
+
+	srv := New(config.HTTP{
+		ReadTimeout:  writeTimeout,
+		WriteTimeout: writeTimeout,
+	}, handler)
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config = srv.serv
+	ts.Start()
+
+	defer ts.Close()
+
+	rsp, err := http.Get(ts.URL + "/pipelines/a7e8bf4b-44f2-4dfb-9a4a-6a5a35d2e7d5/events")
+	require.NoError(t, err)
+
+	defer rsp.Body.Close()
+
+	require.Equal(t, http.StatusOK, rsp.StatusCode)
+
+	var events []string
+
+	scanner := bufio.NewScanner(rsp.Body)
+	for scanner.Scan() {
+		if event := strings.TrimPrefix(scanner.Text(), "event: "); event != scanner.Text() {
+			events = append(events, event)
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+	require.Len(t, events, stepsCount+1)
+	require.Equal(t, "completed", events[stepsCount])
+}
+
+func TestServerChecksPipelineSocketOrigin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name           string
+		AllowedOrigins []string
+		Origin         string
+		ShouldUpgrade  bool
+	}{
+		{
+			Name:          "without_origin",
+			ShouldUpgrade: true,
+		},
+		{
+			Name:           "allowed_origin",
+			AllowedOrigins: []string{"https://some-a.com"},
+			Origin:         "https://some-a.com",
+			ShouldUpgrade:  true,
+		},
+		{
+			Name:           "allowed_wildcard_origin",
+			AllowedOrigins: []string{"https://*.some-b.com"},
+			Origin:         "https://app.some-b.com",
+			ShouldUpgrade:  true,
+		},
+		{
+			Name:           "any_origin",
+			AllowedOrigins: []string{"*"},
+			Origin:         "https://other.com",
+			ShouldUpgrade:  true,
+		},
+		{
+			Name:           "not_allowed_origin",
+			AllowedOrigins: []string{"https://some-a.com"},
+			Origin:         "https://evil.com",
+			ShouldUpgrade:  false,
+		},
+		{
+			Name:          "cross_origin_without_allowed_origins",
+			Origin:        "https://evil.com",
+			ShouldUpgrade: false,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			application := &app.Application{
+				Queries: app.Queries{
+					PipelineSteps: slowPipelineSteps{},
+				},
+			}
+
+			ts := httptest.NewServer(v1.NewHandler(
+				application,
+				mock.NewMemoryLogger(),
+				c.AllowedOrigins,
+				rest.AuthMiddleware(authProvider{}),
+			))
+			defer ts.Close()
+
+			header := http.Header{}
+			if c.Origin != "" {
+				header.Set("Origin", c.Origin)
+			}
+
+			url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/pipelines/a7e8bf4b-44f2-4dfb-9a4a-6a5a35d2e7d5/ws"
+
+			conn, rsp, err := websocket.DefaultDialer.Dial(url, header)
+			if rsp != nil {
+				defer rsp.Body.Close()
+			}
+
+			if !c.ShouldUpgrade {
+				require.ErrorIs(t, err, websocket.ErrBadHandshake)
+				require.Equal(t, http.StatusForbidden, rsp.StatusCode)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusSwitchingProtocols, rsp.StatusCode)
+			require.NoError(t, conn.Close())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/harpyd/thestis/internal/core/entity/specification"
+)
+
+// Diagnostic is a single problem found in the specification file.
+//
+// Context is the path of slugged objects and fields from the
+// root of the specification to the object with the problem.
+// Line and Column are 1-based, zero values mean that position
+// of the problem is unknown.
+type Diagnostic struct {
+	File    string   `json:"file"`
+	Line    int      `json:"line,omitempty"`
+	Column  int      `json:"column,omitempty"`
+	Context []string `json:"context,omitempty"`
+	Message string   `json:"message"`
+}
+
+// Title returns context and message of the
+// diagnostic joined in a single line.
+func (d Diagnostic) Title() string {
+	if len(d.Context) == 0 {
+		return d.Message
+	}
+
+	return strings.Join(d.Context, ": ") + ": " + d.Message
+}
+
+// Diagnostics flattens the tree of specification.BuildError
+// into diagnostics of the file, one for each nested error,
+// sorted by position. Other errors are returned as a single
+// diagnostic.
+func Diagnostics(file string, err error) []Diagnostic {
+	details := specification.FlattenBuildError(err)
+	if len(details) == 0 {
+		return nil
+	}
+
+	ds := make([]Diagnostic, 0, len(details))
+
+	for _, d := range details {
+		ds = append(ds, Diagnostic{
+			File:    file,
+			Line:    d.Position.Line,
+			Column:  d.Position.Column,
+			Context: d.Contexts,
+			Message: d.Err.Error(),
+		})
+	}
+
+	sort.SliceStable(ds, func(i, j int) bool {
+		if ds[i].Line != ds[j].Line {
+			return ds[i].Line < ds[j].Line
+		}
+
+		return ds[i].Column < ds[j].Column
+	})
+
+	return ds
+}
@@ -0,0 +1,307 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+)
+
+type Format string
+
+const (
+	TextFormat  Format = "text"
+	JSONFormat  Format = "json"
+	SARIFFormat Format = "sarif"
+	LSPFormat   Format = "lsp"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// WriteReport writes the report to w in the format. Text format
+// has a line with position and title for each diagnostic and
+// a summary line, JSON format is an array of diagnostics,
+// SARIF format is a SARIF 2.1.0 log for code scanning tools
+// and LSP format is an array of publishDiagnostics params
+// of the Language Server Protocol, one for each file.
+func WriteReport(w io.Writer, r Report, format Format) error {
+	switch format {
+	case TextFormat:
+		return writeText(w, r)
+	case JSONFormat:
+		return writeJSON(w, r)
+	case SARIFFormat:
+		return writeSARIF(w, r)
+	case LSPFormat:
+		return writeLSP(w, r)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+func writeText(w io.Writer, r Report) error {
+	for _, d := range r.Diagnostics {
+		if _, err := fmt.Fprintf(
+			w,
+			"%s: %s\n",
+			contextColor.Render(position(d)),
+			errorColor.Render(d.Title()),
+		); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(
+		w,
+		"%d of %d specifications invalid, %d problems\n",
+		r.InvalidFiles(),
+		len(r.Files),
+		len(r.Diagnostics),
+	)
+
+	return err
+}
+
+func position(d Diagnostic) string {
+	switch {
+	case d.Line > 0 && d.Column > 0:
+		return fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	case d.Line > 0:
+		return fmt.Sprintf("%s:%d", d.File, d.Line)
+	}
+
+	return d.File
+}
+
+func writeJSON(w io.Writer, r Report) error {
+	ds := r.Diagnostics
+	if ds == nil {
+		ds = []Diagnostic{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(ds)
+}
+
+const (
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion  = "2.1.0"
+	sarifToolName = "thestis-validate"
+	sarifToolURI  = "https://github.com/harpyd/thestis"
+	sarifRuleID   = "invalid-specification"
+	sarifLevel    = "error"
+)
+
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool      sarifTool       `json:"tool"`
+		Artifacts []sarifArtifact `json:"artifacts"`
+		Results   []sarifResult   `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+
+	sarifArtifact struct {
+		Location sarifArtifactLocation `json:"location"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+func writeSARIF(w io.Writer, r Report) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifToolURI,
+				Rules: []sarifRule{
+					{
+						ID:               sarifRuleID,
+						ShortDescription: sarifMessage{Text: "Specification is invalid"},
+					},
+				},
+			},
+		},
+		Artifacts: make([]sarifArtifact, 0, len(r.Files)),
+		Results:   make([]sarifResult, 0, len(r.Diagnostics)),
+	}
+
+	for _, f := range r.Files {
+		run.Artifacts = append(run.Artifacts, sarifArtifact{
+			Location: sarifArtifactLocation{URI: artifactURI(f)},
+		})
+	}
+
+	for _, d := range r.Diagnostics {
+		loc := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: artifactURI(d.File)},
+		}
+
+		if d.Line > 0 {
+			loc.Region = &sarifRegion{
+				StartLine:   d.Line,
+				StartColumn: d.Column,
+			}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    sarifRuleID,
+			Level:     sarifLevel,
+			Message:   sarifMessage{Text: d.Title()},
+			Locations: []sarifLocation{{PhysicalLocation: loc}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// artifactURI returns URI of the file
+// with forward slashes as SARIF requires.
+func artifactURI(file string) string {
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+const (
+	lspSeverityError = 1
+	lspSource        = "thestis"
+)
+
+type (
+	lspPublishDiagnosticsParams struct {
+		URI         string          `json:"uri"`
+		Diagnostics []lspDiagnostic `json:"diagnostics"`
+	}
+
+	lspDiagnostic struct {
+		Range    lspRange `json:"range"`
+		Severity int      `json:"severity"`
+		Source   string   `json:"source"`
+		Message  string   `json:"message"`
+	}
+
+	lspRange struct {
+		Start lspPosition `json:"start"`
+		End   lspPosition `json:"end"`
+	}
+
+	lspPosition struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	}
+)
+
+func writeLSP(w io.Writer, r Report) error {
+	var (
+		params = make([]lspPublishDiagnosticsParams, 0, len(r.Files))
+		byFile = make(map[string]int, len(r.Files))
+	)
+
+	for _, f := range r.Files {
+		byFile[f] = len(params)
+		params = append(params, lspPublishDiagnosticsParams{
+			URI:         fileURI(f),
+			Diagnostics: make([]lspDiagnostic, 0),
+		})
+	}
+
+	for _, d := range r.Diagnostics {
+		i, ok := byFile[d.File]
+		if !ok {
+			continue
+		}
+
+		// LSP positions are 0-based, unknown
+		// position points to the file start.
+		pos := lspPosition{
+			Line:      max(d.Line-1, 0),
+			Character: max(d.Column-1, 0),
+		}
+
+		params[i].Diagnostics = append(params[i].Diagnostics, lspDiagnostic{
+			Range:    lspRange{Start: pos, End: pos},
+			Severity: lspSeverityError,
+			Source:   lspSource,
+			Message:  d.Title(),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(params)
+}
+
+func fileURI(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(file)}
+
+	return u.String()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
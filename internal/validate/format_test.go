@@ -0,0 +1,118 @@
+package validate_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/color"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/validate"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite golden reports in testdata")
+
+// TestWriteReport compares reports in each format with golden
+// files in testdata. Run with -update-golden flag to rewrite them.
+//
+// Files of the reports are absolute, so LSP file URIs
+// don't depend on the working directory.
+func TestWriteReport(t *testing.T) {
+	color.Disable()
+
+	reports := []struct {
+		Name   string
+		Report validate.Report
+	}{
+		{
+			Name:   "empty",
+			Report: validate.Report{},
+		},
+		{
+			Name: "valid",
+			Report: validate.Report{
+				Files: []string{"/specs/cart.yml"},
+			},
+		},
+		{
+			Name: "invalid",
+			Report: validate.Report{
+				Files: []string{
+					"/specs/cart.yml",
+					"/specs/checkout.feature",
+					"/specs/missing.yml",
+				},
+				Diagnostics: []validate.Diagnostic{
+					{
+						File:    "/specs/cart.yml",
+						Line:    12,
+						Column:  9,
+						Context: []string{"story(cart)", "scenario(add)", "thesis(check)"},
+						Message: "no assertion or HTTP",
+					},
+					{
+						File:    "/specs/cart.yml",
+						Line:    30,
+						Context: []string{"story(cart)"},
+						Message: "no scenarios",
+					},
+					{
+						File:    "/specs/checkout.feature",
+						Line:    4,
+						Column:  3,
+						Message: "unexpected step keyword",
+					},
+					{
+						File:    "/specs/missing.yml",
+						Message: "open /specs/missing.yml: no such file or directory",
+					},
+				},
+			},
+		},
+	}
+
+	formats := []validate.Format{
+		validate.TextFormat,
+		validate.JSONFormat,
+		validate.SARIFFormat,
+		validate.LSPFormat,
+	}
+
+	for _, r := range reports {
+		for _, f := range formats {
+			r, f := r, f
+
+			t.Run(r.Name+"_"+string(f), func(t *testing.T) {
+				t.Parallel()
+
+				var b bytes.Buffer
+
+				require.NoError(t, validate.WriteReport(&b, r.Report, f))
+
+				golden := filepath.Join("testdata", r.Name+"."+string(f)+".golden")
+
+				if *updateGolden {
+					require.NoError(t, os.WriteFile(golden, b.Bytes(), 0o600))
+				}
+
+				expected, err := os.ReadFile(golden)
+				require.NoError(t, err)
+
+				require.Equal(t, string(expected), b.String())
+			})
+		}
+	}
+}
+
+func TestWriteReportWithUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	err := validate.WriteReport(&b, validate.Report{}, "xml")
+	require.ErrorIs(t, err, validate.ErrUnknownFormat)
+	require.Zero(t, b.Len())
+}
@@ -3,29 +3,153 @@ package validate
 import (
 	"errors"
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/gookit/color"
 
-	"github.com/harpyd/thestis/internal/core/adapter/driven/parser/yaml"
+	"github.com/harpyd/thestis/internal/core/adapter/driven/parser"
 	"github.com/harpyd/thestis/internal/core/entity/specification"
 )
 
-const errorIndent = "  "
+// Report is the result of validation of specification files.
+type Report struct {
+	Files       []string
+	Diagnostics []Diagnostic
+}
+
+// InvalidFiles returns count of files with diagnostics.
+func (r Report) InvalidFiles() int {
+	invalid := make(map[string]bool, len(r.Files))
+
+	for _, d := range r.Diagnostics {
+		invalid[d.File] = true
+	}
+
+	return len(invalid)
+}
+
+// Specifications validates specification files matched by
+// patterns. Pattern is a path to the file, a glob or a path
+// to the directory with .yml, .yaml and .feature files inside.
+//
+// Specifications returns error only if the pattern is malformed,
+// problems of the files are returned as diagnostics of the report.
+func Specifications(patterns ...string) (Report, error) {
+	files, err := Files(patterns...)
+	if err != nil {
+		return Report{}, err
+	}
+
+	r := Report{Files: files}
 
-func Specification(specPath string) {
+	for _, file := range files {
+		r.Diagnostics = append(r.Diagnostics, Specification(file)...)
+	}
+
+	return r, nil
+}
+
+// Specification validates the specification
+// file and returns diagnostics of its problems.
+func Specification(specPath string) []Diagnostic {
 	specFile, err := os.Open(specPath)
 	if err != nil {
-		log.Fatalf("%s: %s", specPath, err)
+		return Diagnostics(specPath, err)
 	}
 
-	parser := yaml.NewSpecificationParser()
+	defer specFile.Close()
+
+	specParser := parser.ForFile(specPath)
+
+	_, err = specParser.ParseSpecification(specFile)
+
+	return Diagnostics(specPath, err)
+}
+
+var specificationExtensions = map[string]bool{
+	".yml":     true,
+	".yaml":    true,
+	".feature": true,
+}
 
-	if _, err := parser.ParseSpecification(specFile); err != nil {
-		log.Fatalf("%s:\n%s", specFile.Name(), formatError(err, errorIndent))
+// Files returns specification files matched by patterns. Pattern
+// without matches is returned as is to be reported as missing.
+func Files(patterns ...string) ([]string, error) {
+	var (
+		files = make([]string, 0, len(patterns))
+		seen  = make(map[string]bool, len(patterns))
+	)
+
+	add := func(file string) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
 	}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			add(pattern)
+
+			continue
+		}
+
+		for _, match := range matches {
+			dirFiles, err := specificationFiles(match)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, f := range dirFiles {
+				add(f)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// specificationFiles returns the path itself if it is not a
+// directory, otherwise sorted specification files inside it.
+func specificationFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && specificationExtensions[strings.ToLower(filepath.Ext(p))] {
+			files = append(files, p)
+		}
+
+		return nil
+	})
+
+	sort.Strings(files)
+
+	return files, err
+}
+
+const errorIndent = "  "
+
+// FormatError formats specification.BuildError as a
+// colored tree of errors nested in slugged objects.
+func FormatError(err error) string {
+	return formatError(err, errorIndent)
 }
 
 const (
@@ -0,0 +1,128 @@
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/harpyd/thestis/internal/validate"
+)
+
+func TestFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for _, f := range []string{
+		"specs/b.yml",
+		"specs/a.yaml",
+		"specs/nested/c.feature",
+		"specs/nested/D.YML",
+		"specs/readme.md",
+		"specs/nested/notes.txt",
+		"single.yml",
+	} {
+		path := filepath.Join(dir, f)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+	}
+
+	path := func(f string) string {
+		return filepath.Join(dir, f)
+	}
+
+	testCases := []struct {
+		Name          string
+		Patterns      []string
+		ExpectedFiles []string
+		ShouldBeErr   bool
+	}{
+		{
+			Name:          "no_patterns",
+			Patterns:      nil,
+			ExpectedFiles: []string{},
+		},
+		{
+			Name:          "single_file",
+			Patterns:      []string{path("single.yml")},
+			ExpectedFiles: []string{path("single.yml")},
+		},
+		{
+			Name:          "file_with_other_extension_given_explicitly",
+			Patterns:      []string{path("specs/readme.md")},
+			ExpectedFiles: []string{path("specs/readme.md")},
+		},
+		{
+			Name:     "glob",
+			Patterns: []string{path("specs/*.y*ml")},
+			ExpectedFiles: []string{
+				path("specs/a.yaml"),
+				path("specs/b.yml"),
+			},
+		},
+		{
+			Name:     "directory_walked_filtered_and_sorted",
+			Patterns: []string{path("specs")},
+			ExpectedFiles: []string{
+				path("specs/a.yaml"),
+				path("specs/b.yml"),
+				path("specs/nested/D.YML"),
+				path("specs/nested/c.feature"),
+			},
+		},
+		{
+			Name: "duplicates_removed",
+			Patterns: []string{
+				path("specs/b.yml"),
+				path("specs"),
+				path("specs/*.yml"),
+			},
+			ExpectedFiles: []string{
+				path("specs/b.yml"),
+				path("specs/a.yaml"),
+				path("specs/nested/D.YML"),
+				path("specs/nested/c.feature"),
+			},
+		},
+		{
+			Name: "pattern_without_matches_returned_as_is",
+			Patterns: []string{
+				path("missing.yml"),
+				path("specs/*.feature"),
+				path("single.yml"),
+			},
+			ExpectedFiles: []string{
+				path("missing.yml"),
+				path("specs/*.feature"),
+				path("single.yml"),
+			},
+		},
+		{
+			Name:        "malformed_pattern",
+			Patterns:    []string{path("single.yml"), path("[")},
+			ShouldBeErr: true,
+		},
+	}
+
+	for _, c := range testCases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := validate.Files(c.Patterns...)
+
+			if c.ShouldBeErr {
+				require.ErrorIs(t, err, filepath.ErrBadPattern)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.ExpectedFiles, files)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package httpconn
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+type connCtx int
+
+const connCtxKey connCtx = iota
+
+// AssignToCtx stores the connection of the request in the context.
+// It is used as http.Server ConnContext, so handlers of long-lived
+// responses can lift the server write timeout with ClearWriteDeadline.
+func AssignToCtx(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, connCtxKey, conn)
+}
+
+func FromCtx(ctx context.Context) (net.Conn, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	conn, ok := ctx.Value(connCtxKey).(net.Conn)
+
+	return conn, ok
+}
+
+// ClearWriteDeadline removes write deadline of the request connection
+// set by the server write timeout. Response streamed after that is
+// written as long as the handler is running.
+func ClearWriteDeadline(ctx context.Context) error {
+	conn, ok := FromCtx(ctx)
+	if !ok {
+		return nil
+	}
+
+	return conn.SetWriteDeadline(time.Time{})
+}
@@ -0,0 +1,24 @@
+package httphost
+
+import "strings"
+
+// Allowed returns true if the host equals one of allowedHosts
+// or matches the pattern like *.example.com. Hosts are compared
+// case-insensitively, with no allowed hosts nothing is allowed.
+func Allowed(host string, allowedHosts []string) bool {
+	host = strings.ToLower(host)
+
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+
+		if host == allowed {
+			return true
+		}
+
+		if strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return true
+		}
+	}
+
+	return false
+}